@@ -1,20 +1,37 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	_ "time/tzdata" // тихие часы считаются в часовом поясе пользователя, а в образе нет tzdata
 
 	"github.com/Vovarama1992/emelya-go/docs"
 	"github.com/Vovarama1992/emelya-go/internal/scheduler"
 
+	auditadapter "github.com/Vovarama1992/emelya-go/internal/audit/delivery"
+	auditinfra "github.com/Vovarama1992/emelya-go/internal/audit/infra"
+	auditusecase "github.com/Vovarama1992/emelya-go/internal/audit/usecase"
 	authadapter "github.com/Vovarama1992/emelya-go/internal/auth/delivery"
+	authinfra "github.com/Vovarama1992/emelya-go/internal/auth/infra"
 	authusecase "github.com/Vovarama1992/emelya-go/internal/auth/usecase"
 	"github.com/Vovarama1992/emelya-go/internal/db"
+	kychttp "github.com/Vovarama1992/emelya-go/internal/kyc/delivery"
+	kycinfra "github.com/Vovarama1992/emelya-go/internal/kyc/infra"
+	kycusecase "github.com/Vovarama1992/emelya-go/internal/kyc/usecase"
+	leadhttp "github.com/Vovarama1992/emelya-go/internal/lead/delivery"
+	leadinfra "github.com/Vovarama1992/emelya-go/internal/lead/infra"
+	leadusecase "github.com/Vovarama1992/emelya-go/internal/lead/usecase"
+	"github.com/Vovarama1992/emelya-go/internal/storage"
 
+	approvalhttp "github.com/Vovarama1992/emelya-go/internal/money/approval/delivery"
+	approvalinfra "github.com/Vovarama1992/emelya-go/internal/money/approval/infra"
 	deposithttp "github.com/Vovarama1992/emelya-go/internal/money/deposit/delivery"
 	depositinfra "github.com/Vovarama1992/emelya-go/internal/money/deposit/infra"
+	payouthttp "github.com/Vovarama1992/emelya-go/internal/money/payout/delivery"
+	payoutinfra "github.com/Vovarama1992/emelya-go/internal/money/payout/infra"
 	usecase "github.com/Vovarama1992/emelya-go/internal/money/usecase"
 
 	rewardhttp "github.com/Vovarama1992/emelya-go/internal/money/reward/delivery"
@@ -23,16 +40,37 @@ import (
 	withdrawalhttp "github.com/Vovarama1992/emelya-go/internal/money/withdrawal/delivery"
 	withdrawalinfra "github.com/Vovarama1992/emelya-go/internal/money/withdrawal/infra"
 
+	exporthttp "github.com/Vovarama1992/emelya-go/internal/money/export/delivery"
+
 	tariffhttp "github.com/Vovarama1992/emelya-go/internal/money/tariff/delivery"
 	tariffinfra "github.com/Vovarama1992/emelya-go/internal/money/tariff/infra"
 
+	notificationhttp "github.com/Vovarama1992/emelya-go/internal/notification/delivery"
+	notificationinfra "github.com/Vovarama1992/emelya-go/internal/notification/infra"
+	notificationusecase "github.com/Vovarama1992/emelya-go/internal/notification/usecase"
+
 	"github.com/Vovarama1992/emelya-go/internal/notifier"
-	notifieradapter "github.com/Vovarama1992/emelya-go/internal/notifier"
+
+	outboxinfra "github.com/Vovarama1992/emelya-go/internal/outbox/infra"
+	outboxmodel "github.com/Vovarama1992/emelya-go/internal/outbox/model"
+	outboxusecase "github.com/Vovarama1992/emelya-go/internal/outbox/usecase"
+
+	rbachttp "github.com/Vovarama1992/emelya-go/internal/rbac/delivery"
+	rbacinfra "github.com/Vovarama1992/emelya-go/internal/rbac/infra"
+	rbacusecase "github.com/Vovarama1992/emelya-go/internal/rbac/usecase"
 
 	useradapter "github.com/Vovarama1992/emelya-go/internal/user/http"
 	userinfra "github.com/Vovarama1992/emelya-go/internal/user/infra"
 	userusecase "github.com/Vovarama1992/emelya-go/internal/user/usecase"
 
+	telegramhttp "github.com/Vovarama1992/emelya-go/internal/telegram/delivery"
+	telegraminfra "github.com/Vovarama1992/emelya-go/internal/telegram/infra"
+	telegramusecase "github.com/Vovarama1992/emelya-go/internal/telegram/usecase"
+
+	webhookhttp "github.com/Vovarama1992/emelya-go/internal/webhook/delivery"
+	webhookinfra "github.com/Vovarama1992/emelya-go/internal/webhook/infra"
+	webhookusecase "github.com/Vovarama1992/emelya-go/internal/webhook/usecase"
+
 	"github.com/joho/godotenv"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/cors"
@@ -63,40 +101,185 @@ func main() {
 	})
 	defer redisClient.Close()
 
+	// Лента уведомлений в кабинете — драйвер канала inapp
+	inboxRepo := notificationinfra.NewInboxRepository(dbConn)
+	inboxService := notificationusecase.NewInboxService(inboxRepo, notificationusecase.NewInboxHub(redisClient))
+	notifier.RegisterDriver(notifier.DriverInbox, notificationusecase.InboxDriver(inboxService))
+
+	// Очередь писем — драйвер канала email; отправляет её фоновый отправитель
+	emailQueueRepo := notificationinfra.NewEmailQueueRepository(dbConn)
+	notifier.RegisterDriver(notifier.DriverEmailQueue, notificationusecase.EmailQueueDriver(emailQueueRepo))
+
+	// SMS через провайдеров с перебором и журналом; статусы доставки опрашиваются в фоне
+	smsRepo := notificationinfra.NewSmsRepository(dbConn)
+	smsStatusCfg := notificationusecase.LoadSmsStatusConfigFromEnv()
+	notifier.RegisterDriver(notifier.DriverSmsFailover, notificationusecase.SmsFailoverDriver(smsRepo, smsStatusCfg))
+
 	// Базовые компоненты
-	notifierService := notifier.NewNotifier()
+	notifierCfg := notifier.LoadConfigFromEnv()
+	notifierService, err := notifier.NewNotifier(notifierCfg)
+	if err != nil {
+		log.Fatal("Ошибка настройки каналов уведомлений:", err)
+	}
+	if notifierCfg.EmailQueueDriver == notifier.DriverEmailQueue {
+		log.Fatal("NOTIFY_EMAIL_QUEUE_DRIVER не может быть очередью")
+	}
+	emailTransport, err := notifier.OpenChannel(notifier.ChannelEmail, notifierCfg.EmailQueueDriver, notifierCfg.Settings)
+	if err != nil {
+		log.Fatal("Ошибка настройки отправки писем из очереди:", err)
+	}
+	emailSender := notificationusecase.NewEmailSender(emailQueueRepo, emailTransport, notificationusecase.LoadEmailSenderConfigFromEnv())
+	var smsProviders []notifier.SmsProvider
+	if notifierCfg.Drivers[notifier.ChannelSMS] == notifier.DriverSmsFailover {
+		if smsProviders, err = notifier.LoadSmsProviders(notifierCfg.Settings); err != nil {
+			log.Fatal("Ошибка настройки SMS-провайдеров:", err)
+		}
+	}
+	smsStatusPoller := notificationusecase.NewSmsStatusPoller(smsRepo, smsProviders, smsStatusCfg)
+
+	// Журнал действий администраторов
+	auditRepo := auditinfra.NewAuditRepository(dbConn)
+	auditService := auditusecase.NewAuditService(auditRepo)
+
+	// Хранилище загруженных файлов (документы KYC)
+	fileStorage, err := storage.NewFromEnv()
+	if err != nil {
+		log.Fatal("Ошибка инициализации хранилища файлов:", err)
+	}
+	kycRepo := kycinfra.NewKYCRepository(dbConn)
+	kycLimits := usecase.LoadKYCLimitsFromEnv()
 
 	// Инфра и сервисы: деньги
 	depositRepo := depositinfra.NewDepositRepository(dbConn)
 	rewardRepo := rewardinfra.NewRewardRepository(dbConn)
 	withdrawalRepo := withdrawalinfra.NewWithdrawalRepository(dbConn)
 	tarifRepo := tariffinfra.NewTariffRepository(dbConn)
+	approvalRepo := approvalinfra.NewApprovalRepository(dbConn)
+	payoutRepo := payoutinfra.NewPayoutRepository(dbConn)
 
 	tariffService := usecase.NewTariffService(tarifRepo)
-	rewardService := usecase.NewRewardService(rewardRepo, depositRepo)
-	depositService := usecase.NewDepositService(depositRepo, rewardService, tariffService, dbConn, notifierService)
-	withdrawalService := usecase.NewWithdrawalService(withdrawalRepo, rewardService, dbConn, notifierService)
+	rewardService := usecase.NewRewardService(rewardRepo, depositRepo, dbConn)
+	depositService := usecase.NewDepositService(depositRepo, rewardService, tariffService, kycRepo, kycLimits, dbConn)
+	payoutService := usecase.NewPayoutService(payoutRepo, auditService, notifierService)
+	contactRepo := authinfra.NewContactChangeRepository(dbConn)
+	withdrawalService := usecase.NewWithdrawalService(withdrawalRepo, rewardService, payoutService, kycRepo, contactRepo, kycLimits, dbConn)
 	operationService := usecase.NewOperationsService(depositService, rewardService, withdrawalService)
+	exportService := usecase.NewExportService(depositRepo, withdrawalRepo, rewardRepo, auditService)
+	approvalService := usecase.NewApprovalService(
+		approvalRepo,
+		depositService,
+		withdrawalService,
+		rewardService,
+		usecase.LoadApprovalThresholdsFromEnv(),
+	)
+
+	// Номера карт из профиля переезжают в зашифрованные реквизиты
+	if err := payoutService.MigrateLegacyCards(context.Background()); err != nil {
+		log.Printf("Не удалось перенести карты из профиля: %v", err)
+	}
 
 	// User (теперь после money-сервисов)
 	userRepo := userinfra.NewUserRepository(dbConn)
-	userService := userusecase.NewService(userRepo, notifierService, depositService, rewardService)
+	profileChangeRepo := userinfra.NewProfileChangeRepository(dbConn)
+	userService := userusecase.NewService(userRepo, profileChangeRepo, notifierService, depositService, rewardService, auditService)
+
+	// Верификация личности
+	kycService := kycusecase.NewKYCService(kycRepo, fileStorage, userService, auditService, notifierService)
+
+	// Роли и права
+	rbacRepo := rbacinfra.NewRBACRepository(dbConn)
+	rbacService := rbacusecase.NewRBACService(rbacRepo, userService, auditService)
+
+	// Outbox: доставка доменных событий в каналы уведомлений
+	outboxRepo := outboxinfra.NewOutboxRepository(dbConn)
+	outboxDispatcher := outboxusecase.NewDispatcher(outboxRepo, outboxusecase.LoadDispatcherConfigFromEnv())
+	operatorNotifications := outboxusecase.NewOperatorNotifications(notifierService, userService)
+	outboxDispatcher.Subscribe("operator_notifications", operatorNotifications, operatorNotifications.Events()...)
+	operatorInbox := outboxusecase.NewOperatorInbox(notifierService, userService, rbacService)
+	outboxDispatcher.Subscribe("operator_inbox", operatorInbox, operatorInbox.Events()...)
+
+	// Telegram-бот: заявки операторам с кнопками решения
+	telegramCfg := telegramusecase.LoadBotConfigFromEnv()
+	telegramLinkRepo := telegraminfra.NewLinkRepository(dbConn)
+	telegramLinkService := telegramusecase.NewLinkService(telegramLinkRepo, redisClient, userService, rbacService, auditService, telegramCfg)
+	var telegramBot *telegramusecase.Bot
+	if telegramCfg.Enabled {
+		telegramBot = telegramusecase.NewBot(telegramusecase.BotDeps{
+			API:               telegraminfra.NewBotClient(telegramCfg.APIURL, telegramCfg.Token, telegramCfg.PollTimeout),
+			Links:             telegramLinkRepo,
+			Posts:             telegraminfra.NewPostRepository(dbConn),
+			LinkService:       telegramLinkService,
+			UserService:       userService,
+			Access:            rbacService,
+			DepositService:    depositService,
+			WithdrawalService: withdrawalService,
+			ApprovalService:   approvalService,
+			TariffService:     tariffService,
+			Notifier:          notifierService,
+			Redis:             redisClient,
+		}, telegramCfg)
+		outboxDispatcher.Subscribe("telegram_bot", telegramBot, telegramBot.Events()...)
+	}
+
+	// Уведомления пользователям о деньгах по их настройкам
+	notificationPrefsRepo := notificationinfra.NewPreferencesRepository(dbConn)
+	notificationPrefsService := notificationusecase.NewPreferencesService(notificationPrefsRepo)
+	notificationTemplateRepo := notificationinfra.NewTemplateRepository(dbConn)
+	notifierService.Templates().SetStore(notificationTemplateRepo)
+	notificationTemplateService := notificationusecase.NewTemplateService(notificationTemplateRepo, notifierService.Templates(), auditService)
+	userNotifications := notificationusecase.NewUserNotifications(dbConn, notificationPrefsRepo, userService, notifierService)
+	outboxDispatcher.Subscribe("user_notifications", userNotifications, userNotifications.Events()...)
+	outboxDispatcher.Subscribe("user_message_delivery", notificationusecase.NewUserMessageDelivery(notifierService), outboxmodel.EventUserMessage)
+
+	// Исходящие вебхуки: outbox ставит доставки, отправитель шлёт их с повторами
+	webhookSubRepo := webhookinfra.NewSubscriptionRepository(dbConn)
+	webhookDeliveryRepo := webhookinfra.NewDeliveryRepository(dbConn)
+	webhookService := webhookusecase.NewWebhookService(webhookSubRepo, webhookDeliveryRepo, auditService)
+	webhookFanout := webhookusecase.NewFanout(webhookSubRepo, webhookDeliveryRepo)
+	outboxDispatcher.Subscribe("webhooks", webhookFanout, webhookFanout.Events()...)
+	webhookSender := webhookusecase.NewSender(webhookSubRepo, webhookDeliveryRepo, webhookusecase.LoadSenderConfigFromEnv())
+
+	dispatcherCtx, stopDispatcher := context.WithCancel(context.Background())
+	defer stopDispatcher()
+	outboxDispatcher.Start(dispatcherCtx)
+	webhookSender.Start(dispatcherCtx)
+	emailSender.Start(dispatcherCtx)
+	smsStatusPoller.Start(dispatcherCtx)
+	if telegramBot != nil {
+		telegramBot.Start(dispatcherCtx)
+	}
 
 	// Auth
-	authService := authusecase.NewAuthService(userService, redisClient, notifierService)
+	totpRepo := authinfra.NewTOTPRepository(dbConn)
+	authService := authusecase.NewAuthService(userService, redisClient, notifierService, auditService, totpRepo, contactRepo)
 	authHandler := authadapter.NewHandler(authService, notifierService)
 
+	// Заявки с сайта
+	leadRepo := leadinfra.NewLeadRepository(dbConn)
+	leadService := leadusecase.NewLeadService(leadRepo, redisClient, tariffService, userService, notifierService, auditService)
+
 	// Cron
 	cronScheduler := scheduler.StartDepositRewardCron(depositService)
 	defer cronScheduler.Stop()
 
 	// HTTP Handlers
 	userHandler := useradapter.NewHandler(userService, notifierService, operationService)
-	depositHandler := deposithttp.NewHandler(depositService)
-	rewardHandler := rewardhttp.NewHandler(rewardService)
-	withdrawalHandler := withdrawalhttp.NewHandler(withdrawalService)
-	notifyHandler := notifieradapter.NewNotifyHandler(notifierService)
+	depositHandler := deposithttp.NewHandler(depositService, approvalService)
+	rewardHandler := rewardhttp.NewHandler(rewardService, approvalService)
+	withdrawalHandler := withdrawalhttp.NewHandler(withdrawalService, approvalService)
+	payoutHandler := payouthttp.NewHandler(payoutService)
+	approvalHandler := approvalhttp.NewHandler(approvalService)
+	leadHandler := leadhttp.NewHandler(leadService)
 	tarifHandler := tariffhttp.NewHandler(tariffService)
+	auditHandler := auditadapter.NewHandler(auditService)
+	rbacHandler := rbachttp.NewHandler(rbacService)
+	exportHandler := exporthttp.NewHandler(exportService)
+	kycHandler := kychttp.NewHandler(kycService)
+	emailQueueService := notificationusecase.NewEmailQueueService(emailQueueRepo, auditService)
+	smsService := notificationusecase.NewSmsService(smsRepo)
+	notificationHandler := notificationhttp.NewHandler(notificationPrefsService, notificationTemplateService, inboxService, emailQueueService, smsService, authService)
+	telegramHandler := telegramhttp.NewHandler(telegramLinkService)
+	webhookHandler := webhookhttp.NewHandler(webhookService)
 
 	// Routes
 	mux := http.NewServeMux()
@@ -104,13 +287,22 @@ func main() {
 		w.Write([]byte("pong"))
 	})
 
-	authadapter.RegisterRoutes(mux, authHandler)
-	useradapter.RegisterRoutes(mux, userHandler, userService)
-	notifieradapter.RegisterRoutes(mux, notifyHandler)
-	deposithttp.RegisterRoutes(mux, depositHandler, userService)
-	rewardhttp.RegisterRoutes(mux, rewardHandler, userService)
-	withdrawalhttp.RegisterRoutes(mux, withdrawalHandler, userService)
-	tariffhttp.RegisterRoutes(mux, tarifHandler, userService)
+	authadapter.RegisterRoutes(mux, authHandler, userService, authService, rbacService)
+	useradapter.RegisterRoutes(mux, userHandler, userService, authService, rbacService)
+	leadhttp.RegisterRoutes(mux, leadHandler, userService, authService, rbacService)
+	deposithttp.RegisterRoutes(mux, depositHandler, userService, authService, rbacService)
+	rewardhttp.RegisterRoutes(mux, rewardHandler, userService, authService, rbacService)
+	withdrawalhttp.RegisterRoutes(mux, withdrawalHandler, userService, authService, rbacService)
+	payouthttp.RegisterRoutes(mux, payoutHandler, userService, authService, rbacService)
+	tariffhttp.RegisterRoutes(mux, tarifHandler, userService, authService, rbacService)
+	auditadapter.RegisterRoutes(mux, auditHandler, userService, authService, rbacService)
+	approvalhttp.RegisterRoutes(mux, approvalHandler, userService, authService, rbacService)
+	rbachttp.RegisterRoutes(mux, rbacHandler, userService, authService, rbacService)
+	exporthttp.RegisterRoutes(mux, exportHandler, userService, authService, rbacService)
+	kychttp.RegisterRoutes(mux, kycHandler, userService, authService, rbacService)
+	notificationhttp.RegisterRoutes(mux, notificationHandler, userService, authService, rbacService)
+	telegramhttp.RegisterRoutes(mux, telegramHandler, userService, authService)
+	webhookhttp.RegisterRoutes(mux, webhookHandler, userService, authService, rbacService)
 
 	// Swagger
 	mux.Handle("/api/docs/", httpSwagger.Handler(
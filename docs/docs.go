@@ -15,7 +15,7 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/admin/deposit/approve": {
+        "/api/admin/approval/confirm": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -24,37 +24,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "deposit"
+                    "admin-approval"
                 ],
-                "summary": "Одобрить депозит",
+                "summary": "Админ: подтвердить и выполнить операцию другого администратора",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID депозита",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Дата одобрения в формате RFC3339",
-                        "name": "approved_at",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Дата блокировки в формате RFC3339",
-                        "name": "block_until",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "number",
-                        "description": "Дневная награда",
-                        "name": "daily_reward",
-                        "in": "query",
-                        "required": true
+                        "description": "ID заявки",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/approvalhttp.ConfirmApprovalRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -76,6 +57,33 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -88,30 +96,66 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/deposit/by-user": {
+        "/api/admin/approval/pending": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "deposit"
+                    "admin-approval"
                 ],
-                "summary": "Получить все депозиты по user_id (только для админа)",
+                "summary": "Админ: операции, ожидающие подтверждения вторым администратором",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {}
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/approval/reject": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-approval"
+                ],
+                "summary": "Админ: отклонить операцию, ожидающую подтверждения",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID пользователя",
-                        "name": "user_id",
-                        "in": "query",
-                        "required": true
+                        "description": "ID заявки и причина",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/approvalhttp.RejectApprovalRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {}
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -123,8 +167,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -132,8 +176,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -153,34 +197,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/deposit/close": {
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/admin/audit": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "deposit"
+                    "admin-audit"
                 ],
-                "summary": "Закрыть депозит",
+                "summary": "Админ: поиск по журналу действий",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID депозита",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "ID администратора",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Действие, например deposit.approve",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тип сущности: deposit, reward, withdrawal",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID сущности",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода (RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода (RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество записей (по умолчанию 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/audit_model.Entry"
                             }
                         }
                     },
@@ -205,7 +287,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/deposit/create": {
+        "/api/admin/auth/2fa/reset": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -214,24 +296,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-deposit"
+                    "admin-user"
                 ],
-                "summary": "Админ: создать депозит вручную",
+                "summary": "Админ: сбросить 2FA пользователя (все его сессии завершаются)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID инвестора",
-                        "name": "user_id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "description": "Данные депозита",
+                        "description": "ID пользователя",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/deposithttp.AdminCreateDepositRequest"
+                            "$ref": "#/definitions/authadapter.UserIDRequest"
                         }
                     }
                 ],
@@ -240,7 +315,9 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -252,6 +329,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -264,8 +359,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/deposit/delete": {
-            "delete": {
+        "/api/admin/auth/unlock": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -273,16 +368,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-deposit"
+                    "admin-user"
                 ],
-                "summary": "Админ: удалить депозит (ручное удаление)",
+                "summary": "Админ: снять блокировку входа с пользователя",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID депозита",
-                        "name": "deposit_id",
-                        "in": "query",
-                        "required": true
+                        "description": "ID пользователя",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authadapter.UserIDRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -304,6 +401,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -316,15 +422,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/deposit/get": {
-            "get": {
+        "/api/admin/deposit/approve": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "deposit"
                 ],
-                "summary": "Получить депозит по ID",
+                "summary": "Одобрить депозит",
                 "parameters": [
                     {
                         "type": "integer",
@@ -332,12 +441,49 @@ const docTemplate = `{
                         "name": "id",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата одобрения в формате RFC3339",
+                        "name": "approved_at",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество дней блокировки",
+                        "name": "block_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Дневная награда",
+                        "name": "daily_reward",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID тарифа",
+                        "name": "tariff_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
-                        "schema": {}
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "202": {
+                        "description": "approval_id",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -348,65 +494,23 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/api/admin/deposit/pending": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "admin-deposit"
-                ],
-                "summary": "Админ: получить все депозиты в статусе pending",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {}
-                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/api/admin/deposit/total-approved-amount": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "admin-deposit"
-                ],
-                "summary": "Получить общую сумму одобренных депозитов",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "number"
-                            }
-                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -420,15 +524,15 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/reward/by-user": {
+        "/api/admin/deposit/by-user": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin-reward"
+                    "deposit"
                 ],
-                "summary": "Админ: получить все награды пользователя",
+                "summary": "Получить все депозиты по user_id (только для админа)",
                 "parameters": [
                     {
                         "type": "integer",
@@ -455,6 +559,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -467,7 +589,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/reward/referral-income": {
+        "/api/admin/deposit/close": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -476,18 +598,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-reward"
+                    "deposit"
                 ],
-                "summary": "Админ: начислить доход от реферала",
+                "summary": "Закрыть депозит",
                 "parameters": [
                     {
-                        "description": "Данные о вознаграждении",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rewardhttp.AdminCreateReferralRewardRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID депозита",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -521,53 +641,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/reward/total-available": {
-            "get": {
+        "/api/admin/deposit/create": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin-reward"
+                    "admin-deposit"
+                ],
+                "summary": "Админ: создать депозит вручную",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID инвестора",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные депозита",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/deposithttp.AdminCreateDepositRequest"
+                        }
+                    }
                 ],
-                "summary": "Получить общую сумму доступных к выводу средств (агрегация по всем пользователям)",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "deposit_id",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "number"
-                            }
+                            "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "202": {
+                        "description": "approval_id",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
-                    }
-                }
-            }
-        },
-        "/api/admin/tariffs": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "tariff"
-                ],
-                "summary": "Получить все тарифы",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/tariff.Tariff"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
@@ -581,8 +705,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/admin/deposit/delete": {
+            "delete": {
                 "consumes": [
                     "application/json"
                 ],
@@ -590,18 +716,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tariff"
+                    "admin-deposit"
                 ],
-                "summary": "Обновить тариф",
+                "summary": "Админ: удалить депозит (мягкое удаление, остаётся в журнале)",
                 "parameters": [
                     {
-                        "description": "Обновляемые данные тарифа",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/tariffhttp.UpdateTariffRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID депозита",
+                        "name": "deposit_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Причина удаления",
+                        "name": "reason",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -633,38 +763,30 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/admin/deposit/get": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tariff"
+                    "deposit"
                 ],
-                "summary": "Создать тариф",
+                "summary": "Получить депозит по ID",
                 "parameters": [
                     {
-                        "description": "Данные тарифа",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/tariffhttp.CreateTariffRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID депозита",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                        "schema": {}
                     },
                     "400": {
                         "description": "Bad Request",
@@ -685,19 +807,21 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/admin/deposit/history": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tariff"
+                    "admin-deposit"
                 ],
-                "summary": "Удалить тариф",
+                "summary": "Админ: история смены статусов депозита",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID тарифа",
+                        "description": "ID депозита",
                         "name": "id",
                         "in": "query",
                         "required": true
@@ -706,6 +830,13 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {}
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -713,14 +844,34 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    }
+                }
+            }
+        },
+        "/api/admin/deposit/pending": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-deposit"
+                ],
+                "summary": "Админ: получить все депозиты в статусе pending",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {}
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -734,7 +885,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/user/add-referal": {
+        "/api/admin/deposit/reject": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -743,17 +894,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-user"
+                    "admin-deposit"
                 ],
-                "summary": "Админ добавляет реферала пользователю",
+                "summary": "Админ: отклонить заявку на депозит",
                 "parameters": [
                     {
-                        "description": "ID пользователя и ID реферала",
+                        "description": "ID депозита и причина",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.AddReferralRequest"
+                            "$ref": "#/definitions/deposithttp.AdminRejectDepositRequest"
                         }
                     }
                 ],
@@ -776,8 +927,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -785,36 +936,14 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/api/admin/user/all": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "user"
-                ],
-                "summary": "Получить всех пользователей",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_Vovarama1992_emelya-go_internal_user_model.User"
-                            }
-                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -828,37 +957,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/user/operations": {
+        "/api/admin/deposit/total-approved-amount": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin-user"
-                ],
-                "summary": "Админ: все операции пользователя (депозиты, выводы, награды)",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID пользователя",
-                        "name": "user_id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "admin-deposit"
                 ],
+                "summary": "Получить общую сумму одобренных депозитов",
                 "responses": {
                     "200": {
                         "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/operation.Operations"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "string"
+                                "type": "number"
                             }
                         }
                     },
@@ -874,29 +988,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/user/search-id": {
+        "/api/admin/emails": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "admin-notifications"
                 ],
-                "summary": "Найти пользователя по ID",
+                "summary": "Админ: очередь писем и статус доставки по адресатам",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pending, sent или failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Адрес получателя",
+                        "name": "to",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "ID пользователя",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "Письма старше этого (для следующей страницы)",
+                        "name": "before_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество (по умолчанию 50, не больше 200)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Vovarama1992_emelya-go_internal_user_model.User"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/notification_model.QueuedEmail"
+                            }
                         }
                     },
                     "400": {
@@ -908,15 +1042,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -929,8 +1054,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/user/update-profile": {
+        "/api/admin/emails/retry": {
             "post": {
+                "description": "Письмо в статусе failed снова ставится в очередь с полным запасом попыток.",
                 "consumes": [
                     "application/json"
                 ],
@@ -938,23 +1064,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-user"
+                    "admin-notifications"
                 ],
-                "summary": "Админ обновляет профиль пользователя",
+                "summary": "Админ: повторить отправку письма",
                 "parameters": [
                     {
-                        "description": "Обновляемые поля пользователя",
+                        "description": "ID письма",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.AdminUpdateProfileRequest"
+                            "$ref": "#/definitions/notificationhttp.RetryEmailRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/notification_model.QueuedEmail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -962,8 +1094,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -971,8 +1103,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -992,21 +1124,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/withdrawal/all": {
+        "/api/admin/finance/export/deposits": {
             "get": {
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "admin-withdrawal"
+                    "admin-finance"
+                ],
+                "summary": "Админ: выгрузка депозитов в CSV",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Начало периода (RFC3339), по умолчанию 30 дней назад",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода (RFC3339), по умолчанию сейчас; не больше года от начала",
+                        "name": "to",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Админ: все заявки на вывод",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {}
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
@@ -1021,37 +1175,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/withdrawal/approve": {
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/admin/finance/export/rewards": {
+            "get": {
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "admin-withdrawal"
+                    "admin-finance"
                 ],
-                "summary": "Админ: подтвердить заявку на вывод",
+                "summary": "Админ: выгрузка наград в CSV",
                 "parameters": [
                     {
-                        "description": "ID заявки",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/withdrawalhttp.AdminApproveWithdrawalRequest"
-                        }
+                        "type": "string",
+                        "description": "Начало периода (RFC3339), по умолчанию 30 дней назад",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода (RFC3339), по умолчанию сейчас; не больше года от начала",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -1075,21 +1226,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/withdrawal/pending": {
+        "/api/admin/finance/export/withdrawals": {
             "get": {
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "admin-withdrawal"
+                    "admin-finance"
+                ],
+                "summary": "Админ: выгрузка заявок на вывод в CSV",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Начало периода (RFC3339), по умолчанию 30 дней назад",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода (RFC3339), по умолчанию сейчас; не больше года от начала",
+                        "name": "to",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Админ: заявки на вывод в статусе pending",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {}
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
@@ -1104,32 +1277,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/admin/withdrawal/reject": {
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/admin/kyc/application": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin-withdrawal"
+                    "admin-kyc"
                 ],
-                "summary": "Админ: отклонить заявку на вывод",
+                "summary": "Админ: заявка на верификацию со списком документов",
                 "parameters": [
                     {
-                        "description": "ID заявки и причина",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/withdrawalhttp.AdminRejectWithdrawalRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID заявки",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/kyc_model.Application"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1137,8 +1311,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1158,7 +1332,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/auth/confirm-login": {
+        "/api/admin/kyc/approve": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -1167,17 +1341,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin-kyc"
                 ],
-                "summary": "Подтверждение входа",
+                "summary": "Админ: подтвердить верификацию",
                 "parameters": [
                     {
-                        "description": "Телефон и код",
+                        "description": "ID заявки",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/authadapter.ConfirmRequest"
+                            "$ref": "#/definitions/kychttp.KYCApplicationRequest"
                         }
                     }
                 ],
@@ -1200,6 +1374,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1212,32 +1404,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/auth/login-by-creds": {
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/admin/kyc/document": {
+            "get": {
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "auth"
+                    "admin-kyc"
                 ],
-                "summary": "Вход по логину и паролю",
+                "summary": "Админ: файл документа (просмотр пишется в журнал)",
                 "parameters": [
                     {
-                        "description": "Логин и пароль",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/authadapter.LoginRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID документа",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1245,8 +1438,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1254,8 +1447,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1266,24 +1459,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/auth/me": {
+        "/api/admin/kyc/pending": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin-kyc"
                 ],
-                "summary": "Получение текущего пользователя",
+                "summary": "Админ: заявки на верификацию, ожидающие проверки",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Vovarama1992_emelya-go_internal_user_model.User"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/kyc_model.Application"
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1294,7 +1490,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/auth/request-login": {
+        "/api/admin/kyc/reject": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -1303,17 +1499,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin-kyc"
                 ],
-                "summary": "Запрос входа по телефону",
+                "summary": "Админ: отклонить верификацию",
                 "parameters": [
                     {
-                        "description": "Телефон",
+                        "description": "ID заявки и причина",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/authadapter.PhoneRequest"
+                            "$ref": "#/definitions/kychttp.RejectKYCRequest"
                         }
                     }
                 ],
@@ -1336,6 +1532,24 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1348,8 +1562,81 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/auth/request-register": {
+        "/api/admin/leads": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-leads"
+                ],
+                "summary": "Админ: заявки с сайта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "new, in_progress, converted, rejected или spam",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID ответственного сотрудника",
+                        "name": "assignee_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Телефон",
+                        "name": "phone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Заявки старше этой (для следующей страницы)",
+                        "name": "before_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество (по умолчанию 50, не больше 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/lead_model.Lead"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/leads/assign": {
             "post": {
+                "description": "Новая заявка при назначении переходит в статус in_progress. assignee_id = null снимает ответственного.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1357,23 +1644,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin-leads"
                 ],
-                "summary": "Запрос на регистрацию",
+                "summary": "Админ: назначить ответственного за заявку",
                 "parameters": [
                     {
-                        "description": "Данные пользователя",
+                        "description": "ID заявки и сотрудника",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/authadapter.RegisterRequest"
+                            "$ref": "#/definitions/leadhttp.AssignLeadRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/lead_model.Lead"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1381,8 +1674,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1402,8 +1704,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/deposit/create": {
+        "/api/admin/leads/convert": {
             "post": {
+                "description": "Создаёт пользователя с телефоном из заявки и присылает ему логин и пароль по SMS. Если номер уже зарегистрирован, заявка привязывается к существующему пользователю (created = false).",
                 "consumes": [
                     "application/json"
                 ],
@@ -1411,23 +1714,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "deposit"
+                    "admin-leads"
                 ],
-                "summary": "Создать заявку на депозит",
+                "summary": "Админ: зарегистрировать пользователя по заявке",
                 "parameters": [
                     {
-                        "description": "Сумма депозита",
+                        "description": "ID заявки и данные пользователя",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/deposithttp.DepositCreateRequest"
+                            "$ref": "#/definitions/leadhttp.ConvertLeadRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/lead_model.Conversion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1435,8 +1744,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1444,8 +1753,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1465,25 +1774,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/deposit/my": {
+        "/api/admin/leads/get": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "deposit"
+                    "admin-leads"
+                ],
+                "summary": "Админ: заявка с сайта",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID заявки",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Получить все депозиты пользователя",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {}
+                            "$ref": "#/definitions/lead_model.Lead"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1503,8 +1829,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/notify": {
+        "/api/admin/leads/status": {
             "post": {
+                "description": "Статус converted ставится только регистрацией пользователя по заявке.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1512,23 +1839,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notifier"
+                    "admin-leads"
                 ],
-                "summary": "Отправка произвольного уведомления оператору",
+                "summary": "Админ: сменить статус заявки",
                 "parameters": [
                     {
-                        "description": "Текст уведомления",
+                        "description": "ID заявки и статус",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/notifier.NotifyRequest"
+                            "$ref": "#/definitions/leadhttp.LeadStatusRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/lead_model.Lead"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1536,8 +1869,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1557,29 +1899,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/reward/my": {
+        "/api/admin/notification-templates": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reward"
+                    "admin-notifications"
                 ],
-                "summary": "Юзер: получить свои вознаграждения",
+                "summary": "Админ: шаблоны сообщений — действующие тексты, встроенные тексты и примеры данных",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
-                            "items": {}
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "items": {
+                                "$ref": "#/definitions/notification_model.TemplateView"
                             }
                         }
                     },
@@ -1595,36 +1930,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/user/balance": {
+        "/api/admin/notification-templates/get": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin-user"
+                    "admin-notifications"
                 ],
-                "summary": "Админ: получить баланс пользователя (депозиты и доступные награды)",
+                "summary": "Админ: шаблон сообщения",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID пользователя",
-                        "name": "user_id",
+                        "type": "string",
+                        "description": "Ключ шаблона",
+                        "name": "key",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык (по умолчанию ru)",
+                        "name": "locale",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/notification_model.TemplateView"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "number"
+                                "type": "string"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1644,7 +1991,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/user/update-profile": {
+        "/api/admin/notification-templates/preview": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -1653,17 +2000,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "admin-notifications"
                 ],
-                "summary": "Обновить профиль (самостоятельно)",
+                "summary": "Админ: предпросмотр шаблона (черновика или сохранённого) на данных",
                 "parameters": [
                     {
-                        "description": "Обновляемые поля",
+                        "description": "Ключ, черновик и данные",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.UpdateProfileRequest"
+                            "$ref": "#/definitions/notificationhttp.PreviewTemplateRequest"
                         }
                     }
                 ],
@@ -1671,10 +2018,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/notifier.Rendered"
                         }
                     },
                     "400": {
@@ -1686,8 +2030,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1707,25 +2051,56 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/withdrawal/my": {
-            "get": {
+        "/api/admin/notification-templates/reset": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "withdrawal"
+                    "admin-notifications"
+                ],
+                "summary": "Админ: вернуть встроенный текст шаблона",
+                "parameters": [
+                    {
+                        "description": "Ключ и язык",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notificationhttp.ResetTemplateRequest"
+                        }
+                    }
                 ],
-                "summary": "Юзер: получить свои заявки на вывод",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {}
+                            "$ref": "#/definitions/notification_model.TemplateView"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1745,8 +2120,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/withdrawal/request": {
+        "/api/admin/notification-templates/update": {
             "post": {
+                "description": "Go-шаблоны: {{.Поле}}, {{money .Amount}}, {{percent .DailyReward}}. HTML оборачивается в общий макет письма. Шаблон проверяется на примере данных.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1754,17 +2130,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "withdrawal"
+                    "admin-notifications"
                 ],
-                "summary": "Юзер: создать заявку на вывод",
+                "summary": "Админ: изменить шаблон сообщения",
                 "parameters": [
                     {
-                        "description": "Данные заявки на вывод",
+                        "description": "Тексты шаблона",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/withdrawalhttp.CreateWithdrawalRequest"
+                            "$ref": "#/definitions/notificationhttp.UpdateTemplateRequest"
                         }
                     }
                 ],
@@ -1772,10 +2148,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/notification_model.TemplateView"
                         }
                     },
                     "400": {
@@ -1787,9 +2160,233 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/payout-method/pending": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-payout"
+                ],
+                "summary": "Админ: реквизиты, ожидающие проверки",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/payout_model.PayoutMethod"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/payout-method/reject": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-payout"
+                ],
+                "summary": "Админ: отклонить реквизиты пользователя",
+                "parameters": [
+                    {
+                        "description": "ID реквизитов и причина",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payouthttp.RejectPayoutMethodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/payout-method/reveal": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-payout"
+                ],
+                "summary": "Админ: полный номер карты или счёта для проведения выплаты (просмотр пишется в журнал)",
+                "parameters": [
+                    {
+                        "description": "ID реквизитов",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payouthttp.PayoutMethodIDRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/payout-method/user": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-payout"
+                ],
+                "summary": "Админ: реквизиты пользователя (номера замаскированы)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/payout_model.PayoutMethod"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
@@ -1807,436 +2404,7326 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "authadapter.ConfirmRequest": {
+        },
+        "/api/admin/payout-method/verify": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-payout"
+                ],
+                "summary": "Админ: подтвердить реквизиты пользователя",
+                "parameters": [
+                    {
+                        "description": "ID реквизитов",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payouthttp.PayoutMethodIDRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/rbac/grant": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-rbac"
+                ],
+                "summary": "Админ: выдать право роли",
+                "parameters": [
+                    {
+                        "description": "Роль и право",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rbachttp.PermissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/rbac/revoke": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-rbac"
+                ],
+                "summary": "Админ: отозвать право у роли",
+                "parameters": [
+                    {
+                        "description": "Роль и право",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rbachttp.PermissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/rbac/roles": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-rbac"
+                ],
+                "summary": "Админ: роли и их права",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rbac_model.RolePermissions"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/rbac/user-role": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-rbac"
+                ],
+                "summary": "Админ: назначить роль пользователю",
+                "parameters": [
+                    {
+                        "description": "ID пользователя и роль",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rbachttp.SetUserRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/reward/by-user": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-reward"
+                ],
+                "summary": "Админ: получить все награды пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {}
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/reward/referral-income": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-reward"
+                ],
+                "summary": "Админ: начислить доход от реферала",
+                "parameters": [
+                    {
+                        "description": "Данные о вознаграждении",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rewardhttp.AdminCreateReferralRewardRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "202": {
+                        "description": "approval_id",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/reward/total-available": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-reward"
+                ],
+                "summary": "Получить общую сумму доступных к выводу средств (агрегация по всем пользователям)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "number"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/sms": {
+            "get": {
+                "description": "Текст сообщений не хранится, вместо него — шаблон или категория.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-notifications"
+                ],
+                "summary": "Админ: журнал SMS — провайдер, статус доставки и цена",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Телефон получателя",
+                        "name": "phone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sent, delivered, undelivered или failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Сообщения старше этого (для следующей страницы)",
+                        "name": "before_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество (по умолчанию 50, не больше 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/notification_model.SmsMessage"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/sms/costs": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-notifications"
+                ],
+                "summary": "Админ: расходы на SMS по провайдерам",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Начало периода (RFC3339), по умолчанию 30 дней назад",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода (RFC3339), по умолчанию сейчас",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/notification_model.SmsCostSummary"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/tariffs": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tariff"
+                ],
+                "summary": "Получить все тарифы",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/tariff.Tariff"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tariff"
+                ],
+                "summary": "Обновить тариф",
+                "parameters": [
+                    {
+                        "description": "Обновляемые данные тарифа",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tariffhttp.UpdateTariffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tariff"
+                ],
+                "summary": "Создать тариф",
+                "parameters": [
+                    {
+                        "description": "Данные тарифа",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tariffhttp.CreateTariffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tariff"
+                ],
+                "summary": "Удалить тариф",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тарифа",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/telegram/link": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "telegram"
+                ],
+                "summary": "Админ: привязанный аккаунт Telegram",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/telegram_model.Link"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/telegram/link-code": {
+            "post": {
+                "description": "Код одноразовый и живёт 10 минут: его нужно отправить боту командой /start \u003cкод\u003e (или открыть ссылку url). Доступно сотрудникам с правом на депозиты или выводы.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "telegram"
+                ],
+                "summary": "Админ: код привязки Telegram-бота",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/telegram_model.LinkCode"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/telegram/unlink": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "telegram"
+                ],
+                "summary": "Админ: отвязать аккаунт Telegram",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/user/add-referal": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-user"
+                ],
+                "summary": "Админ добавляет реферала пользователю",
+                "parameters": [
+                    {
+                        "description": "ID пользователя и ID реферала",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.AddReferralRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/user/all": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Получить всех пользователей",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_Vovarama1992_emelya-go_internal_user_model.User"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/user/operations": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-user"
+                ],
+                "summary": "Админ: все операции пользователя (депозиты, выводы, награды)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/operation.Operations"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/user/profile-changes/approve": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-user"
+                ],
+                "summary": "Админ: одобрить заявку на изменение профиля",
+                "parameters": [
+                    {
+                        "description": "ID заявки",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.ProfileChangeDecisionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/user/profile-changes/pending": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-user"
+                ],
+                "summary": "Админ: заявки на изменение профиля, ожидающие модерации",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/user.ProfileChange"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/user/profile-changes/reject": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-user"
+                ],
+                "summary": "Админ: отклонить заявку на изменение профиля",
+                "parameters": [
+                    {
+                        "description": "ID заявки и причина",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.ProfileChangeRejectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/user/profile-history": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-user"
+                ],
+                "summary": "Админ: история изменений профиля пользователя с прежними значениями",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/user.ProfileChange"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/user/search-id": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Найти пользователя по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vovarama1992_emelya-go_internal_user_model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/user/update-profile": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-user"
+                ],
+                "summary": "Админ обновляет профиль пользователя (без модерации, с записью в историю)",
+                "parameters": [
+                    {
+                        "description": "Обновляемые поля пользователя",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.AdminUpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/webhooks": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-webhooks"
+                ],
+                "summary": "Админ: подписки на вебхуки",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhook_model.Subscription"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/webhooks/create": {
+            "post": {
+                "description": "Запросы подписываются HMAC-SHA256 секретом подписки от \"\u003cX-Emelya-Timestamp\u003e.\u003cтело\u003e\", подпись — в X-Emelya-Signature (sha256=\u003chex\u003e). Секрет возвращается только в этом ответе.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-webhooks"
+                ],
+                "summary": "Админ: создать подписку на вебхуки",
+                "parameters": [
+                    {
+                        "description": "Адрес, события, секрет",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhookhttp.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhook_model.CreatedSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/webhooks/delete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-webhooks"
+                ],
+                "summary": "Админ: удалить подписку вместе с журналом доставок",
+                "parameters": [
+                    {
+                        "description": "ID подписки",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhookhttp.DeleteWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/webhooks/deliveries": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-webhooks"
+                ],
+                "summary": "Админ: журнал доставок вебхуков",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID подписки",
+                        "name": "subscription_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending, delivered или failed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тип события",
+                        "name": "event_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Доставки старше этой (для следующей страницы)",
+                        "name": "before_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество (по умолчанию 50, не больше 200)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhook_model.Delivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/webhooks/delivery": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-webhooks"
+                ],
+                "summary": "Админ: доставка вебхука с журналом попыток и кодами ответов",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID доставки",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhook_model.DeliveryDetails"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/webhooks/events": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-webhooks"
+                ],
+                "summary": "Админ: типы событий, на которые можно подписаться",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/webhooks/redeliver": {
+            "post": {
+                "description": "Доставка снова ставится в очередь с полным запасом попыток.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-webhooks"
+                ],
+                "summary": "Админ: отправить доставку повторно",
+                "parameters": [
+                    {
+                        "description": "ID доставки",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhookhttp.RedeliverRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/webhook_model.Delivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/webhooks/update": {
+            "post": {
+                "description": "Меняются только переданные поля. Новый секрет возвращается в ответе.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-webhooks"
+                ],
+                "summary": "Админ: изменить подписку на вебхуки",
+                "parameters": [
+                    {
+                        "description": "ID и изменяемые поля",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhookhttp.UpdateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhook_model.CreatedSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/withdrawal/all": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-withdrawal"
+                ],
+                "summary": "Админ: все заявки на вывод",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {}
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/withdrawal/approve": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-withdrawal"
+                ],
+                "summary": "Админ: подтвердить заявку на вывод",
+                "parameters": [
+                    {
+                        "description": "ID заявки",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/withdrawalhttp.AdminApproveWithdrawalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "202": {
+                        "description": "approval_id",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/withdrawal/failed": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-withdrawal"
+                ],
+                "summary": "Админ: выплата по заявке не прошла",
+                "parameters": [
+                    {
+                        "description": "ID заявки и причина",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/withdrawalhttp.AdminWithdrawalStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/withdrawal/history": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-withdrawal"
+                ],
+                "summary": "Админ: история смены статусов заявки",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID заявки",
+                        "name": "withdrawal_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {}
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/withdrawal/paid": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-withdrawal"
+                ],
+                "summary": "Админ: заявка выплачена",
+                "parameters": [
+                    {
+                        "description": "ID заявки",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/withdrawalhttp.AdminWithdrawalStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/withdrawal/pending": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-withdrawal"
+                ],
+                "summary": "Админ: заявки на вывод в статусе pending",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {}
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/withdrawal/processing": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-withdrawal"
+                ],
+                "summary": "Админ: выплата по заявке начата",
+                "parameters": [
+                    {
+                        "description": "ID заявки",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/withdrawalhttp.AdminWithdrawalStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/withdrawal/reject": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-withdrawal"
+                ],
+                "summary": "Админ: отклонить заявку на вывод",
+                "parameters": [
+                    {
+                        "description": "ID заявки и причина",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/withdrawalhttp.AdminRejectWithdrawalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/admin/withdrawal/reverse": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-withdrawal"
+                ],
+                "summary": "Админ: сторнировать заявку (средства возвращаются на награду)",
+                "parameters": [
+                    {
+                        "description": "ID заявки и причина",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/withdrawalhttp.AdminWithdrawalStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/2fa/disable": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth-2fa"
+                ],
+                "summary": "Отключить 2FA (недоступно сотрудникам)",
+                "parameters": [
+                    {
+                        "description": "Код из приложения или код восстановления",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authadapter.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/2fa/enable": {
+            "post": {
+                "description": "В ответе — коды восстановления; они показываются один раз",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth-2fa"
+                ],
+                "summary": "Подтвердить подключение 2FA кодом из приложения",
+                "parameters": [
+                    {
+                        "description": "Код из приложения",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authadapter.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/2fa/setup": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth-2fa"
+                ],
+                "summary": "Начать подключение 2FA: секрет и ссылка для QR-кода",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/auth_model.TOTPSetup"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/2fa/verify": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth-2fa"
+                ],
+                "summary": "Второй шаг входа: код из приложения-аутентификатора или код восстановления",
+                "parameters": [
+                    {
+                        "description": "Токен второго шага и код",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authadapter.TwoFactorLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/confirm-login": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Подтверждение входа",
+                "parameters": [
+                    {
+                        "description": "Телефон и код",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authadapter.ConfirmRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/contacts/change": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Запрос смены телефона или email: коды уходят на старый и новый контакт",
+                "parameters": [
+                    {
+                        "description": "Тип контакта и новое значение",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authadapter.ContactChangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/auth_model.ContactChange"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/contacts/confirm": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Подтверждение смены контакта кодами (после смены телефона остальные сессии завершаются)",
+                "parameters": [
+                    {
+                        "description": "ID заявки и оба кода",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authadapter.ContactChangeConfirmRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/email/confirm": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Подтвердить email кодом из письма",
+                "parameters": [
+                    {
+                        "description": "Код из письма",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authadapter.EmailCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/email/confirm-link": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Подтвердить email по ссылке из письма",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Токен из ссылки",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/email/send-verification": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Отправить письмо подтверждения email (повтор — не чаще раза в минуту)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/login-by-creds": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Вход по логину и паролю",
+                "parameters": [
+                    {
+                        "description": "Логин и пароль",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authadapter.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/logout": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Выход: завершение текущей сессии",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/logout-all": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Выход на всех устройствах",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/me": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Получение текущего пользователя",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Vovarama1992_emelya-go_internal_user_model.User"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/password/change": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Смена пароля (остальные сессии завершаются)",
+                "parameters": [
+                    {
+                        "description": "Старый и новый пароль",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authadapter.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/password/reset/confirm": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Подтверждение сброса пароля кодом (все сессии завершаются)",
+                "parameters": [
+                    {
+                        "description": "Телефон или email, код и новый пароль",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authadapter.PasswordResetConfirmRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/password/reset/request": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Запрос кода сброса пароля по SMS или на email",
+                "parameters": [
+                    {
+                        "description": "Телефон или email",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authadapter.PasswordResetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/refresh": {
+            "post": {
+                "description": "Refresh-токен одноразовый: в ответе выдаётся новый, старый становится недействительным",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Обновление access-токена по refresh-токену",
+                "parameters": [
+                    {
+                        "description": "Refresh-токен",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authadapter.RefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/request-login": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Запрос входа по телефону",
+                "parameters": [
+                    {
+                        "description": "Телефон",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authadapter.PhoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/request-register": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Запрос на регистрацию",
+                "parameters": [
+                    {
+                        "description": "Данные пользователя",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authadapter.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/sessions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Активные сессии пользователя (устройство, IP)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/auth_model.Session"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/sessions/revoke": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Завершение одной из своих сессий",
+                "parameters": [
+                    {
+                        "description": "ID сессии",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authadapter.RevokeSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deposit/cancel": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deposit"
+                ],
+                "summary": "Юзер: отменить свою заявку на депозит (только pending)",
+                "parameters": [
+                    {
+                        "description": "ID депозита",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/deposithttp.CancelDepositRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deposit/create": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deposit"
+                ],
+                "summary": "Создать заявку на депозит",
+                "parameters": [
+                    {
+                        "description": "Сумма депозита",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/deposithttp.DepositCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/deposit/my": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "deposit"
+                ],
+                "summary": "Получить все депозиты пользователя",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {}
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/kyc/documents": {
+            "post": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "kyc"
+                ],
+                "summary": "Юзер: загрузить документ для верификации (JPEG, PNG или PDF до 10 МБ)",
+                "parameters": [
+                    {
+                        "enum": [
+                            "passport_main",
+                            "passport_registration",
+                            "selfie"
+                        ],
+                        "type": "string",
+                        "description": "Вид документа",
+                        "name": "kind",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/kyc_model.Document"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/kyc/my": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "kyc"
+                ],
+                "summary": "Юзер: уровень верификации и последняя заявка",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/kychttp.MyKYCResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/kyc/submit": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "kyc"
+                ],
+                "summary": "Юзер: отправить документы на проверку",
+                "parameters": [
+                    {
+                        "description": "Уровень и паспортные данные",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/kychttp.SubmitKYCRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/leads": {
+            "post": {
+                "description": "Заявка сохраняется и пересылается операторам. Повторная заявка с того же номера принимается не чаще раза в 10 минут.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leads"
+                ],
+                "summary": "Заявка с формы на сайте",
+                "parameters": [
+                    {
+                        "description": "Имя, телефон, интересующий тариф",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/leadhttp.SubmitLeadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notification-settings/my": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Юзер: настройки уведомлений — каналы по категориям и тихие часы",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/notification_model.Preferences"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notification-settings/update": {
+            "post": {
+                "description": "Категории: deposits, accruals, withdrawals. Каналы: sms, email, inapp. SMS в тихие часы откладываются до их окончания.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Юзер: изменить настройки уведомлений",
+                "parameters": [
+                    {
+                        "description": "Каналы и тихие часы",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notificationhttp.UpdatePreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/notification_model.Preferences"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/my": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Юзер: лента уведомлений, новые первыми",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Только непрочитанные",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Курсор: next_before_id предыдущей страницы",
+                        "name": "before_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 20, не больше 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/notification_model.InboxPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/read": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Юзер: отметить уведомления прочитанными",
+                "parameters": [
+                    {
+                        "description": "ID уведомлений или all",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/notificationhttp.MarkReadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/stream": {
+            "get": {
+                "description": "События: unread — число непрочитанных при подключении; notification — новое уведомление; read — изменилось число непрочитанных. Токен передаётся заголовком Authorization, поэтому на фронте нужен EventSource с поддержкой заголовков (fetch-стрим). Поток закрывается, когда истекает access-токен или сессия завершена: переподключайтесь со свежим токеном.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Юзер: live-канал ленты (Server-Sent Events)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/notification_model.InboxEvent"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/unread-count": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Юзер: число непрочитанных уведомлений",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/payout-method/add": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payout"
+                ],
+                "summary": "Юзер: добавить карту или счёт для вывода (уходит на проверку оператору)",
+                "parameters": [
+                    {
+                        "description": "Реквизиты",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payouthttp.AddPayoutMethodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/payout_model.PayoutMethod"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/payout-method/default": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payout"
+                ],
+                "summary": "Юзер: сделать реквизиты основными для вывода",
+                "parameters": [
+                    {
+                        "description": "ID реквизитов",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payouthttp.PayoutMethodIDRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/payout-method/delete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payout"
+                ],
+                "summary": "Юзер: удалить реквизиты",
+                "parameters": [
+                    {
+                        "description": "ID реквизитов",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payouthttp.PayoutMethodIDRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/payout-method/my": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payout"
+                ],
+                "summary": "Юзер: свои карты и счета для вывода (номера замаскированы)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/payout_model.PayoutMethod"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reward/my": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reward"
+                ],
+                "summary": "Юзер: получить свои вознаграждения",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {}
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/balance": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-user"
+                ],
+                "summary": "Админ: получить баланс пользователя (депозиты и доступные награды)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID пользователя",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "number"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/profile-changes": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Свои заявки на изменение профиля",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/user.ProfileChange"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/update-profile": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Изменить профиль: заявка на изменение ФИО уходит на модерацию",
+                "parameters": [
+                    {
+                        "description": "Изменяемые поля (не переданные не меняются)",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/user.ProfileChange"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/withdrawal/cancel": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "withdrawal"
+                ],
+                "summary": "Юзер: отменить свою заявку на вывод (только pending)",
+                "parameters": [
+                    {
+                        "description": "ID заявки",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/withdrawalhttp.CancelWithdrawalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/withdrawal/my": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "withdrawal"
+                ],
+                "summary": "Юзер: получить свои заявки на вывод",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {}
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/withdrawal/request": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "withdrawal"
+                ],
+                "summary": "Юзер: создать заявку на вывод",
+                "parameters": [
+                    {
+                        "description": "Данные заявки на вывод",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/withdrawalhttp.CreateWithdrawalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "approvalhttp.ConfirmApprovalRequest": {
+            "type": "object",
+            "required": [
+                "approval_id"
+            ],
+            "properties": {
+                "approval_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "approvalhttp.RejectApprovalRequest": {
+            "type": "object",
+            "required": [
+                "approval_id",
+                "reason"
+            ],
+            "properties": {
+                "approval_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "audit_model.Action": {
+            "type": "string",
+            "enum": [
+                "deposit.create_by_admin",
+                "deposit.approve",
+                "deposit.reject",
+                "deposit.close",
+                "deposit.delete",
+                "reward.create_by_admin",
+                "withdrawal.status",
+                "finance.export",
+                "payout.verify",
+                "payout.reject",
+                "payout.reveal",
+                "kyc.approve",
+                "kyc.reject",
+                "kyc.document_view",
+                "rbac.grant",
+                "rbac.revoke",
+                "user.role_change",
+                "user.contact_change",
+                "user.profile_update",
+                "auth.test_login",
+                "auth.unlock",
+                "auth.2fa_enable",
+                "auth.2fa_disable",
+                "auth.2fa_reset",
+                "auth.password_change",
+                "auth.password_reset",
+                "notification.template_update",
+                "notification.template_reset",
+                "notification.email_retry",
+                "telegram.link",
+                "telegram.unlink",
+                "webhook.create",
+                "webhook.update",
+                "webhook.delete",
+                "webhook.redeliver",
+                "lead.assign",
+                "lead.status",
+                "lead.convert"
+            ],
+            "x-enum-varnames": [
+                "ActionDepositCreateByAdmin",
+                "ActionDepositApprove",
+                "ActionDepositReject",
+                "ActionDepositClose",
+                "ActionDepositDelete",
+                "ActionRewardCreateByAdmin",
+                "ActionWithdrawalStatus",
+                "ActionFinanceExport",
+                "ActionPayoutVerify",
+                "ActionPayoutReject",
+                "ActionPayoutReveal",
+                "ActionKYCApprove",
+                "ActionKYCReject",
+                "ActionKYCDocumentView",
+                "ActionRBACGrant",
+                "ActionRBACRevoke",
+                "ActionUserRoleChange",
+                "ActionUserContactChange",
+                "ActionUserProfileUpdate",
+                "ActionAuthTestLogin",
+                "ActionAuthUnlock",
+                "ActionAuthTOTPEnable",
+                "ActionAuthTOTPDisable",
+                "ActionAuthTOTPReset",
+                "ActionAuthPasswordChange",
+                "ActionAuthPasswordReset",
+                "ActionTemplateUpdate",
+                "ActionTemplateReset",
+                "ActionEmailRetry",
+                "ActionTelegramLink",
+                "ActionTelegramUnlink",
+                "ActionWebhookCreate",
+                "ActionWebhookUpdate",
+                "ActionWebhookDelete",
+                "ActionWebhookRedeliver",
+                "ActionLeadAssign",
+                "ActionLeadStatus",
+                "ActionLeadConvert"
+            ]
+        },
+        "audit_model.Entry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/audit_model.Action"
+                },
+                "actor_id": {
+                    "type": "integer"
+                },
+                "after": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "before": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "integer"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth_model.ContactChange": {
+            "type": "object",
+            "properties": {
+                "confirmed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "$ref": "#/definitions/auth_model.ContactKind"
+                },
+                "new_value": {
+                    "type": "string"
+                },
+                "old_value": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/auth_model.ContactChangeStatus"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "auth_model.ContactChangeStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "confirmed",
+                "cancelled",
+                "expired"
+            ],
+            "x-enum-varnames": [
+                "ContactChangePending",
+                "ContactChangeConfirmed",
+                "ContactChangeCancelled",
+                "ContactChangeExpired"
+            ]
+        },
+        "auth_model.ContactKind": {
+            "type": "string",
+            "enum": [
+                "phone",
+                "email"
+            ],
+            "x-enum-varnames": [
+                "ContactPhone",
+                "ContactEmail"
+            ]
+        },
+        "auth_model.Session": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "auth_model.TOTPSetup": {
+            "type": "object",
+            "properties": {
+                "provisioning_uri": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "authadapter.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string"
+                },
+                "old_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "authadapter.ConfirmRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "phone"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "authadapter.ContactChangeConfirmRequest": {
+            "type": "object",
+            "required": [
+                "change_id",
+                "new_code",
+                "old_code"
+            ],
+            "properties": {
+                "change_id": {
+                    "type": "integer"
+                },
+                "new_code": {
+                    "type": "string"
+                },
+                "old_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "authadapter.ContactChangeRequest": {
+            "type": "object",
+            "required": [
+                "kind",
+                "new_value"
+            ],
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "phone",
+                        "email"
+                    ]
+                },
+                "new_value": {
+                    "type": "string"
+                }
+            }
+        },
+        "authadapter.EmailCodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "authadapter.LoginRequest": {
+            "type": "object",
+            "required": [
+                "login",
+                "password"
+            ],
+            "properties": {
+                "login": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 5
+                }
+            }
+        },
+        "authadapter.PasswordResetConfirmRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "new_password"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "authadapter.PasswordResetRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "authadapter.PhoneRequest": {
+            "type": "object",
+            "required": [
+                "phone"
+            ],
+            "properties": {
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "authadapter.RefreshRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "authadapter.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "first_name",
+                "last_name",
+                "phone"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "last_name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "patronymic": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "referrerId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "authadapter.RevokeSessionRequest": {
+            "type": "object",
+            "required": [
+                "session_id"
+            ],
+            "properties": {
+                "session_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "authadapter.TwoFactorCodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "authadapter.TwoFactorLoginRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "two_factor_token"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "two_factor_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "authadapter.UserIDRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "deposithttp.AdminCreateDepositRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "created_at"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "approved_at": {
+                    "type": "string"
+                },
+                "block_days": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "daily_reward": {
+                    "type": "number"
+                },
+                "initial_reward_amount": {
+                    "type": "number"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "tariff_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "deposithttp.AdminRejectDepositRequest": {
+            "type": "object",
+            "required": [
+                "deposit_id",
+                "reason"
+            ],
+            "properties": {
+                "deposit_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "deposithttp.CancelDepositRequest": {
+            "type": "object",
+            "required": [
+                "deposit_id"
+            ],
+            "properties": {
+                "deposit_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "deposithttp.DepositCreateRequest": {
+            "type": "object",
+            "required": [
+                "amount"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_Vovarama1992_emelya-go_internal_user_model.User": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number"
+                },
+                "contacts_changed_at": {
+                    "description": "Время последней смены телефона или email; после неё вывод временно закрыт",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "firstName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isEmailVerified": {
+                    "type": "boolean"
+                },
+                "isPhoneVerified": {
+                    "type": "boolean"
+                },
+                "kyc_level": {
+                    "type": "integer"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "login": {
+                    "type": "string"
+                },
+                "patronymic": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "referrerID": {
+                    "type": "integer"
+                },
+                "role": {
+                    "$ref": "#/definitions/user.UserRole"
+                },
+                "totp_enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "kyc_model.Application": {
+            "type": "object",
+            "properties": {
+                "birth_date": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "documents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/kyc_model.Document"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "level": {
+                    "$ref": "#/definitions/kyc_model.Level"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/kyc_model.Status"
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "kyc_model.Document": {
+            "type": "object",
+            "properties": {
+                "application_id": {
+                    "type": "integer"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "$ref": "#/definitions/kyc_model.DocumentKind"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "kyc_model.DocumentKind": {
+            "type": "string",
+            "enum": [
+                "passport_main",
+                "passport_registration",
+                "selfie"
+            ],
+            "x-enum-varnames": [
+                "DocPassportMain",
+                "DocPassportRegistration",
+                "DocSelfie"
+            ]
+        },
+        "kyc_model.Level": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2
+            ],
+            "x-enum-comments": {
+                "LevelBasic": "главный разворот паспорта",
+                "LevelFull": "паспорт, прописка и селфи с паспортом",
+                "LevelNone": "не проверен"
+            },
+            "x-enum-varnames": [
+                "LevelNone",
+                "LevelBasic",
+                "LevelFull"
+            ]
+        },
+        "kyc_model.Status": {
+            "type": "string",
+            "enum": [
+                "draft",
+                "submitted",
+                "approved",
+                "rejected"
+            ],
+            "x-enum-comments": {
+                "StatusApproved": "уровень присвоен",
+                "StatusDraft": "пользователь загружает документы",
+                "StatusRejected": "отклонена, можно подать новую",
+                "StatusSubmitted": "ждёт оператора"
+            },
+            "x-enum-varnames": [
+                "StatusDraft",
+                "StatusSubmitted",
+                "StatusApproved",
+                "StatusRejected"
+            ]
+        },
+        "kychttp.KYCApplicationRequest": {
+            "type": "object",
+            "required": [
+                "application_id"
+            ],
+            "properties": {
+                "application_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "kychttp.MyKYCResponse": {
+            "type": "object",
+            "properties": {
+                "application": {
+                    "$ref": "#/definitions/kyc_model.Application"
+                },
+                "level": {
+                    "$ref": "#/definitions/kyc_model.Level"
+                }
+            }
+        },
+        "kychttp.RejectKYCRequest": {
+            "type": "object",
+            "required": [
+                "application_id",
+                "reason"
+            ],
+            "properties": {
+                "application_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "kychttp.SubmitKYCRequest": {
+            "type": "object",
+            "required": [
+                "birth_date",
+                "level",
+                "passport_number"
+            ],
+            "properties": {
+                "birth_date": {
+                    "type": "string",
+                    "example": "1990-05-17"
+                },
+                "level": {
+                    "type": "integer",
+                    "enum": [
+                        1,
+                        2
+                    ],
+                    "example": 1
+                },
+                "passport_number": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "4510 123456"
+                }
+            }
+        },
+        "lead_model.Conversion": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "boolean"
+                },
+                "lead": {
+                    "$ref": "#/definitions/lead_model.Lead"
+                },
+                "login": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "lead_model.Lead": {
+            "type": "object",
+            "properties": {
+                "assignee_id": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "converted_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "spam_reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/lead_model.Status"
+                },
+                "tariff_id": {
+                    "type": "integer"
+                },
+                "tariff_name": {
+                    "description": "Название тарифа на момент заявки",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "lead_model.Status": {
+            "type": "string",
+            "enum": [
+                "new",
+                "in_progress",
+                "converted",
+                "rejected",
+                "spam"
+            ],
+            "x-enum-varnames": [
+                "StatusNew",
+                "StatusInProgress",
+                "StatusConverted",
+                "StatusRejected",
+                "StatusSpam"
+            ]
+        },
+        "leadhttp.AssignLeadRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "assignee_id": {
+                    "description": "null — снять ответственного",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "leadhttp.ConvertLeadRequest": {
+            "type": "object",
+            "required": [
+                "first_name",
+                "id",
+                "last_name"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Пусто — берётся из заявки",
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "patronymic": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "leadhttp.LeadStatusRequest": {
+            "type": "object",
+            "required": [
+                "id",
+                "status"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "new",
+                        "in_progress",
+                        "rejected",
+                        "spam"
+                    ]
+                }
+            }
+        },
+        "leadhttp.SubmitLeadRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "phone"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 254
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Иван"
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "+7 999 123-45-67"
+                },
+                "source": {
+                    "description": "Откуда пришла заявка: страница или рекламная метка",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "landing"
+                },
+                "tariff_id": {
+                    "type": "integer"
+                },
+                "website": {
+                    "description": "Скрытое поле формы; должно оставаться пустым",
+                    "type": "string"
+                }
+            }
+        },
+        "model_deposit.Deposit": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "approved_at": {
+                    "type": "string"
+                },
+                "block_days": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "daily_reward": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/model_deposit.Status"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model_deposit.Status": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "approved",
+                "closed",
+                "rejected",
+                "cancelled"
+            ],
+            "x-enum-comments": {
+                "StatusApproved": "активный",
+                "StatusCancelled": "отменён пользователем",
+                "StatusClosed": "закрыт, разблокирован",
+                "StatusPending": "ожидает подтверждения",
+                "StatusRejected": "отклонён оператором"
+            },
+            "x-enum-varnames": [
+                "StatusPending",
+                "StatusApproved",
+                "StatusClosed",
+                "StatusRejected",
+                "StatusCancelled"
+            ]
+        },
+        "notification_model.EmailStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "sent",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "EmailPending",
+                "EmailSent",
+                "EmailFailed"
+            ]
+        },
+        "notification_model.InboxEvent": {
+            "type": "object",
+            "properties": {
+                "notification": {
+                    "$ref": "#/definitions/notification_model.Notification"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "unread": {
+                    "type": "integer"
+                }
+            }
+        },
+        "notification_model.InboxPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/notification_model.Notification"
+                    }
+                },
+                "next_before_id": {
+                    "type": "integer"
+                },
+                "unread": {
+                    "type": "integer"
+                }
+            }
+        },
+        "notification_model.Notification": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                }
+            }
+        },
+        "notification_model.Preferences": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "object",
+                        "additionalProperties": {
+                            "type": "boolean"
+                        }
+                    }
+                },
+                "quiet_hours": {
+                    "$ref": "#/definitions/notification_model.QuietHours"
+                }
+            }
+        },
+        "notification_model.QueuedEmail": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/notification_model.EmailStatus"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "notification_model.QuietHours": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string",
+                    "example": "08:00"
+                },
+                "start": {
+                    "type": "string",
+                    "example": "22:00"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Europe/Moscow"
+                }
+            }
+        },
+        "notification_model.SmsCostSummary": {
+            "type": "object",
+            "properties": {
+                "cost": {
+                    "type": "number"
+                },
+                "delivered": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "sent": {
+                    "type": "integer"
+                },
+                "undelivered": {
+                    "type": "integer"
+                }
+            }
+        },
+        "notification_model.SmsMessage": {
+            "type": "object",
+            "properties": {
+                "cost": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "description": "Ошибки провайдеров, которые не приняли сообщение раньше",
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "provider_message_id": {
+                    "type": "string"
+                },
+                "provider_status": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/notification_model.SmsStatus"
+                },
+                "status_at": {
+                    "type": "string"
+                },
+                "template": {
+                    "type": "string"
+                }
+            }
+        },
+        "notification_model.SmsStatus": {
+            "type": "string",
+            "enum": [
+                "sent",
+                "delivered",
+                "undelivered",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "SmsSent",
+                "SmsDelivered",
+                "SmsUndelivered",
+                "SmsFailed"
+            ]
+        },
+        "notification_model.TemplateView": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "$ref": "#/definitions/notifier.Template"
+                },
+                "default": {
+                    "$ref": "#/definitions/notifier.Template"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "overridden": {
+                    "type": "boolean"
+                },
+                "sample": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "notificationhttp.MarkReadRequest": {
+            "type": "object",
+            "properties": {
+                "all": {
+                    "type": "boolean"
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "notificationhttp.PreviewTemplateRequest": {
+            "type": "object",
+            "required": [
+                "key"
+            ],
+            "properties": {
+                "data": {
+                    "type": "object"
+                },
+                "draft": {
+                    "$ref": "#/definitions/notifier.Template"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                }
+            }
+        },
+        "notificationhttp.ResetTemplateRequest": {
+            "type": "object",
+            "required": [
+                "key"
+            ],
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                }
+            }
+        },
+        "notificationhttp.RetryEmailRequest": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "notificationhttp.UpdatePreferencesRequest": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "type": "object"
+                },
+                "disable_quiet_hours": {
+                    "type": "boolean"
+                },
+                "quiet_hours": {
+                    "$ref": "#/definitions/notification_model.QuietHours"
+                }
+            }
+        },
+        "notificationhttp.UpdateTemplateRequest": {
+            "type": "object",
+            "required": [
+                "key"
+            ],
+            "properties": {
+                "html": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "sms": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "notifier.Rendered": {
+            "type": "object",
+            "properties": {
+                "html": {
+                    "type": "string"
+                },
+                "sms": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "notifier.Template": {
+            "type": "object",
+            "properties": {
+                "html": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "sms": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "operation.Operations": {
+            "type": "object",
+            "properties": {
+                "deposits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model_deposit.Deposit"
+                    }
+                },
+                "rewards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reward.Reward"
+                    }
+                },
+                "withdrawals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/withdrawal_model.Withdrawal"
+                    }
+                }
+            }
+        },
+        "payout_model.Kind": {
+            "type": "string",
+            "enum": [
+                "card",
+                "bank_account"
+            ],
+            "x-enum-comments": {
+                "KindBankAccount": "расчётный счёт в банке РФ",
+                "KindCard": "банковская карта"
+            },
+            "x-enum-varnames": [
+                "KindCard",
+                "KindBankAccount"
+            ]
+        },
+        "payout_model.PayoutMethod": {
+            "type": "object",
+            "properties": {
+                "bank_bik": {
+                    "type": "string"
+                },
+                "brand": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "holder_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "kind": {
+                    "$ref": "#/definitions/payout_model.Kind"
+                },
+                "masked": {
+                    "type": "string"
+                },
+                "reject_reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/payout_model.Status"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "verified_at": {
+                    "type": "string"
+                },
+                "verified_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payout_model.Status": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "verified",
+                "rejected"
+            ],
+            "x-enum-comments": {
+                "StatusPending": "ждёт проверки оператором",
+                "StatusRejected": "отклонён оператором",
+                "StatusVerified": "можно выводить"
+            },
+            "x-enum-varnames": [
+                "StatusPending",
+                "StatusVerified",
+                "StatusRejected"
+            ]
+        },
+        "payouthttp.AddPayoutMethodRequest": {
+            "type": "object",
+            "required": [
+                "kind",
+                "number"
+            ],
+            "properties": {
+                "bank_bik": {
+                    "description": "БИК банка — только для счёта",
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "044525225"
+                },
+                "holder_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "IVAN IVANOV"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "card",
+                        "bank_account"
+                    ],
+                    "example": "card"
+                },
+                "number": {
+                    "type": "string",
+                    "maxLength": 40,
+                    "example": "2200 0000 0000 0004"
+                }
+            }
+        },
+        "payouthttp.PayoutMethodIDRequest": {
+            "type": "object",
+            "required": [
+                "payout_method_id"
+            ],
+            "properties": {
+                "payout_method_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payouthttp.RejectPayoutMethodRequest": {
+            "type": "object",
+            "required": [
+                "payout_method_id",
+                "reason"
+            ],
+            "properties": {
+                "payout_method_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "rbac_model.Permission": {
+            "type": "string",
+            "enum": [
+                "users.read",
+                "users.write",
+                "kyc.review",
+                "deposits.read",
+                "deposits.manage",
+                "deposits.delete",
+                "withdrawals.read",
+                "withdrawals.manage",
+                "payouts.reveal",
+                "rewards.read",
+                "rewards.manage",
+                "tariffs.manage",
+                "finance.read",
+                "finance.export",
+                "audit.read",
+                "approvals.manage",
+                "rbac.manage",
+                "notifications.manage",
+                "webhooks.manage",
+                "leads.manage"
+            ],
+            "x-enum-varnames": [
+                "PermUsersRead",
+                "PermUsersWrite",
+                "PermKYCReview",
+                "PermDepositsRead",
+                "PermDepositsManage",
+                "PermDepositsDelete",
+                "PermWithdrawalsRead",
+                "PermWithdrawalsManage",
+                "PermPayoutsReveal",
+                "PermRewardsRead",
+                "PermRewardsManage",
+                "PermTariffsManage",
+                "PermFinanceRead",
+                "PermFinanceExport",
+                "PermAuditRead",
+                "PermApprovalsManage",
+                "PermRBACManage",
+                "PermNotificationsManage",
+                "PermWebhooksManage",
+                "PermLeadsManage"
+            ]
+        },
+        "rbac_model.RolePermissions": {
+            "type": "object",
+            "properties": {
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rbac_model.Permission"
+                    }
+                },
+                "role": {
+                    "$ref": "#/definitions/user.UserRole"
+                }
+            }
+        },
+        "rbachttp.PermissionRequest": {
+            "type": "object",
+            "required": [
+                "permission",
+                "role"
+            ],
+            "properties": {
+                "permission": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "rbachttp.SetUserRoleRequest": {
+            "type": "object",
+            "required": [
+                "role",
+                "user_id"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "reward.Reward": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deposit_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_accrued_at": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/reward.RewardType"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "withdrawn": {
+                    "type": "number"
+                }
+            }
+        },
+        "reward.RewardType": {
+            "type": "string",
+            "enum": [
+                "deposit",
+                "referral"
+            ],
+            "x-enum-varnames": [
+                "RewardTypeDeposit",
+                "RewardTypeReferral"
+            ]
+        },
+        "rewardhttp.AdminCreateReferralRewardRequest": {
             "type": "object",
             "required": [
-                "code",
-                "phone"
+                "amount",
+                "user_id"
             ],
             "properties": {
-                "code": {
+                "amount": {
+                    "type": "number"
+                },
+                "reason": {
                     "type": "string"
                 },
-                "phone": {
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "tariff.Tariff": {
+            "type": "object",
+            "properties": {
+                "block_days": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "daily_reward": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "authadapter.LoginRequest": {
+        "tariffhttp.CreateTariffRequest": {
             "type": "object",
             "required": [
-                "login",
-                "password"
+                "name"
             ],
             "properties": {
-                "login": {
-                    "type": "string"
+                "block_days": {
+                    "type": "integer"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 5
+                "daily_reward": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "authadapter.PhoneRequest": {
+        "tariffhttp.UpdateTariffRequest": {
             "type": "object",
             "required": [
-                "phone"
+                "id",
+                "name"
             ],
             "properties": {
-                "phone": {
+                "block_days": {
+                    "type": "integer"
+                },
+                "daily_reward": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "authadapter.RegisterRequest": {
+        "telegram_model.Link": {
+            "type": "object",
+            "properties": {
+                "chat_id": {
+                    "type": "integer"
+                },
+                "linked_at": {
+                    "type": "string"
+                },
+                "telegram_user_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "telegram_model.LinkCode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "Ссылка на бота с кодом, если задан TELEGRAM_BOT_USERNAME",
+                    "type": "string"
+                }
+            }
+        },
+        "user.AddReferralRequest": {
             "type": "object",
             "required": [
-                "email",
-                "first_name",
-                "last_name",
-                "phone"
+                "referrer_id",
+                "user_id"
             ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "referrer_id": {
+                    "type": "integer"
                 },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "user.AdminUpdateProfileRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
                 "first_name": {
-                    "type": "string",
-                    "maxLength": 50
+                    "type": "string"
                 },
                 "last_name": {
-                    "type": "string",
-                    "maxLength": 50
+                    "type": "string"
                 },
                 "patronymic": {
-                    "type": "string",
-                    "maxLength": 50
+                    "type": "string"
                 },
                 "phone": {
                     "type": "string"
                 },
-                "referrerId": {
+                "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "deposithttp.AdminCreateDepositRequest": {
+        "user.ProfileChange": {
             "type": "object",
-            "required": [
-                "amount",
-                "created_at"
-            ],
             "properties": {
-                "amount": {
-                    "type": "number"
+                "changes": {
+                    "$ref": "#/definitions/user.ProfileFields"
                 },
-                "approved_at": {
+                "created_at": {
                     "type": "string"
                 },
-                "block_until": {
+                "decided_at": {
                     "type": "string"
                 },
-                "created_at": {
+                "decided_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "previous": {
+                    "$ref": "#/definitions/user.ProfileFields"
+                },
+                "reason": {
                     "type": "string"
                 },
-                "daily_reward": {
-                    "type": "number"
+                "requested_by": {
+                    "type": "integer"
                 },
-                "initial_reward_amount": {
-                    "type": "number"
+                "status": {
+                    "$ref": "#/definitions/user.ProfileChangeStatus"
                 },
-                "tariff_id": {
+                "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "deposithttp.DepositCreateRequest": {
+        "user.ProfileChangeDecisionRequest": {
             "type": "object",
             "required": [
-                "amount"
+                "id"
             ],
             "properties": {
-                "amount": {
-                    "type": "number"
+                "id": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_Vovarama1992_emelya-go_internal_user_model.User": {
+        "user.ProfileChangeRejectRequest": {
             "type": "object",
+            "required": [
+                "id",
+                "reason"
+            ],
             "properties": {
-                "balance": {
-                    "type": "number"
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "user.ProfileChangeStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "approved",
+                "rejected",
+                "applied"
+            ],
+            "x-enum-comments": {
+                "ProfileChangeApplied": "правка администратора без модерации",
+                "ProfileChangeApproved": "одобрена и применена",
+                "ProfileChangePending": "ждёт оператора",
+                "ProfileChangeRejected": "отклонена"
+            },
+            "x-enum-varnames": [
+                "ProfileChangePending",
+                "ProfileChangeApproved",
+                "ProfileChangeRejected",
+                "ProfileChangeApplied"
+            ]
+        },
+        "user.ProfileFields": {
+            "type": "object",
+            "properties": {
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "patronymic": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "user.UpdateProfileRequest": {
+            "type": "object",
+            "properties": {
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "patronymic": {
+                    "type": "string"
+                }
+            }
+        },
+        "user.UserRole": {
+            "type": "string",
+            "enum": [
+                "user",
+                "admin",
+                "operator",
+                "accountant",
+                "support",
+                "superadmin"
+            ],
+            "x-enum-comments": {
+                "RoleAccountant": "только чтение финансов",
+                "RoleOperator": "подтверждает депозиты",
+                "RoleSuperadmin": "все права, включая управление ролями",
+                "RoleSupport": "только чтение профилей, без денег"
+            },
+            "x-enum-varnames": [
+                "RoleUser",
+                "RoleAdmin",
+                "RoleOperator",
+                "RoleAccountant",
+                "RoleSupport",
+                "RoleSuperadmin"
+            ]
+        },
+        "webhook_model.Attempt": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
                 },
-                "cardNumber": {
+                "created_at": {
                     "type": "string"
                 },
-                "email": {
-                    "type": "string"
+                "delivery_id": {
+                    "type": "integer"
                 },
-                "firstName": {
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "isEmailVerified": {
-                    "type": "boolean"
+                "response_body": {
+                    "type": "string"
                 },
-                "isPhoneVerified": {
+                "response_code": {
+                    "type": "integer"
+                }
+            }
+        },
+        "webhook_model.CreatedSubscription": {
+            "type": "object",
+            "properties": {
+                "active": {
                     "type": "boolean"
                 },
-                "lastName": {
+                "created_at": {
                     "type": "string"
                 },
-                "login": {
-                    "type": "string"
+                "created_by": {
+                    "type": "integer"
                 },
-                "passwordHash": {
+                "description": {
                     "type": "string"
                 },
-                "patronymic": {
-                    "type": "string"
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "phone": {
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
                     "type": "string"
                 },
-                "referrerID": {
-                    "type": "integer"
+                "updated_at": {
+                    "type": "string"
                 },
-                "role": {
-                    "$ref": "#/definitions/user.UserRole"
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "model_deposit.Deposit": {
+        "webhook_model.Delivery": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
+                "attempts": {
+                    "type": "integer"
                 },
-                "approved_at": {
+                "created_at": {
                     "type": "string"
                 },
-                "block_until": {
+                "delivered_at": {
                     "type": "string"
                 },
-                "created_at": {
-                    "type": "string"
+                "event_id": {
+                    "type": "integer"
                 },
-                "daily_reward": {
-                    "type": "number"
+                "event_type": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_response_code": {
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
                 "status": {
-                    "$ref": "#/definitions/model_deposit.Status"
+                    "$ref": "#/definitions/webhook_model.DeliveryStatus"
                 },
-                "user_id": {
+                "subscription_id": {
                     "type": "integer"
                 }
             }
         },
-        "model_deposit.Status": {
-            "type": "string",
-            "enum": [
-                "pending",
-                "approved",
-                "closed"
-            ],
-            "x-enum-comments": {
-                "StatusApproved": "активный",
-                "StatusClosed": "закрыт, разблокирован",
-                "StatusPending": "ожидает подтверждения"
-            },
-            "x-enum-varnames": [
-                "StatusPending",
-                "StatusApproved",
-                "StatusClosed"
-            ]
-        },
-        "notifier.NotifyRequest": {
-            "type": "object",
-            "properties": {
-                "text": {
-                    "type": "string",
-                    "example": "Имя: Иванов Иван\nТелефон: +7900...\nТариф: Премиум"
-                }
-            }
-        },
-        "operation.Operations": {
+        "webhook_model.DeliveryDetails": {
             "type": "object",
             "properties": {
-                "deposits": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model_deposit.Deposit"
-                    }
-                },
-                "rewards": {
+                "attempt_log": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/reward.Reward"
+                        "$ref": "#/definitions/webhook_model.Attempt"
                     }
                 },
-                "withdrawals": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/withdrawal_model.Withdrawal"
-                    }
-                }
-            }
-        },
-        "reward.Reward": {
-            "type": "object",
-            "properties": {
-                "amount": {
-                    "type": "number"
+                "attempts": {
+                    "type": "integer"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "deposit_id": {
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event_id": {
                     "type": "integer"
                 },
+                "event_type": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "last_accrued_at": {
+                "last_error": {
                     "type": "string"
                 },
-                "type": {
-                    "$ref": "#/definitions/reward.RewardType"
-                },
-                "user_id": {
+                "last_response_code": {
                     "type": "integer"
                 },
-                "withdrawn": {
-                    "type": "number"
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "status": {
+                    "$ref": "#/definitions/webhook_model.DeliveryStatus"
+                },
+                "subscription_id": {
+                    "type": "integer"
                 }
             }
         },
-        "reward.RewardType": {
+        "webhook_model.DeliveryStatus": {
             "type": "string",
             "enum": [
-                "deposit",
-                "referral"
+                "pending",
+                "delivered",
+                "failed"
             ],
             "x-enum-varnames": [
-                "RewardTypeDeposit",
-                "RewardTypeReferral"
+                "DeliveryPending",
+                "DeliveryDelivered",
+                "DeliveryFailed"
             ]
         },
-        "rewardhttp.AdminCreateReferralRewardRequest": {
+        "webhook_model.Subscription": {
             "type": "object",
-            "required": [
-                "amount",
-                "user_id"
-            ],
             "properties": {
-                "amount": {
-                    "type": "number"
+                "active": {
+                    "type": "boolean"
                 },
-                "user_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "tariff.Tariff": {
-            "type": "object",
-            "properties": {
-                "block_until": {
+                "created_at": {
                     "type": "string"
                 },
-                "created_at": {
+                "created_by": {
+                    "type": "integer"
+                },
+                "description": {
                     "type": "string"
                 },
-                "daily_reward": {
-                    "type": "number"
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "tariffhttp.CreateTariffRequest": {
+        "webhookhttp.CreateWebhookRequest": {
             "type": "object",
             "required": [
-                "name"
+                "event_types",
+                "url"
             ],
             "properties": {
-                "block_until": {
-                    "description": "ISO8601 строка или nil",
+                "active": {
+                    "type": "boolean"
+                },
+                "description": {
                     "type": "string"
                 },
-                "daily_reward": {
-                    "type": "number"
+                "event_types": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "name": {
+                "secret": {
+                    "description": "Без секрета он генерируется и возвращается в ответе",
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "tariffhttp.UpdateTariffRequest": {
+        "webhookhttp.DeleteWebhookRequest": {
             "type": "object",
             "required": [
-                "id",
-                "name"
+                "id"
             ],
             "properties": {
-                "block_until": {
-                    "type": "string"
-                },
-                "daily_reward": {
-                    "type": "number"
-                },
                 "id": {
                     "type": "integer"
-                },
-                "name": {
-                    "type": "string"
                 }
             }
         },
-        "user.AddReferralRequest": {
+        "webhookhttp.RedeliverRequest": {
             "type": "object",
             "required": [
-                "referrer_id",
-                "user_id"
+                "delivery_id"
             ],
             "properties": {
-                "referrer_id": {
-                    "type": "integer"
-                },
-                "user_id": {
+                "delivery_id": {
                     "type": "integer"
                 }
             }
         },
-        "user.AdminUpdateProfileRequest": {
+        "webhookhttp.UpdateWebhookRequest": {
             "type": "object",
             "required": [
-                "user_id"
+                "id"
             ],
             "properties": {
-                "card_number": {
-                    "type": "string"
-                },
-                "first_name": {
-                    "type": "string"
-                },
-                "last_name": {
-                    "type": "string"
+                "active": {
+                    "type": "boolean"
                 },
-                "patronymic": {
+                "description": {
                     "type": "string"
                 },
-                "phone": {
-                    "type": "string"
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "user_id": {
+                "id": {
                     "type": "integer"
-                }
-            }
-        },
-        "user.UpdateProfileRequest": {
-            "type": "object",
-            "properties": {
-                "card_number": {
-                    "type": "string"
-                },
-                "first_name": {
-                    "type": "string"
                 },
-                "last_name": {
-                    "type": "string"
-                },
-                "patronymic": {
+                "secret": {
                     "type": "string"
                 },
-                "phone": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "user.UserRole": {
-            "type": "string",
-            "enum": [
-                "user",
-                "admin"
-            ],
-            "x-enum-varnames": [
-                "RoleUser",
-                "RoleAdmin"
-            ]
-        },
         "withdrawal_model.Withdrawal": {
             "type": "object",
             "properties": {
@@ -2252,6 +9739,10 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "payout_method_id": {
+                    "description": "Реквизиты, на которые выводятся средства; у старых заявок не заданы",
+                    "type": "integer"
+                },
                 "reason": {
                     "type": "string"
                 },
@@ -2273,13 +9764,33 @@ const docTemplate = `{
             "type": "string",
             "enum": [
                 "pending",
+                "cancelled",
                 "approved",
-                "rejected"
+                "rejected",
+                "processing",
+                "paid",
+                "failed",
+                "reversed"
             ],
+            "x-enum-comments": {
+                "WithdrawalStatusApproved": "одобрена, средства списаны с награды",
+                "WithdrawalStatusCancelled": "отменена пользователем",
+                "WithdrawalStatusFailed": "выплата не прошла",
+                "WithdrawalStatusPaid": "выплачена",
+                "WithdrawalStatusPending": "ожидает решения оператора",
+                "WithdrawalStatusProcessing": "выплата в процессе",
+                "WithdrawalStatusRejected": "отклонена оператором",
+                "WithdrawalStatusReversed": "сторнирована, средства возвращены на награду"
+            },
             "x-enum-varnames": [
                 "WithdrawalStatusPending",
+                "WithdrawalStatusCancelled",
                 "WithdrawalStatusApproved",
-                "WithdrawalStatusRejected"
+                "WithdrawalStatusRejected",
+                "WithdrawalStatusProcessing",
+                "WithdrawalStatusPaid",
+                "WithdrawalStatusFailed",
+                "WithdrawalStatusReversed"
             ]
         },
         "withdrawalhttp.AdminApproveWithdrawalRequest": {
@@ -2308,6 +9819,31 @@ const docTemplate = `{
                 }
             }
         },
+        "withdrawalhttp.AdminWithdrawalStatusRequest": {
+            "type": "object",
+            "required": [
+                "withdrawal_id"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "withdrawal_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "withdrawalhttp.CancelWithdrawalRequest": {
+            "type": "object",
+            "required": [
+                "withdrawal_id"
+            ],
+            "properties": {
+                "withdrawal_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "withdrawalhttp.CreateWithdrawalRequest": {
             "type": "object",
             "required": [
@@ -2318,6 +9854,10 @@ const docTemplate = `{
                 "amount": {
                     "type": "number"
                 },
+                "payout_method_id": {
+                    "description": "Без payout_method_id используются основные реквизиты",
+                    "type": "integer"
+                },
                 "reward_id": {
                     "type": "integer"
                 }
@@ -0,0 +1,113 @@
+package auditadapter
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	model "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/audit/ports"
+)
+
+type Handler struct {
+	auditService ports.AuditService
+}
+
+func NewHandler(auditService ports.AuditService) *Handler {
+	return &Handler{auditService: auditService}
+}
+
+// Search godoc
+// @Summary Админ: поиск по журналу действий
+// @Tags admin-audit
+// @Produce json
+// @Param actor_id query int false "ID администратора"
+// @Param action query string false "Действие, например deposit.approve"
+// @Param entity_type query string false "Тип сущности: deposit, reward, withdrawal"
+// @Param entity_id query int false "ID сущности"
+// @Param from query string false "Начало периода (RFC3339)"
+// @Param to query string false "Конец периода (RFC3339)"
+// @Param limit query int false "Количество записей (по умолчанию 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {array} model.Entry
+// @Failure 400,500 {object} map[string]string
+// @Router /api/admin/audit [get]
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	q := r.URL.Query()
+	var f model.Filter
+
+	if v := q.Get("actor_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Некорректный actor_id")
+			return
+		}
+		f.ActorID = &id
+	}
+	if v := q.Get("action"); v != "" {
+		action := model.Action(v)
+		f.Action = &action
+	}
+	if v := q.Get("entity_type"); v != "" {
+		f.EntityType = &v
+	}
+	if v := q.Get("entity_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Некорректный entity_id")
+			return
+		}
+		f.EntityID = &id
+	}
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Некорректный from")
+			return
+		}
+		f.From = &t
+	}
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Некорректный to")
+			return
+		}
+		f.To = &t
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Некорректный limit")
+			return
+		}
+		f.Limit = n
+	}
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			respondWithError(w, http.StatusBadRequest, "Некорректный offset")
+			return
+		}
+		f.Offset = n
+	}
+
+	entries, err := h.auditService.Search(r.Context(), f)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения журнала")
+		return
+	}
+
+	json.NewEncoder(w).Encode(entries)
+}
+
+func respondWithError(w http.ResponseWriter, code int, message string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
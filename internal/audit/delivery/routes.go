@@ -0,0 +1,24 @@
+package auditadapter
+
+import (
+	"net/http"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	user_ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
+	"github.com/Vovarama1992/go-utils/httputil"
+)
+
+func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService user_ports.UserServiceInterface, sessions middleware.SessionChecker, access middleware.PermissionChecker) {
+	withRecover := func(h http.Handler) http.Handler {
+		return httputil.RecoverMiddleware(h)
+	}
+
+	withPermission := func(perm rbac.Permission, h http.Handler) http.Handler {
+		return middleware.PermissionMiddleware(userService, sessions, access, perm)(h)
+	}
+
+	mux.Handle("/api/admin/audit",
+		withRecover(withPermission(rbac.PermAuditRead, http.HandlerFunc(handler.Search))),
+	)
+}
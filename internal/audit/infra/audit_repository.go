@@ -0,0 +1,123 @@
+package audit_infra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	model "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Интерфейс для пула и транзакции
+type PgxQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+type AuditRepository struct {
+	querier PgxQuerier
+}
+
+func NewAuditRepository(db *db.DB) *AuditRepository {
+	return &AuditRepository{querier: db.Pool}
+}
+
+// Позволяет писать в журнал в той же транзакции, что и само действие
+func NewAuditRepositoryWithTx(tx pgx.Tx) *AuditRepository {
+	return &AuditRepository{querier: tx}
+}
+
+func (r *AuditRepository) Create(ctx context.Context, e *model.Entry) error {
+	query := `
+		INSERT INTO audit_log (actor_id, action, entity_type, entity_id, before, after, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	return r.querier.QueryRow(ctx, query,
+		e.ActorID,
+		e.Action,
+		e.EntityType,
+		e.EntityID,
+		nullableJSON(e.Before),
+		nullableJSON(e.After),
+		e.Reason,
+	).Scan(&e.ID, &e.CreatedAt)
+}
+
+func (r *AuditRepository) Search(ctx context.Context, f model.Filter) ([]*model.Entry, error) {
+	var (
+		conds []string
+		args  []interface{}
+	)
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.ActorID != nil {
+		add("actor_id = $%d", *f.ActorID)
+	}
+	if f.Action != nil {
+		add("action = $%d", *f.Action)
+	}
+	if f.EntityType != nil {
+		add("entity_type = $%d", *f.EntityType)
+	}
+	if f.EntityID != nil {
+		add("entity_id = $%d", *f.EntityID)
+	}
+	if f.From != nil {
+		add("created_at >= $%d", *f.From)
+	}
+	if f.To != nil {
+		add("created_at < $%d", *f.To)
+	}
+
+	query := `
+		SELECT id, actor_id, action, entity_type, entity_id, before, after, reason, created_at
+		FROM audit_log
+	`
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, f.Limit, f.Offset)
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := r.querier.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*model.Entry
+	for rows.Next() {
+		var e model.Entry
+		if err := rows.Scan(
+			&e.ID,
+			&e.ActorID,
+			&e.Action,
+			&e.EntityType,
+			&e.EntityID,
+			&e.Before,
+			&e.After,
+			&e.Reason,
+			&e.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &e)
+	}
+	return entries, nil
+}
+
+// pgx пишет пустой []byte как пустую строку, а jsonb её не примет
+func nullableJSON(data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
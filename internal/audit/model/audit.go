@@ -0,0 +1,114 @@
+package audit_model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Action string
+
+const (
+	ActionDepositCreateByAdmin Action = "deposit.create_by_admin"
+	ActionDepositApprove       Action = "deposit.approve"
+	ActionDepositReject        Action = "deposit.reject"
+	ActionDepositClose         Action = "deposit.close"
+	ActionDepositDelete        Action = "deposit.delete"
+
+	ActionRewardCreateByAdmin Action = "reward.create_by_admin"
+
+	ActionWithdrawalStatus Action = "withdrawal.status"
+
+	ActionFinanceExport Action = "finance.export"
+
+	ActionPayoutVerify Action = "payout.verify"
+	ActionPayoutReject Action = "payout.reject"
+	ActionPayoutReveal Action = "payout.reveal"
+
+	ActionKYCApprove      Action = "kyc.approve"
+	ActionKYCReject       Action = "kyc.reject"
+	ActionKYCDocumentView Action = "kyc.document_view"
+
+	ActionRBACGrant         Action = "rbac.grant"
+	ActionRBACRevoke        Action = "rbac.revoke"
+	ActionUserRoleChange    Action = "user.role_change"
+	ActionUserContactChange Action = "user.contact_change"
+	ActionUserProfileUpdate Action = "user.profile_update"
+
+	ActionAuthTestLogin Action = "auth.test_login"
+	ActionAuthUnlock    Action = "auth.unlock"
+
+	ActionAuthTOTPEnable  Action = "auth.2fa_enable"
+	ActionAuthTOTPDisable Action = "auth.2fa_disable"
+	ActionAuthTOTPReset   Action = "auth.2fa_reset"
+
+	ActionAuthPasswordChange Action = "auth.password_change"
+	ActionAuthPasswordReset  Action = "auth.password_reset"
+
+	ActionTemplateUpdate Action = "notification.template_update"
+	ActionTemplateReset  Action = "notification.template_reset"
+	ActionEmailRetry     Action = "notification.email_retry"
+
+	ActionTelegramLink   Action = "telegram.link"
+	ActionTelegramUnlink Action = "telegram.unlink"
+
+	ActionWebhookCreate    Action = "webhook.create"
+	ActionWebhookUpdate    Action = "webhook.update"
+	ActionWebhookDelete    Action = "webhook.delete"
+	ActionWebhookRedeliver Action = "webhook.redeliver"
+
+	ActionLeadAssign  Action = "lead.assign"
+	ActionLeadStatus  Action = "lead.status"
+	ActionLeadConvert Action = "lead.convert"
+)
+
+const (
+	EntityDeposit    = "deposit"
+	EntityReward     = "reward"
+	EntityWithdrawal = "withdrawal"
+	EntityExport     = "finance_export"
+	EntityPayout     = "payout_method"
+	EntityKYC        = "kyc_application"
+	EntityUser       = "user"
+	EntityRole       = "role"
+	EntityTemplate   = "notification_template"
+	EntityEmail      = "email"
+	EntityWebhook    = "webhook_subscription"
+	EntityLead       = "lead"
+)
+
+// Entry — запись журнала действий: кто, что и над чем сделал, состояние до и после
+type Entry struct {
+	ID         int64           `json:"id"`
+	ActorID    *int64          `json:"actor_id,omitempty"`
+	Action     Action          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   *int64          `json:"entity_id,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	Reason     *string         `json:"reason,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// Filter — параметры поиска по журналу, пустые поля не ограничивают выборку
+type Filter struct {
+	ActorID    *int64
+	Action     *Action
+	EntityType *string
+	EntityID   *int64
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+// Snapshot сериализует состояние сущности для журнала; nil остаётся nil
+func Snapshot(v any) json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil || string(data) == "null" {
+		return nil
+	}
+	return data
+}
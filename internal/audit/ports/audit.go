@@ -0,0 +1,17 @@
+package audit_ports
+
+import (
+	"context"
+
+	model "github.com/Vovarama1992/emelya-go/internal/audit/model"
+)
+
+type AuditRepository interface {
+	Create(ctx context.Context, e *model.Entry) error
+	Search(ctx context.Context, f model.Filter) ([]*model.Entry, error)
+}
+
+type AuditService interface {
+	Record(ctx context.Context, e *model.Entry) error
+	Search(ctx context.Context, f model.Filter) ([]*model.Entry, error)
+}
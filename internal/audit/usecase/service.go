@@ -0,0 +1,45 @@
+package audit_usecase
+
+import (
+	"context"
+
+	model "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/audit/ports"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+)
+
+const (
+	defaultSearchLimit = 100
+	maxSearchLimit     = 1000
+)
+
+type AuditService struct {
+	repo ports.AuditRepository
+}
+
+func NewAuditService(repo ports.AuditRepository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+// Record пишет запись вне транзакции; действия с деньгами пишут журнал сами в своей транзакции
+func (s *AuditService) Record(ctx context.Context, e *model.Entry) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	return s.repo.Create(ctx, e)
+}
+
+func (s *AuditService) Search(ctx context.Context, f model.Filter) ([]*model.Entry, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+
+	if f.Limit <= 0 {
+		f.Limit = defaultSearchLimit
+	}
+	if f.Limit > maxSearchLimit {
+		f.Limit = maxSearchLimit
+	}
+	if f.Offset < 0 {
+		f.Offset = 0
+	}
+	return s.repo.Search(ctx, f)
+}
@@ -22,3 +22,58 @@ type LoginRequest struct {
 	Login    string `json:"login" validate:"required"`
 	Password string `json:"password" validate:"required,min=5"`
 }
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type RevokeSessionRequest struct {
+	SessionID string `json:"session_id" validate:"required"`
+}
+
+type UserIDRequest struct {
+	UserID int64 `json:"user_id" validate:"required"`
+}
+
+type TwoFactorLoginRequest struct {
+	TwoFactorToken string `json:"two_factor_token" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
+// Сброс пароля: указывается телефон или email (код придёт туда же)
+type PasswordResetRequest struct {
+	Phone string `json:"phone" validate:"required_without=Email"`
+	Email string `json:"email" validate:"omitempty,email"`
+}
+
+type PasswordResetConfirmRequest struct {
+	Phone       string `json:"phone" validate:"required_without=Email"`
+	Email       string `json:"email" validate:"omitempty,email"`
+	Code        string `json:"code" validate:"required,len=6"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
+type EmailCodeRequest struct {
+	Code string `json:"code" validate:"required,len=6"`
+}
+
+type ContactChangeRequest struct {
+	Kind     string `json:"kind" validate:"required,oneof=phone email"`
+	NewValue string `json:"new_value" validate:"required"`
+}
+
+// Коды со старого и нового контакта
+type ContactChangeConfirmRequest struct {
+	ChangeID int64  `json:"change_id" validate:"required"`
+	OldCode  string `json:"old_code" validate:"required,len=6"`
+	NewCode  string `json:"new_code" validate:"required,len=6"`
+}
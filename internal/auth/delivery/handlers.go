@@ -2,12 +2,14 @@ package authadapter
 
 import (
 	"encoding/json"
-	"fmt"
+	"errors"
 	"log"
 	"net/http"
+	"strconv"
 
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	auth_model "github.com/Vovarama1992/emelya-go/internal/auth/model"
 	usecase "github.com/Vovarama1992/emelya-go/internal/auth/usecase"
-	"github.com/Vovarama1992/emelya-go/internal/jwtutil"
 	"github.com/Vovarama1992/emelya-go/internal/notifier"
 	model "github.com/Vovarama1992/emelya-go/internal/user/model"
 	"github.com/Vovarama1992/emelya-go/internal/utils"
@@ -20,7 +22,7 @@ type Handler struct {
 	authService *usecase.AuthService
 }
 
-func NewHandler(authService *usecase.AuthService, notifier *notifier.Notifier) *Handler {
+func NewHandler(authService *usecase.AuthService, notifier notifier.NotifierInterface) *Handler {
 	return &Handler{authService: authService}
 }
 
@@ -89,17 +91,12 @@ func (h *Handler) RequestRegister(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	code := usecase.GenerateCode()
-	if err := h.authService.SaveCodeToRedis(ctx, newUser.Phone, code); err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Ошибка Redis (код)")
-		return
-	}
 	if err := h.authService.SavePasswordToRedis(ctx, newUser.Phone, password); err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Ошибка Redis (пароль)")
 		return
 	}
-	if err := h.authService.SendCodeBySms(newUser.Phone, code); err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+	if err := h.authService.SendCode(ctx, newUser.Phone, utils.ClientIP(r)); err != nil {
+		respondWithAuthError(w, err)
 		return
 	}
 
@@ -130,13 +127,12 @@ func (h *Handler) ConfirmRegister(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	storedCode, err := h.authService.GetCodeFromRedis(ctx, req.Phone)
-	if err != nil || storedCode != req.Code {
-		respondWithError(w, http.StatusBadRequest, "Неверный или истекший код")
+	if err := h.authService.VerifyCode(ctx, user, req.Code, utils.ClientIP(r)); err != nil {
+		respondWithAuthError(w, err)
 		return
 	}
 
-	if err := h.authService.VerifyPhone(ctx, user.ID); err != nil {
+	if err := h.authService.ConfirmRegistration(ctx, user.ID); err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Ошибка подтверждения телефона")
 		return
 	}
@@ -151,48 +147,21 @@ func (h *Handler) ConfirmRegister(w http.ResponseWriter, r *http.Request) {
 		log.Println("[RedSMS: ОШИБКА] Не удалось отправить логин и пароль по SMS:", err)
 	}
 
-	log.Println("[DEBUG] Подтверждение регистрации — начинаем формирование письма оператору")
-	refText := "Письмо о подтверждении регистрации:\n"
-
-	if user.ReferrerID == nil {
-		log.Println("[DEBUG] У пользователя нет реферера — формируем стандартное письмо")
-		refText += "Зарегистрирован новый пользователь без реферальной ссылки.\n"
-	} else {
-		log.Printf("[DEBUG] У пользователя есть реферер (ID: %d) — ищем данные...", *user.ReferrerID)
-		refUser, err := h.authService.FindUserByID(ctx, *user.ReferrerID)
-		if err != nil || refUser == nil {
-			log.Println("[DEBUG] Не удалось получить данные реферера — формируем письмо без него")
-			refText += "Зарегистрирован новый пользователь (реферер указан, но не найден).\n"
-		} else {
-			log.Printf("[DEBUG] Найден реферер: %s %s (%s)", refUser.FirstName, refUser.LastName, refUser.Email)
-			refText += fmt.Sprintf(
-				"Зарегистрирован новый пользователь по реферальной ссылке от %s %s (%s).\n",
-				refUser.FirstName, refUser.LastName, refUser.Email,
-			)
-		}
-	}
-
-	body := fmt.Sprintf(
-		"%sСсылка на профиль: https://emelia-invest.com/%d\nИмя: %s %s %s\nТелефон: %s\nEmail: %s\nЛогин: %s",
-		refText,
-		user.ID,
-		user.FirstName, user.LastName, user.Patronymic,
-		user.Phone, user.Email, user.Login,
-	)
-
-	log.Printf("[DEBUG] Финальное письмо оператору:\n%s", body)
-	_ = h.authService.SendEmailToOperator("Подтверждение регистрации", body)
-
-	token, err := jwtutil.GenerateToken(user.ID, user.Email)
+	if err := h.authService.SendEmailVerification(ctx, user, utils.ClientIP(r)); err != nil {
+		log.Printf("[AUTH] Не удалось отправить письмо подтверждения email пользователю %d: %v", user.ID, err)
+	}
+
+	tokens, err := h.startSession(r, user)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Ошибка генерации токена")
 		return
 	}
 
 	json.NewEncoder(w).Encode(map[string]string{
-		"login":    user.Login,
-		"password": password,
-		"token":    token,
+		"login":         user.Login,
+		"password":      password,
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
 	})
 }
 
@@ -230,13 +199,8 @@ func (h *Handler) RequestLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	code := usecase.GenerateCode()
-	if err := h.authService.SaveCodeToRedis(ctx, user.Phone, code); err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Ошибка Redis")
-		return
-	}
-	if err := h.authService.SendCodeBySms(user.Phone, code); err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+	if err := h.authService.SendCode(ctx, user.Phone, utils.ClientIP(r)); err != nil {
+		respondWithAuthError(w, err)
 		return
 	}
 
@@ -276,37 +240,28 @@ func (h *Handler) ConfirmLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.Code == "1111" {
-		token, err := jwtutil.GenerateToken(user.ID, user.Email)
-		if err != nil {
-			respondWithError(w, http.StatusInternalServerError, "Ошибка генерации токена")
-			return
-		}
-		_ = h.authService.SendEmailToOperator("Вход по коду", fmt.Sprintf("Пользователь вошёл по коду:\nТелефон: %s", user.Phone))
-		json.NewEncoder(w).Encode(map[string]string{
-			"message": "Успешный вход (тестовый код)",
-			"token":   token,
-		})
-		return
-	}
-
-	storedCode, err := h.authService.GetCodeFromRedis(ctx, req.Phone)
-	if err != nil || storedCode != req.Code {
-		respondWithError(w, http.StatusBadRequest, "Неверный или истекший код")
+	if err := h.authService.VerifyCode(ctx, user, req.Code, utils.ClientIP(r)); err != nil {
+		respondWithAuthError(w, err)
 		return
 	}
 
-	token, err := jwtutil.GenerateToken(user.ID, user.Email)
+	result, err := h.authService.BeginLogin(ctx, user, r.UserAgent(), utils.ClientIP(r))
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Ошибка генерации токена")
 		return
 	}
+	if result.TwoFactorToken != "" {
+		respondTwoFactorRequired(w, result.TwoFactorToken)
+		return
+	}
+	tokens := result.Tokens
 
-	_ = h.authService.SendEmailToOperator("Вход по коду", fmt.Sprintf("Пользователь вошёл по коду:\nТелефон: %s", user.Phone))
+	_ = h.authService.NotifyOperators(ctx, notifier.TplOperatorLoginCode, map[string]any{"Phone": user.Phone})
 
 	json.NewEncoder(w).Encode(map[string]string{
-		"message": "Успешный вход",
-		"token":   token,
+		"message":       "Успешный вход",
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
 	})
 }
 
@@ -319,6 +274,7 @@ func (h *Handler) ConfirmLogin(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Router /api/auth/login-by-creds [post]
 func (h *Handler) LoginByCredentials(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -336,29 +292,29 @@ func (h *Handler) LoginByCredentials(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ctx := r.Context()
-	user, err := h.authService.FindUserByLogin(ctx, req.Login)
-	if err != nil || user == nil {
-		respondWithError(w, http.StatusBadRequest, "Пользователь не найден")
-		return
-	}
-
-	if !usecase.CheckPasswordHash(req.Password, user.PasswordHash) {
-		respondWithError(w, http.StatusUnauthorized, "Неверный логин или пароль")
+	user, err := h.authService.LoginByPassword(r.Context(), req.Login, req.Password, utils.ClientIP(r))
+	if err != nil {
+		respondWithAuthError(w, err)
 		return
 	}
 
-	token, err := jwtutil.GenerateToken(user.ID, user.Email)
+	result, err := h.authService.BeginLogin(r.Context(), user, r.UserAgent(), utils.ClientIP(r))
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Ошибка генерации токена")
 		return
 	}
+	if result.TwoFactorToken != "" {
+		respondTwoFactorRequired(w, result.TwoFactorToken)
+		return
+	}
+	tokens := result.Tokens
 
-	_ = h.authService.SendEmailToOperator("Вход по логину", fmt.Sprintf("Пользователь вошёл по логину и паролю:\nЛогин: %s\nТелефон: %s", req.Login, user.Phone))
+	_ = h.authService.NotifyOperators(r.Context(), notifier.TplOperatorLoginPassword, map[string]any{"Login": req.Login, "Phone": user.Phone})
 
 	json.NewEncoder(w).Encode(map[string]string{
-		"message": "Успешный вход",
-		"token":   token,
+		"message":       "Успешный вход",
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
 	})
 }
 
@@ -370,27 +326,669 @@ func (h *Handler) LoginByCredentials(w http.ResponseWriter, r *http.Request) {
 // @Failure 401 {object} map[string]string
 // @Router /api/auth/me [get]
 func (h *Handler) Me(w http.ResponseWriter, r *http.Request) {
-	authHeader := r.Header.Get("Authorization")
-	if authHeader == "" {
-		respondWithError(w, http.StatusUnauthorized, "Токен отсутствует")
+	json.NewEncoder(w).Encode(middleware.GetUserFromContext(r.Context()))
+}
+
+// VerifyTwoFactor godoc
+// @Summary Второй шаг входа: код из приложения-аутентификатора или код восстановления
+// @Tags auth-2fa
+// @Accept json
+// @Produce json
+// @Param data body TwoFactorLoginRequest true "Токен второго шага и код"
+// @Success 200 {object} map[string]string
+// @Failure 400,401,429,500 {object} map[string]string
+// @Router /api/auth/2fa/verify [post]
+func (h *Handler) VerifyTwoFactor(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
 		return
 	}
 
-	tokenStr := authHeader[len("Bearer "):]
-	userID, err := jwtutil.ParseToken(tokenStr)
+	var req TwoFactorLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	tokens, err := h.authService.CompleteTwoFactorLogin(r.Context(), req.TwoFactorToken, req.Code, r.UserAgent(), utils.ClientIP(r))
 	if err != nil {
-		respondWithError(w, http.StatusUnauthorized, "Неверный токен")
+		respondWithAuthError(w, err)
 		return
 	}
 
-	ctx := r.Context()
-	user, err := h.authService.FindUserByID(ctx, userID)
-	if err != nil || user == nil {
-		respondWithError(w, http.StatusUnauthorized, "Пользователь не найден")
+	json.NewEncoder(w).Encode(map[string]string{
+		"message":       "Успешный вход",
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+	})
+}
+
+// SetupTwoFactor godoc
+// @Summary Начать подключение 2FA: секрет и ссылка для QR-кода
+// @Tags auth-2fa
+// @Produce json
+// @Success 200 {object} auth_model.TOTPSetup
+// @Failure 401,409,500 {object} map[string]string
+// @Router /api/auth/2fa/setup [post]
+func (h *Handler) SetupTwoFactor(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	setup, err := h.authService.SetupTOTP(r.Context(), middleware.GetUserFromContext(r.Context()))
+	if err != nil {
+		respondWithAuthError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(setup)
+}
+
+// EnableTwoFactor godoc
+// @Summary Подтвердить подключение 2FA кодом из приложения
+// @Description В ответе — коды восстановления; они показываются один раз
+// @Tags auth-2fa
+// @Accept json
+// @Produce json
+// @Param data body TwoFactorCodeRequest true "Код из приложения"
+// @Success 200 {object} map[string][]string
+// @Failure 400,401,409,429,500 {object} map[string]string
+// @Router /api/auth/2fa/enable [post]
+func (h *Handler) EnableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	codes, err := h.authService.EnableTOTP(r.Context(), middleware.GetUserFromContext(r.Context()), req.Code)
+	if err != nil {
+		respondWithAuthError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string][]string{"recovery_codes": codes})
+}
+
+// DisableTwoFactor godoc
+// @Summary Отключить 2FA (недоступно сотрудникам)
+// @Tags auth-2fa
+// @Accept json
+// @Produce json
+// @Param data body TwoFactorCodeRequest true "Код из приложения или код восстановления"
+// @Success 200 {object} map[string]string
+// @Failure 400,401,403,429,500 {object} map[string]string
+// @Router /api/auth/2fa/disable [post]
+func (h *Handler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	if err := h.authService.DisableTOTP(r.Context(), middleware.GetUserFromContext(r.Context()), req.Code); err != nil {
+		respondWithAuthError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Двухфакторная аутентификация отключена"})
+}
+
+// AdminResetTwoFactor godoc
+// @Summary Админ: сбросить 2FA пользователя (все его сессии завершаются)
+// @Tags admin-user
+// @Accept json
+// @Produce json
+// @Param data body UserIDRequest true "ID пользователя"
+// @Success 200 {object} map[string]string
+// @Failure 400,403,404,500 {object} map[string]string
+// @Router /api/admin/auth/2fa/reset [post]
+func (h *Handler) AdminResetTwoFactor(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req UserIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	if err := h.authService.ResetTOTP(r.Context(), admin.ID, req.UserID); err != nil {
+		respondWithAuthError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Двухфакторная аутентификация сброшена"})
+}
+
+// AdminUnlock godoc
+// @Summary Админ: снять блокировку входа с пользователя
+// @Tags admin-user
+// @Accept json
+// @Produce json
+// @Param data body UserIDRequest true "ID пользователя"
+// @Success 200 {object} map[string]string
+// @Failure 400,404,500 {object} map[string]string
+// @Router /api/admin/auth/unlock [post]
+func (h *Handler) AdminUnlock(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req UserIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	err := h.authService.Unlock(r.Context(), admin.ID, req.UserID)
+	if errors.Is(err, usecase.ErrUserNotFound) {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка снятия блокировки")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Блокировка снята"})
+}
+
+// Refresh godoc
+// @Summary Обновление access-токена по refresh-токену
+// @Description Refresh-токен одноразовый: в ответе выдаётся новый, старый становится недействительным
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param data body RefreshRequest true "Refresh-токен"
+// @Success 200 {object} map[string]string
+// @Failure 400,401,500 {object} map[string]string
+// @Router /api/auth/refresh [post]
+func (h *Handler) Refresh(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	tokens, err := h.authService.RefreshSession(r.Context(), req.RefreshToken, r.UserAgent(), utils.ClientIP(r))
+	if errors.Is(err, usecase.ErrInvalidRefreshToken) {
+		respondWithError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка обновления токена")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+	})
+}
+
+// Logout godoc
+// @Summary Выход: завершение текущей сессии
+// @Tags auth
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Failure 401,500 {object} map[string]string
+// @Router /api/auth/logout [post]
+func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	sessionID := middleware.GetSessionIDFromContext(r.Context())
+	if err := h.authService.RevokeSession(r.Context(), user.ID, sessionID); err != nil && !errors.Is(err, usecase.ErrSessionNotFound) {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка завершения сессии")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Сессия завершена"})
+}
+
+// LogoutAll godoc
+// @Summary Выход на всех устройствах
+// @Tags auth
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Failure 401,500 {object} map[string]string
+// @Router /api/auth/logout-all [post]
+func (h *Handler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	if err := h.authService.RevokeAllSessions(r.Context(), user.ID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка завершения сессий")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Все сессии завершены"})
+}
+
+// ListSessions godoc
+// @Summary Активные сессии пользователя (устройство, IP)
+// @Tags auth
+// @Produce json
+// @Success 200 {array} auth_model.Session
+// @Failure 401,500 {object} map[string]string
+// @Router /api/auth/sessions [get]
+func (h *Handler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	sessions, err := h.authService.ListSessions(r.Context(), user.ID, middleware.GetSessionIDFromContext(r.Context()))
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения сессий")
+		return
+	}
+
+	json.NewEncoder(w).Encode(sessions)
+}
+
+// RevokeSession godoc
+// @Summary Завершение одной из своих сессий
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param data body RevokeSessionRequest true "ID сессии"
+// @Success 200 {object} map[string]string
+// @Failure 400,401,404,500 {object} map[string]string
+// @Router /api/auth/sessions/revoke [post]
+func (h *Handler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req RevokeSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	err := h.authService.RevokeSession(r.Context(), user.ID, req.SessionID)
+	if errors.Is(err, usecase.ErrSessionNotFound) {
+		respondWithError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка завершения сессии")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Сессия завершена"})
+}
+
+// ChangePassword godoc
+// @Summary Смена пароля (остальные сессии завершаются)
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param data body ChangePasswordRequest true "Старый и новый пароль"
+// @Success 200 {object} map[string]string
+// @Failure 400,401,429,500 {object} map[string]string
+// @Router /api/auth/password/change [post]
+func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	sessionID := middleware.GetSessionIDFromContext(r.Context())
+	if err := h.authService.ChangePassword(r.Context(), user, req.OldPassword, req.NewPassword, sessionID); err != nil {
+		respondWithAuthError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Пароль изменён"})
+}
+
+// RequestPasswordReset godoc
+// @Summary Запрос кода сброса пароля по SMS или на email
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param data body PasswordResetRequest true "Телефон или email"
+// @Success 200 {object} map[string]string
+// @Failure 400,429,500 {object} map[string]string
+// @Router /api/auth/password/reset/request [post]
+func (h *Handler) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req PasswordResetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+	if req.Email == "" {
+		req.Phone = utils.NormalizePhone(req.Phone)
+	}
+
+	if err := h.authService.RequestPasswordReset(r.Context(), req.Phone, req.Email, utils.ClientIP(r)); err != nil {
+		log.Printf("[AUTH] Ошибка запроса сброса пароля: %v", err)
+		respondWithAuthError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Если аккаунт существует, код отправлен"})
+}
+
+// ConfirmPasswordReset godoc
+// @Summary Подтверждение сброса пароля кодом (все сессии завершаются)
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param data body PasswordResetConfirmRequest true "Телефон или email, код и новый пароль"
+// @Success 200 {object} map[string]string
+// @Failure 400,429,500 {object} map[string]string
+// @Router /api/auth/password/reset/confirm [post]
+func (h *Handler) ConfirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req PasswordResetConfirmRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
 		return
 	}
+	if req.Email == "" {
+		req.Phone = utils.NormalizePhone(req.Phone)
+	}
 
-	json.NewEncoder(w).Encode(user)
+	err := h.authService.ConfirmPasswordReset(r.Context(), req.Phone, req.Email, req.Code, req.NewPassword, utils.ClientIP(r))
+	if err != nil {
+		respondWithAuthError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Пароль изменён, войдите с новым паролем"})
+}
+
+// SendEmailVerification godoc
+// @Summary Отправить письмо подтверждения email (повтор — не чаще раза в минуту)
+// @Tags auth
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Failure 401,409,429,500 {object} map[string]string
+// @Router /api/auth/email/send-verification [post]
+func (h *Handler) SendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	if err := h.authService.SendEmailVerification(r.Context(), user, utils.ClientIP(r)); err != nil {
+		respondWithAuthError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Письмо отправлено на " + user.Email})
+}
+
+// ConfirmEmail godoc
+// @Summary Подтвердить email кодом из письма
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param data body EmailCodeRequest true "Код из письма"
+// @Success 200 {object} map[string]string
+// @Failure 400,401,409,429,500 {object} map[string]string
+// @Router /api/auth/email/confirm [post]
+func (h *Handler) ConfirmEmail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req EmailCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	if err := h.authService.ConfirmEmailByCode(r.Context(), user, req.Code, utils.ClientIP(r)); err != nil {
+		respondWithAuthError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Email подтверждён"})
+}
+
+// ConfirmEmailLink godoc
+// @Summary Подтвердить email по ссылке из письма
+// @Tags auth
+// @Produce json
+// @Param token query string true "Токен из ссылки"
+// @Success 200 {object} map[string]string
+// @Failure 400,409,500 {object} map[string]string
+// @Router /api/auth/email/confirm-link [get]
+func (h *Handler) ConfirmEmailLink(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		respondWithError(w, http.StatusBadRequest, "Не указан токен")
+		return
+	}
+
+	if err := h.authService.ConfirmEmailByToken(r.Context(), token); err != nil {
+		respondWithAuthError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Email подтверждён"})
+}
+
+// RequestContactChange godoc
+// @Summary Запрос смены телефона или email: коды уходят на старый и новый контакт
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param data body ContactChangeRequest true "Тип контакта и новое значение"
+// @Success 200 {object} auth_model.ContactChange
+// @Failure 400,401,409,429,500 {object} map[string]string
+// @Router /api/auth/contacts/change [post]
+func (h *Handler) RequestContactChange(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req ContactChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	change, err := h.authService.RequestContactChange(r.Context(), user, auth_model.ContactKind(req.Kind), req.NewValue, utils.ClientIP(r))
+	if err != nil {
+		respondWithAuthError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(change)
+}
+
+// ConfirmContactChange godoc
+// @Summary Подтверждение смены контакта кодами (после смены телефона остальные сессии завершаются)
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param data body ContactChangeConfirmRequest true "ID заявки и оба кода"
+// @Success 200 {object} map[string]string
+// @Failure 400,401,404,409,429,500 {object} map[string]string
+// @Router /api/auth/contacts/confirm [post]
+func (h *Handler) ConfirmContactChange(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req ContactChangeConfirmRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	sessionID := middleware.GetSessionIDFromContext(r.Context())
+	err := h.authService.ConfirmContactChange(r.Context(), user, req.ChangeID, req.OldCode, req.NewCode, utils.ClientIP(r), sessionID)
+	if err != nil {
+		respondWithAuthError(w, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Контакт изменён"})
+}
+
+// startSession открывает сессию с устройства запроса
+func (h *Handler) startSession(r *http.Request, user *model.User) (*auth_model.TokenPair, error) {
+	return h.authService.StartSession(r.Context(), user, r.UserAgent(), utils.ClientIP(r))
+}
+
+// respondWithAuthError переводит ошибки входа в HTTP-коды; при блокировке отдаёт Retry-After
+func respondWithAuthError(w http.ResponseWriter, err error) {
+	var lockout *usecase.LockoutError
+	switch {
+	case errors.As(err, &lockout):
+		w.Header().Set("Retry-After", strconv.Itoa(int(lockout.RetryAfter.Seconds())+1))
+		respondWithError(w, http.StatusTooManyRequests, err.Error())
+	case errors.Is(err, usecase.ErrInvalidCode),
+		errors.Is(err, usecase.ErrWeakPassword),
+		errors.Is(err, usecase.ErrInvalidVerifyLink),
+		errors.Is(err, usecase.ErrInvalidContact),
+		errors.Is(err, usecase.ErrContactUnchanged):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrInvalidCredentials),
+		errors.Is(err, usecase.ErrInvalidChallenge):
+		respondWithError(w, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, usecase.ErrInvalidTOTPCode),
+		errors.Is(err, usecase.ErrTOTPNotSetUp),
+		errors.Is(err, usecase.ErrTOTPNotEnabled):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, usecase.ErrTOTPAlreadyEnabled),
+		errors.Is(err, usecase.ErrEmailAlreadyVerified),
+		errors.Is(err, usecase.ErrContactTaken):
+		respondWithError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, usecase.ErrTOTPRequired),
+		errors.Is(err, usecase.ErrOwnTOTPReset):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, usecase.ErrUserNotFound),
+		errors.Is(err, usecase.ErrContactChangeNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// respondTwoFactorRequired — первый шаг входа пройден, нужен код второго фактора
+func respondTwoFactorRequired(w http.ResponseWriter, token string) {
+	json.NewEncoder(w).Encode(map[string]string{
+		"message":          "Требуется код двухфакторной аутентификации",
+		"two_factor_token": token,
+	})
 }
 
 func respondWithError(w http.ResponseWriter, code int, message string) {
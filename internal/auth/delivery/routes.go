@@ -4,10 +4,26 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	user_ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
 	"github.com/Vovarama1992/go-utils/httputil"
 )
 
-func RegisterRoutes(mux *http.ServeMux, handler *Handler) {
+func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService user_ports.UserServiceInterface, sessions middleware.SessionChecker, access middleware.PermissionChecker) {
+	withUserAuth := func(h http.Handler) http.Handler {
+		return middleware.AuthMiddleware(userService, sessions)(h)
+	}
+
+	// Маршруты, нужные сотруднику, чтобы подключить 2FA
+	withUserAuthNo2FA := func(h http.Handler) http.Handler {
+		return middleware.AuthMiddleware(userService, sessions, middleware.AllowWithoutTwoFactor())(h)
+	}
+
+	withPermission := func(perm rbac.Permission, h http.Handler) http.Handler {
+		return middleware.PermissionMiddleware(userService, sessions, access, perm)(h)
+	}
+
 	// Registration
 	mux.Handle("/api/auth/request-register",
 		httputil.RecoverMiddleware(
@@ -39,10 +55,129 @@ func RegisterRoutes(mux *http.ServeMux, handler *Handler) {
 		),
 	)
 
+	// Sessions
+	mux.Handle("/api/auth/refresh",
+		httputil.RecoverMiddleware(
+			httputil.NewRateLimiter(30, time.Minute)(
+				http.HandlerFunc(handler.Refresh),
+			),
+		),
+	)
+	mux.Handle("/api/auth/logout",
+		httputil.RecoverMiddleware(
+			withUserAuthNo2FA(http.HandlerFunc(handler.Logout)),
+		),
+	)
+	mux.Handle("/api/auth/logout-all",
+		httputil.RecoverMiddleware(
+			withUserAuthNo2FA(http.HandlerFunc(handler.LogoutAll)),
+		),
+	)
+	mux.Handle("/api/auth/sessions",
+		httputil.RecoverMiddleware(
+			withUserAuthNo2FA(http.HandlerFunc(handler.ListSessions)),
+		),
+	)
+	mux.Handle("/api/auth/sessions/revoke",
+		httputil.RecoverMiddleware(
+			withUserAuthNo2FA(http.HandlerFunc(handler.RevokeSession)),
+		),
+	)
+
+	// Password
+	mux.Handle("/api/auth/password/change",
+		httputil.RecoverMiddleware(
+			withUserAuthNo2FA(http.HandlerFunc(handler.ChangePassword)),
+		),
+	)
+	mux.Handle("/api/auth/password/reset/request",
+		httputil.RecoverMiddleware(
+			httputil.NewRateLimiter(10, time.Minute)(
+				http.HandlerFunc(handler.RequestPasswordReset),
+			),
+		),
+	)
+	mux.Handle("/api/auth/password/reset/confirm",
+		httputil.RecoverMiddleware(
+			httputil.NewRateLimiter(10, time.Minute)(
+				http.HandlerFunc(handler.ConfirmPasswordReset),
+			),
+		),
+	)
+
+	// Email verification
+	mux.Handle("/api/auth/email/send-verification",
+		httputil.RecoverMiddleware(
+			withUserAuthNo2FA(http.HandlerFunc(handler.SendEmailVerification)),
+		),
+	)
+	mux.Handle("/api/auth/email/confirm",
+		httputil.RecoverMiddleware(
+			withUserAuthNo2FA(http.HandlerFunc(handler.ConfirmEmail)),
+		),
+	)
+	mux.Handle("/api/auth/email/confirm-link",
+		httputil.RecoverMiddleware(
+			httputil.NewRateLimiter(10, time.Minute)(
+				http.HandlerFunc(handler.ConfirmEmailLink),
+			),
+		),
+	)
+
+	// Contact change
+	mux.Handle("/api/auth/contacts/change",
+		httputil.RecoverMiddleware(
+			withUserAuth(http.HandlerFunc(handler.RequestContactChange)),
+		),
+	)
+	mux.Handle("/api/auth/contacts/confirm",
+		httputil.RecoverMiddleware(
+			httputil.NewRateLimiter(10, time.Minute)(
+				withUserAuth(http.HandlerFunc(handler.ConfirmContactChange)),
+			),
+		),
+	)
+
+	// Two-factor
+	mux.Handle("/api/auth/2fa/verify",
+		httputil.RecoverMiddleware(
+			httputil.NewRateLimiter(10, time.Minute)(
+				http.HandlerFunc(handler.VerifyTwoFactor),
+			),
+		),
+	)
+	mux.Handle("/api/auth/2fa/setup",
+		httputil.RecoverMiddleware(
+			withUserAuthNo2FA(http.HandlerFunc(handler.SetupTwoFactor)),
+		),
+	)
+	mux.Handle("/api/auth/2fa/enable",
+		httputil.RecoverMiddleware(
+			withUserAuthNo2FA(http.HandlerFunc(handler.EnableTwoFactor)),
+		),
+	)
+	mux.Handle("/api/auth/2fa/disable",
+		httputil.RecoverMiddleware(
+			withUserAuth(http.HandlerFunc(handler.DisableTwoFactor)),
+		),
+	)
+
 	// Current user
 	mux.Handle("/api/auth/me",
 		httputil.RecoverMiddleware(
-			http.HandlerFunc(handler.Me),
+			withUserAuthNo2FA(http.HandlerFunc(handler.Me)),
+		),
+	)
+
+	// Admin
+	mux.Handle("/api/admin/auth/unlock",
+		httputil.RecoverMiddleware(
+			withPermission(rbac.PermUsersWrite, http.HandlerFunc(handler.AdminUnlock)),
+		),
+	)
+	mux.Handle("/api/admin/auth/2fa/reset",
+		httputil.RecoverMiddleware(
+			withPermission(rbac.PermUsersWrite, http.HandlerFunc(handler.AdminResetTwoFactor)),
 		),
 	)
 }
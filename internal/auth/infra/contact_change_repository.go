@@ -0,0 +1,131 @@
+package auth_infra
+
+import (
+	"context"
+	"time"
+
+	auth_model "github.com/Vovarama1992/emelya-go/internal/auth/model"
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	"github.com/jackc/pgx/v5"
+)
+
+type ContactChangeRepository struct {
+	DB *db.DB
+}
+
+func NewContactChangeRepository(db *db.DB) *ContactChangeRepository {
+	return &ContactChangeRepository{DB: db}
+}
+
+func (r *ContactChangeRepository) Create(ctx context.Context, change *auth_model.ContactChange) error {
+	query := `
+		INSERT INTO user_contact_changes (user_id, kind, old_value, new_value, old_code_hash, new_code_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, status, created_at
+	`
+	return r.DB.Pool.QueryRow(ctx, query,
+		change.UserID,
+		change.Kind,
+		change.OldValue,
+		change.NewValue,
+		change.OldCodeHash,
+		change.NewCodeHash,
+		change.ExpiresAt,
+	).Scan(&change.ID, &change.Status, &change.CreatedAt)
+}
+
+// CancelPending — новая заявка того же типа отменяет предыдущую
+func (r *ContactChangeRepository) CancelPending(ctx context.Context, userID int64, kind auth_model.ContactKind) error {
+	query := `
+		UPDATE user_contact_changes
+		SET status = 'cancelled'
+		WHERE user_id = $1 AND kind = $2 AND status = 'pending'
+	`
+	_, err := r.DB.Pool.Exec(ctx, query, userID, kind)
+	return err
+}
+
+func (r *ContactChangeRepository) GetPending(ctx context.Context, id, userID int64) (*auth_model.ContactChange, error) {
+	query := `
+		SELECT id, user_id, kind, old_value, new_value, old_code_hash, new_code_hash,
+		       attempts, status, expires_at, confirmed_at, created_at
+		FROM user_contact_changes
+		WHERE id = $1 AND user_id = $2 AND status = 'pending'
+	`
+	var c auth_model.ContactChange
+	err := r.DB.Pool.QueryRow(ctx, query, id, userID).Scan(
+		&c.ID,
+		&c.UserID,
+		&c.Kind,
+		&c.OldValue,
+		&c.NewValue,
+		&c.OldCodeHash,
+		&c.NewCodeHash,
+		&c.Attempts,
+		&c.Status,
+		&c.ExpiresAt,
+		&c.ConfirmedAt,
+		&c.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *ContactChangeRepository) IncrementAttempts(ctx context.Context, id int64) (int, error) {
+	query := `UPDATE user_contact_changes SET attempts = attempts + 1 WHERE id = $1 RETURNING attempts`
+	var attempts int
+	err := r.DB.Pool.QueryRow(ctx, query, id).Scan(&attempts)
+	return attempts, err
+}
+
+func (r *ContactChangeRepository) SetStatus(ctx context.Context, id int64, status auth_model.ContactChangeStatus) error {
+	query := `UPDATE user_contact_changes SET status = $1 WHERE id = $2`
+	_, err := r.DB.Pool.Exec(ctx, query, status, id)
+	return err
+}
+
+// Apply меняет контакт пользователя (он сразу считается подтверждённым)
+// и закрывает заявку в одной транзакции. pgx.ErrNoRows — заявка уже не ожидает
+// подтверждения или истекла.
+func (r *ContactChangeRepository) Apply(ctx context.Context, change *auth_model.ContactChange) (err error) {
+	tx, err := r.DB.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	// Закрываем заявку первой: отменённую или истёкшую параллельно заявку применять нельзя
+	tag, err := tx.Exec(ctx, `
+		UPDATE user_contact_changes
+		SET status = 'confirmed', confirmed_at = now()
+		WHERE id = $1 AND status = 'pending' AND expires_at > now()
+	`, change.ID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() != 1 {
+		return pgx.ErrNoRows
+	}
+
+	userQuery := `UPDATE users SET phone = $1, is_phone_verified = TRUE, contacts_changed_at = now() WHERE id = $2`
+	if change.Kind == auth_model.ContactEmail {
+		userQuery = `UPDATE users SET email = $1, is_email_verified = TRUE, contacts_changed_at = now() WHERE id = $2`
+	}
+	_, err = tx.Exec(ctx, userQuery, change.NewValue, change.UserID)
+	return err
+}
+
+// ContactsChangedAt — время последней смены контакта; nil — не менялись
+func (r *ContactChangeRepository) ContactsChangedAt(ctx context.Context, userID int64) (*time.Time, error) {
+	var changedAt *time.Time
+	err := r.DB.Pool.QueryRow(ctx, `SELECT contacts_changed_at FROM users WHERE id = $1`, userID).Scan(&changedAt)
+	return changedAt, err
+}
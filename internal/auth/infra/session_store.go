@@ -0,0 +1,159 @@
+package auth_infra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	model "github.com/Vovarama1992/emelya-go/internal/auth/model"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrSessionNotFound = errors.New("сессия не найдена")
+	// ErrRefreshConflict — refresh-токен сессии уже сменился: его предъявили повторно
+	ErrRefreshConflict = errors.New("refresh-токен сессии уже сменился")
+)
+
+// sessionRecord — то, что лежит в Redis; хэш refresh-токена наружу не отдаётся
+type sessionRecord struct {
+	model.Session
+	RefreshHash string `json:"refresh_hash"`
+}
+
+type SessionStore struct {
+	redis *redis.Client
+}
+
+func NewSessionStore(redisClient *redis.Client) *SessionStore {
+	return &SessionStore{redis: redisClient}
+}
+
+func sessionKey(id string) string {
+	return fmt.Sprintf("session:%s", id)
+}
+
+func userSessionsKey(userID int64) string {
+	return fmt.Sprintf("user_sessions:%d", userID)
+}
+
+func (s *SessionStore) Save(ctx context.Context, session *model.Session, refreshHash string, ttl time.Duration) error {
+	data, err := json.Marshal(sessionRecord{Session: *session, RefreshHash: refreshHash})
+	if err != nil {
+		return err
+	}
+
+	pipe := s.redis.TxPipeline()
+	pipe.Set(ctx, sessionKey(session.ID), data, ttl)
+	pipe.SAdd(ctx, userSessionsKey(session.UserID), session.ID)
+	pipe.Expire(ctx, userSessionsKey(session.UserID), ttl)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// Rotate меняет хэш refresh-токена, только если в Redis всё ещё лежит prevHash.
+// WATCH не даст двум параллельным обновлениям с одним токеном пройти оба.
+func (s *SessionStore) Rotate(ctx context.Context, session *model.Session, prevHash, refreshHash string, ttl time.Duration) error {
+	data, err := json.Marshal(sessionRecord{Session: *session, RefreshHash: refreshHash})
+	if err != nil {
+		return err
+	}
+
+	key := sessionKey(session.ID)
+	err = s.redis.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := tx.Get(ctx, key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			return ErrSessionNotFound
+		}
+		if err != nil {
+			return err
+		}
+		var rec sessionRecord
+		if err := json.Unmarshal(current, &rec); err != nil {
+			return err
+		}
+		if rec.RefreshHash != prevHash {
+			return ErrRefreshConflict
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, ttl)
+			pipe.SAdd(ctx, userSessionsKey(session.UserID), session.ID)
+			pipe.Expire(ctx, userSessionsKey(session.UserID), ttl)
+			return nil
+		})
+		return err
+	}, key)
+	if errors.Is(err, redis.TxFailedErr) {
+		return ErrRefreshConflict
+	}
+	return err
+}
+
+func (s *SessionStore) Get(ctx context.Context, id string) (*model.Session, string, error) {
+	data, err := s.redis.Get(ctx, sessionKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, "", ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	var rec sessionRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, "", err
+	}
+	return &rec.Session, rec.RefreshHash, nil
+}
+
+func (s *SessionStore) Exists(ctx context.Context, id string) (bool, error) {
+	n, err := s.redis.Exists(ctx, sessionKey(id)).Result()
+	return n > 0, err
+}
+
+func (s *SessionStore) Delete(ctx context.Context, userID int64, id string) error {
+	pipe := s.redis.TxPipeline()
+	pipe.Del(ctx, sessionKey(id))
+	pipe.SRem(ctx, userSessionsKey(userID), id)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// ListByUser возвращает живые сессии и попутно вычищает из индекса истёкшие
+func (s *SessionStore) ListByUser(ctx context.Context, userID int64) ([]*model.Session, error) {
+	ids, err := s.redis.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*model.Session, 0, len(ids))
+	for _, id := range ids {
+		session, _, err := s.Get(ctx, id)
+		if errors.Is(err, ErrSessionNotFound) {
+			s.redis.SRem(ctx, userSessionsKey(userID), id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+func (s *SessionStore) DeleteAllByUser(ctx context.Context, userID int64) error {
+	ids, err := s.redis.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+
+	pipe := s.redis.TxPipeline()
+	for _, id := range ids {
+		pipe.Del(ctx, sessionKey(id))
+	}
+	pipe.Del(ctx, userSessionsKey(userID))
+	_, err = pipe.Exec(ctx)
+	return err
+}
@@ -0,0 +1,102 @@
+package auth_infra
+
+import (
+	"context"
+
+	"github.com/Vovarama1992/emelya-go/internal/db"
+)
+
+type TOTPRepository struct {
+	DB *db.DB
+}
+
+func NewTOTPRepository(db *db.DB) *TOTPRepository {
+	return &TOTPRepository{DB: db}
+}
+
+// SaveSecret начинает (или перезапускает) enrollment — секрет ещё не подтверждён
+func (r *TOTPRepository) SaveSecret(ctx context.Context, userID int64, secret string) error {
+	query := `
+		INSERT INTO user_totp (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, confirmed_at = NULL, created_at = now()
+	`
+	_, err := r.DB.Pool.Exec(ctx, query, userID, secret)
+	return err
+}
+
+func (r *TOTPRepository) GetSecret(ctx context.Context, userID int64) (secret string, confirmed bool, err error) {
+	query := `SELECT secret, confirmed_at IS NOT NULL FROM user_totp WHERE user_id = $1`
+	err = r.DB.Pool.QueryRow(ctx, query, userID).Scan(&secret, &confirmed)
+	return secret, confirmed, err
+}
+
+// Enable подтверждает секрет, включает 2FA и заменяет коды восстановления
+func (r *TOTPRepository) Enable(ctx context.Context, userID int64, recoveryHashes []string) (err error) {
+	tx, err := r.DB.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, `UPDATE user_totp SET confirmed_at = now() WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, `UPDATE users SET totp_enabled = TRUE WHERE id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	for _, hash := range recoveryHashes {
+		if _, err = tx.Exec(ctx, `INSERT INTO user_recovery_codes (user_id, code_hash) VALUES ($1, $2)`, userID, hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UseRecoveryCode гасит код восстановления; false — кода нет или он уже использован
+func (r *TOTPRepository) UseRecoveryCode(ctx context.Context, userID int64, hash string) (bool, error) {
+	query := `
+		UPDATE user_recovery_codes
+		SET used_at = now()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+	tag, err := r.DB.Pool.Exec(ctx, query, userID, hash)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// Delete полностью выключает 2FA пользователя
+func (r *TOTPRepository) Delete(ctx context.Context, userID int64) (err error) {
+	tx, err := r.DB.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, `DELETE FROM user_totp WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `UPDATE users SET totp_enabled = FALSE WHERE id = $1`, userID)
+	return err
+}
@@ -4,17 +4,57 @@ import (
 	"context"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/Vovarama1992/emelya-go/internal/jwtutil"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
 	models "github.com/Vovarama1992/emelya-go/internal/user/model"
 	ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
 )
 
 type contextKey string
 
-const UserContextKey contextKey = "user"
+const (
+	UserContextKey        contextKey = "user"
+	SessionContextKey     contextKey = "session"
+	TokenExpiryContextKey contextKey = "token_expiry"
+)
+
+// SessionChecker — проверка, что сессия токена не отозвана (logout, «выйти везде»)
+type SessionChecker interface {
+	IsSessionActive(ctx context.Context, sessionID string) bool
+}
+
+// PermissionChecker — источник прав ролей (RBAC)
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, role models.UserRole, perm rbac.Permission) bool
+}
+
+type authOptions struct {
+	allowWithoutTwoFactor bool
+	requireVerifiedEmail  bool
+}
+
+type AuthOption func(*authOptions)
+
+// AllowWithoutTwoFactor — маршруты, доступные сотруднику до подключения 2FA
+// (сама настройка 2FA, профиль, выход)
+func AllowWithoutTwoFactor() AuthOption {
+	return func(o *authOptions) { o.allowWithoutTwoFactor = true }
+}
+
+// RequireVerifiedEmail — маршрут доступен только с подтверждённым email
+func RequireVerifiedEmail() AuthOption {
+	return func(o *authOptions) { o.requireVerifiedEmail = true }
+}
+
+// AuthMiddleware проверяет токен и сессию; сотрудникам без подключённой 2FA доступ закрыт
+func AuthMiddleware(userService ports.UserServiceInterface, sessions SessionChecker, opts ...AuthOption) func(http.Handler) http.Handler {
+	var o authOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
 
-func AuthMiddleware(userService ports.UserServiceInterface, adminOnly bool) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
@@ -23,30 +63,68 @@ func AuthMiddleware(userService ports.UserServiceInterface, adminOnly bool) func
 				return
 			}
 
-			userID, err := jwtutil.ParseToken(authHeader[len("Bearer "):])
+			claims, err := jwtutil.ParseAccessToken(authHeader[len("Bearer "):])
 			if err != nil {
 				http.Error(w, "Неверный токен", http.StatusUnauthorized)
 				return
 			}
 
-			user, err := userService.FindUserByID(r.Context(), userID)
+			if !sessions.IsSessionActive(r.Context(), claims.SessionID) {
+				http.Error(w, "Сессия завершена", http.StatusUnauthorized)
+				return
+			}
+
+			user, err := userService.FindUserByID(r.Context(), claims.UserID)
 			if err != nil || user == nil {
 				http.Error(w, "Пользователь не найден", http.StatusUnauthorized)
 				return
 			}
 
-			if adminOnly && user.Role != models.RoleAdmin {
-				http.Error(w, "Только для админов", http.StatusForbidden)
+			if user.Role.IsStaff() && !user.TOTPEnabled && !o.allowWithoutTwoFactor {
+				http.Error(w, "Для сотрудников требуется двухфакторная аутентификация", http.StatusForbidden)
+				return
+			}
+
+			if o.requireVerifiedEmail && !user.IsEmailVerified {
+				http.Error(w, "Подтвердите email", http.StatusForbidden)
 				return
 			}
 
 			ctx := context.WithValue(r.Context(), UserContextKey, user)
+			ctx = context.WithValue(ctx, SessionContextKey, claims.SessionID)
+			ctx = context.WithValue(ctx, TokenExpiryContextKey, claims.ExpiresAt)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// PermissionMiddleware — авторизация плюс проверка конкретного права роли
+func PermissionMiddleware(userService ports.UserServiceInterface, sessions SessionChecker, checker PermissionChecker, perm rbac.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		check := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := GetUserFromContext(r.Context())
+			if !user.Role.IsStaff() || !checker.HasPermission(r.Context(), user.Role, perm) {
+				http.Error(w, "Недостаточно прав", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+		return AuthMiddleware(userService, sessions)(check)
+	}
+}
+
 func GetUserFromContext(ctx context.Context) *models.User {
 	user, _ := ctx.Value(UserContextKey).(*models.User)
 	return user
 }
+
+func GetSessionIDFromContext(ctx context.Context) string {
+	sessionID, _ := ctx.Value(SessionContextKey).(string)
+	return sessionID
+}
+
+// GetTokenExpiryFromContext — когда истекает access-токен запроса; нулевое время — срок не задан
+func GetTokenExpiryFromContext(ctx context.Context) time.Time {
+	exp, _ := ctx.Value(TokenExpiryContextKey).(time.Time)
+	return exp
+}
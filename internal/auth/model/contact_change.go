@@ -0,0 +1,36 @@
+package auth_model
+
+import "time"
+
+type ContactKind string
+
+const (
+	ContactPhone ContactKind = "phone"
+	ContactEmail ContactKind = "email"
+)
+
+type ContactChangeStatus string
+
+const (
+	ContactChangePending   ContactChangeStatus = "pending"
+	ContactChangeConfirmed ContactChangeStatus = "confirmed"
+	ContactChangeCancelled ContactChangeStatus = "cancelled"
+	ContactChangeExpired   ContactChangeStatus = "expired"
+)
+
+// ContactChange — заявка на смену телефона или email. Коды отправляются на старый
+// и новый контакт, в базе лежат только их хэши.
+type ContactChange struct {
+	ID          int64               `json:"id"`
+	UserID      int64               `json:"user_id"`
+	Kind        ContactKind         `json:"kind"`
+	OldValue    string              `json:"old_value"`
+	NewValue    string              `json:"new_value"`
+	OldCodeHash string              `json:"-"`
+	NewCodeHash string              `json:"-"`
+	Attempts    int                 `json:"-"`
+	Status      ContactChangeStatus `json:"status"`
+	ExpiresAt   time.Time           `json:"expires_at"`
+	ConfirmedAt *time.Time          `json:"confirmed_at,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
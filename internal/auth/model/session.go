@@ -0,0 +1,27 @@
+package auth_model
+
+import "time"
+
+// Session — вход пользователя с конкретного устройства; живёт, пока жив refresh-токен
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     int64     `json:"user_id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	Current    bool      `json:"current"`
+}
+
+// TokenPair — ответ на вход и обновление токенов
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	SessionID    string
+}
+
+// LoginResult — итог первого шага входа: либо токены, либо запрос второго фактора
+type LoginResult struct {
+	Tokens         *TokenPair
+	TwoFactorToken string
+}
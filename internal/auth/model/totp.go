@@ -0,0 +1,7 @@
+package auth_model
+
+// TOTPSetup — данные для подключения приложения-аутентификатора
+type TOTPSetup struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
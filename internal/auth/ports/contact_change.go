@@ -0,0 +1,16 @@
+package auth_ports
+
+import (
+	"context"
+
+	auth_model "github.com/Vovarama1992/emelya-go/internal/auth/model"
+)
+
+type ContactChangeRepository interface {
+	Create(ctx context.Context, change *auth_model.ContactChange) error
+	CancelPending(ctx context.Context, userID int64, kind auth_model.ContactKind) error
+	GetPending(ctx context.Context, id, userID int64) (*auth_model.ContactChange, error)
+	IncrementAttempts(ctx context.Context, id int64) (int, error)
+	SetStatus(ctx context.Context, id int64, status auth_model.ContactChangeStatus) error
+	Apply(ctx context.Context, change *auth_model.ContactChange) error
+}
@@ -0,0 +1,11 @@
+package auth_ports
+
+import "context"
+
+type TOTPRepository interface {
+	SaveSecret(ctx context.Context, userID int64, secret string) error
+	GetSecret(ctx context.Context, userID int64) (secret string, confirmed bool, err error)
+	Enable(ctx context.Context, userID int64, recoveryHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID int64, hash string) (bool, error)
+	Delete(ctx context.Context, userID int64) error
+}
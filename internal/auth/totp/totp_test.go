@@ -0,0 +1,58 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// Секрет из RFC 6238 ("12345678901234567890") в base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		now      int64
+		wantStep int64
+		wantOK   bool
+	}{
+		// Коды — последние шесть цифр векторов RFC 6238 для SHA1
+		{"rfc t=59", rfcSecret, "287082", 59, 1, true},
+		{"rfc t=1111111109", rfcSecret, "081804", 1111111109, 37037036, true},
+		{"rfc t=1111111111", rfcSecret, "050471", 1111111111, 37037037, true},
+		{"rfc t=1234567890", rfcSecret, "005924", 1234567890, 41152263, true},
+		{"rfc t=2000000000", rfcSecret, "279037", 2000000000, 66666666, true},
+		{"секрет в нижнем регистре", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", "287082", 59, 1, true},
+		{"код предыдущего шага", rfcSecret, "287082", 59 + period, 1, true},
+		{"код следующего шага", rfcSecret, "287082", 59 - period, 1, true},
+		{"код двух шагов назад", rfcSecret, "287082", 59 + 2*period, 0, false},
+		{"неверный код", rfcSecret, "287083", 59, 0, false},
+		{"короткий код", rfcSecret, "28708", 59, 0, false},
+		{"битый секрет", "not-base32!", "287082", 59, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := Validate(tt.secret, tt.code, time.Unix(tt.now, 0))
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("got (%d, %v), want (%d, %v)", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGenerateSecretRoundTrip(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := encoding.DecodeString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	code := generate(key, now.Unix()/period)
+	if _, ok := Validate(secret, code, now); !ok {
+		t.Errorf("код %s для нового секрета не прошёл проверку", code)
+	}
+}
@@ -0,0 +1,206 @@
+package auth_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Vovarama1992/go-utils/ctxutil"
+)
+
+var ErrLocked = errors.New("слишком много попыток, повторите позже")
+
+// LockoutError — субъект (телефон, логин или IP) временно заблокирован
+type LockoutError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockoutError) Error() string {
+	return fmt.Sprintf("%s (через %d сек.)", ErrLocked.Error(), int(e.RetryAfter.Seconds())+1)
+}
+
+func (e *LockoutError) Is(target error) bool {
+	return target == ErrLocked
+}
+
+const (
+	// Неверных вводов одного кода, после которых он аннулируется
+	maxCodeAttempts = 5
+
+	// Неудачных попыток за окно, после которых ставится блокировка
+	failureWindow      = time.Hour
+	maxFailuresPerUser = 5
+	maxFailuresPerIP   = 20
+	lockoutLevelTTL    = 24 * time.Hour
+	resendCooldown     = time.Minute
+	sendWindow         = time.Hour
+	maxSendsPerTarget  = 5
+	maxSendsPerIP      = 20
+)
+
+// Блокировки растут с каждым повтором в течение суток
+var lockoutSteps = []time.Duration{
+	5 * time.Minute,
+	15 * time.Minute,
+	time.Hour,
+	6 * time.Hour,
+	24 * time.Hour,
+}
+
+func phoneSubject(phone string) string { return "phone:" + phone }
+func emailSubject(email string) string { return "email:" + strings.ToLower(email) }
+func loginSubject(login string) string { return "login:" + login }
+func ipSubject(ip string) string       { return "ip:" + ip }
+
+func lockoutKey(subject string) string      { return "auth_lock:" + subject }
+func lockoutLevelKey(subject string) string { return "auth_lock_level:" + subject }
+func failuresKey(subject string) string     { return "auth_fail:" + subject }
+func sendsKey(subject string) string        { return "auth_sends:" + subject }
+func codeAttemptsKey(phone string) string   { return "auth_code_attempts:phone:" + phone }
+func cooldownKey(subject string) string     { return "auth_code_cooldown:" + subject }
+
+// checkLocked возвращает LockoutError, если заблокирован хотя бы один из субъектов
+func (s *AuthService) checkLocked(ctx context.Context, subjects ...string) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 1)
+	defer cancel()
+
+	for _, subject := range subjects {
+		ttl, err := s.redisClient.TTL(ctx, lockoutKey(subject)).Result()
+		if err != nil {
+			return err
+		}
+		if ttl > 0 {
+			return &LockoutError{RetryAfter: ttl}
+		}
+	}
+	return nil
+}
+
+// registerFailure считает неудачу и при превышении порога ставит блокировку
+func (s *AuthService) registerFailure(ctx context.Context, subject string, limit int64) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 1)
+	defer cancel()
+
+	count, err := s.incrWithTTL(ctx, failuresKey(subject), failureWindow)
+	if err != nil {
+		log.Printf("[AUTH] Не удалось учесть неудачную попытку %s: %v", subject, err)
+		return
+	}
+	if count < limit {
+		return
+	}
+
+	level, err := s.incrWithTTL(ctx, lockoutLevelKey(subject), lockoutLevelTTL)
+	if err != nil {
+		log.Printf("[AUTH] Не удалось получить уровень блокировки %s: %v", subject, err)
+		level = 1
+	}
+	step := lockoutSteps[min(int(level), len(lockoutSteps))-1]
+
+	pipe := s.redisClient.TxPipeline()
+	pipe.Set(ctx, lockoutKey(subject), 1, step)
+	pipe.Del(ctx, failuresKey(subject))
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("[AUTH] Не удалось заблокировать %s: %v", subject, err)
+		return
+	}
+	log.Printf("[AUTH] %s заблокирован на %s после %d неудачных попыток", subject, step, count)
+}
+
+func (s *AuthService) resetFailures(ctx context.Context, subjects ...string) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 1)
+	defer cancel()
+
+	keys := make([]string, 0, len(subjects))
+	for _, subject := range subjects {
+		keys = append(keys, failuresKey(subject))
+	}
+	if err := s.redisClient.Del(ctx, keys...).Err(); err != nil {
+		log.Printf("[AUTH] Не удалось сбросить счётчики неудач: %v", err)
+	}
+}
+
+// registerCodeFailure — неверный код; после maxCodeAttempts код аннулируется
+func (s *AuthService) registerCodeFailure(ctx context.Context, phone, ip string) {
+	s.registerFailure(ctx, phoneSubject(phone), maxFailuresPerUser)
+	s.registerFailure(ctx, ipSubject(ip), maxFailuresPerIP)
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 1)
+	defer cancel()
+
+	attempts, err := s.incrWithTTL(ctx, codeAttemptsKey(phone), 5*time.Minute)
+	if err != nil {
+		log.Printf("[AUTH] Не удалось учесть попытку ввода кода %s: %v", phone, err)
+		return
+	}
+	if attempts >= maxCodeAttempts {
+		s.redisClient.Del(ctx, codeKey(phone), codeAttemptsKey(phone))
+		log.Printf("[AUTH] Код для %s аннулирован после %d неверных попыток", phone, attempts)
+	}
+}
+
+// reserveSend — пауза между отправками кода и лимит отправок в час на получателя
+// (телефон или email) и на IP
+func (s *AuthService) reserveSend(ctx context.Context, target, ip string) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 1)
+	defer cancel()
+
+	ok, err := s.redisClient.SetNX(ctx, cooldownKey(target), 1, resendCooldown).Result()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		ttl, _ := s.redisClient.TTL(ctx, cooldownKey(target)).Result()
+		return &LockoutError{RetryAfter: max(ttl, 0)}
+	}
+
+	limits := []struct {
+		subject string
+		limit   int64
+	}{
+		{target, maxSendsPerTarget},
+		{ipSubject(ip), maxSendsPerIP},
+	}
+	for _, l := range limits {
+		count, err := s.incrWithTTL(ctx, sendsKey(l.subject), sendWindow)
+		if err != nil {
+			return err
+		}
+		if count > l.limit {
+			ttl, _ := s.redisClient.TTL(ctx, sendsKey(l.subject)).Result()
+			log.Printf("[AUTH] Превышен лимит отправки кодов для %s", l.subject)
+			return &LockoutError{RetryAfter: max(ttl, 0)}
+		}
+	}
+	return nil
+}
+
+// unlockSubjects снимает блокировки и счётчики с телефона и логина пользователя
+func (s *AuthService) unlockSubjects(ctx context.Context, phone, login string) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 1)
+	defer cancel()
+
+	var keys []string
+	for _, subject := range []string{phoneSubject(phone), loginSubject(login)} {
+		keys = append(keys, lockoutKey(subject), lockoutLevelKey(subject), failuresKey(subject), sendsKey(subject))
+	}
+	keys = append(keys, codeAttemptsKey(phone), cooldownKey(phoneSubject(phone)))
+	return s.redisClient.Del(ctx, keys...).Err()
+}
+
+// incrWithTTL — счётчик с окном, которое отсчитывается от первой попытки
+func (s *AuthService) incrWithTTL(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	count, err := s.redisClient.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if count == 1 {
+		if err := s.redisClient.Expire(ctx, key, ttl).Err(); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
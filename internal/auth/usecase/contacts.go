@@ -0,0 +1,239 @@
+package auth_usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode"
+
+	audit "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	auth_model "github.com/Vovarama1992/emelya-go/internal/auth/model"
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	model "github.com/Vovarama1992/emelya-go/internal/user/model"
+	"github.com/Vovarama1992/emelya-go/internal/utils"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrInvalidContact        = errors.New("некорректный телефон или email")
+	ErrContactUnchanged      = errors.New("новый контакт совпадает с текущим")
+	ErrContactTaken          = errors.New("контакт уже используется другим аккаунтом")
+	ErrContactChangeNotFound = errors.New("заявка на смену контакта не найдена или истекла")
+)
+
+const contactChangeTTL = 15 * time.Minute
+
+// RequestContactChange заводит заявку на смену телефона или email и отправляет
+// коды на старый и новый контакт. Предыдущая заявка того же типа отменяется.
+func (s *AuthService) RequestContactChange(ctx context.Context, user *model.User, kind auth_model.ContactKind, newValue, ip string) (*auth_model.ContactChange, error) {
+	newValue, err := normalizeContact(kind, newValue)
+	if err != nil {
+		return nil, err
+	}
+	oldValue := user.Phone
+	if kind == auth_model.ContactEmail {
+		oldValue = user.Email
+	}
+	if strings.EqualFold(oldValue, newValue) {
+		return nil, ErrContactUnchanged
+	}
+
+	existing, _ := s.findByContact(ctx, kind, newValue)
+	if existing != nil && existing.ID != user.ID {
+		return nil, ErrContactTaken
+	}
+
+	subject := contactSubject(kind, newValue)
+	if err := s.checkLocked(ctx, subject, ipSubject(ip)); err != nil {
+		return nil, err
+	}
+	if err := s.reserveSend(ctx, subject, ip); err != nil {
+		return nil, err
+	}
+
+	oldCode := GenerateResetCode()
+	newCode := GenerateResetCode()
+	change := &auth_model.ContactChange{
+		UserID:      user.ID,
+		Kind:        kind,
+		OldValue:    oldValue,
+		NewValue:    newValue,
+		OldCodeHash: hashSecret(oldCode),
+		NewCodeHash: hashSecret(newCode),
+		ExpiresAt:   time.Now().Add(contactChangeTTL),
+	}
+
+	repoCtx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+	if err := s.contactRepo.CancelPending(repoCtx, user.ID, kind); err != nil {
+		return nil, err
+	}
+	if err := s.contactRepo.Create(repoCtx, change); err != nil {
+		return nil, err
+	}
+
+	if err := s.sendContactCode(kind, oldValue, oldCode); err != nil {
+		return nil, err
+	}
+	if err := s.sendContactCode(kind, newValue, newCode); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// ConfirmContactChange применяет заявку по двум кодам. После смены телефона
+// закрываются остальные сессии: телефон — идентификатор для входа.
+func (s *AuthService) ConfirmContactChange(ctx context.Context, user *model.User, changeID int64, oldCode, newCode, ip, currentSessionID string) error {
+	if err := s.checkLocked(ctx, ipSubject(ip)); err != nil {
+		return err
+	}
+
+	repoCtx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+
+	change, err := s.contactRepo.GetPending(repoCtx, changeID, user.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrContactChangeNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if time.Now().After(change.ExpiresAt) {
+		if err := s.contactRepo.SetStatus(repoCtx, change.ID, auth_model.ContactChangeExpired); err != nil {
+			log.Printf("[AUTH] Не удалось закрыть истёкшую заявку %d: %v", change.ID, err)
+		}
+		return ErrContactChangeNotFound
+	}
+
+	oldOK := subtle.ConstantTimeCompare([]byte(hashSecret(oldCode)), []byte(change.OldCodeHash)) == 1
+	newOK := subtle.ConstantTimeCompare([]byte(hashSecret(newCode)), []byte(change.NewCodeHash)) == 1
+	if !oldOK || !newOK {
+		s.registerFailure(ctx, ipSubject(ip), maxFailuresPerIP)
+		attempts, err := s.contactRepo.IncrementAttempts(repoCtx, change.ID)
+		if err == nil && attempts >= maxCodeAttempts {
+			_ = s.contactRepo.SetStatus(repoCtx, change.ID, auth_model.ContactChangeCancelled)
+			log.Printf("[AUTH] Заявка на смену контакта %d отменена после %d неверных попыток", change.ID, attempts)
+		}
+		return ErrInvalidCode
+	}
+
+	var pgErr *pgconn.PgError
+	if err := s.contactRepo.Apply(repoCtx, change); errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrContactTaken
+	} else if errors.Is(err, pgx.ErrNoRows) {
+		return ErrContactChangeNotFound
+	} else if err != nil {
+		return err
+	}
+
+	if change.Kind == auth_model.ContactPhone {
+		if err := s.RevokeOtherSessions(ctx, user.ID, currentSessionID); err != nil {
+			log.Printf("[AUTH] Не удалось закрыть сессии пользователя %d после смены телефона: %v", user.ID, err)
+		}
+	}
+
+	s.recordContactChange(ctx, change)
+	s.notifyContactChanged(user, change)
+	return nil
+}
+
+func (s *AuthService) sendContactCode(kind auth_model.ContactKind, to, code string) error {
+	if kind == auth_model.ContactEmail {
+		return s.notifier.SendCodeByEmail(to, code)
+	}
+	return s.SendCodeBySms(to, code)
+}
+
+func (s *AuthService) findByContact(ctx context.Context, kind auth_model.ContactKind, value string) (*model.User, error) {
+	if kind == auth_model.ContactEmail {
+		return s.UserService.FindUserByEmail(ctx, value)
+	}
+	return s.UserService.FindUserByPhone(ctx, value)
+}
+
+func (s *AuthService) recordContactChange(ctx context.Context, change *auth_model.ContactChange) {
+	err := s.auditService.Record(ctx, &audit.Entry{
+		ActorID:    &change.UserID,
+		Action:     audit.ActionUserContactChange,
+		EntityType: audit.EntityUser,
+		EntityID:   &change.UserID,
+		Before:     audit.Snapshot(map[string]string{string(change.Kind): change.OldValue}),
+		After:      audit.Snapshot(map[string]string{string(change.Kind): change.NewValue}),
+	})
+	if err != nil {
+		log.Printf("[AUTH] Не удалось записать журнал смены контакта: %v", err)
+	}
+}
+
+// notifyContactChanged — предупреждение на старый контакт и письмо оператору
+func (s *AuthService) notifyContactChanged(user *model.User, change *auth_model.ContactChange) {
+	ctx := context.Background()
+	var err error
+	if change.Kind == auth_model.ContactEmail {
+		err = s.notifier.SendTemplate(ctx, notifier.ChannelEmail, change.OldValue, notifier.TplAuthEmailChanged, map[string]any{"NewEmail": change.NewValue})
+	} else {
+		err = s.notifier.SendContactChangedBySms(change.OldValue)
+	}
+	if err != nil {
+		log.Printf("[AUTH] Не удалось предупредить пользователя %d о смене контакта: %v", user.ID, err)
+	}
+
+	kindText := "телефон"
+	if change.Kind == auth_model.ContactEmail {
+		kindText = "email"
+	}
+	err = s.notifier.SendTemplateToOperators(ctx, notifier.TplOperatorContactChanged, map[string]any{
+		"Kind":       kindText,
+		"UserID":     user.ID,
+		"FirstName":  user.FirstName,
+		"LastName":   user.LastName,
+		"Patronymic": user.Patronymic,
+		"OldValue":   change.OldValue,
+		"NewValue":   change.NewValue,
+	})
+	if err != nil {
+		log.Printf("[AUTH] Не удалось уведомить оператора о смене контакта: %v", err)
+	}
+}
+
+func normalizeContact(kind auth_model.ContactKind, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch kind {
+	case auth_model.ContactPhone:
+		digits := strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, value)
+		if len(digits) < 10 {
+			return "", ErrInvalidContact
+		}
+		phone := utils.NormalizePhone(value)
+		if len(phone) != len("+79991234567") {
+			return "", ErrInvalidContact
+		}
+		return phone, nil
+	case auth_model.ContactEmail:
+		addr, err := mail.ParseAddress(value)
+		if err != nil || addr.Address != value {
+			return "", ErrInvalidContact
+		}
+		return strings.ToLower(value), nil
+	default:
+		return "", ErrInvalidContact
+	}
+}
+
+func contactSubject(kind auth_model.ContactKind, value string) string {
+	if kind == auth_model.ContactEmail {
+		return emailSubject(value)
+	}
+	return phoneSubject(value)
+}
@@ -0,0 +1,170 @@
+package auth_usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	model "github.com/Vovarama1992/emelya-go/internal/user/model"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrEmailAlreadyVerified = errors.New("email уже подтверждён")
+	ErrInvalidVerifyLink    = errors.New("ссылка подтверждения недействительна или устарела")
+)
+
+const (
+	emailVerifyTTL         = 24 * time.Hour
+	defaultAppBaseURL      = "https://emelia-invest.com"
+	emailVerifyLinkPath    = "/verify-email"
+	emailVerifyCodeCharset = "0123456789"
+)
+
+// В хэше лежат код, токен ссылки и адрес, на который ушло письмо
+func emailVerifyKey(userID int64) string { return fmt.Sprintf("auth_email_verify:user:%d", userID) }
+func emailVerifyAttemptsKey(userID int64) string {
+	return fmt.Sprintf("auth_email_verify_attempts:user:%d", userID)
+}
+func emailVerifyTokenKey(token string) string { return "auth_email_verify_token:" + token }
+
+func appBaseURL() string {
+	if base := os.Getenv("APP_BASE_URL"); base != "" {
+		return strings.TrimRight(base, "/")
+	}
+	return defaultAppBaseURL
+}
+
+// SendEmailVerification отправляет письмо с кодом и ссылкой подтверждения.
+// Повторная отправка — с паузой и лимитом, как у SMS-кодов.
+func (s *AuthService) SendEmailVerification(ctx context.Context, user *model.User, ip string) error {
+	if user.IsEmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+	if err := s.checkLocked(ctx, emailSubject(user.Email), ipSubject(ip)); err != nil {
+		return err
+	}
+	if err := s.reserveSend(ctx, emailSubject(user.Email), ip); err != nil {
+		return err
+	}
+
+	code := generateRandomString(6, emailVerifyCodeCharset)
+	token := randomSecret()
+
+	redisCtx, cancel := ctxutil.WithTimeout(ctx, 1)
+	defer cancel()
+
+	// Предыдущая ссылка перестаёт работать
+	if oldToken, err := s.redisClient.HGet(redisCtx, emailVerifyKey(user.ID), "token").Result(); err == nil {
+		s.redisClient.Del(redisCtx, emailVerifyTokenKey(oldToken))
+	}
+
+	pipe := s.redisClient.TxPipeline()
+	pipe.HSet(redisCtx, emailVerifyKey(user.ID), "code", code, "token", token, "email", user.Email)
+	pipe.Expire(redisCtx, emailVerifyKey(user.ID), emailVerifyTTL)
+	pipe.Set(redisCtx, emailVerifyTokenKey(token), user.ID, emailVerifyTTL)
+	pipe.Del(redisCtx, emailVerifyAttemptsKey(user.ID))
+	if _, err := pipe.Exec(redisCtx); err != nil {
+		return err
+	}
+
+	link := appBaseURL() + emailVerifyLinkPath + "?token=" + url.QueryEscape(token)
+	return s.notifier.SendEmailVerification(user.Email, code, link)
+}
+
+// ConfirmEmailByCode — подтверждение кодом из письма в личном кабинете
+func (s *AuthService) ConfirmEmailByCode(ctx context.Context, user *model.User, code, ip string) error {
+	if user.IsEmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+	subject := emailSubject(user.Email)
+	if err := s.checkLocked(ctx, subject, ipSubject(ip)); err != nil {
+		return err
+	}
+
+	redisCtx, cancel := ctxutil.WithTimeout(ctx, 1)
+	pending, err := s.redisClient.HGetAll(redisCtx, emailVerifyKey(user.ID)).Result()
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(pending["code"]), []byte(code)) != 1 || !strings.EqualFold(pending["email"], user.Email) {
+		s.registerEmailCodeFailure(ctx, user.ID, subject, ip)
+		return ErrInvalidCode
+	}
+
+	s.resetFailures(ctx, subject)
+	return s.completeEmailVerification(ctx, user.ID, pending["token"])
+}
+
+// ConfirmEmailByToken — переход по ссылке из письма, вход не требуется
+func (s *AuthService) ConfirmEmailByToken(ctx context.Context, token string) error {
+	redisCtx, cancel := ctxutil.WithTimeout(ctx, 1)
+	userID, err := s.redisClient.Get(redisCtx, emailVerifyTokenKey(token)).Int64()
+	cancel()
+	if errors.Is(err, redis.Nil) {
+		return ErrInvalidVerifyLink
+	}
+	if err != nil {
+		return err
+	}
+
+	user, err := s.UserService.FindUserByID(ctx, userID)
+	if err != nil || user == nil {
+		return ErrInvalidVerifyLink
+	}
+	if user.IsEmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+
+	redisCtx, cancel = ctxutil.WithTimeout(ctx, 1)
+	email, err := s.redisClient.HGet(redisCtx, emailVerifyKey(userID), "email").Result()
+	cancel()
+	// Адрес сменился после отправки письма — ссылка относится к старому адресу
+	if err != nil || !strings.EqualFold(email, user.Email) {
+		return ErrInvalidVerifyLink
+	}
+
+	return s.completeEmailVerification(ctx, userID, token)
+}
+
+func (s *AuthService) completeEmailVerification(ctx context.Context, userID int64, token string) error {
+	if err := s.UserService.VerifyEmail(ctx, userID); err != nil {
+		return err
+	}
+
+	redisCtx, cancel := ctxutil.WithTimeout(ctx, 1)
+	defer cancel()
+	s.redisClient.Del(redisCtx, emailVerifyKey(userID), emailVerifyAttemptsKey(userID), emailVerifyTokenKey(token))
+
+	log.Printf("[AUTH] Email пользователя %d подтверждён", userID)
+	return nil
+}
+
+// registerEmailCodeFailure — неверный код из письма; после maxCodeAttempts письмо нужно запросить заново
+func (s *AuthService) registerEmailCodeFailure(ctx context.Context, userID int64, subject, ip string) {
+	s.registerFailure(ctx, subject, maxFailuresPerUser)
+	s.registerFailure(ctx, ipSubject(ip), maxFailuresPerIP)
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 1)
+	defer cancel()
+
+	attempts, err := s.incrWithTTL(ctx, emailVerifyAttemptsKey(userID), emailVerifyTTL)
+	if err != nil {
+		log.Printf("[AUTH] Не удалось учесть попытку подтверждения email пользователя %d: %v", userID, err)
+		return
+	}
+	if attempts >= maxCodeAttempts {
+		token, _ := s.redisClient.HGet(ctx, emailVerifyKey(userID), "token").Result()
+		s.redisClient.Del(ctx, emailVerifyKey(userID), emailVerifyAttemptsKey(userID), emailVerifyTokenKey(token))
+		log.Printf("[AUTH] Код подтверждения email пользователя %d аннулирован после %d неверных попыток", userID, attempts)
+	}
+}
@@ -0,0 +1,240 @@
+package auth_usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode"
+
+	audit "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	model "github.com/Vovarama1992/emelya-go/internal/user/model"
+	"github.com/Vovarama1992/emelya-go/internal/utils"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+)
+
+var ErrWeakPassword = errors.New("пароль должен быть не короче 8 символов, содержать буквы и цифры и не совпадать с логином, телефоном или email")
+
+const (
+	minPasswordLength = 8
+	// bcrypt учитывает только первые 72 байта
+	maxPasswordBytes = 72
+	resetCodeTTL     = 15 * time.Minute
+)
+
+func resetCodeKey(userID int64) string { return fmt.Sprintf("auth_password_reset:user:%d", userID) }
+func resetAttemptsKey(userID int64) string {
+	return fmt.Sprintf("auth_password_reset_attempts:user:%d", userID)
+}
+
+// ValidatePasswordStrength — политика паролей, которые пользователь задаёт сам
+func ValidatePasswordStrength(password string, user *model.User) error {
+	if len([]rune(password)) < minPasswordLength || len(password) > maxPasswordBytes {
+		return ErrWeakPassword
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return ErrWeakPassword
+	}
+
+	lower := strings.ToLower(password)
+	for _, forbidden := range []string{user.Login, user.Email, user.Phone, utils.NormalizePhone(user.Phone)} {
+		if forbidden != "" && lower == strings.ToLower(forbidden) {
+			return ErrWeakPassword
+		}
+	}
+	return nil
+}
+
+// ChangePassword — смена пароля по старому паролю. Остальные сессии закрываются,
+// текущая остаётся.
+func (s *AuthService) ChangePassword(ctx context.Context, user *model.User, oldPassword, newPassword, currentSessionID string) error {
+	if err := s.checkLocked(ctx, loginSubject(user.Login)); err != nil {
+		return err
+	}
+	if !CheckPasswordHash(oldPassword, user.PasswordHash) {
+		s.registerFailure(ctx, loginSubject(user.Login), maxFailuresPerUser)
+		return ErrInvalidCredentials
+	}
+	if err := ValidatePasswordStrength(newPassword, user); err != nil {
+		return err
+	}
+
+	if err := s.setPassword(ctx, user, newPassword); err != nil {
+		return err
+	}
+	if err := s.RevokeOtherSessions(ctx, user.ID, currentSessionID); err != nil {
+		log.Printf("[AUTH] Не удалось закрыть сессии пользователя %d после смены пароля: %v", user.ID, err)
+	}
+
+	s.recordSecurityEvent(ctx, user.ID, user.ID, audit.ActionAuthPasswordChange)
+	s.notifyPasswordChanged(user)
+	return nil
+}
+
+// RequestPasswordReset отправляет код сброса по SMS или на подтверждённый email.
+// Ответ не зависит от того, существует ли пользователь.
+func (s *AuthService) RequestPasswordReset(ctx context.Context, phone, email, ip string) error {
+	subject := resetSubject(phone, email)
+	if err := s.checkLocked(ctx, subject, ipSubject(ip)); err != nil {
+		return err
+	}
+	if err := s.reserveSend(ctx, subject, ip); err != nil {
+		return err
+	}
+
+	user := s.findResetUser(ctx, phone, email)
+	if user == nil {
+		log.Printf("[AUTH] Запрошен сброс пароля для неизвестного получателя %s", subject)
+		return nil
+	}
+
+	code := GenerateResetCode()
+	redisCtx, cancel := ctxutil.WithTimeout(ctx, 1)
+	pipe := s.redisClient.TxPipeline()
+	pipe.Set(redisCtx, resetCodeKey(user.ID), code, resetCodeTTL)
+	pipe.Del(redisCtx, resetAttemptsKey(user.ID))
+	_, err := pipe.Exec(redisCtx)
+	cancel()
+	if err != nil {
+		return err
+	}
+
+	if email != "" {
+		return s.notifier.SendCodeByEmail(user.Email, code)
+	}
+	return s.SendCodeBySms(user.Phone, code)
+}
+
+// ConfirmPasswordReset проверяет код и задаёт новый пароль. Все сессии закрываются,
+// блокировки входа снимаются.
+func (s *AuthService) ConfirmPasswordReset(ctx context.Context, phone, email, code, newPassword, ip string) error {
+	subject := resetSubject(phone, email)
+	if err := s.checkLocked(ctx, subject, ipSubject(ip)); err != nil {
+		return err
+	}
+
+	user := s.findResetUser(ctx, phone, email)
+	if user == nil {
+		s.registerFailure(ctx, ipSubject(ip), maxFailuresPerIP)
+		return ErrInvalidCode
+	}
+
+	redisCtx, cancel := ctxutil.WithTimeout(ctx, 1)
+	storedCode, err := s.redisClient.Get(redisCtx, resetCodeKey(user.ID)).Result()
+	cancel()
+	if err != nil || subtle.ConstantTimeCompare([]byte(storedCode), []byte(code)) != 1 {
+		s.registerResetFailure(ctx, user.ID, subject, ip)
+		return ErrInvalidCode
+	}
+
+	if err := ValidatePasswordStrength(newPassword, user); err != nil {
+		return err
+	}
+	if err := s.setPassword(ctx, user, newPassword); err != nil {
+		return err
+	}
+
+	redisCtx, cancel = ctxutil.WithTimeout(ctx, 1)
+	s.redisClient.Del(redisCtx, resetCodeKey(user.ID), resetAttemptsKey(user.ID))
+	cancel()
+
+	if err := s.RevokeAllSessions(ctx, user.ID); err != nil {
+		log.Printf("[AUTH] Не удалось закрыть сессии пользователя %d после сброса пароля: %v", user.ID, err)
+	}
+	s.resetFailures(ctx, subject)
+	if err := s.unlockSubjects(ctx, user.Phone, user.Login); err != nil {
+		log.Printf("[AUTH] Не удалось снять блокировки пользователя %d: %v", user.ID, err)
+	}
+
+	s.recordSecurityEvent(ctx, user.ID, user.ID, audit.ActionAuthPasswordReset)
+	s.notifyPasswordChanged(user)
+	return nil
+}
+
+func (s *AuthService) setPassword(ctx context.Context, user *model.User, password string) error {
+	hash, err := HashPassword(password)
+	if err != nil {
+		return err
+	}
+	if err := s.UserService.UpdatePassword(ctx, user.ID, hash); err != nil {
+		return err
+	}
+	user.PasswordHash = hash
+	return nil
+}
+
+// findResetUser ищет пользователя по телефону или email; код на email
+// отправляется только на подтверждённый адрес
+func (s *AuthService) findResetUser(ctx context.Context, phone, email string) *model.User {
+	if email != "" {
+		user, err := s.UserService.FindUserByEmail(ctx, email)
+		if err != nil || user == nil || !user.IsEmailVerified {
+			return nil
+		}
+		return user
+	}
+
+	user, err := s.UserService.FindUserByPhone(ctx, phone)
+	if err != nil {
+		return nil
+	}
+	return user
+}
+
+// registerResetFailure — неверный код сброса; после maxCodeAttempts код аннулируется
+func (s *AuthService) registerResetFailure(ctx context.Context, userID int64, subject, ip string) {
+	s.registerFailure(ctx, subject, maxFailuresPerUser)
+	s.registerFailure(ctx, ipSubject(ip), maxFailuresPerIP)
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 1)
+	defer cancel()
+
+	attempts, err := s.incrWithTTL(ctx, resetAttemptsKey(userID), resetCodeTTL)
+	if err != nil {
+		log.Printf("[AUTH] Не удалось учесть попытку ввода кода сброса пользователя %d: %v", userID, err)
+		return
+	}
+	if attempts >= maxCodeAttempts {
+		s.redisClient.Del(ctx, resetCodeKey(userID), resetAttemptsKey(userID))
+		log.Printf("[AUTH] Код сброса пароля пользователя %d аннулирован после %d неверных попыток", userID, attempts)
+	}
+}
+
+// notifyPasswordChanged — уведомление о смене пароля по SMS и на email
+func (s *AuthService) notifyPasswordChanged(user *model.User) {
+	if err := s.notifier.SendPasswordChangedBySms(user.Phone); err != nil {
+		log.Printf("[AUTH] Не удалось отправить SMS о смене пароля пользователю %d: %v", user.ID, err)
+	}
+	if user.Email == "" {
+		return
+	}
+	if err := s.notifier.SendTemplate(context.Background(), notifier.ChannelEmail, user.Email, notifier.TplAuthPasswordChanged, nil); err != nil {
+		log.Printf("[AUTH] Не удалось отправить письмо о смене пароля пользователю %d: %v", user.ID, err)
+	}
+}
+
+func resetSubject(phone, email string) string {
+	if email != "" {
+		return emailSubject(email)
+	}
+	return phoneSubject(phone)
+}
+
+// GenerateResetCode — код сброса длиннее кода входа: он живёт 15 минут
+func GenerateResetCode() string {
+	return generateRandomString(6, "0123456789")
+}
@@ -3,11 +3,17 @@ package auth_usecase
 import (
 	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
+	"log"
 	"math/big"
 	"os"
 	"time"
 
+	audit "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	audit_ports "github.com/Vovarama1992/emelya-go/internal/audit/ports"
+	auth_infra "github.com/Vovarama1992/emelya-go/internal/auth/infra"
+	auth_ports "github.com/Vovarama1992/emelya-go/internal/auth/ports"
 	"github.com/Vovarama1992/emelya-go/internal/notifier"
 	model "github.com/Vovarama1992/emelya-go/internal/user/model"
 	ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
@@ -16,21 +22,37 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+var (
+	ErrInvalidCode        = errors.New("неверный или истекший код")
+	ErrInvalidCredentials = errors.New("неверный логин или пароль")
+	ErrUserNotFound       = errors.New("пользователь не найден")
+)
+
 type AuthService struct {
-	UserService ports.UserServiceInterface
-	redisClient *redis.Client
-	smsApiKey   string
-	smsSender   string
-	notifier    *notifier.Notifier
+	UserService  ports.UserServiceInterface
+	redisClient  *redis.Client
+	sessions     *auth_infra.SessionStore
+	smsApiKey    string
+	smsSender    string
+	notifier     notifier.NotifierInterface
+	auditService audit_ports.AuditService
+	totpRepo     auth_ports.TOTPRepository
+	contactRepo  auth_ports.ContactChangeRepository
+	testMode     TestMode
 }
 
-func NewAuthService(userService ports.UserServiceInterface, redisClient *redis.Client, notifier *notifier.Notifier) *AuthService {
+func NewAuthService(userService ports.UserServiceInterface, redisClient *redis.Client, notifier notifier.NotifierInterface, auditService audit_ports.AuditService, totpRepo auth_ports.TOTPRepository, contactRepo auth_ports.ContactChangeRepository) *AuthService {
 	return &AuthService{
-		UserService: userService,
-		redisClient: redisClient,
-		smsApiKey:   os.Getenv("SMS_API_KEY"),
-		smsSender:   os.Getenv("SMS_SENDER_NAME"),
-		notifier:    notifier,
+		UserService:  userService,
+		redisClient:  redisClient,
+		sessions:     auth_infra.NewSessionStore(redisClient),
+		smsApiKey:    os.Getenv("SMS_API_KEY"),
+		smsSender:    os.Getenv("SMS_SENDER_NAME"),
+		notifier:     notifier,
+		auditService: auditService,
+		totpRepo:     totpRepo,
+		contactRepo:  contactRepo,
+		testMode:     LoadTestModeFromEnv(),
 	}
 }
 
@@ -52,22 +74,129 @@ func (s *AuthService) FindUserByLogin(ctx context.Context, login string) (*model
 	return s.UserService.FindUserByLogin(ctx, login)
 }
 
-func (s *AuthService) VerifyPhone(ctx context.Context, userID int64) error {
-	return s.UserService.VerifyPhone(ctx, userID)
+func (s *AuthService) ConfirmRegistration(ctx context.Context, userID int64) error {
+	return s.UserService.ConfirmRegistration(ctx, userID)
 }
 
 func (s *AuthService) SaveCodeToRedis(ctx context.Context, phone string, code string) error {
 	ctx, cancel := ctxutil.WithTimeout(ctx, 1)
 	defer cancel()
-	key := fmt.Sprintf("auth_code:phone:%s", phone)
-	return s.redisClient.Set(ctx, key, code, 5*time.Minute).Err()
+	pipe := s.redisClient.TxPipeline()
+	pipe.Set(ctx, codeKey(phone), code, 5*time.Minute)
+	pipe.Del(ctx, codeAttemptsKey(phone))
+	_, err := pipe.Exec(ctx)
+	return err
 }
 
 func (s *AuthService) GetCodeFromRedis(ctx context.Context, phone string) (string, error) {
 	ctx, cancel := ctxutil.WithTimeout(ctx, 1)
 	defer cancel()
-	key := fmt.Sprintf("auth_code:phone:%s", phone)
-	return s.redisClient.Get(ctx, key).Result()
+	return s.redisClient.Get(ctx, codeKey(phone)).Result()
+}
+
+func codeKey(phone string) string {
+	return fmt.Sprintf("auth_code:phone:%s", phone)
+}
+
+// SendCode генерирует и отправляет код по SMS с учётом паузы между отправками и лимитов.
+// Тестовым номерам сохраняется их фиксированный код, SMS не отправляется.
+func (s *AuthService) SendCode(ctx context.Context, phone, ip string) error {
+	if err := s.checkLocked(ctx, phoneSubject(phone), ipSubject(ip)); err != nil {
+		return err
+	}
+	if err := s.reserveSend(ctx, phoneSubject(phone), ip); err != nil {
+		return err
+	}
+
+	if code, ok := s.testMode.CodeFor(phone); ok {
+		log.Printf("[AUTH:TEST] Запрошен код для тестового номера %s", phone)
+		return s.SaveCodeToRedis(ctx, phone, code)
+	}
+
+	code := GenerateCode()
+	if err := s.SaveCodeToRedis(ctx, phone, code); err != nil {
+		return err
+	}
+	return s.SendCodeBySms(phone, code)
+}
+
+// VerifyCode сверяет код из SMS. Код одноразовый; неверные попытки считаются по телефону и IP.
+// Вход по тестовому номеру пишется в журнал.
+func (s *AuthService) VerifyCode(ctx context.Context, user *model.User, code, ip string) error {
+	if err := s.checkLocked(ctx, phoneSubject(user.Phone), ipSubject(ip)); err != nil {
+		return err
+	}
+
+	storedCode, err := s.GetCodeFromRedis(ctx, user.Phone)
+	if err != nil || storedCode != code {
+		s.registerCodeFailure(ctx, user.Phone, ip)
+		return ErrInvalidCode
+	}
+
+	s.resetFailures(ctx, phoneSubject(user.Phone))
+	delCtx, cancel := ctxutil.WithTimeout(ctx, 1)
+	defer cancel()
+	s.redisClient.Del(delCtx, codeKey(user.Phone), codeAttemptsKey(user.Phone))
+
+	if _, ok := s.testMode.CodeFor(user.Phone); ok {
+		s.recordTestLogin(ctx, user)
+	}
+	return nil
+}
+
+// LoginByPassword — вход по логину и паролю с учётом блокировок по логину и IP.
+// Неизвестный логин и неверный пароль неразличимы для клиента.
+func (s *AuthService) LoginByPassword(ctx context.Context, login, password, ip string) (*model.User, error) {
+	if err := s.checkLocked(ctx, loginSubject(login), ipSubject(ip)); err != nil {
+		return nil, err
+	}
+
+	user, err := s.UserService.FindUserByLogin(ctx, login)
+	if err != nil || user == nil || !CheckPasswordHash(password, user.PasswordHash) {
+		s.registerFailure(ctx, loginSubject(login), maxFailuresPerUser)
+		s.registerFailure(ctx, ipSubject(ip), maxFailuresPerIP)
+		return nil, ErrInvalidCredentials
+	}
+
+	s.resetFailures(ctx, loginSubject(login))
+	return user, nil
+}
+
+// Unlock — админ снимает блокировки входа с пользователя
+func (s *AuthService) Unlock(ctx context.Context, actorID, userID int64) error {
+	user, err := s.UserService.FindUserByID(ctx, userID)
+	if err != nil || user == nil {
+		return ErrUserNotFound
+	}
+
+	if err := s.unlockSubjects(ctx, user.Phone, user.Login); err != nil {
+		return err
+	}
+
+	if err := s.auditService.Record(ctx, &audit.Entry{
+		ActorID:    &actorID,
+		Action:     audit.ActionAuthUnlock,
+		EntityType: audit.EntityUser,
+		EntityID:   &userID,
+	}); err != nil {
+		log.Printf("[AUTH] Не удалось записать журнал разблокировки: %v", err)
+	}
+	return nil
+}
+
+func (s *AuthService) recordTestLogin(ctx context.Context, user *model.User) {
+	log.Printf("[AUTH:TEST] Вход по тестовому номеру %s (пользователь %d)", user.Phone, user.ID)
+
+	err := s.auditService.Record(ctx, &audit.Entry{
+		ActorID:    &user.ID,
+		Action:     audit.ActionAuthTestLogin,
+		EntityType: audit.EntityUser,
+		EntityID:   &user.ID,
+		After:      audit.Snapshot(map[string]string{"phone": user.Phone}),
+	})
+	if err != nil {
+		log.Printf("[AUTH:TEST] Не удалось записать журнал: %v", err)
+	}
 }
 
 func (s *AuthService) SavePasswordToRedis(ctx context.Context, phone string, password string) error {
@@ -92,8 +221,9 @@ func (s *AuthService) SendLoginAndPasswordBySms(phone string, login, password st
 	return s.notifier.SendLoginAndPasswordBySms(phone, login, password)
 }
 
-func (s *AuthService) SendEmailToOperator(subject, body string) error {
-	return s.notifier.SendEmailToOperator(subject, body)
+// NotifyOperators — письмо операторам по шаблону
+func (s *AuthService) NotifyOperators(ctx context.Context, key string, data any) error {
+	return s.notifier.SendTemplateToOperators(ctx, key, data)
 }
 
 // =====================
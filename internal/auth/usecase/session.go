@@ -0,0 +1,217 @@
+package auth_usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	auth_infra "github.com/Vovarama1992/emelya-go/internal/auth/infra"
+	auth_model "github.com/Vovarama1992/emelya-go/internal/auth/model"
+	"github.com/Vovarama1992/emelya-go/internal/jwtutil"
+	model "github.com/Vovarama1992/emelya-go/internal/user/model"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("недействительный refresh-токен")
+	ErrSessionNotFound     = auth_infra.ErrSessionNotFound
+)
+
+const defaultRefreshTTL = 30 * 24 * time.Hour
+
+func refreshTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("REFRESH_TOKEN_TTL"))
+	if err != nil || ttl <= 0 {
+		return defaultRefreshTTL
+	}
+	return ttl
+}
+
+// StartSession заводит сессию устройства и выдаёт первую пару токенов
+func (s *AuthService) StartSession(ctx context.Context, user *model.User, userAgent, ip string) (*auth_model.TokenPair, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	now := time.Now()
+	session := &auth_model.Session{
+		ID:         randomHex(16),
+		UserID:     user.ID,
+		UserAgent:  userAgent,
+		IP:         ip,
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
+	return s.issueTokens(ctx, session, user.Email, "")
+}
+
+// RefreshSession меняет refresh-токен на новую пару (ротация).
+// Повторное предъявление старого refresh-токена означает утечку — сессия закрывается.
+func (s *AuthService) RefreshSession(ctx context.Context, refreshToken, userAgent, ip string) (*auth_model.TokenPair, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	sessionID, secret, ok := strings.Cut(refreshToken, ".")
+	if !ok || sessionID == "" || secret == "" {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	session, storedHash, err := s.sessions.Get(ctx, sessionID)
+	if errors.Is(err, auth_infra.ErrSessionNotFound) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(storedHash)) != 1 {
+		s.closeReusedSession(ctx, session)
+		return nil, ErrInvalidRefreshToken
+	}
+
+	user, err := s.UserService.FindUserByID(ctx, session.UserID)
+	if err != nil || user == nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	session.UserAgent = userAgent
+	session.IP = ip
+	session.LastUsedAt = time.Now()
+	// Токен могли предъявить параллельно: новую пару получит только первый запрос
+	tokens, err := s.issueTokens(ctx, session, user.Email, storedHash)
+	if errors.Is(err, auth_infra.ErrRefreshConflict) {
+		s.closeReusedSession(ctx, session)
+		return nil, ErrInvalidRefreshToken
+	}
+	if errors.Is(err, auth_infra.ErrSessionNotFound) {
+		return nil, ErrInvalidRefreshToken
+	}
+	return tokens, err
+}
+
+func (s *AuthService) closeReusedSession(ctx context.Context, session *auth_model.Session) {
+	log.Printf("[AUTH] Повторное использование refresh-токена, сессия %s пользователя %d закрыта", session.ID, session.UserID)
+	if err := s.sessions.Delete(ctx, session.UserID, session.ID); err != nil {
+		log.Printf("[AUTH] Не удалось закрыть сессию %s: %v", session.ID, err)
+	}
+}
+
+// IsSessionActive проверяется на каждом запросе; при недоступности Redis доступ запрещается
+func (s *AuthService) IsSessionActive(ctx context.Context, sessionID string) bool {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 1)
+	defer cancel()
+
+	ok, err := s.sessions.Exists(ctx, sessionID)
+	if err != nil {
+		log.Printf("[AUTH] Не удалось проверить сессию %s: %v", sessionID, err)
+		return false
+	}
+	return ok
+}
+
+func (s *AuthService) ListSessions(ctx context.Context, userID int64, currentSessionID string) ([]*auth_model.Session, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	sessions, err := s.sessions.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		session.Current = session.ID == currentSessionID
+	}
+	return sessions, nil
+}
+
+// RevokeSession закрывает сессию пользователя; чужую сессию закрыть нельзя
+func (s *AuthService) RevokeSession(ctx context.Context, userID int64, sessionID string) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	session, _, err := s.sessions.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return ErrSessionNotFound
+	}
+	return s.sessions.Delete(ctx, userID, sessionID)
+}
+
+// RevokeAllSessions — «выйти на всех устройствах»
+func (s *AuthService) RevokeAllSessions(ctx context.Context, userID int64) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	return s.sessions.DeleteAllByUser(ctx, userID)
+}
+
+// RevokeOtherSessions закрывает все сессии пользователя, кроме текущей
+func (s *AuthService) RevokeOtherSessions(ctx context.Context, userID int64, currentSessionID string) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	sessions, err := s.sessions.ListByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if session.ID == currentSessionID {
+			continue
+		}
+		if err := s.sessions.Delete(ctx, userID, session.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// issueTokens сохраняет новый refresh-токен сессии. prevHash — хэш предъявленного
+// токена при ротации, пустой — для новой сессии.
+func (s *AuthService) issueTokens(ctx context.Context, session *auth_model.Session, email, prevHash string) (*auth_model.TokenPair, error) {
+	secret := randomSecret()
+	var err error
+	if prevHash == "" {
+		err = s.sessions.Save(ctx, session, hashSecret(secret), refreshTTL())
+	} else {
+		err = s.sessions.Rotate(ctx, session, prevHash, hashSecret(secret), refreshTTL())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := jwtutil.GenerateAccessToken(session.UserID, email, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth_model.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: session.ID + "." + secret,
+		SessionID:    session.ID,
+	}, nil
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func randomSecret() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// В Redis хранится только хэш секрета, чтобы дамп базы не давал живых токенов
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
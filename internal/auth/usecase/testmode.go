@@ -0,0 +1,63 @@
+package auth_usecase
+
+import (
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/Vovarama1992/emelya-go/internal/utils"
+)
+
+var testCodePattern = regexp.MustCompile(`^\d{4}$`)
+
+// TestMode — тестовые номера с фиксированными кодами для QA.
+// Включается только явно: AUTH_TEST_MODE=true и AUTH_TEST_PHONES="+79990000001:1234,+79990000002:5678".
+type TestMode struct {
+	codes map[string]string
+}
+
+func LoadTestModeFromEnv() TestMode {
+	if os.Getenv("AUTH_TEST_MODE") != "true" {
+		return TestMode{}
+	}
+
+	codes := make(map[string]string)
+	for _, entry := range strings.Split(os.Getenv("AUTH_TEST_PHONES"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		phone, code, ok := strings.Cut(entry, ":")
+		if !ok || !testCodePattern.MatchString(code) || !isTestPhone(phone) {
+			log.Printf("[AUTH:TEST] Пропущена некорректная запись AUTH_TEST_PHONES: %q", entry)
+			continue
+		}
+		codes[utils.NormalizePhone(phone)] = code
+	}
+
+	log.Printf("[AUTH:TEST] Тестовый режим входа включён, тестовых номеров: %d", len(codes))
+	return TestMode{codes: codes}
+}
+
+// isTestPhone — номер, который NormalizePhone приведёт к виду +7XXXXXXXXXX.
+// На пустой строке без цифр NormalizePhone падает, поэтому проверяем до вызова.
+func isTestPhone(phone string) bool {
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, phone)
+	if len(digits) < 10 {
+		return false
+	}
+	return len(utils.NormalizePhone(phone)) == len("+79991234567")
+}
+
+// CodeFor — фиксированный код, если номер в списке тестовых
+func (t TestMode) CodeFor(phone string) (string, bool) {
+	code, ok := t.codes[phone]
+	return code, ok
+}
@@ -0,0 +1,30 @@
+package auth_usecase
+
+import "testing"
+
+func TestLoadTestModeFromEnv(t *testing.T) {
+	t.Setenv("AUTH_TEST_MODE", "true")
+	t.Setenv("AUTH_TEST_PHONES", ":1234, abc:1234, 12345:1111, +7 (999) 000-00-01:1234, 89990000002:5678, +79990000003:12")
+
+	tm := LoadTestModeFromEnv()
+
+	tests := []struct {
+		phone string
+		code  string
+		ok    bool
+	}{
+		{phone: "+79990000001", code: "1234", ok: true},
+		{phone: "+79990000002", code: "5678", ok: true},
+		{phone: "+79990000003"},
+		{phone: "+712345"},
+	}
+	for _, tt := range tests {
+		code, ok := tm.CodeFor(tt.phone)
+		if ok != tt.ok || code != tt.code {
+			t.Errorf("CodeFor(%q) = %q, %v; want %q, %v", tt.phone, code, ok, tt.code, tt.ok)
+		}
+	}
+	if len(tm.codes) != 2 {
+		t.Errorf("тестовых номеров: %d, ожидалось 2", len(tm.codes))
+	}
+}
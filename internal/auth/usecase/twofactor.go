@@ -0,0 +1,302 @@
+package auth_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	audit "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	auth_model "github.com/Vovarama1992/emelya-go/internal/auth/model"
+	"github.com/Vovarama1992/emelya-go/internal/auth/totp"
+	"github.com/Vovarama1992/emelya-go/internal/cryptoutil"
+	model "github.com/Vovarama1992/emelya-go/internal/user/model"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+	"github.com/jackc/pgx/v5"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	ErrTOTPAlreadyEnabled = errors.New("двухфакторная аутентификация уже включена")
+	ErrTOTPNotSetUp       = errors.New("сначала начните подключение двухфакторной аутентификации")
+	ErrTOTPNotEnabled     = errors.New("двухфакторная аутентификация не включена")
+	ErrTOTPRequired       = errors.New("для сотрудников двухфакторная аутентификация обязательна")
+	ErrInvalidTOTPCode    = errors.New("неверный код двухфакторной аутентификации")
+	ErrInvalidChallenge   = errors.New("сессия входа истекла, войдите заново")
+	ErrOwnTOTPReset       = errors.New("нельзя сбросить собственную двухфакторную аутентификацию")
+)
+
+const (
+	challengeTTL       = 5 * time.Minute
+	recoveryCodesCount = 10
+	recoveryCharset    = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+func challengeKey(token string) string { return "auth_2fa_challenge:" + token }
+func usedStepKey(userID, step int64) string {
+	return fmt.Sprintf("auth_totp_used:%d:%d", userID, step)
+}
+func twoFactorSubject(userID int64) string {
+	return fmt.Sprintf("2fa:%d", userID)
+}
+
+// BeginLogin — общий финал всех способов входа: при включённой 2FA вместо токенов
+// выдаётся одноразовый токен второго шага
+func (s *AuthService) BeginLogin(ctx context.Context, user *model.User, userAgent, ip string) (*auth_model.LoginResult, error) {
+	if !user.TOTPEnabled {
+		tokens, err := s.StartSession(ctx, user, userAgent, ip)
+		if err != nil {
+			return nil, err
+		}
+		return &auth_model.LoginResult{Tokens: tokens}, nil
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 1)
+	defer cancel()
+
+	token := randomSecret()
+	if err := s.redisClient.Set(ctx, challengeKey(token), user.ID, challengeTTL).Err(); err != nil {
+		return nil, err
+	}
+	return &auth_model.LoginResult{TwoFactorToken: token}, nil
+}
+
+// CompleteTwoFactorLogin — второй шаг входа: код из приложения или код восстановления
+func (s *AuthService) CompleteTwoFactorLogin(ctx context.Context, challenge, code, userAgent, ip string) (*auth_model.TokenPair, error) {
+	redisCtx, cancel := ctxutil.WithTimeout(ctx, 1)
+	userID, err := s.redisClient.Get(redisCtx, challengeKey(challenge)).Int64()
+	cancel()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrInvalidChallenge
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.verifySecondFactor(ctx, userID, code); err != nil {
+		return nil, err
+	}
+
+	redisCtx, cancel = ctxutil.WithTimeout(ctx, 1)
+	s.redisClient.Del(redisCtx, challengeKey(challenge))
+	cancel()
+
+	user, err := s.UserService.FindUserByID(ctx, userID)
+	if err != nil || user == nil {
+		return nil, ErrUserNotFound
+	}
+	return s.StartSession(ctx, user, userAgent, ip)
+}
+
+// SetupTOTP создаёт новый секрет; 2FA включится только после подтверждения кодом
+func (s *AuthService) SetupTOTP(ctx context.Context, user *model.User) (*auth_model.TOTPSetup, error) {
+	if user.TOTPEnabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := cryptoutil.Encrypt(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	if err := s.totpRepo.SaveSecret(ctx, user.ID, encrypted); err != nil {
+		return nil, err
+	}
+
+	return &auth_model.TOTPSetup{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(secret, totpIssuer(), user.Login),
+	}, nil
+}
+
+// EnableTOTP подтверждает подключение и возвращает коды восстановления — они показываются один раз
+func (s *AuthService) EnableTOTP(ctx context.Context, user *model.User, code string) ([]string, error) {
+	if user.TOTPEnabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	if err := s.checkLocked(ctx, twoFactorSubject(user.ID)); err != nil {
+		return nil, err
+	}
+
+	secret, confirmed, err := s.loadSecret(ctx, user.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTOTPNotSetUp
+	}
+	if err != nil {
+		return nil, err
+	}
+	if confirmed {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	if err := s.checkTOTP(ctx, user.ID, secret, code); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, recoveryCodesCount)
+	hashes := make([]string, recoveryCodesCount)
+	for i := range codes {
+		raw := generateRandomString(10, recoveryCharset)
+		codes[i] = raw[:5] + "-" + raw[5:]
+		hashes[i] = hashSecret(raw)
+	}
+
+	repoCtx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+	if err := s.totpRepo.Enable(repoCtx, user.ID, hashes); err != nil {
+		return nil, err
+	}
+
+	s.recordSecurityEvent(ctx, user.ID, user.ID, audit.ActionAuthTOTPEnable)
+	return codes, nil
+}
+
+// DisableTOTP — отключение самим пользователем; сотрудникам 2FA отключать нельзя
+func (s *AuthService) DisableTOTP(ctx context.Context, user *model.User, code string) error {
+	if user.Role.IsStaff() {
+		return ErrTOTPRequired
+	}
+	if !user.TOTPEnabled {
+		return ErrTOTPNotEnabled
+	}
+	if err := s.verifySecondFactor(ctx, user.ID, code); err != nil {
+		return err
+	}
+
+	repoCtx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+	if err := s.totpRepo.Delete(repoCtx, user.ID); err != nil {
+		return err
+	}
+
+	s.recordSecurityEvent(ctx, user.ID, user.ID, audit.ActionAuthTOTPDisable)
+	return nil
+}
+
+// ResetTOTP — админ сбрасывает 2FA (потерян телефон и коды); все сессии пользователя закрываются
+func (s *AuthService) ResetTOTP(ctx context.Context, actorID, userID int64) error {
+	if actorID == userID {
+		return ErrOwnTOTPReset
+	}
+
+	user, err := s.UserService.FindUserByID(ctx, userID)
+	if err != nil || user == nil {
+		return ErrUserNotFound
+	}
+
+	repoCtx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+	if err := s.totpRepo.Delete(repoCtx, userID); err != nil {
+		return err
+	}
+	if err := s.RevokeAllSessions(ctx, userID); err != nil {
+		log.Printf("[AUTH] Не удалось закрыть сессии пользователя %d после сброса 2FA: %v", userID, err)
+	}
+
+	s.recordSecurityEvent(ctx, actorID, userID, audit.ActionAuthTOTPReset)
+	return nil
+}
+
+// verifySecondFactor принимает шестизначный код из приложения или код восстановления
+func (s *AuthService) verifySecondFactor(ctx context.Context, userID int64, code string) error {
+	subject := twoFactorSubject(userID)
+	if err := s.checkLocked(ctx, subject); err != nil {
+		return err
+	}
+
+	code = strings.TrimSpace(code)
+	if _, err := strconv.Atoi(code); err == nil && len(code) == 6 {
+		secret, confirmed, err := s.loadSecret(ctx, userID)
+		if err != nil || !confirmed {
+			return ErrTOTPNotEnabled
+		}
+		if err := s.checkTOTP(ctx, userID, secret, code); err != nil {
+			return err
+		}
+		s.resetFailures(ctx, subject)
+		return nil
+	}
+
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	repoCtx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	ok, err := s.totpRepo.UseRecoveryCode(repoCtx, userID, hashSecret(normalized))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		s.registerFailure(ctx, subject, maxFailuresPerUser)
+		return ErrInvalidTOTPCode
+	}
+
+	log.Printf("[AUTH] Пользователь %d вошёл по коду восстановления", userID)
+	s.resetFailures(ctx, subject)
+	return nil
+}
+
+// checkTOTP сверяет код и не даёт использовать один и тот же код дважды: шаг
+// помечается использованным атомарно, так что из параллельных запросов с одним
+// кодом пройдёт только один
+func (s *AuthService) checkTOTP(ctx context.Context, userID int64, secret, code string) error {
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		s.registerFailure(ctx, twoFactorSubject(userID), maxFailuresPerUser)
+		return ErrInvalidTOTPCode
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 1)
+	defer cancel()
+
+	// Код действует не дольше трёх шагов по 30 секунд, ключ живёт с запасом
+	fresh, err := s.redisClient.SetNX(ctx, usedStepKey(userID, step), 1, 2*time.Minute).Result()
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrInvalidTOTPCode
+	}
+	return nil
+}
+
+func (s *AuthService) loadSecret(ctx context.Context, userID int64) (string, bool, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	encrypted, confirmed, err := s.totpRepo.GetSecret(ctx, userID)
+	if err != nil {
+		return "", false, err
+	}
+	secret, err := cryptoutil.Decrypt(encrypted)
+	if err != nil {
+		return "", false, err
+	}
+	return secret, confirmed, nil
+}
+
+func (s *AuthService) recordSecurityEvent(ctx context.Context, actorID, userID int64, action audit.Action) {
+	err := s.auditService.Record(ctx, &audit.Entry{
+		ActorID:    &actorID,
+		Action:     action,
+		EntityType: audit.EntityUser,
+		EntityID:   &userID,
+	})
+	if err != nil {
+		log.Printf("[AUTH] Не удалось записать журнал %s: %v", action, err)
+	}
+}
+
+func totpIssuer() string {
+	if issuer := os.Getenv("TOTP_ISSUER"); issuer != "" {
+		return issuer
+	}
+	return "Emelia Invest"
+}
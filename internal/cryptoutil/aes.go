@@ -0,0 +1,101 @@
+package cryptoutil
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var ErrNoKey = errors.New("DATA_ENCRYPTION_KEY не задан или не 32 байта в base64")
+
+// Ключ читается при каждом вызове: .env загружается уже после инициализации пакетов
+func key() ([]byte, error) {
+	k, err := base64.StdEncoding.DecodeString(os.Getenv("DATA_ENCRYPTION_KEY"))
+	if err != nil || len(k) != 32 {
+		return nil, ErrNoKey
+	}
+	return k, nil
+}
+
+// Encrypt — AES-256-GCM, результат: base64(nonce || ciphertext)
+func Encrypt(plaintext string) (string, error) {
+	sealed, err := EncryptBytes([]byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func Decrypt(ciphertext string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("некорректный шифротекст: %w", err)
+	}
+	plain, err := DecryptBytes(data)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// EncryptBytes — то же для файлов, без base64: nonce || ciphertext
+func EncryptBytes(plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func DecryptBytes(data []byte) ([]byte, error) {
+	gcm, err := newGCM()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("некорректный шифротекст")
+	}
+
+	nonce, sealed := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось расшифровать: %w", err)
+	}
+	return plain, nil
+}
+
+// Fingerprint — HMAC-SHA256 значения на том же ключе: позволяет искать дубли
+// зашифрованных данных, не расшифровывая их
+func Fingerprint(value string) (string, error) {
+	k, err := key()
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, k)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func newGCM() (cipher.AEAD, error) {
+	k, err := key()
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(k)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
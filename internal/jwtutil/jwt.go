@@ -10,7 +10,25 @@ import (
 
 var jwtSecret = []byte(os.Getenv("JWT_SECRET"))
 
-func ParseToken(tokenStr string) (int64, error) {
+// Access-токен короткоживущий; продлевается через refresh-токен сессии
+const defaultAccessTTL = 15 * time.Minute
+
+// AccessClaims — нагрузка access-токена: пользователь и сессия, к которой он выпущен
+type AccessClaims struct {
+	UserID    int64
+	SessionID string
+	ExpiresAt time.Time
+}
+
+func AccessTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("ACCESS_TOKEN_TTL"))
+	if err != nil || ttl <= 0 {
+		return defaultAccessTTL
+	}
+	return ttl
+}
+
+func ParseAccessToken(tokenStr string) (*AccessClaims, error) {
 	token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("неверный метод подписи")
@@ -18,22 +36,35 @@ func ParseToken(tokenStr string) (int64, error) {
 		return jwtSecret, nil
 	})
 	if err != nil || !token.Valid {
-		return 0, fmt.Errorf("некорректный токен")
+		return nil, fmt.Errorf("некорректный токен")
 	}
 
 	claims, ok := token.Claims.(jwt.MapClaims)
 	if !ok || claims["user_id"] == nil {
-		return 0, fmt.Errorf("некорректная нагрузка токена")
+		return nil, fmt.Errorf("некорректная нагрузка токена")
+	}
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("некорректная нагрузка токена")
+	}
+	sessionID, ok := claims["sid"].(string)
+	if !ok || sessionID == "" {
+		return nil, fmt.Errorf("токен выпущен без сессии")
 	}
 
-	return int64(claims["user_id"].(float64)), nil
+	access := &AccessClaims{UserID: int64(userID), SessionID: sessionID}
+	if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+		access.ExpiresAt = exp.Time
+	}
+	return access, nil
 }
 
-func GenerateToken(userID int64, email string) (string, error) {
+func GenerateAccessToken(userID int64, email, sessionID string) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": userID,
 		"email":   email,
-		"exp":     time.Now().Add(24 * time.Hour).Unix(),
+		"sid":     sessionID,
+		"exp":     time.Now().Add(AccessTTL()).Unix(),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(jwtSecret)
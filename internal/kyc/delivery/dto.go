@@ -0,0 +1,23 @@
+package kychttp
+
+import model "github.com/Vovarama1992/emelya-go/internal/kyc/model"
+
+type SubmitKYCRequest struct {
+	Level          int    `json:"level" validate:"required,oneof=1 2" example:"1"`
+	BirthDate      string `json:"birth_date" validate:"required,datetime=2006-01-02" example:"1990-05-17"`
+	PassportNumber string `json:"passport_number" validate:"required,max=20" example:"4510 123456"`
+}
+
+type KYCApplicationRequest struct {
+	ApplicationID int64 `json:"application_id" validate:"required"`
+}
+
+type RejectKYCRequest struct {
+	ApplicationID int64  `json:"application_id" validate:"required"`
+	Reason        string `json:"reason" validate:"required,max=500"`
+}
+
+type MyKYCResponse struct {
+	Level       model.Level        `json:"level"`
+	Application *model.Application `json:"application,omitempty"`
+}
@@ -0,0 +1,308 @@
+package kychttp
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	model "github.com/Vovarama1992/emelya-go/internal/kyc/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/kyc/ports"
+	service "github.com/Vovarama1992/emelya-go/internal/kyc/usecase"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type Handler struct {
+	kycService ports.KYCService
+}
+
+func NewHandler(kycService ports.KYCService) *Handler {
+	return &Handler{kycService: kycService}
+}
+
+// GetMyKYC godoc
+// @Summary Юзер: уровень верификации и последняя заявка
+// @Tags kyc
+// @Produce json
+// @Success 200 {object} MyKYCResponse
+// @Failure 401,500 {object} map[string]string
+// @Router /api/kyc/my [get]
+func (h *Handler) GetMyKYC(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	level, app, err := h.kycService.GetMyKYC(r.Context(), user.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения данных верификации")
+		return
+	}
+
+	json.NewEncoder(w).Encode(MyKYCResponse{Level: level, Application: app})
+}
+
+// UploadDocument godoc
+// @Summary Юзер: загрузить документ для верификации (JPEG, PNG или PDF до 10 МБ)
+// @Tags kyc
+// @Accept multipart/form-data
+// @Produce json
+// @Param kind formData string true "Вид документа" Enums(passport_main, passport_registration, selfie)
+// @Param file formData file true "Файл"
+// @Success 201 {object} model.Document
+// @Failure 400,401,409,413,500 {object} map[string]string
+// @Router /api/kyc/documents [post]
+func (h *Handler) UploadDocument(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	// Запас на заголовки multipart сверх лимита на сам файл
+	r.Body = http.MaxBytesReader(w, r.Body, service.MaxDocumentSize+1<<20)
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondWithError(w, http.StatusRequestEntityTooLarge, service.ErrDocumentTooLarge.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, "Не передан файл")
+		return
+	}
+	defer file.Close()
+
+	user := middleware.GetUserFromContext(r.Context())
+	doc, err := h.kycService.UploadDocument(r.Context(), user.ID, model.DocumentKind(r.FormValue("kind")), file)
+	if err != nil {
+		respondWithServiceError(w, err, "Не удалось загрузить документ")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(doc)
+}
+
+// Submit godoc
+// @Summary Юзер: отправить документы на проверку
+// @Tags kyc
+// @Accept json
+// @Produce json
+// @Param data body SubmitKYCRequest true "Уровень и паспортные данные"
+// @Success 200 {object} map[string]string
+// @Failure 400,401,409,500 {object} map[string]string
+// @Router /api/kyc/submit [post]
+func (h *Handler) Submit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req SubmitKYCRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+	birthDate, _ := time.Parse(time.DateOnly, req.BirthDate)
+
+	user := middleware.GetUserFromContext(r.Context())
+	if err := h.kycService.Submit(r.Context(), user, model.Level(req.Level), birthDate, req.PassportNumber); err != nil {
+		respondWithServiceError(w, err, "Не удалось отправить заявку")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Документы отправлены на проверку"})
+}
+
+// AdminListPending godoc
+// @Summary Админ: заявки на верификацию, ожидающие проверки
+// @Tags admin-kyc
+// @Produce json
+// @Success 200 {array} model.Application
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/kyc/pending [get]
+func (h *Handler) AdminListPending(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	apps, err := h.kycService.ListSubmitted(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения заявок")
+		return
+	}
+
+	json.NewEncoder(w).Encode(apps)
+}
+
+// AdminGetApplication godoc
+// @Summary Админ: заявка на верификацию со списком документов
+// @Tags admin-kyc
+// @Produce json
+// @Param id query int true "ID заявки"
+// @Success 200 {object} model.Application
+// @Failure 400,404,500 {object} map[string]string
+// @Router /api/admin/kyc/application [get]
+func (h *Handler) AdminGetApplication(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil || id <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Некорректный id")
+		return
+	}
+
+	app, err := h.kycService.GetApplication(r.Context(), id)
+	if err != nil {
+		respondWithServiceError(w, err, "Ошибка получения заявки")
+		return
+	}
+
+	json.NewEncoder(w).Encode(app)
+}
+
+// AdminGetDocument godoc
+// @Summary Админ: файл документа (просмотр пишется в журнал)
+// @Tags admin-kyc
+// @Produce octet-stream
+// @Param id query int true "ID документа"
+// @Success 200 {file} file
+// @Failure 400,404,500 {object} map[string]string
+// @Router /api/admin/kyc/document [get]
+func (h *Handler) AdminGetDocument(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil || id <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Некорректный id")
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	doc, content, err := h.kycService.OpenDocument(r.Context(), id, admin.ID)
+	if err != nil {
+		respondWithServiceError(w, err, "Не удалось открыть документ")
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", doc.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(doc.SizeBytes, 10))
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	io.Copy(w, content)
+}
+
+// AdminApprove godoc
+// @Summary Админ: подтвердить верификацию
+// @Tags admin-kyc
+// @Accept json
+// @Produce json
+// @Param data body KYCApplicationRequest true "ID заявки"
+// @Success 200 {object} map[string]string
+// @Failure 400,404,409,500 {object} map[string]string
+// @Router /api/admin/kyc/approve [post]
+func (h *Handler) AdminApprove(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req KYCApplicationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	if err := h.kycService.Approve(r.Context(), req.ApplicationID, admin.ID); err != nil {
+		respondWithServiceError(w, err, "Не удалось подтвердить заявку")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Верификация подтверждена"})
+}
+
+// AdminReject godoc
+// @Summary Админ: отклонить верификацию
+// @Tags admin-kyc
+// @Accept json
+// @Produce json
+// @Param data body RejectKYCRequest true "ID заявки и причина"
+// @Success 200 {object} map[string]string
+// @Failure 400,404,409,500 {object} map[string]string
+// @Router /api/admin/kyc/reject [post]
+func (h *Handler) AdminReject(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req RejectKYCRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	if err := h.kycService.Reject(r.Context(), req.ApplicationID, admin.ID, req.Reason); err != nil {
+		respondWithServiceError(w, err, "Не удалось отклонить заявку")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Верификация отклонена"})
+}
+
+// respondWithServiceError переводит ошибки сервиса в HTTP-коды
+func respondWithServiceError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrApplicationNotFound),
+		errors.Is(err, service.ErrDocumentNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrUnderReview),
+		errors.Is(err, service.ErrLevelAlreadyGranted),
+		errors.Is(err, service.ErrApplicationProcessed):
+		respondWithError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrDocumentTooLarge):
+		respondWithError(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, service.ErrInvalidDocumentKind),
+		errors.Is(err, service.ErrDocumentType),
+		errors.Is(err, service.ErrInvalidLevel),
+		errors.Is(err, service.ErrMissingDocuments),
+		errors.Is(err, service.ErrInvalidBirthDate),
+		errors.Is(err, service.ErrInvalidPassport):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+func respondWithError(w http.ResponseWriter, code int, message string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,63 @@
+package kychttp
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
+	"github.com/Vovarama1992/go-utils/httputil"
+)
+
+func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService ports.UserServiceInterface, sessions middleware.SessionChecker, access middleware.PermissionChecker) {
+	withRecover := func(h http.Handler) http.Handler {
+		return httputil.RecoverMiddleware(h)
+	}
+
+	withRecoverAndRateLimit := func(h http.Handler) http.Handler {
+		return httputil.RecoverMiddleware(httputil.NewRateLimiter(10, time.Minute)(h))
+	}
+
+	withUserAuth := func(h http.Handler) http.Handler {
+		return middleware.AuthMiddleware(userService, sessions)(h)
+	}
+
+	withPermission := func(perm rbac.Permission, h http.Handler) http.Handler {
+		return middleware.PermissionMiddleware(userService, sessions, access, perm)(h)
+	}
+
+	// === USER ===
+	mux.Handle("/api/kyc/my",
+		withRecover(withUserAuth(http.HandlerFunc(handler.GetMyKYC))),
+	)
+
+	mux.Handle("/api/kyc/documents",
+		withRecoverAndRateLimit(withUserAuth(http.HandlerFunc(handler.UploadDocument))),
+	)
+
+	mux.Handle("/api/kyc/submit",
+		withRecoverAndRateLimit(withUserAuth(http.HandlerFunc(handler.Submit))),
+	)
+
+	// === ADMIN ===
+	mux.Handle("/api/admin/kyc/pending",
+		withRecover(withPermission(rbac.PermKYCReview, http.HandlerFunc(handler.AdminListPending))),
+	)
+
+	mux.Handle("/api/admin/kyc/application",
+		withRecover(withPermission(rbac.PermKYCReview, http.HandlerFunc(handler.AdminGetApplication))),
+	)
+
+	mux.Handle("/api/admin/kyc/document",
+		withRecover(withPermission(rbac.PermKYCReview, http.HandlerFunc(handler.AdminGetDocument))),
+	)
+
+	mux.Handle("/api/admin/kyc/approve",
+		withRecover(withPermission(rbac.PermKYCReview, http.HandlerFunc(handler.AdminApprove))),
+	)
+
+	mux.Handle("/api/admin/kyc/reject",
+		withRecover(withPermission(rbac.PermKYCReview, http.HandlerFunc(handler.AdminReject))),
+	)
+}
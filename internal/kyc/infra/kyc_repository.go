@@ -0,0 +1,287 @@
+package kyc_infra
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	model "github.com/Vovarama1992/emelya-go/internal/kyc/model"
+	"github.com/jackc/pgx/v5"
+)
+
+// Заявка уже не в ожидаемом статусе
+var ErrStatusMismatch = errors.New("статус заявки изменился")
+
+type KYCRepository struct {
+	DB *db.DB
+}
+
+func NewKYCRepository(db *db.DB) *KYCRepository {
+	return &KYCRepository{DB: db}
+}
+
+const applicationColumns = `id, user_id, level, status, birth_date, passport_number_encrypted,
+	reviewed_by, reviewed_at, reason, submitted_at, created_at`
+
+const documentColumns = `id, application_id, kind, storage_key, content_type, size_bytes, created_at`
+
+// GetLevel — текущий уровень верификации пользователя
+func (r *KYCRepository) GetLevel(ctx context.Context, userID int64) (model.Level, error) {
+	var level model.Level
+	err := r.DB.Pool.QueryRow(ctx, `SELECT kyc_level FROM users WHERE id = $1`, userID).Scan(&level)
+	return level, err
+}
+
+func (r *KYCRepository) CreateDraft(ctx context.Context, userID int64) (*model.Application, error) {
+	query := `
+		INSERT INTO kyc_applications (user_id) VALUES ($1)
+		RETURNING ` + applicationColumns
+	return scanApplication(r.DB.Pool.QueryRow(ctx, query, userID))
+}
+
+func (r *KYCRepository) GetApplication(ctx context.Context, id int64) (*model.Application, error) {
+	query := `SELECT ` + applicationColumns + ` FROM kyc_applications WHERE id = $1`
+	a, err := scanApplication(r.DB.Pool.QueryRow(ctx, query, id))
+	if err != nil {
+		return nil, err
+	}
+	return a, r.loadDocuments(ctx, a)
+}
+
+// GetOpenApplication — черновик или заявка на проверке
+func (r *KYCRepository) GetOpenApplication(ctx context.Context, userID int64) (*model.Application, error) {
+	query := `
+		SELECT ` + applicationColumns + `
+		FROM kyc_applications
+		WHERE user_id = $1 AND status IN ('draft', 'submitted')
+	`
+	a, err := scanApplication(r.DB.Pool.QueryRow(ctx, query, userID))
+	if err != nil {
+		return nil, err
+	}
+	return a, r.loadDocuments(ctx, a)
+}
+
+// GetLatestApplication — последняя заявка пользователя в любом статусе
+func (r *KYCRepository) GetLatestApplication(ctx context.Context, userID int64) (*model.Application, error) {
+	query := `
+		SELECT ` + applicationColumns + `
+		FROM kyc_applications
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	`
+	a, err := scanApplication(r.DB.Pool.QueryRow(ctx, query, userID))
+	if err != nil {
+		return nil, err
+	}
+	return a, r.loadDocuments(ctx, a)
+}
+
+func (r *KYCRepository) FindSubmitted(ctx context.Context) ([]*model.Application, error) {
+	query := `
+		SELECT ` + applicationColumns + `
+		FROM kyc_applications
+		WHERE status = 'submitted'
+		ORDER BY submitted_at ASC
+	`
+	rows, err := r.DB.Pool.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var apps []*model.Application
+	for rows.Next() {
+		a, err := scanApplication(rows)
+		if err != nil {
+			return nil, err
+		}
+		apps = append(apps, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, a := range apps {
+		if err := r.loadDocuments(ctx, a); err != nil {
+			return nil, err
+		}
+	}
+	return apps, nil
+}
+
+// SaveDocument добавляет документ в черновик; документ того же вида заменяется,
+// и возвращается ключ прежнего файла, чтобы его можно было удалить из хранилища
+func (r *KYCRepository) SaveDocument(ctx context.Context, d *model.Document) (previousKey *string, err error) {
+	tx, err := r.DB.Pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	var status model.Status
+	err = tx.QueryRow(ctx, `SELECT status FROM kyc_applications WHERE id = $1 FOR UPDATE`, d.ApplicationID).Scan(&status)
+	if err != nil {
+		return nil, err
+	}
+	if status != model.StatusDraft {
+		return nil, ErrStatusMismatch
+	}
+
+	var oldKey string
+	err = tx.QueryRow(ctx, `
+		DELETE FROM kyc_documents WHERE application_id = $1 AND kind = $2
+		RETURNING storage_key
+	`, d.ApplicationID, d.Kind).Scan(&oldKey)
+	if err == nil {
+		previousKey = &oldKey
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO kyc_documents (application_id, kind, storage_key, content_type, size_bytes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`, d.ApplicationID, d.Kind, d.StorageKey, d.ContentType, d.SizeBytes).Scan(&d.ID, &d.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return previousKey, nil
+}
+
+func (r *KYCRepository) GetDocument(ctx context.Context, id int64) (*model.Document, error) {
+	query := `SELECT ` + documentColumns + ` FROM kyc_documents WHERE id = $1`
+	return scanDocument(r.DB.Pool.QueryRow(ctx, query, id))
+}
+
+func (r *KYCRepository) Submit(ctx context.Context, id int64, level model.Level, birthDate time.Time, passportEncrypted string) error {
+	query := `
+		UPDATE kyc_applications
+		SET status = 'submitted', level = $1, birth_date = $2, passport_number_encrypted = $3, submitted_at = now()
+		WHERE id = $4 AND status = 'draft'
+	`
+	tag, err := r.DB.Pool.Exec(ctx, query, level, birthDate, passportEncrypted, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrStatusMismatch
+	}
+	return nil
+}
+
+// Approve закрывает заявку и повышает уровень пользователя в одной транзакции.
+// Уровень не понижается, если у пользователя уже есть более высокий.
+func (r *KYCRepository) Approve(ctx context.Context, id, reviewedBy int64) (err error) {
+	tx, err := r.DB.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	var userID int64
+	var level model.Level
+	err = tx.QueryRow(ctx, `
+		UPDATE kyc_applications
+		SET status = 'approved', reviewed_by = $1, reviewed_at = now()
+		WHERE id = $2 AND status = 'submitted'
+		RETURNING user_id, level
+	`, reviewedBy, id).Scan(&userID, &level)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrStatusMismatch
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE users SET kyc_level = GREATEST(kyc_level, $1) WHERE id = $2`, level, userID)
+	return err
+}
+
+func (r *KYCRepository) Reject(ctx context.Context, id, reviewedBy int64, reason string) error {
+	query := `
+		UPDATE kyc_applications
+		SET status = 'rejected', reviewed_by = $1, reviewed_at = now(), reason = $2
+		WHERE id = $3 AND status = 'submitted'
+	`
+	tag, err := r.DB.Pool.Exec(ctx, query, reviewedBy, reason, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrStatusMismatch
+	}
+	return nil
+}
+
+func (r *KYCRepository) loadDocuments(ctx context.Context, a *model.Application) error {
+	query := `SELECT ` + documentColumns + ` FROM kyc_documents WHERE application_id = $1 ORDER BY id`
+	rows, err := r.DB.Pool.Query(ctx, query, a.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	a.Documents = []*model.Document{}
+	for rows.Next() {
+		d, err := scanDocument(rows)
+		if err != nil {
+			return err
+		}
+		a.Documents = append(a.Documents, d)
+	}
+	return rows.Err()
+}
+
+func scanApplication(row pgx.Row) (*model.Application, error) {
+	var a model.Application
+	err := row.Scan(
+		&a.ID,
+		&a.UserID,
+		&a.Level,
+		&a.Status,
+		&a.BirthDate,
+		&a.PassportNumberEncrypted,
+		&a.ReviewedBy,
+		&a.ReviewedAt,
+		&a.Reason,
+		&a.SubmittedAt,
+		&a.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func scanDocument(row pgx.Row) (*model.Document, error) {
+	var d model.Document
+	err := row.Scan(
+		&d.ID,
+		&d.ApplicationID,
+		&d.Kind,
+		&d.StorageKey,
+		&d.ContentType,
+		&d.SizeBytes,
+		&d.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
@@ -0,0 +1,91 @@
+package kyc_model
+
+import "time"
+
+// Level — уровень верификации личности
+type Level int
+
+const (
+	LevelNone  Level = 0 // не проверен
+	LevelBasic Level = 1 // главный разворот паспорта
+	LevelFull  Level = 2 // паспорт, прописка и селфи с паспортом
+)
+
+func (l Level) IsValid() bool {
+	return l == LevelBasic || l == LevelFull
+}
+
+type DocumentKind string
+
+const (
+	DocPassportMain         DocumentKind = "passport_main"
+	DocPassportRegistration DocumentKind = "passport_registration"
+	DocSelfie               DocumentKind = "selfie"
+)
+
+func (k DocumentKind) IsValid() bool {
+	return k == DocPassportMain || k == DocPassportRegistration || k == DocSelfie
+}
+
+// RequiredDocuments — документы, без которых заявку на уровень не отправить
+func (l Level) RequiredDocuments() []DocumentKind {
+	switch l {
+	case LevelBasic:
+		return []DocumentKind{DocPassportMain}
+	case LevelFull:
+		return []DocumentKind{DocPassportMain, DocPassportRegistration, DocSelfie}
+	default:
+		return nil
+	}
+}
+
+type Status string
+
+const (
+	StatusDraft     Status = "draft"     // пользователь загружает документы
+	StatusSubmitted Status = "submitted" // ждёт оператора
+	StatusApproved  Status = "approved"  // уровень присвоен
+	StatusRejected  Status = "rejected"  // отклонена, можно подать новую
+)
+
+// Application — заявка на верификацию. Номер паспорта хранится зашифрованным
+// и в ответы не попадает.
+type Application struct {
+	ID                      int64       `json:"id"`
+	UserID                  int64       `json:"user_id"`
+	Level                   *Level      `json:"level,omitempty"`
+	Status                  Status      `json:"status"`
+	BirthDate               *time.Time  `json:"birth_date,omitempty"`
+	PassportNumberEncrypted *string     `json:"-"`
+	ReviewedBy              *int64      `json:"reviewed_by,omitempty"`
+	ReviewedAt              *time.Time  `json:"reviewed_at,omitempty"`
+	Reason                  *string     `json:"reason,omitempty"`
+	SubmittedAt             *time.Time  `json:"submitted_at,omitempty"`
+	CreatedAt               time.Time   `json:"created_at"`
+	Documents               []*Document `json:"documents"`
+}
+
+// Document — загруженный файл; путь в хранилище наружу не отдаётся
+type Document struct {
+	ID            int64        `json:"id"`
+	ApplicationID int64        `json:"application_id"`
+	Kind          DocumentKind `json:"kind"`
+	StorageKey    string       `json:"-"`
+	ContentType   string       `json:"content_type"`
+	SizeBytes     int64        `json:"size_bytes"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+// HasDocuments — все ли нужные для уровня документы загружены
+func (a *Application) HasDocuments(level Level) bool {
+	have := make(map[DocumentKind]bool, len(a.Documents))
+	for _, d := range a.Documents {
+		have[d.Kind] = true
+	}
+	for _, kind := range level.RequiredDocuments() {
+		if !have[kind] {
+			return false
+		}
+	}
+	return true
+}
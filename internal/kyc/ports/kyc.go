@@ -0,0 +1,35 @@
+package kyc_ports
+
+import (
+	"context"
+	"io"
+	"time"
+
+	model "github.com/Vovarama1992/emelya-go/internal/kyc/model"
+	user "github.com/Vovarama1992/emelya-go/internal/user/model"
+)
+
+type KYCRepository interface {
+	GetLevel(ctx context.Context, userID int64) (model.Level, error)
+	CreateDraft(ctx context.Context, userID int64) (*model.Application, error)
+	GetApplication(ctx context.Context, id int64) (*model.Application, error)
+	GetOpenApplication(ctx context.Context, userID int64) (*model.Application, error)
+	GetLatestApplication(ctx context.Context, userID int64) (*model.Application, error)
+	FindSubmitted(ctx context.Context) ([]*model.Application, error)
+	SaveDocument(ctx context.Context, d *model.Document) (previousKey *string, err error)
+	GetDocument(ctx context.Context, id int64) (*model.Document, error)
+	Submit(ctx context.Context, id int64, level model.Level, birthDate time.Time, passportEncrypted string) error
+	Approve(ctx context.Context, id, reviewedBy int64) error
+	Reject(ctx context.Context, id, reviewedBy int64, reason string) error
+}
+
+type KYCService interface {
+	GetMyKYC(ctx context.Context, userID int64) (model.Level, *model.Application, error)
+	UploadDocument(ctx context.Context, userID int64, kind model.DocumentKind, r io.Reader) (*model.Document, error)
+	Submit(ctx context.Context, u *user.User, level model.Level, birthDate time.Time, passportNumber string) error
+	ListSubmitted(ctx context.Context) ([]*model.Application, error)
+	GetApplication(ctx context.Context, id int64) (*model.Application, error)
+	OpenDocument(ctx context.Context, documentID, actorID int64) (*model.Document, io.ReadCloser, error)
+	Approve(ctx context.Context, applicationID, actorID int64) error
+	Reject(ctx context.Context, applicationID, actorID int64, reason string) error
+}
@@ -0,0 +1,395 @@
+package kyc_usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	audit "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	audit_ports "github.com/Vovarama1992/emelya-go/internal/audit/ports"
+	"github.com/Vovarama1992/emelya-go/internal/cryptoutil"
+	kyc_infra "github.com/Vovarama1992/emelya-go/internal/kyc/infra"
+	model "github.com/Vovarama1992/emelya-go/internal/kyc/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/kyc/ports"
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	"github.com/Vovarama1992/emelya-go/internal/storage"
+	user "github.com/Vovarama1992/emelya-go/internal/user/model"
+	user_ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrInvalidDocumentKind  = errors.New("неизвестный вид документа")
+	ErrDocumentTooLarge     = errors.New("файл больше 10 МБ")
+	ErrDocumentType         = errors.New("допустимы только JPEG, PNG и PDF")
+	ErrInvalidLevel         = errors.New("неизвестный уровень верификации")
+	ErrLevelAlreadyGranted  = errors.New("этот уровень верификации уже пройден")
+	ErrUnderReview          = errors.New("заявка на верификацию уже на проверке")
+	ErrMissingDocuments     = errors.New("загрузите все документы, нужные для этого уровня")
+	ErrInvalidBirthDate     = errors.New("верификацию могут пройти только совершеннолетние")
+	ErrInvalidPassport      = errors.New("номер паспорта — 10 цифр: серия и номер")
+	ErrApplicationNotFound  = errors.New("заявка на верификацию не найдена")
+	ErrDocumentNotFound     = errors.New("документ не найден")
+	ErrApplicationProcessed = errors.New("заявка уже обработана")
+)
+
+const (
+	MaxDocumentSize = 10 << 20
+	minAge          = 18
+)
+
+// Допустимые форматы по сигнатуре файла, а не по заявленному Content-Type
+var allowedDocumentTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
+}
+
+type KYCService struct {
+	repo         ports.KYCRepository
+	storage      storage.Storage
+	userService  user_ports.UserServiceInterface
+	auditService audit_ports.AuditService
+	notifier     notifier.NotifierInterface
+}
+
+func NewKYCService(
+	repo ports.KYCRepository,
+	storage storage.Storage,
+	userService user_ports.UserServiceInterface,
+	auditService audit_ports.AuditService,
+	notifier notifier.NotifierInterface,
+) *KYCService {
+	return &KYCService{
+		repo:         repo,
+		storage:      storage,
+		userService:  userService,
+		auditService: auditService,
+		notifier:     notifier,
+	}
+}
+
+// GetMyKYC — текущий уровень и последняя заявка пользователя (nil, если заявок не было)
+func (s *KYCService) GetMyKYC(ctx context.Context, userID int64) (model.Level, *model.Application, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	level, err := s.repo.GetLevel(ctx, userID)
+	if err != nil {
+		return 0, nil, err
+	}
+	app, err := s.repo.GetLatestApplication(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return level, nil, nil
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	return level, app, nil
+}
+
+// UploadDocument кладёт документ в черновик заявки (черновик создаётся при первой
+// загрузке). Файл шифруется перед записью в хранилище.
+func (s *KYCService) UploadDocument(ctx context.Context, userID int64, kind model.DocumentKind, r io.Reader) (*model.Document, error) {
+	if !kind.IsValid() {
+		return nil, ErrInvalidDocumentKind
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, MaxDocumentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxDocumentSize {
+		return nil, ErrDocumentTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := allowedDocumentTypes[contentType]
+	if !ok {
+		return nil, ErrDocumentType
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 10)
+	defer cancel()
+
+	app, err := s.draft(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := cryptoutil.EncryptBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("kyc/%d/%s%s", userID, randomName(), ext)
+	if err := s.storage.Save(ctx, key, bytes.NewReader(sealed)); err != nil {
+		return nil, err
+	}
+
+	doc := &model.Document{
+		ApplicationID: app.ID,
+		Kind:          kind,
+		StorageKey:    key,
+		ContentType:   contentType,
+		SizeBytes:     int64(len(data)),
+	}
+	previousKey, err := s.repo.SaveDocument(ctx, doc)
+	if err != nil {
+		s.deleteFile(ctx, key)
+		if errors.Is(err, kyc_infra.ErrStatusMismatch) {
+			return nil, ErrUnderReview
+		}
+		return nil, err
+	}
+	if previousKey != nil {
+		s.deleteFile(ctx, *previousKey)
+	}
+	return doc, nil
+}
+
+// draft возвращает черновик пользователя или создаёт новый
+func (s *KYCService) draft(ctx context.Context, userID int64) (*model.Application, error) {
+	app, err := s.repo.GetOpenApplication(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s.repo.CreateDraft(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if app.Status != model.StatusDraft {
+		return nil, ErrUnderReview
+	}
+	return app, nil
+}
+
+// Submit отправляет черновик на проверку оператору
+func (s *KYCService) Submit(ctx context.Context, u *user.User, level model.Level, birthDate time.Time, passportNumber string) error {
+	if !level.IsValid() {
+		return ErrInvalidLevel
+	}
+	if !isAdult(birthDate, time.Now()) {
+		return ErrInvalidBirthDate
+	}
+	passport, ok := normalizePassport(passportNumber)
+	if !ok {
+		return ErrInvalidPassport
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+
+	current, err := s.repo.GetLevel(ctx, u.ID)
+	if err != nil {
+		return err
+	}
+	if level <= current {
+		return ErrLevelAlreadyGranted
+	}
+
+	app, err := s.repo.GetOpenApplication(ctx, u.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrMissingDocuments
+	}
+	if err != nil {
+		return err
+	}
+	if app.Status != model.StatusDraft {
+		return ErrUnderReview
+	}
+	if !app.HasDocuments(level) {
+		return ErrMissingDocuments
+	}
+
+	encrypted, err := cryptoutil.Encrypt(passport)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Submit(ctx, app.ID, level, birthDate, encrypted); err != nil {
+		if errors.Is(err, kyc_infra.ErrStatusMismatch) {
+			return ErrUnderReview
+		}
+		return err
+	}
+
+	err = s.notifier.SendTemplateToOperators(ctx, notifier.TplOperatorKYCSubmitted, map[string]any{
+		"UserID":        u.ID,
+		"FirstName":     u.FirstName,
+		"LastName":      u.LastName,
+		"Patronymic":    u.Patronymic,
+		"ApplicationID": app.ID,
+		"Level":         level,
+	})
+	if err != nil {
+		log.Printf("[KYC] Не удалось уведомить оператора о заявке %d: %v", app.ID, err)
+	}
+	return nil
+}
+
+// Заявки на проверке, старые первыми
+func (s *KYCService) ListSubmitted(ctx context.Context) ([]*model.Application, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+	return s.repo.FindSubmitted(ctx)
+}
+
+func (s *KYCService) GetApplication(ctx context.Context, id int64) (*model.Application, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	app, err := s.repo.GetApplication(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrApplicationNotFound
+	}
+	return app, err
+}
+
+// OpenDocument расшифровывает документ для оператора; каждый просмотр пишется в журнал
+func (s *KYCService) OpenDocument(ctx context.Context, documentID, actorID int64) (*model.Document, io.ReadCloser, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 10)
+	defer cancel()
+
+	doc, err := s.repo.GetDocument(ctx, documentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, ErrDocumentNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f, err := s.storage.Open(ctx, doc.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, ErrDocumentNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	sealed, err := io.ReadAll(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := cryptoutil.DecryptBytes(sealed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.record(ctx, actorID, audit.ActionKYCDocumentView, doc.ApplicationID, nil, map[string]any{"document_id": doc.ID, "kind": doc.Kind}, nil)
+	return doc, io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// Approve присваивает пользователю уровень из заявки
+func (s *KYCService) Approve(ctx context.Context, applicationID, actorID int64) error {
+	return s.decide(ctx, applicationID, actorID, model.StatusApproved, nil)
+}
+
+func (s *KYCService) Reject(ctx context.Context, applicationID, actorID int64, reason string) error {
+	return s.decide(ctx, applicationID, actorID, model.StatusRejected, &reason)
+}
+
+func (s *KYCService) decide(ctx context.Context, applicationID, actorID int64, status model.Status, reason *string) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+
+	before, err := s.repo.GetApplication(ctx, applicationID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrApplicationNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	action := audit.ActionKYCApprove
+	if status == model.StatusApproved {
+		err = s.repo.Approve(ctx, applicationID, actorID)
+	} else {
+		action = audit.ActionKYCReject
+		err = s.repo.Reject(ctx, applicationID, actorID, *reason)
+	}
+	if errors.Is(err, kyc_infra.ErrStatusMismatch) {
+		return ErrApplicationProcessed
+	}
+	if err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetApplication(ctx, applicationID)
+	if err != nil {
+		return err
+	}
+	s.record(ctx, actorID, action, applicationID, before, after, reason)
+	s.notifyDecision(ctx, after)
+	return nil
+}
+
+func (s *KYCService) notifyDecision(ctx context.Context, app *model.Application) {
+	u, err := s.userService.FindUserByID(ctx, app.UserID)
+	if err != nil || u == nil || u.Email == "" {
+		return
+	}
+
+	key := notifier.TplUserKYCApproved
+	if app.Status == model.StatusRejected {
+		key = notifier.TplUserKYCRejected
+	}
+	data := map[string]any{"Level": app.Level, "Reason": app.Reason}
+	if err := s.notifier.SendTemplate(ctx, notifier.ChannelEmail, u.Email, key, data); err != nil {
+		log.Printf("[KYC] Не удалось уведомить пользователя %d о решении по заявке %d: %v", u.ID, app.ID, err)
+	}
+}
+
+func (s *KYCService) record(ctx context.Context, actorID int64, action audit.Action, applicationID int64, before, after any, reason *string) {
+	err := s.auditService.Record(ctx, &audit.Entry{
+		ActorID:    &actorID,
+		Action:     action,
+		EntityType: audit.EntityKYC,
+		EntityID:   &applicationID,
+		Before:     audit.Snapshot(before),
+		After:      audit.Snapshot(after),
+		Reason:     reason,
+	})
+	if err != nil {
+		log.Printf("[KYC] Не удалось записать журнал по заявке %d: %v", applicationID, err)
+	}
+}
+
+func (s *KYCService) deleteFile(ctx context.Context, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil {
+		log.Printf("[KYC] Не удалось удалить файл %s: %v", key, err)
+	}
+}
+
+func isAdult(birthDate, now time.Time) bool {
+	if birthDate.Year() < 1900 {
+		return false
+	}
+	return !birthDate.AddDate(minAge, 0, 0).After(now)
+}
+
+// normalizePassport — паспорт РФ: 4 цифры серии и 6 цифр номера, пробелы допускаются
+func normalizePassport(number string) (string, bool) {
+	number = strings.ReplaceAll(number, " ", "")
+	if len(number) != 10 {
+		return "", false
+	}
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return "", false
+		}
+	}
+	return number, true
+}
+
+func randomName() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
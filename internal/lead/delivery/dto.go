@@ -0,0 +1,33 @@
+package leadhttp
+
+type SubmitLeadRequest struct {
+	Name     string `json:"name" validate:"required,max=100" example:"Иван"`
+	Phone    string `json:"phone" validate:"required,max=32" example:"+7 999 123-45-67"`
+	Email    string `json:"email" validate:"omitempty,email,max=254"`
+	TariffID *int64 `json:"tariff_id,omitempty"`
+	Comment  string `json:"comment" validate:"max=1000"`
+	// Откуда пришла заявка: страница или рекламная метка
+	Source string `json:"source" validate:"max=100" example:"landing"`
+	// Скрытое поле формы; должно оставаться пустым
+	Website string `json:"website"`
+}
+
+type AssignLeadRequest struct {
+	ID int64 `json:"id" validate:"required"`
+	// null — снять ответственного
+	AssigneeID *int64 `json:"assignee_id"`
+}
+
+type LeadStatusRequest struct {
+	ID     int64  `json:"id" validate:"required"`
+	Status string `json:"status" validate:"required,oneof=new in_progress rejected spam"`
+}
+
+type ConvertLeadRequest struct {
+	ID         int64  `json:"id" validate:"required"`
+	FirstName  string `json:"first_name" validate:"required,max=50"`
+	LastName   string `json:"last_name" validate:"required,max=50"`
+	Patronymic string `json:"patronymic" validate:"omitempty,max=50"`
+	// Пусто — берётся из заявки
+	Email string `json:"email" validate:"omitempty,email"`
+}
@@ -0,0 +1,306 @@
+package leadhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	model "github.com/Vovarama1992/emelya-go/internal/lead/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/lead/ports"
+	"github.com/Vovarama1992/emelya-go/internal/utils"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// Форма заявки небольшая; всё, что больше, — не от формы
+const maxSubmitBody = 16 << 10
+
+type Handler struct {
+	leadService ports.LeadService
+}
+
+func NewHandler(leadService ports.LeadService) *Handler {
+	return &Handler{leadService: leadService}
+}
+
+// SubmitLead godoc
+// @Summary Заявка с формы на сайте
+// @Description Заявка сохраняется и пересылается операторам. Повторная заявка с того же номера принимается не чаще раза в 10 минут.
+// @Tags leads
+// @Accept json
+// @Produce json
+// @Param data body SubmitLeadRequest true "Имя, телефон, интересующий тариф"
+// @Success 200 {object} map[string]string
+// @Failure 400,429,500 {object} map[string]string
+// @Router /api/leads [post]
+func (h *Handler) SubmitLead(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxSubmitBody)
+	var req SubmitLeadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	err := h.leadService.Submit(r.Context(), model.Submission{
+		Name:      req.Name,
+		Phone:     req.Phone,
+		Email:     req.Email,
+		TariffID:  req.TariffID,
+		Comment:   req.Comment,
+		Source:    req.Source,
+		Honeypot:  req.Website,
+		IP:        utils.ClientIP(r),
+		UserAgent: r.UserAgent(),
+	})
+	if err != nil {
+		respondWithServiceError(w, err, "Не удалось принять заявку")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Заявка принята"})
+}
+
+// ListLeads godoc
+// @Summary Админ: заявки с сайта
+// @Tags admin-leads
+// @Produce json
+// @Param status query string false "new, in_progress, converted, rejected или spam"
+// @Param assignee_id query int false "ID ответственного сотрудника"
+// @Param phone query string false "Телефон"
+// @Param before_id query int false "Заявки старше этой (для следующей страницы)"
+// @Param limit query int false "Количество (по умолчанию 50, не больше 200)"
+// @Success 200 {array} model.Lead
+// @Failure 400,500 {object} map[string]string
+// @Router /api/admin/leads [get]
+func (h *Handler) ListLeads(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	q := r.URL.Query()
+	var f model.Filter
+
+	if v := q.Get("status"); v != "" {
+		status := model.Status(v)
+		if !status.IsValid() {
+			respondWithError(w, http.StatusBadRequest, "Некорректный status")
+			return
+		}
+		f.Status = &status
+	}
+	if v := q.Get("assignee_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Некорректный assignee_id")
+			return
+		}
+		f.AssigneeID = &id
+	}
+	if v := q.Get("phone"); v != "" {
+		f.Phone = &v
+	}
+	if v := q.Get("before_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Некорректный before_id")
+			return
+		}
+		f.BeforeID = id
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Некорректный limit")
+			return
+		}
+		f.Limit = n
+	}
+
+	leads, err := h.leadService.List(r.Context(), f)
+	if err != nil {
+		respondWithServiceError(w, err, "Ошибка получения заявок")
+		return
+	}
+
+	json.NewEncoder(w).Encode(leads)
+}
+
+// GetLead godoc
+// @Summary Админ: заявка с сайта
+// @Tags admin-leads
+// @Produce json
+// @Param id query int true "ID заявки"
+// @Success 200 {object} model.Lead
+// @Failure 400,404,500 {object} map[string]string
+// @Router /api/admin/leads/get [get]
+func (h *Handler) GetLead(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil || id <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Некорректный id")
+		return
+	}
+
+	lead, err := h.leadService.Get(r.Context(), id)
+	if err != nil {
+		respondWithServiceError(w, err, "Ошибка получения заявки")
+		return
+	}
+
+	json.NewEncoder(w).Encode(lead)
+}
+
+// AssignLead godoc
+// @Summary Админ: назначить ответственного за заявку
+// @Description Новая заявка при назначении переходит в статус in_progress. assignee_id = null снимает ответственного.
+// @Tags admin-leads
+// @Accept json
+// @Produce json
+// @Param data body AssignLeadRequest true "ID заявки и сотрудника"
+// @Success 200 {object} model.Lead
+// @Failure 400,404,409,500 {object} map[string]string
+// @Router /api/admin/leads/assign [post]
+func (h *Handler) AssignLead(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req AssignLeadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	lead, err := h.leadService.Assign(r.Context(), admin.ID, req.ID, req.AssigneeID)
+	if err != nil {
+		respondWithServiceError(w, err, "Ошибка назначения заявки")
+		return
+	}
+
+	json.NewEncoder(w).Encode(lead)
+}
+
+// SetLeadStatus godoc
+// @Summary Админ: сменить статус заявки
+// @Description Статус converted ставится только регистрацией пользователя по заявке.
+// @Tags admin-leads
+// @Accept json
+// @Produce json
+// @Param data body LeadStatusRequest true "ID заявки и статус"
+// @Success 200 {object} model.Lead
+// @Failure 400,404,409,500 {object} map[string]string
+// @Router /api/admin/leads/status [post]
+func (h *Handler) SetLeadStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req LeadStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	lead, err := h.leadService.SetStatus(r.Context(), admin.ID, req.ID, model.Status(req.Status))
+	if err != nil {
+		respondWithServiceError(w, err, "Ошибка смены статуса заявки")
+		return
+	}
+
+	json.NewEncoder(w).Encode(lead)
+}
+
+// ConvertLead godoc
+// @Summary Админ: зарегистрировать пользователя по заявке
+// @Description Создаёт пользователя с телефоном из заявки и присылает ему логин и пароль по SMS. Если номер уже зарегистрирован, заявка привязывается к существующему пользователю (created = false).
+// @Tags admin-leads
+// @Accept json
+// @Produce json
+// @Param data body ConvertLeadRequest true "ID заявки и данные пользователя"
+// @Success 200 {object} model.Conversion
+// @Failure 400,404,409,500 {object} map[string]string
+// @Router /api/admin/leads/convert [post]
+func (h *Handler) ConvertLead(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req ConvertLeadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	conversion, err := h.leadService.Convert(r.Context(), admin.ID, req.ID, model.ConvertInput{
+		FirstName:  req.FirstName,
+		LastName:   req.LastName,
+		Patronymic: req.Patronymic,
+		Email:      req.Email,
+	})
+	if err != nil {
+		respondWithServiceError(w, err, "Ошибка регистрации пользователя по заявке")
+		return
+	}
+
+	json.NewEncoder(w).Encode(conversion)
+}
+
+func respondWithServiceError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, model.ErrInvalidPhone),
+		errors.Is(err, model.ErrTariffNotFound),
+		errors.Is(err, model.ErrInvalidStatus),
+		errors.Is(err, model.ErrAssigneeNotStaff),
+		errors.Is(err, model.ErrEmailRequired):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, model.ErrLeadNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, model.ErrAlreadyConverted),
+		errors.Is(err, model.ErrEmailTaken):
+		respondWithError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, model.ErrTooManyLeads):
+		respondWithError(w, http.StatusTooManyRequests, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+func respondWithError(w http.ResponseWriter, code int, message string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,47 @@
+package leadhttp
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
+	"github.com/Vovarama1992/go-utils/httputil"
+)
+
+func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService ports.UserServiceInterface, sessions middleware.SessionChecker, access middleware.PermissionChecker) {
+	withRecover := func(h http.Handler) http.Handler {
+		return httputil.RecoverMiddleware(h)
+	}
+
+	withPermission := func(perm rbac.Permission, h http.Handler) http.Handler {
+		return middleware.PermissionMiddleware(userService, sessions, access, perm)(h)
+	}
+
+	// === PUBLIC ===
+	mux.Handle("/api/leads",
+		withRecover(httputil.NewRateLimiter(3, time.Minute)(http.HandlerFunc(handler.SubmitLead))),
+	)
+
+	// === ADMIN ===
+	mux.Handle("/api/admin/leads",
+		withRecover(withPermission(rbac.PermLeadsManage, http.HandlerFunc(handler.ListLeads))),
+	)
+
+	mux.Handle("/api/admin/leads/get",
+		withRecover(withPermission(rbac.PermLeadsManage, http.HandlerFunc(handler.GetLead))),
+	)
+
+	mux.Handle("/api/admin/leads/assign",
+		withRecover(withPermission(rbac.PermLeadsManage, http.HandlerFunc(handler.AssignLead))),
+	)
+
+	mux.Handle("/api/admin/leads/status",
+		withRecover(withPermission(rbac.PermLeadsManage, http.HandlerFunc(handler.SetLeadStatus))),
+	)
+
+	mux.Handle("/api/admin/leads/convert",
+		withRecover(withPermission(rbac.PermLeadsManage, http.HandlerFunc(handler.ConvertLead))),
+	)
+}
@@ -0,0 +1,179 @@
+package lead_infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	model "github.com/Vovarama1992/emelya-go/internal/lead/model"
+	outbox_infra "github.com/Vovarama1992/emelya-go/internal/outbox/infra"
+	outbox_model "github.com/Vovarama1992/emelya-go/internal/outbox/model"
+	"github.com/jackc/pgx/v5"
+)
+
+type LeadRepository struct {
+	DB *db.DB
+}
+
+func NewLeadRepository(db *db.DB) *LeadRepository {
+	return &LeadRepository{DB: db}
+}
+
+const leadColumns = `id, name, phone, email, tariff_id, tariff_name, comment, source, status, spam_reason, assignee_id, user_id, ip, user_agent, created_at, updated_at, converted_at`
+
+func scanLead(row pgx.Row) (*model.Lead, error) {
+	var l model.Lead
+	err := row.Scan(
+		&l.ID,
+		&l.Name,
+		&l.Phone,
+		&l.Email,
+		&l.TariffID,
+		&l.TariffName,
+		&l.Comment,
+		&l.Source,
+		&l.Status,
+		&l.SpamReason,
+		&l.AssigneeID,
+		&l.UserID,
+		&l.IP,
+		&l.UserAgent,
+		&l.CreatedAt,
+		&l.UpdatedAt,
+		&l.ConvertedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// Create сохраняет заявку и в той же транзакции пишет lead.created — операторы
+// узнают о заявке, только если она сохранена. Спам событий не порождает.
+func (r *LeadRepository) Create(ctx context.Context, l *model.Lead) (err error) {
+	tx, err := r.DB.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO leads (name, phone, email, tariff_id, tariff_name, comment, source, status, spam_reason, ip, user_agent)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at, updated_at
+	`, l.Name, l.Phone, l.Email, l.TariffID, l.TariffName, l.Comment, l.Source, l.Status, l.SpamReason, l.IP, l.UserAgent,
+	).Scan(&l.ID, &l.CreatedAt, &l.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if l.Status == model.StatusSpam {
+		return nil
+	}
+
+	event, err := outbox_model.NewEvent(outbox_model.EventLeadCreated, outbox_model.AggregateLead, l.ID, outbox_model.LeadPayload{
+		LeadID:     l.ID,
+		Name:       l.Name,
+		Phone:      l.Phone,
+		Email:      l.Email,
+		TariffID:   l.TariffID,
+		TariffName: l.TariffName,
+		Comment:    l.Comment,
+		Source:     l.Source,
+	})
+	if err != nil {
+		return err
+	}
+	return outbox_infra.NewOutboxRepositoryWithTx(tx).Create(ctx, event)
+}
+
+func (r *LeadRepository) GetByID(ctx context.Context, id int64) (*model.Lead, error) {
+	l, err := scanLead(r.DB.Pool.QueryRow(ctx, `SELECT `+leadColumns+` FROM leads WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return l, err
+}
+
+func (r *LeadRepository) List(ctx context.Context, f model.Filter) ([]*model.Lead, error) {
+	var (
+		conds []string
+		args  []interface{}
+	)
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.Status != nil {
+		add("status = $%d", *f.Status)
+	}
+	if f.AssigneeID != nil {
+		add("assignee_id = $%d", *f.AssigneeID)
+	}
+	if f.Phone != nil {
+		add("phone = $%d", *f.Phone)
+	}
+	if f.BeforeID > 0 {
+		add("id < $%d", f.BeforeID)
+	}
+
+	query := `SELECT ` + leadColumns + ` FROM leads`
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, f.Limit)
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args))
+
+	rows, err := r.DB.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*model.Lead
+	for rows.Next() {
+		l, err := scanLead(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, l)
+	}
+	return list, rows.Err()
+}
+
+// Update сохраняет ответственного и статус. Преобразованную заявку не меняет.
+func (r *LeadRepository) Update(ctx context.Context, l *model.Lead) error {
+	err := r.DB.Pool.QueryRow(ctx, `
+		UPDATE leads
+		SET assignee_id = $2, status = $3, updated_at = now()
+		WHERE id = $1 AND status <> 'converted'
+		RETURNING updated_at
+	`, l.ID, l.AssigneeID, l.Status).Scan(&l.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrAlreadyConverted
+	}
+	return err
+}
+
+// MarkConverted привязывает заявку к зарегистрированному пользователю
+func (r *LeadRepository) MarkConverted(ctx context.Context, id, userID int64) (*model.Lead, error) {
+	l, err := scanLead(r.DB.Pool.QueryRow(ctx, `
+		UPDATE leads
+		SET status = 'converted', user_id = $2, converted_at = now(), updated_at = now()
+		WHERE id = $1 AND status <> 'converted'
+		RETURNING `+leadColumns,
+		id, userID,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, model.ErrAlreadyConverted
+	}
+	return l, err
+}
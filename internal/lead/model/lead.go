@@ -0,0 +1,116 @@
+package lead_model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrLeadNotFound     = errors.New("заявка не найдена")
+	ErrInvalidPhone     = errors.New("некорректный номер телефона")
+	ErrTariffNotFound   = errors.New("тариф не найден")
+	ErrTooManyLeads     = errors.New("заявка с этого номера уже принята, мы скоро свяжемся с вами")
+	ErrInvalidStatus    = errors.New("некорректный статус заявки")
+	ErrAssigneeNotStaff = errors.New("заявку можно назначить только на сотрудника")
+	ErrAlreadyConverted = errors.New("заявка уже преобразована в пользователя")
+	ErrEmailRequired    = errors.New("для регистрации пользователя нужен email")
+	ErrEmailTaken       = errors.New("пользователь с таким email уже существует")
+)
+
+type Status string
+
+const (
+	StatusNew        Status = "new"
+	StatusInProgress Status = "in_progress"
+	// Ставится только преобразованием в пользователя
+	StatusConverted Status = "converted"
+	StatusRejected  Status = "rejected"
+	StatusSpam      Status = "spam"
+)
+
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusNew, StatusInProgress, StatusConverted, StatusRejected, StatusSpam:
+		return true
+	}
+	return false
+}
+
+// IsManual — статус, который оператор может поставить сам
+func (s Status) IsManual() bool {
+	return s.IsValid() && s != StatusConverted
+}
+
+// Lead — заявка с формы на сайте
+type Lead struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Phone    string `json:"phone"`
+	Email    string `json:"email,omitempty"`
+	TariffID *int64 `json:"tariff_id,omitempty"`
+	// Название тарифа на момент заявки
+	TariffName  string     `json:"tariff_name,omitempty"`
+	Comment     string     `json:"comment,omitempty"`
+	Source      string     `json:"source,omitempty"`
+	Status      Status     `json:"status"`
+	SpamReason  *string    `json:"spam_reason,omitempty"`
+	AssigneeID  *int64     `json:"assignee_id,omitempty"`
+	UserID      *int64     `json:"user_id,omitempty"`
+	IP          string     `json:"ip"`
+	UserAgent   string     `json:"user_agent"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ConvertedAt *time.Time `json:"converted_at,omitempty"`
+}
+
+// Submission — заявка в том виде, в каком её прислала форма
+type Submission struct {
+	Name     string
+	Phone    string
+	Email    string
+	TariffID *int64
+	Comment  string
+	Source   string
+	// Скрытое поле формы: человек его не видит и не заполняет, боты — заполняют
+	Honeypot  string
+	IP        string
+	UserAgent string
+}
+
+// Причины, по которым заявка помечена спамом
+const (
+	SpamHoneypot = "honeypot"
+	SpamLinks    = "links"
+)
+
+type Filter struct {
+	Status     *Status
+	AssigneeID *int64
+	Phone      *string
+	// Заявки с ID меньше этого — следующая страница
+	BeforeID int64
+	Limit    int
+}
+
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 200
+)
+
+// ConvertInput — данные для регистрации пользователя по заявке; пустой email
+// берётся из заявки
+type ConvertInput struct {
+	FirstName  string
+	LastName   string
+	Patronymic string
+	Email      string
+}
+
+// Conversion — результат преобразования заявки. Created = false, если номер уже
+// был зарегистрирован и заявка просто привязана к существующему пользователю.
+type Conversion struct {
+	Lead    *Lead  `json:"lead"`
+	UserID  int64  `json:"user_id"`
+	Login   string `json:"login"`
+	Created bool   `json:"created"`
+}
@@ -0,0 +1,24 @@
+package lead_ports
+
+import (
+	"context"
+
+	model "github.com/Vovarama1992/emelya-go/internal/lead/model"
+)
+
+type LeadRepository interface {
+	Create(ctx context.Context, l *model.Lead) error
+	GetByID(ctx context.Context, id int64) (*model.Lead, error)
+	List(ctx context.Context, f model.Filter) ([]*model.Lead, error)
+	Update(ctx context.Context, l *model.Lead) error
+	MarkConverted(ctx context.Context, id, userID int64) (*model.Lead, error)
+}
+
+type LeadService interface {
+	Submit(ctx context.Context, in model.Submission) error
+	List(ctx context.Context, f model.Filter) ([]*model.Lead, error)
+	Get(ctx context.Context, id int64) (*model.Lead, error)
+	Assign(ctx context.Context, actorID, id int64, assigneeID *int64) (*model.Lead, error)
+	SetStatus(ctx context.Context, actorID, id int64, status model.Status) (*model.Lead, error)
+	Convert(ctx context.Context, actorID, id int64, in model.ConvertInput) (*model.Conversion, error)
+}
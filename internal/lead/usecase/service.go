@@ -0,0 +1,366 @@
+package lead_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	audit "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	audit_ports "github.com/Vovarama1992/emelya-go/internal/audit/ports"
+	auth_usecase "github.com/Vovarama1992/emelya-go/internal/auth/usecase"
+	model "github.com/Vovarama1992/emelya-go/internal/lead/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/lead/ports"
+	money_ports "github.com/Vovarama1992/emelya-go/internal/money/ports"
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	user_model "github.com/Vovarama1992/emelya-go/internal/user/model"
+	user_ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
+	"github.com/Vovarama1992/emelya-go/internal/utils"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/redis/go-redis/v9"
+)
+
+// Сколько ждать следующей заявки с того же номера (LEAD_PHONE_COOLDOWN)
+const defaultPhoneCooldown = 10 * time.Minute
+
+// Ссылки в имени или комментарии — почти всегда рекламный спам
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.|\[url|<a\s)`)
+
+// LeadService — заявки с сайта: приём с формы, работа операторов и регистрация
+// клиента по заявке
+type LeadService struct {
+	repo          ports.LeadRepository
+	redis         *redis.Client
+	tariffService money_ports.TariffService
+	userService   user_ports.UserServiceInterface
+	notifier      notifier.NotifierInterface
+	auditService  audit_ports.AuditService
+	phoneCooldown time.Duration
+}
+
+func NewLeadService(
+	repo ports.LeadRepository,
+	redisClient *redis.Client,
+	tariffService money_ports.TariffService,
+	userService user_ports.UserServiceInterface,
+	notifier notifier.NotifierInterface,
+	auditService audit_ports.AuditService,
+) *LeadService {
+	cooldown := defaultPhoneCooldown
+	if d, err := time.ParseDuration(os.Getenv("LEAD_PHONE_COOLDOWN")); err == nil && d >= 0 {
+		cooldown = d
+	}
+	return &LeadService{
+		repo:          repo,
+		redis:         redisClient,
+		tariffService: tariffService,
+		userService:   userService,
+		notifier:      notifier,
+		auditService:  auditService,
+		phoneCooldown: cooldown,
+	}
+}
+
+func phoneCooldownKey(phone string) string {
+	return fmt.Sprintf("lead:phone:%s", phone)
+}
+
+// Submit принимает заявку с формы. Спам сохраняется для разбора, но операторам
+// не уходит; для формы он выглядит как обычная принятая заявка.
+func (s *LeadService) Submit(ctx context.Context, in model.Submission) error {
+	phone, err := normalizePhone(in.Phone)
+	if err != nil {
+		return err
+	}
+
+	lead := &model.Lead{
+		Name:      strings.TrimSpace(in.Name),
+		Phone:     phone,
+		Email:     strings.TrimSpace(in.Email),
+		Comment:   strings.TrimSpace(in.Comment),
+		Source:    strings.TrimSpace(in.Source),
+		Status:    model.StatusNew,
+		IP:        in.IP,
+		UserAgent: in.UserAgent,
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+
+	if in.TariffID != nil {
+		tariff, err := s.tariffService.FindByID(ctx, *in.TariffID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.ErrTariffNotFound
+		}
+		if err != nil {
+			return err
+		}
+		lead.TariffID = &tariff.ID
+		lead.TariffName = tariff.Name
+	}
+
+	if reason := spamReason(in); reason != "" {
+		lead.Status = model.StatusSpam
+		lead.SpamReason = &reason
+		log.Printf("[LEAD] Заявка с %s помечена как спам (%s)", in.IP, reason)
+		return s.repo.Create(ctx, lead)
+	}
+
+	// Повторная отправка формы не плодит заявки и письма операторам
+	if s.phoneCooldown > 0 {
+		ok, err := s.redis.SetNX(ctx, phoneCooldownKey(phone), 1, s.phoneCooldown).Result()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return model.ErrTooManyLeads
+		}
+	}
+
+	if err := s.repo.Create(ctx, lead); err != nil {
+		if s.phoneCooldown > 0 {
+			_ = s.redis.Del(ctx, phoneCooldownKey(phone)).Err()
+		}
+		return err
+	}
+	return nil
+}
+
+func spamReason(in model.Submission) string {
+	if strings.TrimSpace(in.Honeypot) != "" {
+		return model.SpamHoneypot
+	}
+	if linkPattern.MatchString(in.Name) || linkPattern.MatchString(in.Comment) {
+		return model.SpamLinks
+	}
+	return ""
+}
+
+// Российский номер: 7 или 8 и десять цифр, либо десять цифр мобильного с 9 в начале
+var phonePattern = regexp.MustCompile(`^([78]\d{10}|9\d{9})$`)
+
+// normalizePhone — номер в формате +7XXXXXXXXXX, как у пользователей
+func normalizePhone(raw string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, raw)
+	if !phonePattern.MatchString(digits) {
+		return "", model.ErrInvalidPhone
+	}
+	return utils.NormalizePhone(digits), nil
+}
+
+func (s *LeadService) List(ctx context.Context, f model.Filter) ([]*model.Lead, error) {
+	if f.Limit <= 0 {
+		f.Limit = model.DefaultListLimit
+	}
+	f.Limit = min(f.Limit, model.MaxListLimit)
+	if f.Phone != nil {
+		phone, err := normalizePhone(*f.Phone)
+		if err != nil {
+			return nil, err
+		}
+		f.Phone = &phone
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+
+	list, err := s.repo.List(ctx, f)
+	if list == nil && err == nil {
+		list = []*model.Lead{}
+	}
+	return list, err
+}
+
+func (s *LeadService) Get(ctx context.Context, id int64) (*model.Lead, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	lead, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if lead == nil {
+		return nil, model.ErrLeadNotFound
+	}
+	return lead, nil
+}
+
+// Assign назначает ответственного сотрудника (nil — снять). Новая заявка при
+// назначении переходит в работу.
+func (s *LeadService) Assign(ctx context.Context, actorID, id int64, assigneeID *int64) (*model.Lead, error) {
+	lead, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if lead.Status == model.StatusConverted {
+		return nil, model.ErrAlreadyConverted
+	}
+
+	if assigneeID != nil {
+		assignee, err := s.userService.FindUserByID(ctx, *assigneeID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		if assignee == nil || !assignee.Role.IsStaff() {
+			return nil, model.ErrAssigneeNotStaff
+		}
+	}
+
+	before := *lead
+	lead.AssigneeID = assigneeID
+	if assigneeID != nil && lead.Status == model.StatusNew {
+		lead.Status = model.StatusInProgress
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	if err := s.repo.Update(ctx, lead); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, &audit.Entry{
+		ActorID:    &actorID,
+		Action:     audit.ActionLeadAssign,
+		EntityType: audit.EntityLead,
+		EntityID:   &lead.ID,
+		Before:     audit.Snapshot(before),
+		After:      audit.Snapshot(lead),
+	})
+	return lead, nil
+}
+
+// SetStatus — ручная смена статуса; converted ставится только через Convert
+func (s *LeadService) SetStatus(ctx context.Context, actorID, id int64, status model.Status) (*model.Lead, error) {
+	if !status.IsManual() {
+		return nil, model.ErrInvalidStatus
+	}
+	lead, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if lead.Status == model.StatusConverted {
+		return nil, model.ErrAlreadyConverted
+	}
+
+	before := *lead
+	lead.Status = status
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	if err := s.repo.Update(ctx, lead); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, &audit.Entry{
+		ActorID:    &actorID,
+		Action:     audit.ActionLeadStatus,
+		EntityType: audit.EntityLead,
+		EntityID:   &lead.ID,
+		Before:     audit.Snapshot(before),
+		After:      audit.Snapshot(lead),
+	})
+	return lead, nil
+}
+
+// Convert регистрирует клиента по заявке и присылает ему логин и пароль по SMS.
+// Оператор уже созвонился с клиентом по этому номеру, поэтому телефон считается
+// подтверждённым. Если номер уже зарегистрирован, заявка привязывается к
+// существующему пользователю.
+func (s *LeadService) Convert(ctx context.Context, actorID, id int64, in model.ConvertInput) (*model.Conversion, error) {
+	lead, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if lead.Status == model.StatusConverted {
+		return nil, model.ErrAlreadyConverted
+	}
+
+	user, err := s.userService.FindUserByPhone(ctx, lead.Phone)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	created := user == nil
+	if created {
+		if user, err = s.registerUser(ctx, lead, in); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	converted, err := s.repo.MarkConverted(ctx, lead.ID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, &audit.Entry{
+		ActorID:    &actorID,
+		Action:     audit.ActionLeadConvert,
+		EntityType: audit.EntityLead,
+		EntityID:   &lead.ID,
+		Before:     audit.Snapshot(lead),
+		After:      audit.Snapshot(map[string]any{"lead": converted, "user_id": user.ID, "user_created": created}),
+	})
+	return &model.Conversion{Lead: converted, UserID: user.ID, Login: user.Login, Created: created}, nil
+}
+
+func (s *LeadService) registerUser(ctx context.Context, lead *model.Lead, in model.ConvertInput) (*user_model.User, error) {
+	email := strings.TrimSpace(in.Email)
+	if email == "" {
+		email = lead.Email
+	}
+	if email == "" {
+		return nil, model.ErrEmailRequired
+	}
+
+	password := auth_usecase.GeneratePassword()
+	hash, err := auth_usecase.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	user := &user_model.User{
+		FirstName:  strings.TrimSpace(in.FirstName),
+		LastName:   strings.TrimSpace(in.LastName),
+		Patronymic: strings.TrimSpace(in.Patronymic),
+		Email:      email,
+		Phone:      lead.Phone,
+		Login:      auth_usecase.GenerateLogin(),
+		// Пароль передаётся только клиенту по SMS
+		PasswordHash: hash,
+	}
+
+	var pgErr *pgconn.PgError
+	if err := s.userService.CreateUser(ctx, user); errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return nil, model.ErrEmailTaken
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Подтверждение пишет user.registered: операторы и вебхуки узнают о клиенте
+	// так же, как о зарегистрировавшемся самостоятельно
+	if err := s.userService.ConfirmRegistration(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	if err := s.notifier.SendLoginAndPasswordBySms(user.Phone, user.Login, password); err != nil {
+		log.Printf("[LEAD] Не удалось отправить логин и пароль пользователю %d: %v", user.ID, err)
+	}
+	return user, nil
+}
+
+// record — сбой записи в журнал не отменяет действие с заявкой
+func (s *LeadService) record(ctx context.Context, e *audit.Entry) {
+	if err := s.auditService.Record(ctx, e); err != nil {
+		log.Printf("[LEAD] Не удалось записать журнал %s: %v", e.Action, err)
+	}
+}
@@ -0,0 +1,55 @@
+package lead_usecase
+
+import (
+	"errors"
+	"testing"
+
+	model "github.com/Vovarama1992/emelya-go/internal/lead/model"
+)
+
+func TestSpamReason(t *testing.T) {
+	tests := []struct {
+		name string
+		in   model.Submission
+		want string
+	}{
+		{"обычная заявка", model.Submission{Name: "Иван", Comment: "Перезвоните после обеда"}, ""},
+		{"заполнено скрытое поле", model.Submission{Name: "Иван", Honeypot: "x"}, model.SpamHoneypot},
+		{"пробелы в скрытом поле не в счёт", model.Submission{Name: "Иван", Honeypot: "  "}, ""},
+		{"ссылка в комментарии", model.Submission{Name: "Иван", Comment: "смотрите https://spam.example"}, model.SpamLinks},
+		{"www в имени", model.Submission{Name: "WWW.spam.example"}, model.SpamLinks},
+		{"bbcode", model.Submission{Comment: "[url=http://x]x[/url]"}, model.SpamLinks},
+		{"html-ссылка", model.Submission{Comment: `<a href="x">x</a>`}, model.SpamLinks},
+		{"скрытое поле важнее ссылок", model.Submission{Comment: "http://x", Honeypot: "1"}, model.SpamHoneypot},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := spamReason(tt.in); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+		err  error
+	}{
+		{"+7 (912) 345-67-89", "+79123456789", nil},
+		{"8 912 345 67 89", "+79123456789", nil},
+		{"79123456789", "+79123456789", nil},
+		{"9123456789", "+79123456789", nil},
+		{"8123456789", "", model.ErrInvalidPhone},
+		{"+1 555 123 4567", "", model.ErrInvalidPhone},
+		{"912345678", "", model.ErrInvalidPhone},
+		{"", "", model.ErrInvalidPhone},
+	}
+	for _, tt := range tests {
+		got, err := normalizePhone(tt.raw)
+		if !errors.Is(err, tt.err) || got != tt.want {
+			t.Errorf("normalizePhone(%q): got (%q, %v), want (%q, %v)", tt.raw, got, err, tt.want, tt.err)
+		}
+	}
+}
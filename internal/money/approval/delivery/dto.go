@@ -0,0 +1,10 @@
+package approvalhttp
+
+type ConfirmApprovalRequest struct {
+	ApprovalID int64 `json:"approval_id" validate:"required"`
+}
+
+type RejectApprovalRequest struct {
+	ApprovalID int64  `json:"approval_id" validate:"required"`
+	Reason     string `json:"reason" validate:"required"`
+}
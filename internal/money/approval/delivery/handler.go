@@ -0,0 +1,132 @@
+package approvalhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	service "github.com/Vovarama1992/emelya-go/internal/money/usecase"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type Handler struct {
+	approvalService *service.ApprovalService
+}
+
+func NewHandler(approvalService *service.ApprovalService) *Handler {
+	return &Handler{approvalService: approvalService}
+}
+
+// ListPending godoc
+// @Summary Админ: операции, ожидающие подтверждения вторым администратором
+// @Tags admin-approval
+// @Produce json
+// @Success 200 {array} interface{}
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/approval/pending [get]
+func (h *Handler) ListPending(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	approvals, err := h.approvalService.ListPending(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения заявок")
+		return
+	}
+
+	json.NewEncoder(w).Encode(approvals)
+}
+
+// Confirm godoc
+// @Summary Админ: подтвердить и выполнить операцию другого администратора
+// @Tags admin-approval
+// @Accept json
+// @Produce json
+// @Param data body ConfirmApprovalRequest true "ID заявки"
+// @Success 200 {object} map[string]string
+// @Failure 400,403,404,409,500 {object} map[string]string
+// @Router /api/admin/approval/confirm [post]
+func (h *Handler) Confirm(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req ConfirmApprovalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	if err := h.approvalService.Confirm(r.Context(), req.ApprovalID, admin.ID); err != nil {
+		respondWithServiceError(w, err, "Не удалось выполнить операцию")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Операция подтверждена и выполнена"})
+}
+
+// Reject godoc
+// @Summary Админ: отклонить операцию, ожидающую подтверждения
+// @Tags admin-approval
+// @Accept json
+// @Produce json
+// @Param data body RejectApprovalRequest true "ID заявки и причина"
+// @Success 200 {object} map[string]string
+// @Failure 400,404,409,500 {object} map[string]string
+// @Router /api/admin/approval/reject [post]
+func (h *Handler) Reject(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req RejectApprovalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	if err := h.approvalService.Reject(r.Context(), req.ApprovalID, admin.ID, req.Reason); err != nil {
+		respondWithServiceError(w, err, "Не удалось отклонить операцию")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Операция отклонена"})
+}
+
+// respondWithServiceError переводит ошибки сервиса в HTTP-коды
+func respondWithServiceError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrApprovalNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrSameApprover):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrApprovalNotPending),
+		errors.Is(err, service.ErrDepositAlreadyProcessed),
+		errors.Is(err, service.ErrAlreadyProcessed):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, fallback+": "+err.Error())
+	}
+}
+
+func respondWithError(w http.ResponseWriter, code int, message string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
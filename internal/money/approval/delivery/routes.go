@@ -0,0 +1,32 @@
+package approvalhttp
+
+import (
+	"net/http"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
+	"github.com/Vovarama1992/go-utils/httputil"
+)
+
+func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService ports.UserServiceInterface, sessions middleware.SessionChecker, access middleware.PermissionChecker) {
+	withRecover := func(h http.Handler) http.Handler {
+		return httputil.RecoverMiddleware(h)
+	}
+
+	withPermission := func(perm rbac.Permission, h http.Handler) http.Handler {
+		return middleware.PermissionMiddleware(userService, sessions, access, perm)(h)
+	}
+
+	mux.Handle("/api/admin/approval/pending",
+		withRecover(withPermission(rbac.PermApprovalsManage, http.HandlerFunc(handler.ListPending))),
+	)
+
+	mux.Handle("/api/admin/approval/confirm",
+		withRecover(withPermission(rbac.PermApprovalsManage, http.HandlerFunc(handler.Confirm))),
+	)
+
+	mux.Handle("/api/admin/approval/reject",
+		withRecover(withPermission(rbac.PermApprovalsManage, http.HandlerFunc(handler.Reject))),
+	)
+}
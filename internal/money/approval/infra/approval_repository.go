@@ -0,0 +1,149 @@
+package approval_infra
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	model "github.com/Vovarama1992/emelya-go/internal/money/approval/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Интерфейс для пула и транзакции
+type PgxQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+// Заявка уже не в статусе pending
+var ErrNotPending = errors.New("заявка на подтверждение уже обработана")
+
+// По этой сущности уже есть ожидающая заявка
+var ErrDuplicatePending = errors.New("по этой операции уже есть заявка на подтверждение")
+
+type ApprovalRepository struct {
+	querier PgxQuerier
+}
+
+func NewApprovalRepository(db *db.DB) *ApprovalRepository {
+	return &ApprovalRepository{querier: db.Pool}
+}
+
+func NewApprovalRepositoryWithTx(tx pgx.Tx) *ApprovalRepository {
+	return &ApprovalRepository{querier: tx}
+}
+
+func (r *ApprovalRepository) Create(ctx context.Context, a *model.Approval) error {
+	query := `
+		INSERT INTO pending_approvals (operation, entity_id, amount, payload, status, requested_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+	err := r.querier.QueryRow(ctx, query,
+		a.Operation,
+		a.EntityID,
+		a.Amount,
+		string(a.Payload),
+		a.Status,
+		a.RequestedBy,
+	).Scan(&a.ID, &a.CreatedAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrDuplicatePending
+	}
+	return err
+}
+
+func (r *ApprovalRepository) GetByID(ctx context.Context, id int64) (*model.Approval, error) {
+	query := `
+		SELECT id, operation, entity_id, amount, payload, status, requested_by, decided_by, decided_at, reason, created_at
+		FROM pending_approvals
+		WHERE id = $1
+	`
+	var a model.Approval
+	err := r.querier.QueryRow(ctx, query, id).Scan(
+		&a.ID,
+		&a.Operation,
+		&a.EntityID,
+		&a.Amount,
+		&a.Payload,
+		&a.Status,
+		&a.RequestedBy,
+		&a.DecidedBy,
+		&a.DecidedAt,
+		&a.Reason,
+		&a.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *ApprovalRepository) FindPending(ctx context.Context) ([]*model.Approval, error) {
+	query := `
+		SELECT id, operation, entity_id, amount, payload, status, requested_by, decided_by, decided_at, reason, created_at
+		FROM pending_approvals
+		WHERE status = 'pending'
+		ORDER BY created_at ASC
+	`
+	rows, err := r.querier.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var approvals []*model.Approval
+	for rows.Next() {
+		var a model.Approval
+		if err := rows.Scan(
+			&a.ID,
+			&a.Operation,
+			&a.EntityID,
+			&a.Amount,
+			&a.Payload,
+			&a.Status,
+			&a.RequestedBy,
+			&a.DecidedBy,
+			&a.DecidedAt,
+			&a.Reason,
+			&a.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		approvals = append(approvals, &a)
+	}
+	return approvals, nil
+}
+
+// Decide переводит заявку из pending в итоговый статус
+func (r *ApprovalRepository) Decide(ctx context.Context, id int64, status model.Status, decidedBy int64, reason *string) error {
+	query := `
+		UPDATE pending_approvals
+		SET status = $1, decided_by = $2, decided_at = $3, reason = COALESCE($4, reason)
+		WHERE id = $5 AND status = 'pending'
+	`
+	tag, err := r.querier.Exec(ctx, query, status, decidedBy, time.Now(), reason, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotPending
+	}
+	return nil
+}
+
+// MarkFailed фиксирует, что подтверждённую операцию выполнить не удалось
+func (r *ApprovalRepository) MarkFailed(ctx context.Context, id int64, reason string) error {
+	query := `
+		UPDATE pending_approvals
+		SET status = 'failed', reason = $1
+		WHERE id = $2 AND status = 'confirmed'
+	`
+	_, err := r.querier.Exec(ctx, query, reason, id)
+	return err
+}
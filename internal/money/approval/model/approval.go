@@ -0,0 +1,71 @@
+package approval_model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Operation string
+
+const (
+	OperationDepositApprove    Operation = "deposit.approve"
+	OperationWithdrawalApprove Operation = "withdrawal.approve"
+	OperationDepositCreate     Operation = "deposit.create_by_admin"
+	OperationRewardCreate      Operation = "reward.create_by_admin"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // ждёт второго администратора
+	StatusConfirmed Status = "confirmed" // подтверждена и выполнена
+	StatusRejected  Status = "rejected"  // отклонена
+	StatusFailed    Status = "failed"    // подтверждена, но выполнить не удалось
+)
+
+// Approval — операция, ожидающая подтверждения вторым администратором
+type Approval struct {
+	ID          int64           `json:"id"`
+	Operation   Operation       `json:"operation"`
+	EntityID    *int64          `json:"entity_id,omitempty"`
+	Amount      float64         `json:"amount"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      Status          `json:"status"`
+	RequestedBy int64           `json:"requested_by"`
+	DecidedBy   *int64          `json:"decided_by,omitempty"`
+	DecidedAt   *time.Time      `json:"decided_at,omitempty"`
+	Reason      *string         `json:"reason,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// Параметры отложенных операций, хранятся в Payload
+
+type DepositApproveParams struct {
+	DepositID   int64     `json:"deposit_id"`
+	ApprovedAt  time.Time `json:"approved_at"`
+	BlockDays   *int      `json:"block_days,omitempty"`
+	DailyReward *float64  `json:"daily_reward,omitempty"`
+	TariffID    *int64    `json:"tariff_id,omitempty"`
+}
+
+type WithdrawalApproveParams struct {
+	WithdrawalID int64 `json:"withdrawal_id"`
+}
+
+type DepositCreateParams struct {
+	UserID              int64      `json:"user_id"`
+	Amount              float64    `json:"amount"`
+	CreatedAt           time.Time  `json:"created_at"`
+	ApprovedAt          *time.Time `json:"approved_at,omitempty"`
+	BlockDays           *int       `json:"block_days,omitempty"`
+	DailyReward         *float64   `json:"daily_reward,omitempty"`
+	TariffID            *int64     `json:"tariff_id,omitempty"`
+	InitialRewardAmount *float64   `json:"initial_reward_amount,omitempty"`
+	Reason              *string    `json:"reason,omitempty"`
+}
+
+type RewardCreateParams struct {
+	UserID int64   `json:"user_id"`
+	Amount float64 `json:"amount"`
+	Reason *string `json:"reason,omitempty"`
+}
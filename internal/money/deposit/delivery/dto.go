@@ -12,4 +12,14 @@ type AdminCreateDepositRequest struct {
 	DailyReward         *float64 `json:"daily_reward,omitempty"`
 	TariffID            *int64   `json:"tariff_id,omitempty"`
 	InitialRewardAmount *float64 `json:"initial_reward_amount,omitempty"`
+	Reason              *string  `json:"reason,omitempty"`
+}
+
+type CancelDepositRequest struct {
+	DepositID int64 `json:"deposit_id" validate:"required"`
+}
+
+type AdminRejectDepositRequest struct {
+	DepositID int64  `json:"deposit_id" validate:"required"`
+	Reason    string `json:"reason" validate:"required"`
 }
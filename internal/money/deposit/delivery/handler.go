@@ -2,11 +2,13 @@ package deposithttp
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
-	"github.com/Vovarama1992/emelya-go/internal/jwtutil"
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	approval_model "github.com/Vovarama1992/emelya-go/internal/money/approval/model"
 	service "github.com/Vovarama1992/emelya-go/internal/money/usecase"
 	"github.com/go-playground/validator/v10"
 )
@@ -14,12 +16,14 @@ import (
 var validate = validator.New()
 
 type Handler struct {
-	depositService *service.DepositService
+	depositService  *service.DepositService
+	approvalService *service.ApprovalService
 }
 
-func NewHandler(depositService *service.DepositService) *Handler {
+func NewHandler(depositService *service.DepositService, approvalService *service.ApprovalService) *Handler {
 	return &Handler{
-		depositService: depositService,
+		depositService:  depositService,
+		approvalService: approvalService,
 	}
 }
 
@@ -30,7 +34,7 @@ func NewHandler(depositService *service.DepositService) *Handler {
 // @Produce json
 // @Param data body DepositCreateRequest true "Сумма депозита"
 // @Success 200 {object} map[string]string
-// @Failure 400,401,500 {object} map[string]string
+// @Failure 400,401,403,500 {object} map[string]string
 // @Router /api/deposit/create [post]
 func (h *Handler) CreateDeposit(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -38,16 +42,7 @@ func (h *Handler) CreateDeposit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authHeader := r.Header.Get("Authorization")
-	if authHeader == "" {
-		respondWithError(w, http.StatusUnauthorized, "Токен отсутствует")
-		return
-	}
-	userID, err := jwtutil.ParseToken(authHeader[len("Bearer "):])
-	if err != nil {
-		respondWithError(w, http.StatusUnauthorized, "Неверный токен")
-		return
-	}
+	userID := middleware.GetUserFromContext(r.Context()).ID
 
 	var req DepositCreateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -60,8 +55,8 @@ func (h *Handler) CreateDeposit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.depositService.CreateDeposit(r.Context(), int64(userID), req.Amount); err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Не удалось создать депозит")
+	if err := h.depositService.CreateDeposit(r.Context(), userID, req.Amount); err != nil {
+		respondWithServiceError(w, err, "Не удалось создать депозит")
 		return
 	}
 
@@ -79,7 +74,8 @@ func (h *Handler) CreateDeposit(w http.ResponseWriter, r *http.Request) {
 // @Param daily_reward query number false "Дневная награда"
 // @Param tariff_id query int false "ID тарифа"
 // @Success 200 {object} map[string]string
-// @Failure 400,500 {object} map[string]string
+// @Success 202 {object} map[string]interface{} "approval_id"
+// @Failure 400,404,409,500 {object} map[string]string
 // @Router /api/admin/deposit/approve [post]
 func (h *Handler) ApproveDeposit(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -135,8 +131,20 @@ func (h *Handler) ApproveDeposit(w http.ResponseWriter, r *http.Request) {
 		tariffID = &v
 	}
 
-	if err := h.depositService.ApproveDeposit(r.Context(), id, approvedAt, blockDays, dailyReward, tariffID); err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Не удалось одобрить депозит")
+	admin := middleware.GetUserFromContext(r.Context())
+	approval, err := h.approvalService.SubmitDepositApprove(r.Context(), approval_model.DepositApproveParams{
+		DepositID:   id,
+		ApprovedAt:  approvedAt,
+		BlockDays:   blockDays,
+		DailyReward: dailyReward,
+		TariffID:    tariffID,
+	}, admin.ID)
+	if err != nil {
+		respondWithServiceError(w, err, "Не удалось одобрить депозит")
+		return
+	}
+	if approval != nil {
+		respondApprovalRequired(w, approval)
 		return
 	}
 
@@ -186,24 +194,137 @@ func (h *Handler) GetUserDeposits(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authHeader := r.Header.Get("Authorization")
-	if authHeader == "" {
-		respondWithError(w, http.StatusUnauthorized, "Токен отсутствует")
+	userID := middleware.GetUserFromContext(r.Context()).ID
+
+	deposits, err := h.depositService.GetDepositsByUserID(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения депозитов")
 		return
 	}
-	userID, err := jwtutil.ParseToken(authHeader[len("Bearer "):])
-	if err != nil {
-		respondWithError(w, http.StatusUnauthorized, "Неверный токен")
+
+	json.NewEncoder(w).Encode(deposits)
+}
+
+// CancelDeposit godoc
+// @Summary Юзер: отменить свою заявку на депозит (только pending)
+// @Tags deposit
+// @Accept json
+// @Produce json
+// @Param data body CancelDepositRequest true "ID депозита"
+// @Success 200 {object} map[string]string
+// @Failure 400,401,404,409,500 {object} map[string]string
+// @Router /api/deposit/cancel [post]
+func (h *Handler) CancelDeposit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req CancelDepositRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	if err := h.depositService.CancelDeposit(r.Context(), user.ID, req.DepositID); err != nil {
+		respondWithServiceError(w, err, "Не удалось отменить заявку")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Заявка на депозит отменена"})
+}
+
+// AdminRejectDeposit godoc
+// @Summary Админ: отклонить заявку на депозит
+// @Tags admin-deposit
+// @Accept json
+// @Produce json
+// @Param data body AdminRejectDepositRequest true "ID депозита и причина"
+// @Success 200 {object} map[string]string
+// @Failure 400,404,409,500 {object} map[string]string
+// @Router /api/admin/deposit/reject [post]
+func (h *Handler) AdminRejectDeposit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req AdminRejectDepositRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
 		return
 	}
 
-	deposits, err := h.depositService.GetDepositsByUserID(r.Context(), int64(userID))
+	admin := middleware.GetUserFromContext(r.Context())
+	if err := h.depositService.RejectDeposit(r.Context(), req.DepositID, admin.ID, req.Reason); err != nil {
+		respondWithServiceError(w, err, "Не удалось отклонить депозит")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Депозит отклонён"})
+}
+
+// AdminGetDepositHistory godoc
+// @Summary Админ: история смены статусов депозита
+// @Tags admin-deposit
+// @Produce json
+// @Param id query int true "ID депозита"
+// @Success 200 {array} interface{}
+// @Failure 400,500 {object} map[string]string
+// @Router /api/admin/deposit/history [get]
+func (h *Handler) AdminGetDepositHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil || id <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Некорректный id")
+		return
+	}
+
+	history, err := h.depositService.GetDepositHistory(r.Context(), id)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Ошибка получения депозитов")
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения истории")
 		return
 	}
 
-	json.NewEncoder(w).Encode(deposits)
+	json.NewEncoder(w).Encode(history)
+}
+
+// respondWithServiceError переводит ошибки сервиса в HTTP-коды
+func respondWithServiceError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrDepositNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrDepositAlreadyProcessed),
+		errors.Is(err, service.ErrDepositInvalidTransition),
+		errors.Is(err, service.ErrApprovalDuplicate):
+		respondWithError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrKYCLimitExceeded):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// respondApprovalRequired — операция выше порога ждёт второго администратора
+func respondApprovalRequired(w http.ResponseWriter, approval *approval_model.Approval) {
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message":     "Операция ждёт подтверждения второго администратора",
+		"approval_id": approval.ID,
+	})
 }
 
 func respondWithError(w http.ResponseWriter, code int, message string) {
@@ -263,8 +384,9 @@ func (h *Handler) CloseDeposit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.depositService.CloseDeposit(r.Context(), id); err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Не удалось закрыть депозит")
+	admin := middleware.GetUserFromContext(r.Context())
+	if err := h.depositService.CloseDeposit(r.Context(), id, admin.ID); err != nil {
+		respondWithServiceError(w, err, "Не удалось закрыть депозит")
 		return
 	}
 
@@ -279,6 +401,7 @@ func (h *Handler) CloseDeposit(w http.ResponseWriter, r *http.Request) {
 // @Param user_id query int true "ID инвестора"
 // @Param data body AdminCreateDepositRequest true "Данные депозита"
 // @Success 200 {object} map[string]interface{} "deposit_id"
+// @Success 202 {object} map[string]interface{} "approval_id"
 // @Failure 400,500 {object} map[string]string
 // @Router /api/admin/deposit/create [post]
 func (h *Handler) AdminCreateDeposit(w http.ResponseWriter, r *http.Request) {
@@ -340,21 +463,25 @@ func (h *Handler) AdminCreateDeposit(w http.ResponseWriter, r *http.Request) {
 		initialRewardAmount = req.InitialRewardAmount
 	}
 
-	id, err := h.depositService.CreateDepositByAdmin(
-		r.Context(),
-		userID,
-		req.Amount,
-		createdAt,
-		approvedAt,
-		blockDays,
-		dailyReward,
-		tariffID,
-		initialRewardAmount,
-	)
+	approval, id, err := h.approvalService.SubmitDepositCreate(r.Context(), approval_model.DepositCreateParams{
+		UserID:              userID,
+		Amount:              req.Amount,
+		CreatedAt:           createdAt,
+		ApprovedAt:          approvedAt,
+		BlockDays:           blockDays,
+		DailyReward:         dailyReward,
+		TariffID:            tariffID,
+		InitialRewardAmount: initialRewardAmount,
+		Reason:              req.Reason,
+	}, middleware.GetUserFromContext(r.Context()).ID)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	if approval != nil {
+		respondApprovalRequired(w, approval)
+		return
+	}
 
 	json.NewEncoder(w).Encode(map[string]any{
 		"message":    "Депозит создан",
@@ -363,11 +490,12 @@ func (h *Handler) AdminCreateDeposit(w http.ResponseWriter, r *http.Request) {
 }
 
 // AdminDeleteDeposit godoc
-// @Summary Админ: удалить депозит (ручное удаление)
+// @Summary Админ: удалить депозит (мягкое удаление, остаётся в журнале)
 // @Tags admin-deposit
 // @Accept json
 // @Produce json
 // @Param deposit_id query int true "ID депозита"
+// @Param reason query string false "Причина удаления"
 // @Success 200 {object} map[string]string
 // @Failure 400,500 {object} map[string]string
 // @Router /api/admin/deposit/delete [delete]
@@ -384,8 +512,14 @@ func (h *Handler) AdminDeleteDeposit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.depositService.DeleteDepositByAdmin(r.Context(), depositID); err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Не удалось удалить депозит")
+	var reason *string
+	if v := r.URL.Query().Get("reason"); v != "" {
+		reason = &v
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	if err := h.depositService.DeleteDepositByAdmin(r.Context(), depositID, admin.ID, reason); err != nil {
+		respondWithServiceError(w, err, "Не удалось удалить депозит")
 		return
 	}
 
@@ -5,11 +5,12 @@ import (
 	"time"
 
 	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
 	ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
 	"github.com/Vovarama1992/go-utils/httputil"
 )
 
-func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService ports.UserServiceInterface) {
+func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService ports.UserServiceInterface, sessions middleware.SessionChecker, access middleware.PermissionChecker) {
 	withRecover := func(h http.Handler) http.Handler {
 		return httputil.RecoverMiddleware(h)
 	}
@@ -18,47 +19,65 @@ func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService ports.User
 		return httputil.RecoverMiddleware(httputil.NewRateLimiter(3, time.Minute)(h))
 	}
 
-	withAdminAuth := func(h http.Handler) http.Handler {
-		return middleware.AuthMiddleware(userService, true)(h)
+	withUserAuth := func(h http.Handler) http.Handler {
+		return middleware.AuthMiddleware(userService, sessions)(h)
 	}
 
-	// === PUBLIC ===
+	withPermission := func(perm rbac.Permission, h http.Handler) http.Handler {
+		return middleware.PermissionMiddleware(userService, sessions, access, perm)(h)
+	}
+
+	// === USER ===
 	mux.Handle("/api/deposit/create",
-		withRecoverAndRateLimit(http.HandlerFunc(handler.CreateDeposit)),
+		withRecoverAndRateLimit(withUserAuth(http.HandlerFunc(handler.CreateDeposit))),
 	)
 
 	mux.Handle("/api/deposit/my",
-		withRecover(http.HandlerFunc(handler.GetUserDeposits)),
+		withRecover(withUserAuth(http.HandlerFunc(handler.GetUserDeposits))),
+	)
+
+	mux.Handle("/api/deposit/cancel",
+		withRecoverAndRateLimit(withUserAuth(http.HandlerFunc(handler.CancelDeposit))),
 	)
 
 	// === ADMIN ===
 	mux.Handle("/api/admin/deposit/approve",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.ApproveDeposit))),
+		withRecover(withPermission(rbac.PermDepositsManage, http.HandlerFunc(handler.ApproveDeposit))),
+	)
+
+	mux.Handle("/api/admin/deposit/reject",
+		withRecover(withPermission(rbac.PermDepositsManage, http.HandlerFunc(handler.AdminRejectDeposit))),
+	)
+
+	mux.Handle("/api/admin/deposit/history",
+		withRecover(withPermission(rbac.PermDepositsRead, http.HandlerFunc(handler.AdminGetDepositHistory))),
 	)
 
 	mux.Handle("/api/admin/deposit/get",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.GetDepositByID))),
+		withRecover(withPermission(rbac.PermDepositsRead, http.HandlerFunc(handler.GetDepositByID))),
 	)
 
 	mux.Handle("/api/admin/deposit/by-user",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.GetDepositsByUserID))),
+		withRecover(withPermission(rbac.PermDepositsRead, http.HandlerFunc(handler.GetDepositsByUserID))),
 	)
 
 	mux.Handle("/api/admin/deposit/close",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.CloseDeposit))),
+		withRecover(withPermission(rbac.PermDepositsManage, http.HandlerFunc(handler.CloseDeposit))),
 	)
 
 	mux.Handle("/api/admin/deposit/create",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.AdminCreateDeposit))),
+		withRecover(withPermission(rbac.PermDepositsManage, http.HandlerFunc(handler.AdminCreateDeposit))),
 	)
 
 	mux.Handle("/api/admin/deposit/delete",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.AdminDeleteDeposit))),
+		withRecover(withPermission(rbac.PermDepositsDelete, http.HandlerFunc(handler.AdminDeleteDeposit))),
 	)
 
 	mux.Handle("/api/admin/deposit/pending",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.ListPendingDeposits))),
+		withRecover(withPermission(rbac.PermDepositsRead, http.HandlerFunc(handler.ListPendingDeposits))),
 	)
 
-	mux.Handle("/api/admin/deposit/total-approved-amount", http.HandlerFunc(handler.GetTotalApprovedAmount))
+	mux.Handle("/api/admin/deposit/total-approved-amount",
+		withRecover(withPermission(rbac.PermFinanceRead, http.HandlerFunc(handler.GetTotalApprovedAmount))),
+	)
 }
@@ -2,6 +2,7 @@ package deposit_infra
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/Vovarama1992/emelya-go/internal/db"
@@ -17,6 +18,9 @@ type PgxQuerier interface {
 	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
 }
 
+// Статус депозита уже изменился с момента чтения
+var ErrStatusMismatch = errors.New("статус депозита изменился")
+
 type DepositRepository struct {
 	querier PgxQuerier
 }
@@ -42,17 +46,87 @@ func (r *DepositRepository) Approve(ctx context.Context, id int64, approvedAt ti
 	query := `
 		UPDATE deposits
 		SET approved_at = $1, block_days = $2, daily_reward = $3, status = 'approved'
-		WHERE id = $4
+		WHERE id = $4 AND status = 'pending' AND deleted_at IS NULL
+	`
+	tag, err := r.querier.Exec(ctx, query, approvedAt, blockDays, dailyReward, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrStatusMismatch
+	}
+	return nil
+}
+
+// UpdateStatus меняет статус только если депозит всё ещё в статусе from
+func (r *DepositRepository) UpdateStatus(ctx context.Context, id int64, from, to model.Status, reason *string) error {
+	query := `
+		UPDATE deposits
+		SET status = $1, reason = COALESCE($2, reason)
+		WHERE id = $3 AND status = $4 AND deleted_at IS NULL
+	`
+	tag, err := r.querier.Exec(ctx, query, to, reason, id, from)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrStatusMismatch
+	}
+	return nil
+}
+
+func (r *DepositRepository) AddTransition(ctx context.Context, t *model.Transition) error {
+	query := `
+		INSERT INTO deposit_transitions (deposit_id, from_status, to_status, actor_id, reason)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
 	`
-	_, err := r.querier.Exec(ctx, query, approvedAt, blockDays, dailyReward, id)
-	return err
+	return r.querier.QueryRow(ctx, query,
+		t.DepositID,
+		t.FromStatus,
+		t.ToStatus,
+		t.ActorID,
+		t.Reason,
+	).Scan(&t.ID, &t.CreatedAt)
+}
+
+func (r *DepositRepository) FindTransitions(ctx context.Context, depositID int64) ([]*model.Transition, error) {
+	query := `
+		SELECT id, deposit_id, from_status, to_status, actor_id, reason, created_at
+		FROM deposit_transitions
+		WHERE deposit_id = $1
+		ORDER BY created_at ASC, id ASC
+	`
+	rows, err := r.querier.Query(ctx, query, depositID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transitions []*model.Transition
+	for rows.Next() {
+		var t model.Transition
+		if err := rows.Scan(
+			&t.ID,
+			&t.DepositID,
+			&t.FromStatus,
+			&t.ToStatus,
+			&t.ActorID,
+			&t.Reason,
+			&t.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		transitions = append(transitions, &t)
+	}
+	return transitions, nil
 }
 
 func (r *DepositRepository) FindByID(ctx context.Context, id int64) (*model.Deposit, error) {
 	query := `
-		SELECT id, user_id, amount, created_at, approved_at, block_days, daily_reward, status
+		SELECT id, user_id, amount, created_at, approved_at, block_days, daily_reward, status, reason
 		FROM deposits
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 	var d model.Deposit
 	err := r.querier.QueryRow(ctx, query, id).Scan(
@@ -64,6 +138,7 @@ func (r *DepositRepository) FindByID(ctx context.Context, id int64) (*model.Depo
 		&d.BlockDays,
 		&d.DailyReward,
 		&d.Status,
+		&d.Reason,
 	)
 	if err != nil {
 		return nil, err
@@ -73,9 +148,9 @@ func (r *DepositRepository) FindByID(ctx context.Context, id int64) (*model.Depo
 
 func (r *DepositRepository) FindByUserID(ctx context.Context, userID int64) ([]*model.Deposit, error) {
 	query := `
-		SELECT id, user_id, amount, created_at, approved_at, block_days, daily_reward, status
+		SELECT id, user_id, amount, created_at, approved_at, block_days, daily_reward, status, reason
 		FROM deposits
-		WHERE user_id = $1
+		WHERE user_id = $1 AND deleted_at IS NULL
 		ORDER BY created_at DESC
 	`
 	rows, err := r.querier.Query(ctx, query, userID)
@@ -96,6 +171,7 @@ func (r *DepositRepository) FindByUserID(ctx context.Context, userID int64) ([]*
 			&d.BlockDays,
 			&d.DailyReward,
 			&d.Status,
+			&d.Reason,
 		); err != nil {
 			return nil, err
 		}
@@ -104,21 +180,46 @@ func (r *DepositRepository) FindByUserID(ctx context.Context, userID int64) ([]*
 	return deposits, nil
 }
 
-func (r *DepositRepository) Close(ctx context.Context, id int64) error {
+// FindCreatedBetween — депозиты, созданные за [from, to), для выгрузки бухгалтерии
+func (r *DepositRepository) FindCreatedBetween(ctx context.Context, from, to time.Time) ([]*model.Deposit, error) {
 	query := `
-		UPDATE deposits
-		SET status = 'closed'
-		WHERE id = $1
+		SELECT id, user_id, amount, created_at, approved_at, block_days, daily_reward, status, reason
+		FROM deposits
+		WHERE created_at >= $1 AND created_at < $2 AND deleted_at IS NULL
+		ORDER BY created_at
 	`
-	_, err := r.querier.Exec(ctx, query, id)
-	return err
+	rows, err := r.querier.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deposits []*model.Deposit
+	for rows.Next() {
+		var d model.Deposit
+		if err := rows.Scan(
+			&d.ID,
+			&d.UserID,
+			&d.Amount,
+			&d.CreatedAt,
+			&d.ApprovedAt,
+			&d.BlockDays,
+			&d.DailyReward,
+			&d.Status,
+			&d.Reason,
+		); err != nil {
+			return nil, err
+		}
+		deposits = append(deposits, &d)
+	}
+	return deposits, rows.Err()
 }
 
 func (r *DepositRepository) FindPending(ctx context.Context) ([]*model.Deposit, error) {
 	query := `
-		SELECT id, user_id, amount, created_at, approved_at, block_days, daily_reward, status
+		SELECT id, user_id, amount, created_at, approved_at, block_days, daily_reward, status, reason
 		FROM deposits
-		WHERE status = 'pending'
+		WHERE status = 'pending' AND deleted_at IS NULL
 		ORDER BY created_at DESC
 	`
 	rows, err := r.querier.Query(ctx, query)
@@ -139,6 +240,7 @@ func (r *DepositRepository) FindPending(ctx context.Context) ([]*model.Deposit,
 			&d.BlockDays,
 			&d.DailyReward,
 			&d.Status,
+			&d.Reason,
 		); err != nil {
 			return nil, err
 		}
@@ -166,9 +268,9 @@ func (r *DepositRepository) CreateApproved(ctx context.Context, d *model.Deposit
 
 func (r *DepositRepository) FindAllApproved(ctx context.Context) ([]*model.Deposit, error) {
 	query := `
-		SELECT id, user_id, amount, created_at, approved_at, block_days, daily_reward, status
+		SELECT id, user_id, amount, created_at, approved_at, block_days, daily_reward, status, reason
 		FROM deposits
-		WHERE status = 'approved'
+		WHERE status = 'approved' AND deleted_at IS NULL
 		ORDER BY created_at DESC
 	`
 	rows, err := r.querier.Query(ctx, query)
@@ -189,6 +291,7 @@ func (r *DepositRepository) FindAllApproved(ctx context.Context) ([]*model.Depos
 			&d.BlockDays,
 			&d.DailyReward,
 			&d.Status,
+			&d.Reason,
 		); err != nil {
 			return nil, err
 		}
@@ -197,15 +300,26 @@ func (r *DepositRepository) FindAllApproved(ctx context.Context) ([]*model.Depos
 	return deposits, nil
 }
 
-func (r *DepositRepository) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM deposits WHERE id = $1`
-	_, err := r.querier.Exec(ctx, query, id)
-	return err
+// SoftDelete скрывает депозит из всех выборок, но оставляет его в базе для истории
+func (r *DepositRepository) SoftDelete(ctx context.Context, id int64, deletedBy int64) error {
+	query := `
+		UPDATE deposits
+		SET deleted_at = now(), deleted_by = $1
+		WHERE id = $2 AND deleted_at IS NULL
+	`
+	tag, err := r.querier.Exec(ctx, query, deletedBy, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
 }
 
 func (r *DepositRepository) GetTotalApprovedAmount(ctx context.Context) (float64, error) {
 	var total float64
-	query := `SELECT COALESCE(SUM(amount), 0) FROM deposits WHERE status = 'approved'`
+	query := `SELECT COALESCE(SUM(amount), 0) FROM deposits WHERE status = 'approved' AND deleted_at IS NULL`
 	err := r.querier.QueryRow(ctx, query).Scan(&total)
 	if err != nil {
 		return 0, err
@@ -213,11 +327,22 @@ func (r *DepositRepository) GetTotalApprovedAmount(ctx context.Context) (float64
 	return total, nil
 }
 
+// SumActiveByUserID — сумма депозитов пользователя на рассмотрении и активных
+func (r *DepositRepository) SumActiveByUserID(ctx context.Context, userID int64) (float64, error) {
+	var total float64
+	query := `
+		SELECT COALESCE(SUM(amount), 0) FROM deposits
+		WHERE user_id = $1 AND status IN ('pending', 'approved') AND deleted_at IS NULL
+	`
+	err := r.querier.QueryRow(ctx, query, userID).Scan(&total)
+	return total, err
+}
+
 func (r *DepositRepository) FindApprovedByUserID(ctx context.Context, userID int64) ([]*model.Deposit, error) {
 	query := `
-		SELECT id, user_id, amount, created_at, approved_at, block_days, daily_reward, status
+		SELECT id, user_id, amount, created_at, approved_at, block_days, daily_reward, status, reason
 		FROM deposits
-		WHERE user_id = $1 AND status = 'approved'
+		WHERE user_id = $1 AND status = 'approved' AND deleted_at IS NULL
 		ORDER BY created_at DESC
 	`
 	rows, err := r.querier.Query(ctx, query, userID)
@@ -238,6 +363,7 @@ func (r *DepositRepository) FindApprovedByUserID(ctx context.Context, userID int
 			&d.BlockDays,
 			&d.DailyReward,
 			&d.Status,
+			&d.Reason,
 		); err != nil {
 			return nil, err
 		}
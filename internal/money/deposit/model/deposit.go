@@ -9,11 +9,28 @@ type Status string
 type TarifType string
 
 const (
-	StatusPending  Status = "pending"  // ожидает подтверждения
-	StatusApproved Status = "approved" // активный
-	StatusClosed   Status = "closed"   // закрыт, разблокирован
+	StatusPending   Status = "pending"   // ожидает подтверждения
+	StatusApproved  Status = "approved"  // активный
+	StatusClosed    Status = "closed"    // закрыт, разблокирован
+	StatusRejected  Status = "rejected"  // отклонён оператором
+	StatusCancelled Status = "cancelled" // отменён пользователем
 )
 
+// Разрешённые переходы между статусами депозита
+var transitions = map[Status][]Status{
+	StatusPending:  {StatusApproved, StatusRejected, StatusCancelled},
+	StatusApproved: {StatusClosed},
+}
+
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
 type Deposit struct {
 	ID          int64      `json:"id"`
 	UserID      int64      `json:"user_id"`
@@ -23,4 +40,16 @@ type Deposit struct {
 	BlockDays   *int       `json:"block_days,omitempty"`
 	DailyReward *float64   `json:"daily_reward,omitempty"`
 	Status      Status     `json:"status"`
+	Reason      *string    `json:"reason,omitempty"`
+}
+
+// Transition — запись в истории смены статусов депозита
+type Transition struct {
+	ID         int64     `json:"id"`
+	DepositID  int64     `json:"deposit_id"`
+	FromStatus Status    `json:"from_status"`
+	ToStatus   Status    `json:"to_status"`
+	ActorID    *int64    `json:"actor_id,omitempty"`
+	Reason     *string   `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
 }
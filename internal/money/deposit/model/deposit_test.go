@@ -0,0 +1,28 @@
+package model_deposit
+
+import "testing"
+
+func TestStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to Status
+		want     bool
+	}{
+		{StatusPending, StatusApproved, true},
+		{StatusPending, StatusRejected, true},
+		{StatusPending, StatusCancelled, true},
+		{StatusPending, StatusClosed, false},
+		{StatusApproved, StatusClosed, true},
+		{StatusApproved, StatusRejected, false},
+		{StatusApproved, StatusCancelled, false},
+		// Конечные статусы
+		{StatusClosed, StatusApproved, false},
+		{StatusRejected, StatusApproved, false},
+		{StatusCancelled, StatusPending, false},
+		{StatusPending, StatusPending, false},
+	}
+	for _, tt := range tests {
+		if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+			t.Errorf("%s -> %s: got %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
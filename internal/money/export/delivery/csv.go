@@ -0,0 +1,57 @@
+package exporthttp
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// writeCSV отдаёт выгрузку файлом. BOM нужен Excel, чтобы он открыл UTF-8 без
+// мастера импорта.
+func writeCSV(w http.ResponseWriter, name string, from, to time.Time, header []string, rows [][]string) {
+	filename := fmt.Sprintf("%s_%s_%s.csv", name, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.Write([]byte("\ufeff"))
+
+	cw := csv.NewWriter(w)
+	cw.Write(header)
+	cw.WriteAll(rows)
+}
+
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatOptTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatTime(*t)
+}
+
+func formatOptInt(v *int64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatInt(*v, 10)
+}
+
+// formatText — произвольный текст (причины отказа). Ячейку, которая начинается
+// с =, +, - или @, табличный редактор примет за формулу, поэтому экранируем её.
+func formatText(s *string) string {
+	if s == nil {
+		return ""
+	}
+	if *s != "" && strings.ContainsRune("=+-@\t\r", rune((*s)[0])) {
+		return "'" + *s
+	}
+	return *s
+}
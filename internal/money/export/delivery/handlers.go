@@ -0,0 +1,207 @@
+package exporthttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	service "github.com/Vovarama1992/emelya-go/internal/money/usecase"
+)
+
+type Handler struct {
+	exportService *service.ExportService
+}
+
+func NewHandler(exportService *service.ExportService) *Handler {
+	return &Handler{exportService: exportService}
+}
+
+// ExportDeposits godoc
+// @Summary Админ: выгрузка депозитов в CSV
+// @Tags admin-finance
+// @Produce text/csv
+// @Param from query string false "Начало периода (RFC3339), по умолчанию 30 дней назад"
+// @Param to query string false "Конец периода (RFC3339), по умолчанию сейчас; не больше года от начала"
+// @Success 200 {file} file
+// @Failure 400,500 {object} map[string]string
+// @Router /api/admin/finance/export/deposits [get]
+func (h *Handler) ExportDeposits(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+	from, to, ok := parsePeriod(w, r)
+	if !ok {
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	list, err := h.exportService.ExportDeposits(r.Context(), admin.ID, from, to)
+	if err != nil {
+		respondWithServiceError(w, err, "Не удалось выгрузить депозиты")
+		return
+	}
+
+	rows := make([][]string, 0, len(list))
+	for _, d := range list {
+		var blockDays, dailyReward string
+		if d.BlockDays != nil {
+			blockDays = strconv.Itoa(*d.BlockDays)
+		}
+		if d.DailyReward != nil {
+			dailyReward = formatAmount(*d.DailyReward)
+		}
+		rows = append(rows, []string{
+			strconv.FormatInt(d.ID, 10),
+			strconv.FormatInt(d.UserID, 10),
+			formatAmount(d.Amount),
+			string(d.Status),
+			formatTime(d.CreatedAt),
+			formatOptTime(d.ApprovedAt),
+			blockDays,
+			dailyReward,
+			formatText(d.Reason),
+		})
+	}
+	writeCSV(w, "deposits", from, to,
+		[]string{"id", "user_id", "amount", "status", "created_at", "approved_at", "block_days", "daily_reward", "reason"},
+		rows)
+}
+
+// ExportWithdrawals godoc
+// @Summary Админ: выгрузка заявок на вывод в CSV
+// @Tags admin-finance
+// @Produce text/csv
+// @Param from query string false "Начало периода (RFC3339), по умолчанию 30 дней назад"
+// @Param to query string false "Конец периода (RFC3339), по умолчанию сейчас; не больше года от начала"
+// @Success 200 {file} file
+// @Failure 400,500 {object} map[string]string
+// @Router /api/admin/finance/export/withdrawals [get]
+func (h *Handler) ExportWithdrawals(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+	from, to, ok := parsePeriod(w, r)
+	if !ok {
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	list, err := h.exportService.ExportWithdrawals(r.Context(), admin.ID, from, to)
+	if err != nil {
+		respondWithServiceError(w, err, "Не удалось выгрузить заявки на вывод")
+		return
+	}
+
+	rows := make([][]string, 0, len(list))
+	for _, wd := range list {
+		rows = append(rows, []string{
+			strconv.FormatInt(wd.ID, 10),
+			strconv.FormatInt(wd.UserID, 10),
+			strconv.FormatInt(wd.RewardID, 10),
+			formatOptInt(wd.PayoutMethodID),
+			formatAmount(wd.Amount),
+			string(wd.Status),
+			formatTime(wd.CreatedAt),
+			formatOptTime(wd.ApprovedAt),
+			formatOptTime(wd.RejectedAt),
+			formatText(wd.Reason),
+		})
+	}
+	writeCSV(w, "withdrawals", from, to,
+		[]string{"id", "user_id", "reward_id", "payout_method_id", "amount", "status", "created_at", "approved_at", "rejected_at", "reason"},
+		rows)
+}
+
+// ExportRewards godoc
+// @Summary Админ: выгрузка наград в CSV
+// @Tags admin-finance
+// @Produce text/csv
+// @Param from query string false "Начало периода (RFC3339), по умолчанию 30 дней назад"
+// @Param to query string false "Конец периода (RFC3339), по умолчанию сейчас; не больше года от начала"
+// @Success 200 {file} file
+// @Failure 400,500 {object} map[string]string
+// @Router /api/admin/finance/export/rewards [get]
+func (h *Handler) ExportRewards(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+	from, to, ok := parsePeriod(w, r)
+	if !ok {
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	list, err := h.exportService.ExportRewards(r.Context(), admin.ID, from, to)
+	if err != nil {
+		respondWithServiceError(w, err, "Не удалось выгрузить награды")
+		return
+	}
+
+	rows := make([][]string, 0, len(list))
+	for _, rw := range list {
+		rows = append(rows, []string{
+			strconv.FormatInt(rw.ID, 10),
+			strconv.FormatInt(rw.UserID, 10),
+			formatOptInt(rw.DepositID),
+			string(rw.Type),
+			formatAmount(rw.Amount),
+			formatAmount(rw.Withdrawn),
+			formatTime(rw.CreatedAt),
+			formatOptTime(rw.LastAccruedAt),
+		})
+	}
+	writeCSV(w, "rewards", from, to,
+		[]string{"id", "user_id", "deposit_id", "type", "amount", "withdrawn", "created_at", "last_accrued_at"},
+		rows)
+}
+
+// parsePeriod читает from и to и подставляет период по умолчанию, чтобы имя
+// файла совпадало с выгруженными данными
+func parsePeriod(w http.ResponseWriter, r *http.Request) (time.Time, time.Time, bool) {
+	q := r.URL.Query()
+	var from, to time.Time
+
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Некорректный from")
+			return from, to, false
+		}
+		from = t
+	}
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Некорректный to")
+			return from, to, false
+		}
+		to = t
+	}
+
+	from, to, err := service.ExportPeriod(from, to)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return from, to, false
+	}
+	return from, to, true
+}
+
+func respondWithError(w http.ResponseWriter, code int, message string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+func respondWithServiceError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrInvalidExportPeriod):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, fallback)
+	}
+}
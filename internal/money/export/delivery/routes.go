@@ -0,0 +1,33 @@
+package exporthttp
+
+import (
+	"net/http"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
+	"github.com/Vovarama1992/go-utils/httputil"
+)
+
+func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService ports.UserServiceInterface, sessions middleware.SessionChecker, access middleware.PermissionChecker) {
+	withRecover := func(h http.Handler) http.Handler {
+		return httputil.RecoverMiddleware(h)
+	}
+
+	withPermission := func(perm rbac.Permission, h http.Handler) http.Handler {
+		return middleware.PermissionMiddleware(userService, sessions, access, perm)(h)
+	}
+
+	// === ADMIN ===
+	mux.Handle("/api/admin/finance/export/deposits",
+		withRecover(withPermission(rbac.PermFinanceExport, http.HandlerFunc(handler.ExportDeposits))),
+	)
+
+	mux.Handle("/api/admin/finance/export/withdrawals",
+		withRecover(withPermission(rbac.PermFinanceExport, http.HandlerFunc(handler.ExportWithdrawals))),
+	)
+
+	mux.Handle("/api/admin/finance/export/rewards",
+		withRecover(withPermission(rbac.PermFinanceExport, http.HandlerFunc(handler.ExportRewards))),
+	)
+}
@@ -0,0 +1,18 @@
+package payouthttp
+
+type AddPayoutMethodRequest struct {
+	Kind   string `json:"kind" validate:"required,oneof=card bank_account" example:"card"`
+	Number string `json:"number" validate:"required,max=40" example:"2200 0000 0000 0004"`
+	// БИК банка — только для счёта
+	BankBIK    *string `json:"bank_bik,omitempty" validate:"omitempty,max=20" example:"044525225"`
+	HolderName *string `json:"holder_name,omitempty" validate:"omitempty,max=100" example:"IVAN IVANOV"`
+}
+
+type PayoutMethodIDRequest struct {
+	PayoutMethodID int64 `json:"payout_method_id" validate:"required"`
+}
+
+type RejectPayoutMethodRequest struct {
+	PayoutMethodID int64  `json:"payout_method_id" validate:"required"`
+	Reason         string `json:"reason" validate:"required,max=500"`
+}
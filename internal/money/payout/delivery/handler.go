@@ -0,0 +1,325 @@
+package payouthttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	payout_infra "github.com/Vovarama1992/emelya-go/internal/money/payout/infra"
+	model "github.com/Vovarama1992/emelya-go/internal/money/payout/model"
+	service "github.com/Vovarama1992/emelya-go/internal/money/usecase"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type Handler struct {
+	payoutService *service.PayoutService
+}
+
+func NewHandler(payoutService *service.PayoutService) *Handler {
+	return &Handler{payoutService: payoutService}
+}
+
+// GetMyPayoutMethods godoc
+// @Summary Юзер: свои карты и счета для вывода (номера замаскированы)
+// @Tags payout
+// @Produce json
+// @Success 200 {array} model.PayoutMethod
+// @Failure 401,500 {object} map[string]string
+// @Router /api/payout-method/my [get]
+func (h *Handler) GetMyPayoutMethods(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	methods, err := h.payoutService.ListPayoutMethods(r.Context(), user.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения реквизитов")
+		return
+	}
+
+	json.NewEncoder(w).Encode(methods)
+}
+
+// AddPayoutMethod godoc
+// @Summary Юзер: добавить карту или счёт для вывода (уходит на проверку оператору)
+// @Tags payout
+// @Accept json
+// @Produce json
+// @Param data body AddPayoutMethodRequest true "Реквизиты"
+// @Success 201 {object} model.PayoutMethod
+// @Failure 400,401,409,500 {object} map[string]string
+// @Router /api/payout-method/add [post]
+func (h *Handler) AddPayoutMethod(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req AddPayoutMethodRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	method, err := h.payoutService.AddPayoutMethod(r.Context(), user.ID, model.Kind(req.Kind), req.Number, req.BankBIK, req.HolderName)
+	if err != nil {
+		respondWithServiceError(w, err, "Не удалось добавить реквизиты")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(method)
+}
+
+// SetDefaultPayoutMethod godoc
+// @Summary Юзер: сделать реквизиты основными для вывода
+// @Tags payout
+// @Accept json
+// @Produce json
+// @Param data body PayoutMethodIDRequest true "ID реквизитов"
+// @Success 200 {object} map[string]string
+// @Failure 400,401,404,500 {object} map[string]string
+// @Router /api/payout-method/default [post]
+func (h *Handler) SetDefaultPayoutMethod(w http.ResponseWriter, r *http.Request) {
+	h.userAction(w, r, "Основные реквизиты изменены", func(req PayoutMethodIDRequest, userID int64) error {
+		return h.payoutService.SetDefaultPayoutMethod(r.Context(), userID, req.PayoutMethodID)
+	})
+}
+
+// DeletePayoutMethod godoc
+// @Summary Юзер: удалить реквизиты
+// @Tags payout
+// @Accept json
+// @Produce json
+// @Param data body PayoutMethodIDRequest true "ID реквизитов"
+// @Success 200 {object} map[string]string
+// @Failure 400,401,404,500 {object} map[string]string
+// @Router /api/payout-method/delete [post]
+func (h *Handler) DeletePayoutMethod(w http.ResponseWriter, r *http.Request) {
+	h.userAction(w, r, "Реквизиты удалены", func(req PayoutMethodIDRequest, userID int64) error {
+		return h.payoutService.DeletePayoutMethod(r.Context(), userID, req.PayoutMethodID)
+	})
+}
+
+// AdminGetPendingPayoutMethods godoc
+// @Summary Админ: реквизиты, ожидающие проверки
+// @Tags admin-payout
+// @Produce json
+// @Success 200 {array} model.PayoutMethod
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/payout-method/pending [get]
+func (h *Handler) AdminGetPendingPayoutMethods(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	methods, err := h.payoutService.ListPendingPayoutMethods(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения реквизитов")
+		return
+	}
+
+	json.NewEncoder(w).Encode(methods)
+}
+
+// AdminGetUserPayoutMethods godoc
+// @Summary Админ: реквизиты пользователя (номера замаскированы)
+// @Tags admin-payout
+// @Produce json
+// @Param user_id query int true "ID пользователя"
+// @Success 200 {array} model.PayoutMethod
+// @Failure 400,500 {object} map[string]string
+// @Router /api/admin/payout-method/user [get]
+func (h *Handler) AdminGetUserPayoutMethods(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	userID, err := strconv.ParseInt(r.URL.Query().Get("user_id"), 10, 64)
+	if err != nil || userID <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Некорректный user_id")
+		return
+	}
+
+	methods, err := h.payoutService.ListPayoutMethods(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения реквизитов")
+		return
+	}
+
+	json.NewEncoder(w).Encode(methods)
+}
+
+// AdminVerifyPayoutMethod godoc
+// @Summary Админ: подтвердить реквизиты пользователя
+// @Tags admin-payout
+// @Accept json
+// @Produce json
+// @Param data body PayoutMethodIDRequest true "ID реквизитов"
+// @Success 200 {object} map[string]string
+// @Failure 400,404,409,500 {object} map[string]string
+// @Router /api/admin/payout-method/verify [post]
+func (h *Handler) AdminVerifyPayoutMethod(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req PayoutMethodIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	if err := h.payoutService.VerifyPayoutMethod(r.Context(), req.PayoutMethodID, admin.ID); err != nil {
+		respondWithServiceError(w, err, "Не удалось подтвердить реквизиты")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Реквизиты подтверждены"})
+}
+
+// AdminRejectPayoutMethod godoc
+// @Summary Админ: отклонить реквизиты пользователя
+// @Tags admin-payout
+// @Accept json
+// @Produce json
+// @Param data body RejectPayoutMethodRequest true "ID реквизитов и причина"
+// @Success 200 {object} map[string]string
+// @Failure 400,404,409,500 {object} map[string]string
+// @Router /api/admin/payout-method/reject [post]
+func (h *Handler) AdminRejectPayoutMethod(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req RejectPayoutMethodRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	if err := h.payoutService.RejectPayoutMethod(r.Context(), req.PayoutMethodID, admin.ID, req.Reason); err != nil {
+		respondWithServiceError(w, err, "Не удалось отклонить реквизиты")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Реквизиты отклонены"})
+}
+
+// AdminRevealPayoutMethod godoc
+// @Summary Админ: полный номер карты или счёта для проведения выплаты (просмотр пишется в журнал)
+// @Tags admin-payout
+// @Accept json
+// @Produce json
+// @Param data body PayoutMethodIDRequest true "ID реквизитов"
+// @Success 200 {object} map[string]string
+// @Failure 400,403,404,500 {object} map[string]string
+// @Router /api/admin/payout-method/reveal [post]
+func (h *Handler) AdminRevealPayoutMethod(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req PayoutMethodIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	number, err := h.payoutService.RevealPayoutNumber(r.Context(), req.PayoutMethodID, admin.ID)
+	if err != nil {
+		respondWithServiceError(w, err, "Не удалось получить номер")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(map[string]string{"number": number})
+}
+
+// userAction — общий разбор запроса для действий пользователя над своими реквизитами
+func (h *Handler) userAction(
+	w http.ResponseWriter,
+	r *http.Request,
+	successMessage string,
+	action func(req PayoutMethodIDRequest, userID int64) error,
+) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req PayoutMethodIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	if err := action(req, user.ID); err != nil {
+		respondWithServiceError(w, err, "Не удалось изменить реквизиты")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": successMessage})
+}
+
+// respondWithServiceError переводит ошибки сервиса в HTTP-коды
+func respondWithServiceError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrPayoutMethodNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrAlreadyProcessed),
+		errors.Is(err, payout_infra.ErrDuplicate),
+		errors.Is(err, service.ErrPayoutMethodsLimit):
+		respondWithError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, model.ErrInvalidCardNumber),
+		errors.Is(err, model.ErrUnsupportedCard),
+		errors.Is(err, model.ErrInvalidBIK),
+		errors.Is(err, model.ErrInvalidAccount),
+		errors.Is(err, service.ErrPayoutHolderRequired),
+		errors.Is(err, service.ErrInvalidHolderName):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+func respondWithError(w http.ResponseWriter, code int, message string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,67 @@
+package payouthttp
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
+	"github.com/Vovarama1992/go-utils/httputil"
+)
+
+func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService ports.UserServiceInterface, sessions middleware.SessionChecker, access middleware.PermissionChecker) {
+	withRecover := func(h http.Handler) http.Handler {
+		return httputil.RecoverMiddleware(h)
+	}
+
+	withRecoverAndRateLimit := func(h http.Handler) http.Handler {
+		return httputil.RecoverMiddleware(httputil.NewRateLimiter(3, time.Minute)(h))
+	}
+
+	withUserAuth := func(h http.Handler) http.Handler {
+		return middleware.AuthMiddleware(userService, sessions)(h)
+	}
+
+	withPermission := func(perm rbac.Permission, h http.Handler) http.Handler {
+		return middleware.PermissionMiddleware(userService, sessions, access, perm)(h)
+	}
+
+	// === USER ===
+	mux.Handle("/api/payout-method/my",
+		withRecover(withUserAuth(http.HandlerFunc(handler.GetMyPayoutMethods))),
+	)
+
+	mux.Handle("/api/payout-method/add",
+		withRecoverAndRateLimit(withUserAuth(http.HandlerFunc(handler.AddPayoutMethod))),
+	)
+
+	mux.Handle("/api/payout-method/default",
+		withRecoverAndRateLimit(withUserAuth(http.HandlerFunc(handler.SetDefaultPayoutMethod))),
+	)
+
+	mux.Handle("/api/payout-method/delete",
+		withRecoverAndRateLimit(withUserAuth(http.HandlerFunc(handler.DeletePayoutMethod))),
+	)
+
+	// === ADMIN ===
+	mux.Handle("/api/admin/payout-method/pending",
+		withRecover(withPermission(rbac.PermWithdrawalsRead, http.HandlerFunc(handler.AdminGetPendingPayoutMethods))),
+	)
+
+	mux.Handle("/api/admin/payout-method/user",
+		withRecover(withPermission(rbac.PermWithdrawalsRead, http.HandlerFunc(handler.AdminGetUserPayoutMethods))),
+	)
+
+	mux.Handle("/api/admin/payout-method/verify",
+		withRecover(withPermission(rbac.PermWithdrawalsManage, http.HandlerFunc(handler.AdminVerifyPayoutMethod))),
+	)
+
+	mux.Handle("/api/admin/payout-method/reject",
+		withRecover(withPermission(rbac.PermWithdrawalsManage, http.HandlerFunc(handler.AdminRejectPayoutMethod))),
+	)
+
+	mux.Handle("/api/admin/payout-method/reveal",
+		withRecover(withPermission(rbac.PermPayoutsReveal, http.HandlerFunc(handler.AdminRevealPayoutMethod))),
+	)
+}
@@ -0,0 +1,242 @@
+package payout_infra
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	model "github.com/Vovarama1992/emelya-go/internal/money/payout/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Такие реквизиты у пользователя уже есть
+var ErrDuplicate = errors.New("эти реквизиты уже добавлены")
+
+type PayoutRepository struct {
+	DB *db.DB
+}
+
+func NewPayoutRepository(db *db.DB) *PayoutRepository {
+	return &PayoutRepository{DB: db}
+}
+
+const payoutColumns = `id, user_id, kind, number_encrypted, fingerprint, last4, brand, bank_bik, holder_name,
+	is_default, status, reject_reason, verified_by, verified_at, deleted_at, created_at`
+
+// Create добавляет реквизиты; первые реквизиты пользователя становятся основными
+func (r *PayoutRepository) Create(ctx context.Context, m *model.PayoutMethod) error {
+	query := `
+		INSERT INTO payout_methods (user_id, kind, number_encrypted, fingerprint, last4, brand, bank_bik, holder_name, status, is_default)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9,
+		        NOT EXISTS (SELECT 1 FROM payout_methods WHERE user_id = $1 AND deleted_at IS NULL))
+		RETURNING id, is_default, created_at
+	`
+	err := r.DB.Pool.QueryRow(ctx, query,
+		m.UserID,
+		m.Kind,
+		m.NumberEncrypted,
+		m.Fingerprint,
+		m.Last4,
+		m.Brand,
+		m.BankBIK,
+		m.HolderName,
+		m.Status,
+	).Scan(&m.ID, &m.IsDefault, &m.CreatedAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrDuplicate
+	}
+	return err
+}
+
+// GetByID находит и удалённые реквизиты: на них могут ссылаться заявки на вывод
+func (r *PayoutRepository) GetByID(ctx context.Context, id int64) (*model.PayoutMethod, error) {
+	query := `SELECT ` + payoutColumns + ` FROM payout_methods WHERE id = $1`
+	return scanPayoutMethod(r.DB.Pool.QueryRow(ctx, query, id))
+}
+
+func (r *PayoutRepository) GetDefault(ctx context.Context, userID int64) (*model.PayoutMethod, error) {
+	query := `SELECT ` + payoutColumns + ` FROM payout_methods WHERE user_id = $1 AND is_default AND deleted_at IS NULL`
+	return scanPayoutMethod(r.DB.Pool.QueryRow(ctx, query, userID))
+}
+
+func (r *PayoutRepository) FindByUser(ctx context.Context, userID int64) ([]*model.PayoutMethod, error) {
+	query := `
+		SELECT ` + payoutColumns + `
+		FROM payout_methods
+		WHERE user_id = $1 AND deleted_at IS NULL
+		ORDER BY is_default DESC, created_at ASC
+	`
+	return r.query(ctx, query, userID)
+}
+
+func (r *PayoutRepository) FindPending(ctx context.Context) ([]*model.PayoutMethod, error) {
+	query := `
+		SELECT ` + payoutColumns + `
+		FROM payout_methods
+		WHERE status = 'pending' AND deleted_at IS NULL
+		ORDER BY created_at ASC
+	`
+	return r.query(ctx, query)
+}
+
+// SetDefault переключает основные реквизиты пользователя в одной транзакции
+func (r *PayoutRepository) SetDefault(ctx context.Context, userID, id int64) (err error) {
+	tx, err := r.DB.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	if _, err = tx.Exec(ctx, `UPDATE payout_methods SET is_default = FALSE WHERE user_id = $1 AND is_default`, userID); err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, `
+		UPDATE payout_methods SET is_default = TRUE
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+	`, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// SoftDelete скрывает реквизиты; заявки на вывод продолжают на них ссылаться.
+// Если удалены основные — основными становятся самые старые из оставшихся.
+func (r *PayoutRepository) SoftDelete(ctx context.Context, userID, id int64) (err error) {
+	tx, err := r.DB.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	var wasDefault bool
+	err = tx.QueryRow(ctx, `
+		SELECT is_default FROM payout_methods
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+		FOR UPDATE
+	`, id, userID).Scan(&wasDefault)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, `UPDATE payout_methods SET deleted_at = now(), is_default = FALSE WHERE id = $1`, id); err != nil {
+		return err
+	}
+	if !wasDefault {
+		return nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE payout_methods SET is_default = TRUE
+		WHERE id = (
+			SELECT id FROM payout_methods
+			WHERE user_id = $1 AND deleted_at IS NULL
+			ORDER BY (status = 'verified') DESC, created_at ASC
+			LIMIT 1
+		)
+	`, userID)
+	return err
+}
+
+// Decide переводит реквизиты из pending в verified или rejected
+func (r *PayoutRepository) Decide(ctx context.Context, id int64, status model.Status, decidedBy int64, reason *string) error {
+	query := `
+		UPDATE payout_methods
+		SET status = $1, reject_reason = $2, verified_by = $3, verified_at = now()
+		WHERE id = $4 AND status = 'pending' AND deleted_at IS NULL
+	`
+	tag, err := r.DB.Pool.Exec(ctx, query, status, reason, decidedBy, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *PayoutRepository) FindLegacyCards(ctx context.Context) ([]model.LegacyCard, error) {
+	rows, err := r.DB.Pool.Query(ctx, `SELECT id, card_number FROM users WHERE card_number IS NOT NULL AND card_number <> ''`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cards []model.LegacyCard
+	for rows.Next() {
+		var c model.LegacyCard
+		if err := rows.Scan(&c.UserID, &c.Number); err != nil {
+			return nil, err
+		}
+		cards = append(cards, c)
+	}
+	return cards, rows.Err()
+}
+
+// ClearLegacyCard стирает открытый номер после переноса
+func (r *PayoutRepository) ClearLegacyCard(ctx context.Context, userID int64) error {
+	_, err := r.DB.Pool.Exec(ctx, `UPDATE users SET card_number = NULL WHERE id = $1`, userID)
+	return err
+}
+
+func (r *PayoutRepository) query(ctx context.Context, query string, args ...any) ([]*model.PayoutMethod, error) {
+	rows, err := r.DB.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var methods []*model.PayoutMethod
+	for rows.Next() {
+		m, err := scanPayoutMethod(rows)
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, m)
+	}
+	return methods, rows.Err()
+}
+
+func scanPayoutMethod(row pgx.Row) (*model.PayoutMethod, error) {
+	var m model.PayoutMethod
+	err := row.Scan(
+		&m.ID,
+		&m.UserID,
+		&m.Kind,
+		&m.NumberEncrypted,
+		&m.Fingerprint,
+		&m.Last4,
+		&m.Brand,
+		&m.BankBIK,
+		&m.HolderName,
+		&m.IsDefault,
+		&m.Status,
+		&m.RejectReason,
+		&m.VerifiedBy,
+		&m.VerifiedAt,
+		&m.DeletedAt,
+		&m.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	m.Masked = model.Mask(m.Kind, m.Last4)
+	return &m, nil
+}
@@ -0,0 +1,58 @@
+package payout_model
+
+import "time"
+
+type Kind string
+
+const (
+	KindCard        Kind = "card"         // банковская карта
+	KindBankAccount Kind = "bank_account" // расчётный счёт в банке РФ
+)
+
+func (k Kind) IsValid() bool {
+	return k == KindCard || k == KindBankAccount
+}
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"  // ждёт проверки оператором
+	StatusVerified Status = "verified" // можно выводить
+	StatusRejected Status = "rejected" // отклонён оператором
+)
+
+// PayoutMethod — реквизиты для вывода. Полный номер хранится только
+// зашифрованным и наружу не отдаётся: в ответах виден Masked.
+type PayoutMethod struct {
+	ID              int64      `json:"id"`
+	UserID          int64      `json:"user_id"`
+	Kind            Kind       `json:"kind"`
+	NumberEncrypted string     `json:"-"`
+	Fingerprint     string     `json:"-"`
+	Last4           string     `json:"-"`
+	Masked          string     `json:"masked"`
+	Brand           *string    `json:"brand,omitempty"`
+	BankBIK         *string    `json:"bank_bik,omitempty"`
+	HolderName      *string    `json:"holder_name,omitempty"`
+	IsDefault       bool       `json:"is_default"`
+	Status          Status     `json:"status"`
+	RejectReason    *string    `json:"reject_reason,omitempty"`
+	VerifiedBy      *int64     `json:"verified_by,omitempty"`
+	VerifiedAt      *time.Time `json:"verified_at,omitempty"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// Mask — вид номера для ответов и писем: только последние 4 цифры
+func Mask(kind Kind, last4 string) string {
+	if kind == KindBankAccount {
+		return "счёт •••• " + last4
+	}
+	return "•••• " + last4
+}
+
+// LegacyCard — номер из старого поля users.card_number
+type LegacyCard struct {
+	UserID int64
+	Number string
+}
@@ -0,0 +1,147 @@
+package payout_model
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidCardNumber = errors.New("некорректный номер карты")
+	ErrUnsupportedCard   = errors.New("карты этой платёжной системы не принимаются")
+	ErrInvalidBIK        = errors.New("некорректный БИК банка")
+	ErrInvalidAccount    = errors.New("некорректный номер счёта или он не соответствует БИК")
+)
+
+const (
+	BrandMir        = "mir"
+	BrandVisa       = "visa"
+	BrandMastercard = "mastercard"
+	BrandMaestro    = "maestro"
+	BrandUnionPay   = "unionpay"
+)
+
+type binRange struct {
+	from, to int // диапазон первых цифр номера, длина задаётся digits
+	digits   int
+	brand    string
+}
+
+// Принимаемые платёжные системы по BIN
+var binRanges = []binRange{
+	{2200, 2204, 4, BrandMir},
+	{4, 4, 1, BrandVisa},
+	{51, 55, 2, BrandMastercard},
+	{2221, 2720, 4, BrandMastercard},
+	{50, 50, 2, BrandMaestro},
+	{56, 58, 2, BrandMaestro},
+	{62, 62, 2, BrandUnionPay},
+}
+
+// NormalizeNumber убирает пробелы и дефисы; другие символы делают номер некорректным
+func NormalizeNumber(number string) (string, bool) {
+	var b strings.Builder
+	for _, r := range number {
+		switch {
+		case r == ' ' || r == '-':
+			continue
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		default:
+			return "", false
+		}
+	}
+	return b.String(), b.Len() > 0
+}
+
+// ValidateCard проверяет длину, контрольную сумму и платёжную систему,
+// возвращает нормализованный номер и бренд
+func ValidateCard(number string) (normalized, brand string, err error) {
+	normalized, ok := NormalizeNumber(number)
+	if !ok || len(normalized) < 13 || len(normalized) > 19 || !luhnValid(normalized) {
+		return "", "", ErrInvalidCardNumber
+	}
+	brand = DetectBrand(normalized)
+	if brand == "" {
+		return "", "", ErrUnsupportedCard
+	}
+	return normalized, brand, nil
+}
+
+// DetectBrand определяет платёжную систему по первым цифрам номера
+func DetectBrand(number string) string {
+	for _, r := range binRanges {
+		if len(number) < r.digits {
+			continue
+		}
+		prefix := atoi(number[:r.digits])
+		if prefix >= r.from && prefix <= r.to {
+			return r.brand
+		}
+	}
+	return ""
+}
+
+// luhnValid — контрольная сумма номера карты
+func luhnValid(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		d := int(number[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// ValidateBankAccount проверяет 20-значный счёт по контрольному ключу
+// вместе с БИК банка (алгоритм ЦБ РФ для счетов в кредитных организациях)
+func ValidateBankAccount(account, bik string) (normalizedAccount, normalizedBIK string, err error) {
+	normalizedBIK, ok := NormalizeNumber(bik)
+	if !ok || len(normalizedBIK) != 9 || !strings.HasPrefix(normalizedBIK, "04") {
+		return "", "", ErrInvalidBIK
+	}
+	normalizedAccount, ok = NormalizeNumber(account)
+	if !ok || len(normalizedAccount) != 20 {
+		return "", "", ErrInvalidAccount
+	}
+
+	const weights = "71371371371371371371371"
+	control := normalizedBIK[6:] + normalizedAccount
+	sum := 0
+	for i := range control {
+		sum += (int(control[i]-'0') * int(weights[i]-'0')) % 10
+	}
+	if sum%10 != 0 {
+		return "", "", ErrInvalidAccount
+	}
+	return normalizedAccount, normalizedBIK, nil
+}
+
+// NormalizeHolderName — имя держателя латиницей или кириллицей, как на карте
+func NormalizeHolderName(name string) (string, bool) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" || len([]rune(name)) > 100 {
+		return "", false
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && r != ' ' && r != '-' && r != '.' && r != '\'' {
+			return "", false
+		}
+	}
+	return strings.ToUpper(name), true
+}
+
+func atoi(s string) int {
+	n := 0
+	for i := range s {
+		n = n*10 + int(s[i]-'0')
+	}
+	return n
+}
@@ -0,0 +1,58 @@
+package payout_model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateCard(t *testing.T) {
+	tests := []struct {
+		name       string
+		number     string
+		normalized string
+		brand      string
+		err        error
+	}{
+		{"visa", "4111 1111 1111 1111", "4111111111111111", BrandVisa, nil},
+		{"mastercard с дефисами", "5555-5555-5555-4444", "5555555555554444", BrandMastercard, nil},
+		{"mastercard 2-series", "2223003122003222", "2223003122003222", BrandMastercard, nil},
+		{"мир", "2200 0000 0000 0004", "2200000000000004", BrandMir, nil},
+		{"диапазон 67 не принимается", "6759649826438453", "", "", ErrUnsupportedCard},
+		{"unionpay", "6200000000000005", "6200000000000005", BrandUnionPay, nil},
+		{"неверная контрольная сумма", "4111 1111 1111 1112", "", "", ErrInvalidCardNumber},
+		{"буквы", "4111 1111 1111 111a", "", "", ErrInvalidCardNumber},
+		{"слишком короткий", "411111111111", "", "", ErrInvalidCardNumber},
+		{"слишком длинный", "41111111111111111111", "", "", ErrInvalidCardNumber},
+		{"пусто", "", "", "", ErrInvalidCardNumber},
+		{"amex не принимается", "378282246310005", "", "", ErrUnsupportedCard},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized, brand, err := ValidateCard(tt.number)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err: got %v, want %v", err, tt.err)
+			}
+			if normalized != tt.normalized || brand != tt.brand {
+				t.Errorf("got (%q, %q), want (%q, %q)", normalized, brand, tt.normalized, tt.brand)
+			}
+		})
+	}
+}
+
+func TestLuhnValid(t *testing.T) {
+	tests := []struct {
+		number string
+		want   bool
+	}{
+		{"79927398713", true},
+		{"79927398710", false},
+		{"4111111111111111", true},
+		{"4111111111111121", false},
+		{"0", true},
+	}
+	for _, tt := range tests {
+		if got := luhnValid(tt.number); got != tt.want {
+			t.Errorf("luhnValid(%q): got %v, want %v", tt.number, got, tt.want)
+		}
+	}
+}
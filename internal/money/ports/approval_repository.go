@@ -0,0 +1,15 @@
+package money_ports
+
+import (
+	"context"
+
+	model "github.com/Vovarama1992/emelya-go/internal/money/approval/model"
+)
+
+type ApprovalRepository interface {
+	Create(ctx context.Context, a *model.Approval) error
+	GetByID(ctx context.Context, id int64) (*model.Approval, error)
+	FindPending(ctx context.Context) ([]*model.Approval, error)
+	Decide(ctx context.Context, id int64, status model.Status, decidedBy int64, reason *string) error
+	MarkFailed(ctx context.Context, id int64, reason string) error
+}
@@ -0,0 +1,11 @@
+package money_ports
+
+import (
+	"context"
+	"time"
+)
+
+// ContactChangeReader — когда пользователь последний раз сменил телефон или email
+type ContactChangeReader interface {
+	ContactsChangedAt(ctx context.Context, userID int64) (*time.Time, error)
+}
@@ -12,11 +12,15 @@ type DepositRepository interface {
 	FindByID(ctx context.Context, id int64) (*models.Deposit, error)
 	FindByUserID(ctx context.Context, userID int64) ([]*models.Deposit, error)
 	Approve(ctx context.Context, id int64, approvedAt time.Time, blockDays int, dailyReward float64) error
-	Close(ctx context.Context, id int64) error
+	UpdateStatus(ctx context.Context, id int64, from, to models.Status, reason *string) error
+	AddTransition(ctx context.Context, t *models.Transition) error
+	FindTransitions(ctx context.Context, depositID int64) ([]*models.Transition, error)
 	FindPending(ctx context.Context) ([]*models.Deposit, error)
+	FindCreatedBetween(ctx context.Context, from, to time.Time) ([]*models.Deposit, error)
 	FindAllApproved(ctx context.Context) ([]*models.Deposit, error)
 	CreateApproved(ctx context.Context, d *models.Deposit) error
-	Delete(ctx context.Context, id int64) error
+	SoftDelete(ctx context.Context, id int64, deletedBy int64) error
 	GetTotalApprovedAmount(ctx context.Context) (float64, error)
 	FindApprovedByUserID(ctx context.Context, userID int64) ([]*models.Deposit, error)
+	SumActiveByUserID(ctx context.Context, userID int64) (float64, error)
 }
@@ -17,12 +17,17 @@ type DepositService interface {
 		blockDays *int,
 		dailyReward *float64,
 		tariffID *int64,
+		actorID int64,
 	) error
 
+	RejectDeposit(ctx context.Context, depositID, actorID int64, reason string) error
+	CancelDeposit(ctx context.Context, userID, depositID int64) error
+	GetDepositHistory(ctx context.Context, depositID int64) ([]*model.Transition, error)
+
 	GetDepositByID(ctx context.Context, id int64) (*model.Deposit, error)
 	GetDepositsByUserID(ctx context.Context, userID int64) ([]*model.Deposit, error)
 	AccrueDailyRewardsForAllDeposits(ctx context.Context) error
-	CloseDeposit(ctx context.Context, id int64) error
+	CloseDeposit(ctx context.Context, id, actorID int64) error
 	ListPendingDeposits(ctx context.Context) ([]*model.Deposit, error)
 
 	CreateDepositByAdmin(
@@ -35,9 +40,11 @@ type DepositService interface {
 		dailyReward *float64,
 		tariffID *int64,
 		initialRewardAmount *float64,
+		actorID int64,
+		reason *string,
 	) (int64, error)
 
-	DeleteDepositByAdmin(ctx context.Context, id int64) error
+	DeleteDepositByAdmin(ctx context.Context, id, actorID int64, reason *string) error
 	GetTotalApprovedAmount(ctx context.Context) (float64, error)
 	GetAllApprovedDeposits(ctx context.Context) ([]*model.Deposit, error)
 	GetApprovedDepositsByUserID(ctx context.Context, userID int64) ([]*model.Deposit, error)
@@ -0,0 +1,12 @@
+package money_ports
+
+import (
+	"context"
+
+	kyc "github.com/Vovarama1992/emelya-go/internal/kyc/model"
+)
+
+// KYCLevelReader — уровень верификации пользователя для лимитов
+type KYCLevelReader interface {
+	GetLevel(ctx context.Context, userID int64) (kyc.Level, error)
+}
@@ -0,0 +1,20 @@
+package money_ports
+
+import (
+	"context"
+
+	model "github.com/Vovarama1992/emelya-go/internal/money/payout/model"
+)
+
+type PayoutRepository interface {
+	Create(ctx context.Context, m *model.PayoutMethod) error
+	GetByID(ctx context.Context, id int64) (*model.PayoutMethod, error)
+	GetDefault(ctx context.Context, userID int64) (*model.PayoutMethod, error)
+	FindByUser(ctx context.Context, userID int64) ([]*model.PayoutMethod, error)
+	FindPending(ctx context.Context) ([]*model.PayoutMethod, error)
+	SetDefault(ctx context.Context, userID, id int64) error
+	SoftDelete(ctx context.Context, userID, id int64) error
+	Decide(ctx context.Context, id int64, status model.Status, decidedBy int64, reason *string) error
+	FindLegacyCards(ctx context.Context) ([]model.LegacyCard, error)
+	ClearLegacyCard(ctx context.Context, userID int64) error
+}
@@ -0,0 +1,20 @@
+package money_ports
+
+import (
+	"context"
+
+	model "github.com/Vovarama1992/emelya-go/internal/money/payout/model"
+)
+
+type PayoutService interface {
+	AddPayoutMethod(ctx context.Context, userID int64, kind model.Kind, number string, bik, holderName *string) (*model.PayoutMethod, error)
+	ListPayoutMethods(ctx context.Context, userID int64) ([]*model.PayoutMethod, error)
+	SetDefaultPayoutMethod(ctx context.Context, userID, methodID int64) error
+	DeletePayoutMethod(ctx context.Context, userID, methodID int64) error
+	ListPendingPayoutMethods(ctx context.Context) ([]*model.PayoutMethod, error)
+	VerifyPayoutMethod(ctx context.Context, methodID, actorID int64) error
+	RejectPayoutMethod(ctx context.Context, methodID, actorID int64, reason string) error
+	RevealPayoutNumber(ctx context.Context, methodID, actorID int64) (string, error)
+	ResolveForWithdrawal(ctx context.Context, userID int64, methodID *int64) (*model.PayoutMethod, error)
+	MigrateLegacyCards(ctx context.Context) error
+}
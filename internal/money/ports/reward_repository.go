@@ -16,4 +16,5 @@ type RewardRepository interface {
 	UpdateAmountAndLastAccruedAt(ctx context.Context, rewardID int64, delta float64, accruedAt time.Time) error
 	GetTotalAvailableAmount(ctx context.Context) (float64, error)
 	FindByDepositIDs(ctx context.Context, depositIDs []int64) ([]*model.Reward, error)
+	FindCreatedBetween(ctx context.Context, from, to time.Time) ([]*model.Reward, error)
 }
@@ -8,6 +8,7 @@ import (
 
 type RewardService interface {
 	Create(ctx context.Context, reward *model.Reward) error
+	CreateByAdmin(ctx context.Context, reward *model.Reward, actorID int64, reason *string) error
 	GetByID(ctx context.Context, id int64) (*model.Reward, error)
 	UpdateWithdrawn(ctx context.Context, rewardID int64, delta float64) error
 	FindByUserID(ctx context.Context, userID int64) ([]*model.Reward, error)
@@ -9,9 +9,13 @@ import (
 
 type WithdrawalRepository interface {
 	Create(ctx context.Context, w *model.Withdrawal) error
-	UpdateStatus(ctx context.Context, id int64, status string, approvedAt, rejectedAt *time.Time, reason *string) error
+	UpdateStatus(ctx context.Context, id int64, from, to model.WithdrawalStatus, approvedAt, rejectedAt *time.Time, reason *string) error
+	AddTransition(ctx context.Context, t *model.WithdrawalTransition) error
+	FindTransitions(ctx context.Context, withdrawalID int64) ([]*model.WithdrawalTransition, error)
 	FindByUserID(ctx context.Context, userID int64) ([]*model.Withdrawal, error)
 	GetByID(ctx context.Context, id int64) (*model.Withdrawal, error)
 	FindAll(ctx context.Context) ([]*model.Withdrawal, error)
 	FindAllPendings(ctx context.Context) ([]*model.Withdrawal, error)
+	FindCreatedBetween(ctx context.Context, from, to time.Time) ([]*model.Withdrawal, error)
+	SumSinceByUserID(ctx context.Context, userID int64, since time.Time) (float64, error)
 }
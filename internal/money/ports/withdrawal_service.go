@@ -7,9 +7,16 @@ import (
 )
 
 type WithdrawalService interface {
-	CreateWithdrawal(ctx context.Context, userID, rewardID int64, amount float64) error
-	ApproveWithdrawal(ctx context.Context, withdrawalID int64) error
-	RejectWithdrawal(ctx context.Context, withdrawalID int64, reason string) error
+	CreateWithdrawal(ctx context.Context, userID, rewardID int64, payoutMethodID *int64, amount float64) error
+	CancelWithdrawal(ctx context.Context, userID, withdrawalID int64) error
+	ApproveWithdrawal(ctx context.Context, withdrawalID, actorID int64) error
+	RejectWithdrawal(ctx context.Context, withdrawalID, actorID int64, reason string) error
+	MarkWithdrawalProcessing(ctx context.Context, withdrawalID, actorID int64) error
+	MarkWithdrawalPaid(ctx context.Context, withdrawalID, actorID int64) error
+	MarkWithdrawalFailed(ctx context.Context, withdrawalID, actorID int64, reason string) error
+	ReverseWithdrawal(ctx context.Context, withdrawalID, actorID int64, reason string) error
+	GetWithdrawalHistory(ctx context.Context, withdrawalID int64) ([]*model.WithdrawalTransition, error)
+	GetWithdrawalByID(ctx context.Context, withdrawalID int64) (*model.Withdrawal, error)
 	ListWithdrawalsByUser(ctx context.Context, userID int64) ([]*model.Withdrawal, error)
 	ListAllWithdrawals(ctx context.Context) ([]*model.Withdrawal, error)
 	ListPendingWithdrawals(ctx context.Context) ([]*model.Withdrawal, error)
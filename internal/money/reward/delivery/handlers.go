@@ -5,8 +5,8 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/Vovarama1992/emelya-go/internal/jwtutil"
-	model "github.com/Vovarama1992/emelya-go/internal/money/reward/model"
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	approval_model "github.com/Vovarama1992/emelya-go/internal/money/approval/model"
 	service "github.com/Vovarama1992/emelya-go/internal/money/usecase"
 	"github.com/go-playground/validator/v10"
 )
@@ -14,12 +14,14 @@ import (
 var validate = validator.New()
 
 type Handler struct {
-	rewardService *service.RewardService
+	rewardService   *service.RewardService
+	approvalService *service.ApprovalService
 }
 
-func NewHandler(rewardService *service.RewardService) *Handler {
+func NewHandler(rewardService *service.RewardService, approvalService *service.ApprovalService) *Handler {
 	return &Handler{
-		rewardService: rewardService,
+		rewardService:   rewardService,
+		approvalService: approvalService,
 	}
 }
 
@@ -30,6 +32,7 @@ func NewHandler(rewardService *service.RewardService) *Handler {
 // @Produce json
 // @Param data body AdminCreateReferralRewardRequest true "Данные о вознаграждении"
 // @Success 200 {object} map[string]string
+// @Success 202 {object} map[string]interface{} "approval_id"
 // @Failure 400,500 {object} map[string]string
 // @Router /api/admin/reward/referral-income [post]
 func (h *Handler) AdminCreateReferralReward(w http.ResponseWriter, r *http.Request) {
@@ -48,16 +51,20 @@ func (h *Handler) AdminCreateReferralReward(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	reward := &model.Reward{
+	admin := middleware.GetUserFromContext(r.Context())
+	approval, err := h.approvalService.SubmitRewardCreate(r.Context(), approval_model.RewardCreateParams{
 		UserID: req.UserID,
-		Type:   model.RewardTypeReferral,
 		Amount: req.Amount,
-	}
-
-	if err := h.rewardService.Create(r.Context(), reward); err != nil {
+		Reason: req.Reason,
+	}, admin.ID)
+	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Не удалось создать вознаграждение")
 		return
 	}
+	if approval != nil {
+		respondApprovalRequired(w, approval)
+		return
+	}
 
 	json.NewEncoder(w).Encode(map[string]string{"message": "Вознаграждение успешно создано"})
 }
@@ -105,18 +112,9 @@ func (h *Handler) GetMyRewards(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authHeader := r.Header.Get("Authorization")
-	if authHeader == "" {
-		respondWithError(w, http.StatusUnauthorized, "Токен отсутствует")
-		return
-	}
-	userID, err := jwtutil.ParseToken(authHeader[len("Bearer "):])
-	if err != nil {
-		respondWithError(w, http.StatusUnauthorized, "Неверный токен")
-		return
-	}
+	userID := middleware.GetUserFromContext(r.Context()).ID
 
-	rewards, err := h.rewardService.FindByUserID(r.Context(), int64(userID))
+	rewards, err := h.rewardService.FindByUserID(r.Context(), userID)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Ошибка получения вознаграждений")
 		return
@@ -125,6 +123,15 @@ func (h *Handler) GetMyRewards(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(rewards)
 }
 
+// respondApprovalRequired — операция выше порога ждёт второго администратора
+func respondApprovalRequired(w http.ResponseWriter, approval *approval_model.Approval) {
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message":     "Операция ждёт подтверждения второго администратора",
+		"approval_id": approval.ID,
+	})
+}
+
 func respondWithError(w http.ResponseWriter, code int, message string) {
 	w.WriteHeader(code)
 	json.NewEncoder(w).Encode(map[string]string{"error": message})
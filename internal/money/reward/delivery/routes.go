@@ -5,11 +5,12 @@ import (
 	"time"
 
 	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
 	ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
 	"github.com/Vovarama1992/go-utils/httputil"
 )
 
-func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService ports.UserServiceInterface) {
+func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService ports.UserServiceInterface, sessions middleware.SessionChecker, access middleware.PermissionChecker) {
 	withRecover := func(h http.Handler) http.Handler {
 		return httputil.RecoverMiddleware(h)
 	}
@@ -18,25 +19,29 @@ func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService ports.User
 		return httputil.RecoverMiddleware(httputil.NewRateLimiter(3, time.Minute)(h))
 	}
 
-	withAdminAuth := func(h http.Handler) http.Handler {
-		return middleware.AuthMiddleware(userService, true)(h)
+	withUserAuth := func(h http.Handler) http.Handler {
+		return middleware.AuthMiddleware(userService, sessions)(h)
+	}
+
+	withPermission := func(perm rbac.Permission, h http.Handler) http.Handler {
+		return middleware.PermissionMiddleware(userService, sessions, access, perm)(h)
 	}
 
 	// === USER ===
 	mux.Handle("/api/reward/my",
-		withRecoverAndRateLimit(http.HandlerFunc(handler.GetMyRewards)),
+		withRecoverAndRateLimit(withUserAuth(http.HandlerFunc(handler.GetMyRewards))),
 	)
 
 	// === ADMIN ===
 	mux.Handle("/api/admin/reward/referral-income",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.AdminCreateReferralReward))),
+		withRecover(withPermission(rbac.PermRewardsManage, http.HandlerFunc(handler.AdminCreateReferralReward))),
 	)
 
 	mux.Handle("/api/admin/reward/by-user",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.AdminGetRewardsByUser))),
+		withRecover(withPermission(rbac.PermRewardsRead, http.HandlerFunc(handler.AdminGetRewardsByUser))),
 	)
 
 	mux.Handle("/api/admin/reward/total-available",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.GetTotalAvailableAmount))),
+		withRecover(withPermission(rbac.PermFinanceRead, http.HandlerFunc(handler.GetTotalAvailableAmount))),
 	)
 }
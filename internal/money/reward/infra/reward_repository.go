@@ -62,6 +62,10 @@ func (r *RewardRepository) FindByUserID(ctx context.Context, userID int64) ([]*m
 		SELECT id, user_id, deposit_id, type, amount, withdrawn, created_at
 		FROM rewards
 		WHERE user_id = $1
+		  AND NOT EXISTS (
+		      SELECT 1 FROM deposits d
+		      WHERE d.id = rewards.deposit_id AND d.deleted_at IS NOT NULL
+		  )
 	`
 	rows, err := r.querier.Query(ctx, query, userID)
 	if err != nil {
@@ -88,11 +92,56 @@ func (r *RewardRepository) FindByUserID(ctx context.Context, userID int64) ([]*m
 	return rewards, nil
 }
 
+// FindCreatedBetween — награды, заведённые за [from, to), без наград удалённых
+// депозитов; для выгрузки бухгалтерии
+func (r *RewardRepository) FindCreatedBetween(ctx context.Context, from, to time.Time) ([]*model.Reward, error) {
+	query := `
+		SELECT id, user_id, deposit_id, type, amount, withdrawn, last_accrued_at, created_at
+		FROM rewards
+		WHERE created_at >= $1 AND created_at < $2
+		  AND NOT EXISTS (
+		      SELECT 1 FROM deposits d
+		      WHERE d.id = rewards.deposit_id AND d.deleted_at IS NOT NULL
+		  )
+		ORDER BY created_at
+	`
+	rows, err := r.querier.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rewards []*model.Reward
+	for rows.Next() {
+		var rw model.Reward
+		if err := rows.Scan(
+			&rw.ID,
+			&rw.UserID,
+			&rw.DepositID,
+			&rw.Type,
+			&rw.Amount,
+			&rw.Withdrawn,
+			&rw.LastAccruedAt,
+			&rw.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		rewards = append(rewards, &rw)
+	}
+	return rewards, rows.Err()
+}
+
+// GetByID и FindByDepositID не видят награды удалённых депозитов: с них нельзя
+// выводить и на них нельзя начислять
 func (r *RewardRepository) GetByID(ctx context.Context, id int64) (*model.Reward, error) {
 	query := `
 		SELECT id, user_id, deposit_id, type, amount, withdrawn, created_at
 		FROM rewards
 		WHERE id = $1
+		  AND NOT EXISTS (
+		      SELECT 1 FROM deposits d
+		      WHERE d.id = rewards.deposit_id AND d.deleted_at IS NOT NULL
+		  )
 	`
 	var rw model.Reward
 	err := r.querier.QueryRow(ctx, query, id).Scan(
@@ -115,6 +164,10 @@ func (r *RewardRepository) FindByDepositID(ctx context.Context, depositID int64)
 		SELECT id, user_id, deposit_id, type, amount, withdrawn, last_accrued_at, created_at
 		FROM rewards
 		WHERE deposit_id = $1
+		  AND NOT EXISTS (
+		      SELECT 1 FROM deposits d
+		      WHERE d.id = rewards.deposit_id AND d.deleted_at IS NOT NULL
+		  )
 	`
 	var rw model.Reward
 	err := r.querier.QueryRow(ctx, query, depositID).Scan(
@@ -148,6 +201,10 @@ func (r *RewardRepository) GetTotalAvailableAmount(ctx context.Context) (float64
 	query := `
 		SELECT COALESCE(SUM(amount - withdrawn), 0)
 		FROM rewards
+		WHERE NOT EXISTS (
+		    SELECT 1 FROM deposits d
+		    WHERE d.id = rewards.deposit_id AND d.deleted_at IS NOT NULL
+		)
 	`
 	var total float64
 	err := r.querier.QueryRow(ctx, query).Scan(&total)
@@ -5,20 +5,21 @@ import (
 	"time"
 
 	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
 	user_ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
 	"github.com/Vovarama1992/go-utils/httputil"
 )
 
-func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService user_ports.UserServiceInterface) {
+func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService user_ports.UserServiceInterface, sessions middleware.SessionChecker, access middleware.PermissionChecker) {
 	withRecoverAndRateLimit := func(h http.Handler) http.Handler {
 		return httputil.RecoverMiddleware(httputil.NewRateLimiter(5, time.Minute)(h))
 	}
 
-	withAdminAuth := func(h http.Handler) http.Handler {
-		return middleware.AuthMiddleware(userService, true)(h)
+	withPermission := func(perm rbac.Permission, h http.Handler) http.Handler {
+		return middleware.PermissionMiddleware(userService, sessions, access, perm)(h)
 	}
 
 	mux.Handle("/api/admin/tariffs", withRecoverAndRateLimit(
-		withAdminAuth(http.HandlerFunc(handler.HandleTariffs)),
+		withPermission(rbac.PermTariffsManage, http.HandlerFunc(handler.HandleTariffs)),
 	))
 }
@@ -0,0 +1,298 @@
+package money_usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	approval_infra "github.com/Vovarama1992/emelya-go/internal/money/approval/infra"
+	model "github.com/Vovarama1992/emelya-go/internal/money/approval/model"
+	deposit_model "github.com/Vovarama1992/emelya-go/internal/money/deposit/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/money/ports"
+	reward_model "github.com/Vovarama1992/emelya-go/internal/money/reward/model"
+	withdrawal_model "github.com/Vovarama1992/emelya-go/internal/money/withdrawal/model"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+)
+
+var (
+	ErrApprovalNotFound   = errors.New("заявка на подтверждение не найдена")
+	ErrApprovalNotPending = approval_infra.ErrNotPending
+	ErrApprovalDuplicate  = approval_infra.ErrDuplicatePending
+	ErrSameApprover       = errors.New("операцию должен подтвердить другой администратор")
+)
+
+// ApprovalThresholds — суммы, выше которых операция требует второго администратора.
+// Нулевое значение отключает проверку для операции.
+type ApprovalThresholds struct {
+	DepositApprove    float64
+	WithdrawalApprove float64
+	DepositCreate     float64
+	InitialReward     float64
+	RewardCreate      float64
+}
+
+func LoadApprovalThresholdsFromEnv() ApprovalThresholds {
+	return ApprovalThresholds{
+		DepositApprove:    envFloat("FOUR_EYES_DEPOSIT_APPROVE_THRESHOLD"),
+		WithdrawalApprove: envFloat("FOUR_EYES_WITHDRAWAL_APPROVE_THRESHOLD"),
+		DepositCreate:     envFloat("FOUR_EYES_DEPOSIT_CREATE_THRESHOLD"),
+		InitialReward:     envFloat("FOUR_EYES_INITIAL_REWARD_THRESHOLD"),
+		RewardCreate:      envFloat("FOUR_EYES_REWARD_CREATE_THRESHOLD"),
+	}
+}
+
+func envFloat(key string) float64 {
+	v, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+func exceeds(amount, threshold float64) bool {
+	return threshold > 0 && amount > threshold
+}
+
+// ApprovalService — точка входа для крупных админских операций (принцип четырёх глаз).
+// Операция ниже порога выполняется сразу, выше — ставится в очередь и ждёт
+// подтверждения другим администратором.
+type ApprovalService struct {
+	repo          ports.ApprovalRepository
+	depositSvc    *DepositService
+	withdrawalSvc *WithdrawalService
+	rewardSvc     *RewardService
+	thresholds    ApprovalThresholds
+}
+
+func NewApprovalService(
+	repo ports.ApprovalRepository,
+	depositSvc *DepositService,
+	withdrawalSvc *WithdrawalService,
+	rewardSvc *RewardService,
+	thresholds ApprovalThresholds,
+) *ApprovalService {
+	return &ApprovalService{
+		repo:          repo,
+		depositSvc:    depositSvc,
+		withdrawalSvc: withdrawalSvc,
+		rewardSvc:     rewardSvc,
+		thresholds:    thresholds,
+	}
+}
+
+// SubmitDepositApprove одобряет депозит или ставит одобрение в очередь.
+// Возвращает заявку, если нужно подтверждение, иначе nil.
+func (s *ApprovalService) SubmitDepositApprove(ctx context.Context, p model.DepositApproveParams, actorID int64) (*model.Approval, error) {
+	deposit, err := s.depositSvc.GetDepositByID(ctx, p.DepositID)
+	if err != nil {
+		return nil, ErrDepositNotFound
+	}
+	if deposit.Status != deposit_model.StatusPending {
+		return nil, ErrDepositAlreadyProcessed
+	}
+
+	if !exceeds(deposit.Amount, s.thresholds.DepositApprove) {
+		return nil, s.executeDepositApprove(ctx, p, actorID)
+	}
+	return s.enqueue(ctx, model.OperationDepositApprove, &p.DepositID, deposit.Amount, p, actorID)
+}
+
+// SubmitWithdrawalApprove одобряет заявку на вывод или ставит одобрение в очередь
+func (s *ApprovalService) SubmitWithdrawalApprove(ctx context.Context, withdrawalID, actorID int64) (*model.Approval, error) {
+	withdrawal, err := s.withdrawalSvc.GetWithdrawalByID(ctx, withdrawalID)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	if withdrawal.Status != withdrawal_model.WithdrawalStatusPending {
+		return nil, ErrAlreadyProcessed
+	}
+
+	if !exceeds(withdrawal.Amount, s.thresholds.WithdrawalApprove) {
+		return nil, s.withdrawalSvc.ApproveWithdrawal(ctx, withdrawalID, actorID)
+	}
+	p := model.WithdrawalApproveParams{WithdrawalID: withdrawalID}
+	return s.enqueue(ctx, model.OperationWithdrawalApprove, &withdrawalID, withdrawal.Amount, p, actorID)
+}
+
+// SubmitDepositCreate создаёт депозит вручную или ставит создание в очередь.
+// Если депозит создан сразу, возвращается его ID.
+func (s *ApprovalService) SubmitDepositCreate(ctx context.Context, p model.DepositCreateParams, actorID int64) (*model.Approval, int64, error) {
+	initialReward := 0.0
+	if p.InitialRewardAmount != nil {
+		initialReward = *p.InitialRewardAmount
+	}
+
+	if !exceeds(p.Amount, s.thresholds.DepositCreate) && !exceeds(initialReward, s.thresholds.InitialReward) {
+		id, err := s.executeDepositCreate(ctx, p, actorID)
+		return nil, id, err
+	}
+	approval, err := s.enqueue(ctx, model.OperationDepositCreate, nil, p.Amount+initialReward, p, actorID)
+	return approval, 0, err
+}
+
+// SubmitRewardCreate начисляет награду вручную или ставит начисление в очередь
+func (s *ApprovalService) SubmitRewardCreate(ctx context.Context, p model.RewardCreateParams, actorID int64) (*model.Approval, error) {
+	if !exceeds(p.Amount, s.thresholds.RewardCreate) {
+		return nil, s.executeRewardCreate(ctx, p, actorID)
+	}
+	return s.enqueue(ctx, model.OperationRewardCreate, nil, p.Amount, p, actorID)
+}
+
+// Confirm — второй администратор подтверждает и выполняет операцию
+func (s *ApprovalService) Confirm(ctx context.Context, approvalID, actorID int64) error {
+	approval, err := s.getByID(ctx, approvalID)
+	if err != nil {
+		return err
+	}
+	if approval.Status != model.StatusPending {
+		return ErrApprovalNotPending
+	}
+	if approval.RequestedBy == actorID {
+		return ErrSameApprover
+	}
+
+	// Сначала забираем заявку, чтобы два параллельных подтверждения не выполнили операцию дважды
+	if err := s.decide(ctx, approvalID, model.StatusConfirmed, actorID, nil); err != nil {
+		return err
+	}
+
+	if err := s.execute(ctx, approval, actorID); err != nil {
+		failCtx, cancel := ctxutil.WithTimeout(context.Background(), 2)
+		defer cancel()
+		if markErr := s.repo.MarkFailed(failCtx, approvalID, err.Error()); markErr != nil {
+			log.Printf("[APPROVAL] Не удалось отметить заявку %d как failed: %v", approvalID, markErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// Reject — отклонение заявки; отозвать свою заявку может и сам инициатор
+func (s *ApprovalService) Reject(ctx context.Context, approvalID, actorID int64, reason string) error {
+	if _, err := s.getByID(ctx, approvalID); err != nil {
+		return err
+	}
+	return s.decide(ctx, approvalID, model.StatusRejected, actorID, &reason)
+}
+
+func (s *ApprovalService) ListPending(ctx context.Context) ([]*model.Approval, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	return s.repo.FindPending(ctx)
+}
+
+func (s *ApprovalService) enqueue(ctx context.Context, op model.Operation, entityID *int64, amount float64, params any, actorID int64) (*model.Approval, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	approval := &model.Approval{
+		Operation:   op,
+		EntityID:    entityID,
+		Amount:      amount,
+		Payload:     payload,
+		Status:      model.StatusPending,
+		RequestedBy: actorID,
+	}
+	if err := s.repo.Create(ctx, approval); err != nil {
+		return nil, err
+	}
+	return approval, nil
+}
+
+func (s *ApprovalService) execute(ctx context.Context, approval *model.Approval, actorID int64) error {
+	switch approval.Operation {
+	case model.OperationDepositApprove:
+		var p model.DepositApproveParams
+		if err := json.Unmarshal(approval.Payload, &p); err != nil {
+			return err
+		}
+		return s.executeDepositApprove(ctx, p, actorID)
+	case model.OperationWithdrawalApprove:
+		var p model.WithdrawalApproveParams
+		if err := json.Unmarshal(approval.Payload, &p); err != nil {
+			return err
+		}
+		return s.withdrawalSvc.ApproveWithdrawal(ctx, p.WithdrawalID, actorID)
+	case model.OperationDepositCreate:
+		var p model.DepositCreateParams
+		if err := json.Unmarshal(approval.Payload, &p); err != nil {
+			return err
+		}
+		p.Reason = approvalReason(approval, p.Reason)
+		_, err := s.executeDepositCreate(ctx, p, actorID)
+		return err
+	case model.OperationRewardCreate:
+		var p model.RewardCreateParams
+		if err := json.Unmarshal(approval.Payload, &p); err != nil {
+			return err
+		}
+		p.Reason = approvalReason(approval, p.Reason)
+		return s.executeRewardCreate(ctx, p, actorID)
+	default:
+		return fmt.Errorf("неизвестная операция: %s", approval.Operation)
+	}
+}
+
+func (s *ApprovalService) executeDepositApprove(ctx context.Context, p model.DepositApproveParams, actorID int64) error {
+	return s.depositSvc.ApproveDeposit(ctx, p.DepositID, p.ApprovedAt, p.BlockDays, p.DailyReward, p.TariffID, actorID)
+}
+
+func (s *ApprovalService) executeDepositCreate(ctx context.Context, p model.DepositCreateParams, actorID int64) (int64, error) {
+	return s.depositSvc.CreateDepositByAdmin(
+		ctx,
+		p.UserID,
+		p.Amount,
+		p.CreatedAt,
+		p.ApprovedAt,
+		p.BlockDays,
+		p.DailyReward,
+		p.TariffID,
+		p.InitialRewardAmount,
+		actorID,
+		p.Reason,
+	)
+}
+
+func (s *ApprovalService) executeRewardCreate(ctx context.Context, p model.RewardCreateParams, actorID int64) error {
+	reward := &reward_model.Reward{
+		UserID: p.UserID,
+		Type:   reward_model.RewardTypeReferral,
+		Amount: p.Amount,
+	}
+	return s.rewardSvc.CreateByAdmin(ctx, reward, actorID, p.Reason)
+}
+
+func (s *ApprovalService) getByID(ctx context.Context, id int64) (*model.Approval, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	approval, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrApprovalNotFound
+	}
+	return approval, nil
+}
+
+func (s *ApprovalService) decide(ctx context.Context, id int64, status model.Status, actorID int64, reason *string) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	return s.repo.Decide(ctx, id, status, actorID, reason)
+}
+
+// approvalReason дописывает к причине ссылку на заявку и её инициатора — для журнала
+func approvalReason(approval *model.Approval, reason *string) *string {
+	text := fmt.Sprintf("подтверждение #%d, инициатор ID %d", approval.ID, approval.RequestedBy)
+	if reason != nil && *reason != "" {
+		text = *reason + " (" + text + ")"
+	}
+	return &text
+}
@@ -0,0 +1,32 @@
+package money_usecase
+
+import (
+	"context"
+
+	audit_infra "github.com/Vovarama1992/emelya-go/internal/audit/infra"
+	audit_model "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	"github.com/jackc/pgx/v5"
+)
+
+// writeAudit пишет запись журнала в транзакции самой денежной операции,
+// чтобы операция без записи в журнале не могла закоммититься
+func writeAudit(
+	ctx context.Context,
+	tx pgx.Tx,
+	actorID *int64,
+	action audit_model.Action,
+	entityType string,
+	entityID int64,
+	before, after any,
+	reason *string,
+) error {
+	return audit_infra.NewAuditRepositoryWithTx(tx).Create(ctx, &audit_model.Entry{
+		ActorID:    actorID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   &entityID,
+		Before:     audit_model.Snapshot(before),
+		After:      audit_model.Snapshot(after),
+		Reason:     reason,
+	})
+}
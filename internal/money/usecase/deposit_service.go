@@ -6,25 +6,29 @@ import (
 	"fmt"
 	"time"
 
+	audit_model "github.com/Vovarama1992/emelya-go/internal/audit/model"
 	"github.com/Vovarama1992/emelya-go/internal/db"
 	deposit_infra "github.com/Vovarama1992/emelya-go/internal/money/deposit/infra"
 	model "github.com/Vovarama1992/emelya-go/internal/money/deposit/model"
 	ports "github.com/Vovarama1992/emelya-go/internal/money/ports"
 	reward_infra "github.com/Vovarama1992/emelya-go/internal/money/reward/infra"
 	reward_model "github.com/Vovarama1992/emelya-go/internal/money/reward/model"
-	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	outbox_model "github.com/Vovarama1992/emelya-go/internal/outbox/model"
 	"github.com/Vovarama1992/go-utils/ctxutil"
 )
 
 var (
-	ErrDepositNotFound = errors.New("депозит не найден")
+	ErrDepositNotFound          = errors.New("депозит не найден")
+	ErrDepositAlreadyProcessed  = errors.New("депозит уже обработан")
+	ErrDepositInvalidTransition = errors.New("недопустимая смена статуса депозита")
 )
 
 type DepositService struct {
 	repo      ports.DepositRepository
 	rewardSvc ports.RewardService
 	tarifSvc  ports.TariffService
-	notifier  notifier.NotifierInterface
+	kycLevels ports.KYCLevelReader
+	limits    KYCLimits
 	db        *db.DB
 }
 
@@ -32,45 +36,69 @@ func NewDepositService(
 	repo ports.DepositRepository,
 	rewardSvc ports.RewardService,
 	tarifSvc ports.TariffService,
+	kycLevels ports.KYCLevelReader,
+	limits KYCLimits,
 	db *db.DB,
-	notifier *notifier.Notifier,
 ) *DepositService {
 	return &DepositService{
 		repo:      repo,
 		rewardSvc: rewardSvc,
 		tarifSvc:  tarifSvc,
+		kycLevels: kycLevels,
+		limits:    limits,
 		db:        db,
-		notifier:  notifier,
 	}
 }
 
-// CreateDeposit — создаёт заявку на депозит без транзакции
-func (s *DepositService) CreateDeposit(ctx context.Context, userID int64, amount float64) error {
+// CreateDeposit — создаёт заявку на депозит; событие для операторов пишется в outbox
+// в той же транзакции. Сумма депозитов пользователя ограничена лимитом его уровня верификации.
+func (s *DepositService) CreateDeposit(ctx context.Context, userID int64, amount float64) (err error) {
 	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
 	defer cancel()
 
+	if err := s.checkKYCLimit(ctx, userID, amount); err != nil {
+		return err
+	}
+
 	deposit := &model.Deposit{
 		UserID: userID,
 		Amount: amount,
 		Status: model.StatusPending,
 	}
 
-	if err := s.repo.Create(ctx, deposit); err != nil {
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
 		return err
 	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
 
-	// Отправляем уведомление операторам
-	subject := "Новая заявка на депозит"
-	body := fmt.Sprintf(
-		"Пользователь ID: %d подал заявку на депозит на сумму %.2f руб.",
-		userID, amount,
-	)
-
-	if err := s.notifier.SendEmailToOperator(subject, body); err != nil {
-		fmt.Printf("[DEPOSIT] Не удалось отправить уведомление оператору: %v\n", err)
+	if err = deposit_infra.NewDepositRepositoryWithTx(tx).Create(ctx, deposit); err != nil {
+		return err
 	}
 
-	return nil
+	return writeEvent(ctx, tx, outbox_model.EventDepositRequested, outbox_model.AggregateDeposit, deposit.ID, depositPayload(deposit, nil))
+}
+
+func (s *DepositService) checkKYCLimit(ctx context.Context, userID int64, amount float64) error {
+	level, err := s.kycLevels.GetLevel(ctx, userID)
+	if err != nil {
+		return err
+	}
+	limit := s.limits.Deposit[level]
+	if limit <= 0 {
+		return nil
+	}
+	used, err := s.repo.SumActiveByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return checkKYCLimit(limit, used, amount)
 }
 
 func (s *DepositService) ApproveDeposit(
@@ -80,6 +108,7 @@ func (s *DepositService) ApproveDeposit(
 	BlockDays *int,
 	dailyReward *float64,
 	tariffID *int64,
+	actorID int64,
 ) (err error) {
 	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
 	defer cancel()
@@ -104,6 +133,10 @@ func (s *DepositService) ApproveDeposit(
 		return ErrDepositNotFound
 	}
 
+	if !deposit.Status.CanTransitionTo(model.StatusApproved) {
+		return ErrDepositAlreadyProcessed
+	}
+
 	if (BlockDays == nil || dailyReward == nil) && tariffID == nil {
 		return errors.New("либо передайте blockUntil/dailyReward, либо tariffID")
 	}
@@ -121,6 +154,32 @@ func (s *DepositService) ApproveDeposit(
 	}
 
 	err = txDepositRepo.Approve(ctx, depositID, approvedAt, *BlockDays, *dailyReward)
+	if errors.Is(err, deposit_infra.ErrStatusMismatch) {
+		return ErrDepositAlreadyProcessed
+	}
+	if err != nil {
+		return err
+	}
+
+	err = txDepositRepo.AddTransition(ctx, &model.Transition{
+		DepositID:  deposit.ID,
+		FromStatus: deposit.Status,
+		ToStatus:   model.StatusApproved,
+		ActorID:    &actorID,
+	})
+	if err != nil {
+		return err
+	}
+
+	approved, err := txDepositRepo.FindByID(ctx, deposit.ID)
+	if err != nil {
+		return err
+	}
+	err = writeAudit(ctx, tx, &actorID, audit_model.ActionDepositApprove, audit_model.EntityDeposit, deposit.ID, deposit, approved, nil)
+	if err != nil {
+		return err
+	}
+	err = writeEvent(ctx, tx, outbox_model.EventDepositApproved, outbox_model.AggregateDeposit, deposit.ID, depositPayload(approved, &actorID))
 	if err != nil {
 		return err
 	}
@@ -163,11 +222,117 @@ func (s *DepositService) GetDepositsByUserID(ctx context.Context, userID int64)
 	return s.repo.FindByUserID(ctx, userID)
 }
 
-func (s *DepositService) CloseDeposit(ctx context.Context, id int64) error {
+// CloseDeposit — закрывает активный депозит
+func (s *DepositService) CloseDeposit(ctx context.Context, id, actorID int64) error {
+	return s.changeStatus(ctx, id, nil, model.StatusClosed, &actorID, nil)
+}
+
+// RejectDeposit — отклоняет заявку на депозит с указанием причины
+func (s *DepositService) RejectDeposit(ctx context.Context, depositID, actorID int64, reason string) error {
+	return s.changeStatus(ctx, depositID, nil, model.StatusRejected, &actorID, &reason)
+}
+
+// CancelDeposit — пользователь отменяет свою заявку, пока она не одобрена
+func (s *DepositService) CancelDeposit(ctx context.Context, userID, depositID int64) error {
+	return s.changeStatus(ctx, depositID, &userID, model.StatusCancelled, &userID, nil)
+}
+
+// GetDepositHistory — история смены статусов депозита
+func (s *DepositService) GetDepositHistory(ctx context.Context, depositID int64) ([]*model.Transition, error) {
 	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
 	defer cancel()
 
-	return s.repo.Close(ctx, id)
+	return s.repo.FindTransitions(ctx, depositID)
+}
+
+// changeStatus проверяет допустимость перехода и пишет его в историю в одной транзакции.
+// ownerID задаётся, когда действие выполняет сам владелец депозита.
+func (s *DepositService) changeStatus(
+	ctx context.Context,
+	depositID int64,
+	ownerID *int64,
+	to model.Status,
+	actorID *int64,
+	reason *string,
+) (err error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	txDepositRepo := deposit_infra.NewDepositRepositoryWithTx(tx)
+
+	deposit, err := txDepositRepo.FindByID(ctx, depositID)
+	if err != nil {
+		return ErrDepositNotFound
+	}
+	if ownerID != nil && deposit.UserID != *ownerID {
+		return ErrDepositNotFound
+	}
+
+	if !deposit.Status.CanTransitionTo(to) {
+		return ErrDepositInvalidTransition
+	}
+
+	err = txDepositRepo.UpdateStatus(ctx, deposit.ID, deposit.Status, to, reason)
+	if errors.Is(err, deposit_infra.ErrStatusMismatch) {
+		return ErrDepositAlreadyProcessed
+	}
+	if err != nil {
+		return err
+	}
+
+	err = txDepositRepo.AddTransition(ctx, &model.Transition{
+		DepositID:  deposit.ID,
+		FromStatus: deposit.Status,
+		ToStatus:   to,
+		ActorID:    actorID,
+		Reason:     reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Действия пользователя над своей заявкой в журнал администраторов не пишем
+	if ownerID != nil {
+		return nil
+	}
+
+	action := audit_model.ActionDepositReject
+	if to == model.StatusClosed {
+		action = audit_model.ActionDepositClose
+	}
+	after := *deposit
+	after.Status = to
+	if reason != nil {
+		after.Reason = reason
+	}
+	err = writeAudit(ctx, tx, actorID, action, audit_model.EntityDeposit, deposit.ID, deposit, &after, reason)
+	if err != nil || to != model.StatusClosed {
+		return err
+	}
+	return writeEvent(ctx, tx, outbox_model.EventDepositClosed, outbox_model.AggregateDeposit, deposit.ID, depositPayload(&after, actorID))
+}
+
+func depositPayload(d *model.Deposit, actorID *int64) outbox_model.DepositPayload {
+	return outbox_model.DepositPayload{
+		DepositID:   d.ID,
+		UserID:      d.UserID,
+		Amount:      d.Amount,
+		DailyReward: d.DailyReward,
+		BlockDays:   d.BlockDays,
+		ActorID:     actorID,
+	}
 }
 
 func (s *DepositService) AccrueDailyRewardsForAllDeposits(ctx context.Context) error {
@@ -204,7 +369,9 @@ func (s *DepositService) CreateDepositByAdmin(
 	dailyReward *float64,
 	tariffID *int64,
 	initialRewardAmount *float64,
-) (int64, error) {
+	actorID int64,
+	reason *string,
+) (id int64, err error) {
 	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
 	defer cancel()
 
@@ -272,14 +439,49 @@ func (s *DepositService) CreateDepositByAdmin(
 		return 0, err
 	}
 
+	// created_at и стартовая награда задаются вручную — фиксируем их в журнале как есть
+	after := map[string]any{
+		"deposit":               deposit,
+		"initial_reward_amount": rewardAmount,
+		"tariff_id":             tariffID,
+	}
+	err = writeAudit(ctx, tx, &actorID, audit_model.ActionDepositCreateByAdmin, audit_model.EntityDeposit, deposit.ID, nil, after, reason)
+	if err != nil {
+		return 0, err
+	}
+
 	return deposit.ID, nil
 }
 
-func (s *DepositService) DeleteDepositByAdmin(ctx context.Context, id int64) error {
+// DeleteDepositByAdmin — мягкое удаление: депозит пропадает из выборок, но остаётся в базе и журнале
+func (s *DepositService) DeleteDepositByAdmin(ctx context.Context, id, actorID int64, reason *string) (err error) {
 	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
 	defer cancel()
 
-	return s.repo.Delete(ctx, id)
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	txDepositRepo := deposit_infra.NewDepositRepositoryWithTx(tx)
+
+	deposit, err := txDepositRepo.FindByID(ctx, id)
+	if err != nil {
+		return ErrDepositNotFound
+	}
+
+	if err = txDepositRepo.SoftDelete(ctx, id, actorID); err != nil {
+		return err
+	}
+
+	return writeAudit(ctx, tx, &actorID, audit_model.ActionDepositDelete, audit_model.EntityDeposit, id, deposit, nil, reason)
 }
 
 func (s *DepositService) GetTotalApprovedAmount(ctx context.Context) (float64, error) {
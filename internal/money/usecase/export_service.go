@@ -0,0 +1,132 @@
+package money_usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	audit_model "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	audit_ports "github.com/Vovarama1992/emelya-go/internal/audit/ports"
+	deposit_model "github.com/Vovarama1992/emelya-go/internal/money/deposit/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/money/ports"
+	reward_model "github.com/Vovarama1992/emelya-go/internal/money/reward/model"
+	withdrawal_model "github.com/Vovarama1992/emelya-go/internal/money/withdrawal/model"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+)
+
+var ErrInvalidExportPeriod = errors.New("некорректный период выгрузки")
+
+// Выгрузка больше чем за год — это уже отчёт, а не запрос из админки
+const maxExportPeriod = 366 * 24 * time.Hour
+
+// ExportKind — что выгружается
+type ExportKind string
+
+const (
+	ExportDeposits    ExportKind = "deposits"
+	ExportWithdrawals ExportKind = "withdrawals"
+	ExportRewards     ExportKind = "rewards"
+)
+
+// ExportService — выгрузки для бухгалтерии. Каждая выгрузка пишется в журнал
+// действий: в ней суммы и ID пользователей за весь период.
+type ExportService struct {
+	deposits     ports.DepositRepository
+	withdrawals  ports.WithdrawalRepository
+	rewards      ports.RewardRepository
+	auditService audit_ports.AuditService
+}
+
+func NewExportService(
+	deposits ports.DepositRepository,
+	withdrawals ports.WithdrawalRepository,
+	rewards ports.RewardRepository,
+	auditService audit_ports.AuditService,
+) *ExportService {
+	return &ExportService{
+		deposits:     deposits,
+		withdrawals:  withdrawals,
+		rewards:      rewards,
+		auditService: auditService,
+	}
+}
+
+// ExportPeriod — период [from, to); по умолчанию последние 30 дней
+func ExportPeriod(from, to time.Time) (time.Time, time.Time, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -30)
+	}
+	if !from.Before(to) || to.Sub(from) > maxExportPeriod {
+		return from, to, ErrInvalidExportPeriod
+	}
+	return from, to, nil
+}
+
+func (s *ExportService) ExportDeposits(ctx context.Context, actorID int64, from, to time.Time) ([]*deposit_model.Deposit, error) {
+	from, to, err := ExportPeriod(from, to)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := ctxutil.WithTimeout(ctx, 15)
+	defer cancel()
+
+	list, err := s.deposits.FindCreatedBetween(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	s.record(ctx, actorID, ExportDeposits, from, to, len(list))
+	return list, nil
+}
+
+func (s *ExportService) ExportWithdrawals(ctx context.Context, actorID int64, from, to time.Time) ([]*withdrawal_model.Withdrawal, error) {
+	from, to, err := ExportPeriod(from, to)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := ctxutil.WithTimeout(ctx, 15)
+	defer cancel()
+
+	list, err := s.withdrawals.FindCreatedBetween(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	s.record(ctx, actorID, ExportWithdrawals, from, to, len(list))
+	return list, nil
+}
+
+func (s *ExportService) ExportRewards(ctx context.Context, actorID int64, from, to time.Time) ([]*reward_model.Reward, error) {
+	from, to, err := ExportPeriod(from, to)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := ctxutil.WithTimeout(ctx, 15)
+	defer cancel()
+
+	list, err := s.rewards.FindCreatedBetween(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	s.record(ctx, actorID, ExportRewards, from, to, len(list))
+	return list, nil
+}
+
+func (s *ExportService) record(ctx context.Context, actorID int64, kind ExportKind, from, to time.Time, rows int) {
+	err := s.auditService.Record(ctx, &audit_model.Entry{
+		ActorID:    &actorID,
+		Action:     audit_model.ActionFinanceExport,
+		EntityType: audit_model.EntityExport,
+		After: audit_model.Snapshot(map[string]any{
+			"kind": kind,
+			"from": from,
+			"to":   to,
+			"rows": rows,
+		}),
+	})
+	if err != nil {
+		log.Printf("[EXPORT] Не удалось записать журнал выгрузки %s: %v", kind, err)
+	}
+}
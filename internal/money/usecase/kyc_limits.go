@@ -0,0 +1,42 @@
+package money_usecase
+
+import (
+	"errors"
+	"fmt"
+
+	kyc "github.com/Vovarama1992/emelya-go/internal/kyc/model"
+)
+
+var ErrKYCLimitExceeded = errors.New("сумма превышает лимит для вашего уровня верификации")
+
+// Окно, за которое суммируются выводы
+const withdrawalLimitWindowDays = 30
+
+// KYCLimits — лимиты по уровню верификации; 0 — без ограничений.
+// Deposit ограничивает сумму депозитов на рассмотрении и активных,
+// Withdrawal — сумму заявок на вывод за последние 30 дней.
+type KYCLimits struct {
+	Deposit    map[kyc.Level]float64
+	Withdrawal map[kyc.Level]float64
+}
+
+// LoadKYCLimitsFromEnv читает KYC_LEVEL{0,1,2}_DEPOSIT_LIMIT и KYC_LEVEL{0,1,2}_WITHDRAWAL_LIMIT
+func LoadKYCLimitsFromEnv() KYCLimits {
+	limits := KYCLimits{
+		Deposit:    map[kyc.Level]float64{},
+		Withdrawal: map[kyc.Level]float64{},
+	}
+	for _, level := range []kyc.Level{kyc.LevelNone, kyc.LevelBasic, kyc.LevelFull} {
+		limits.Deposit[level] = envFloat(fmt.Sprintf("KYC_LEVEL%d_DEPOSIT_LIMIT", level))
+		limits.Withdrawal[level] = envFloat(fmt.Sprintf("KYC_LEVEL%d_WITHDRAWAL_LIMIT", level))
+	}
+	return limits
+}
+
+// checkKYCLimit — used уже занято, amount — новая операция
+func checkKYCLimit(limit, used, amount float64) error {
+	if !exceeds(used+amount, limit) {
+		return nil
+	}
+	return fmt.Errorf("%w: доступно %.2f руб., пройдите верификацию, чтобы увеличить лимит", ErrKYCLimitExceeded, max(limit-used, 0))
+}
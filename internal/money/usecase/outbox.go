@@ -0,0 +1,26 @@
+package money_usecase
+
+import (
+	"context"
+
+	outbox_infra "github.com/Vovarama1992/emelya-go/internal/outbox/infra"
+	outbox_model "github.com/Vovarama1992/emelya-go/internal/outbox/model"
+	"github.com/jackc/pgx/v5"
+)
+
+// writeEvent кладёт доменное событие в outbox в транзакции операции:
+// уведомление уйдёт только если операция закоммитилась
+func writeEvent(
+	ctx context.Context,
+	tx pgx.Tx,
+	eventType outbox_model.EventType,
+	aggregateType string,
+	aggregateID int64,
+	payload any,
+) error {
+	event, err := outbox_model.NewEvent(eventType, aggregateType, aggregateID, payload)
+	if err != nil {
+		return err
+	}
+	return outbox_infra.NewOutboxRepositoryWithTx(tx).Create(ctx, event)
+}
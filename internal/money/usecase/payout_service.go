@@ -0,0 +1,319 @@
+package money_usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	audit_model "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	audit_ports "github.com/Vovarama1992/emelya-go/internal/audit/ports"
+	"github.com/Vovarama1992/emelya-go/internal/cryptoutil"
+	payout_infra "github.com/Vovarama1992/emelya-go/internal/money/payout/infra"
+	model "github.com/Vovarama1992/emelya-go/internal/money/payout/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/money/ports"
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrPayoutMethodNotFound    = errors.New("реквизиты не найдены")
+	ErrPayoutMethodNotVerified = errors.New("реквизиты ещё не проверены оператором")
+	ErrNoPayoutMethod          = errors.New("добавьте карту или счёт для вывода")
+	ErrPayoutMethodsLimit      = errors.New("достигнуто максимальное число реквизитов")
+	ErrPayoutHolderRequired    = errors.New("для счёта укажите ФИО получателя")
+	ErrInvalidHolderName       = errors.New("некорректное имя держателя")
+)
+
+const maxPayoutMethods = 5
+
+type PayoutService struct {
+	repo         ports.PayoutRepository
+	auditService audit_ports.AuditService
+	notifier     notifier.NotifierInterface
+}
+
+func NewPayoutService(repo ports.PayoutRepository, auditService audit_ports.AuditService, notifier notifier.NotifierInterface) *PayoutService {
+	return &PayoutService{
+		repo:         repo,
+		auditService: auditService,
+		notifier:     notifier,
+	}
+}
+
+// AddPayoutMethod проверяет реквизиты, шифрует номер и отправляет их на проверку оператору.
+// Первые реквизиты пользователя становятся основными.
+func (s *PayoutService) AddPayoutMethod(ctx context.Context, userID int64, kind model.Kind, number string, bik, holderName *string) (*model.PayoutMethod, error) {
+	method := &model.PayoutMethod{
+		UserID: userID,
+		Kind:   kind,
+		Status: model.StatusPending,
+	}
+
+	switch kind {
+	case model.KindCard:
+		normalized, brand, err := model.ValidateCard(number)
+		if err != nil {
+			return nil, err
+		}
+		number, method.Brand = normalized, &brand
+	case model.KindBankAccount:
+		if bik == nil {
+			return nil, model.ErrInvalidBIK
+		}
+		if holderName == nil {
+			return nil, ErrPayoutHolderRequired
+		}
+		account, normalizedBIK, err := model.ValidateBankAccount(number, *bik)
+		if err != nil {
+			return nil, err
+		}
+		number, method.BankBIK = account, &normalizedBIK
+	default:
+		return nil, model.ErrInvalidCardNumber
+	}
+
+	if holderName != nil {
+		name, ok := model.NormalizeHolderName(*holderName)
+		if !ok {
+			return nil, ErrInvalidHolderName
+		}
+		method.HolderName = &name
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+
+	existing, err := s.repo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxPayoutMethods {
+		return nil, ErrPayoutMethodsLimit
+	}
+
+	if err := sealPayoutNumber(method, number); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, method); err != nil {
+		return nil, err
+	}
+
+	err = s.notifier.SendTemplateToOperators(ctx, notifier.TplOperatorPayoutMethodAdded, map[string]any{
+		"UserID":   userID,
+		"MethodID": method.ID,
+		"Method":   describePayoutMethod(method),
+	})
+	if err != nil {
+		log.Printf("[PAYOUT] Не удалось уведомить оператора о реквизитах %d: %v", method.ID, err)
+	}
+	return method, nil
+}
+
+// Реквизиты пользователя, основные первыми
+func (s *PayoutService) ListPayoutMethods(ctx context.Context, userID int64) ([]*model.PayoutMethod, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	return s.repo.FindByUser(ctx, userID)
+}
+
+func (s *PayoutService) SetDefaultPayoutMethod(ctx context.Context, userID, methodID int64) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+	err := s.repo.SetDefault(ctx, userID, methodID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrPayoutMethodNotFound
+	}
+	return err
+}
+
+func (s *PayoutService) DeletePayoutMethod(ctx context.Context, userID, methodID int64) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+	err := s.repo.SoftDelete(ctx, userID, methodID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrPayoutMethodNotFound
+	}
+	return err
+}
+
+// Реквизиты, ожидающие проверки (для админки)
+func (s *PayoutService) ListPendingPayoutMethods(ctx context.Context) ([]*model.PayoutMethod, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	return s.repo.FindPending(ctx)
+}
+
+func (s *PayoutService) VerifyPayoutMethod(ctx context.Context, methodID, actorID int64) error {
+	return s.decide(ctx, methodID, actorID, model.StatusVerified, nil, audit_model.ActionPayoutVerify)
+}
+
+func (s *PayoutService) RejectPayoutMethod(ctx context.Context, methodID, actorID int64, reason string) error {
+	return s.decide(ctx, methodID, actorID, model.StatusRejected, &reason, audit_model.ActionPayoutReject)
+}
+
+func (s *PayoutService) decide(ctx context.Context, methodID, actorID int64, status model.Status, reason *string, action audit_model.Action) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+
+	before, err := s.repo.GetByID(ctx, methodID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrPayoutMethodNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Decide(ctx, methodID, status, actorID, reason)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrAlreadyProcessed
+	}
+	if err != nil {
+		return err
+	}
+
+	after, err := s.repo.GetByID(ctx, methodID)
+	if err != nil {
+		return err
+	}
+	s.record(ctx, actorID, action, methodID, before, after, reason)
+	return nil
+}
+
+// RevealPayoutNumber — полный номер для оператора, который проводит выплату.
+// Каждый просмотр попадает в журнал.
+func (s *PayoutService) RevealPayoutNumber(ctx context.Context, methodID, actorID int64) (string, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	method, err := s.repo.GetByID(ctx, methodID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrPayoutMethodNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	number, err := cryptoutil.Decrypt(method.NumberEncrypted)
+	if err != nil {
+		return "", err
+	}
+	s.record(ctx, actorID, audit_model.ActionPayoutReveal, methodID, nil, nil, nil)
+	return number, nil
+}
+
+// ResolveForWithdrawal возвращает проверенные реквизиты пользователя для заявки:
+// указанные явно или основные
+func (s *PayoutService) ResolveForWithdrawal(ctx context.Context, userID int64, methodID *int64) (*model.PayoutMethod, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	var method *model.PayoutMethod
+	var err error
+	if methodID != nil {
+		method, err = s.repo.GetByID(ctx, *methodID)
+	} else {
+		method, err = s.repo.GetDefault(ctx, userID)
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		if methodID == nil {
+			return nil, ErrNoPayoutMethod
+		}
+		return nil, ErrPayoutMethodNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if method.UserID != userID || method.DeletedAt != nil {
+		return nil, ErrPayoutMethodNotFound
+	}
+	if method.Status != model.StatusVerified {
+		return nil, ErrPayoutMethodNotVerified
+	}
+	return method, nil
+}
+
+// MigrateLegacyCards переносит номера из users.card_number в зашифрованные реквизиты
+// и стирает открытые номера. Карты, которые раньше принимались к выплате, считаются
+// проверенными; номера, не прошедшие проверку, сохраняются отклонёнными.
+func (s *PayoutService) MigrateLegacyCards(ctx context.Context) error {
+	cards, err := s.repo.FindLegacyCards(ctx)
+	if err != nil {
+		return err
+	}
+
+	migrated := 0
+	for _, card := range cards {
+		method := &model.PayoutMethod{
+			UserID: card.UserID,
+			Kind:   model.KindCard,
+			Status: model.StatusVerified,
+		}
+		number, brand, err := model.ValidateCard(card.Number)
+		if err != nil {
+			reason := "Номер не прошёл проверку при переносе: " + err.Error()
+			number = card.Number
+			method.Status, method.RejectReason = model.StatusRejected, &reason
+		} else {
+			method.Brand = &brand
+		}
+
+		if err := sealPayoutNumber(method, number); err != nil {
+			return err
+		}
+		if err := s.repo.Create(ctx, method); err != nil && !errors.Is(err, payout_infra.ErrDuplicate) {
+			log.Printf("[PAYOUT] Не удалось перенести карту пользователя %d: %v", card.UserID, err)
+			continue
+		}
+		if err := s.repo.ClearLegacyCard(ctx, card.UserID); err != nil {
+			log.Printf("[PAYOUT] Не удалось стереть старый номер карты пользователя %d: %v", card.UserID, err)
+			continue
+		}
+		migrated++
+	}
+
+	if migrated > 0 {
+		log.Printf("[PAYOUT] Перенесено карт из профиля: %d", migrated)
+	}
+	return nil
+}
+
+// sealPayoutNumber шифрует номер и заполняет поля для поиска дублей и маски
+func sealPayoutNumber(method *model.PayoutMethod, number string) error {
+	encrypted, err := cryptoutil.Encrypt(number)
+	if err != nil {
+		return err
+	}
+	fingerprint, err := cryptoutil.Fingerprint(string(method.Kind) + ":" + number)
+	if err != nil {
+		return err
+	}
+	method.NumberEncrypted = encrypted
+	method.Fingerprint = fingerprint
+	method.Last4 = number[max(len(number)-4, 0):]
+	method.Masked = model.Mask(method.Kind, method.Last4)
+	return nil
+}
+
+func (s *PayoutService) record(ctx context.Context, actorID int64, action audit_model.Action, methodID int64, before, after any, reason *string) {
+	err := s.auditService.Record(ctx, &audit_model.Entry{
+		ActorID:    &actorID,
+		Action:     action,
+		EntityType: audit_model.EntityPayout,
+		EntityID:   &methodID,
+		Before:     audit_model.Snapshot(before),
+		After:      audit_model.Snapshot(after),
+		Reason:     reason,
+	})
+	if err != nil {
+		log.Printf("[PAYOUT] Не удалось записать журнал по реквизитам %d: %v", methodID, err)
+	}
+}
+
+// describePayoutMethod — маска и бренд для писем
+func describePayoutMethod(m *model.PayoutMethod) string {
+	if m.Brand != nil {
+		return m.Masked + " (" + *m.Brand + ")"
+	}
+	return m.Masked
+}
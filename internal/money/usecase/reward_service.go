@@ -5,8 +5,12 @@ import (
 	"errors"
 	"time"
 
+	audit_model "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	"github.com/Vovarama1992/emelya-go/internal/db"
 	ports "github.com/Vovarama1992/emelya-go/internal/money/ports"
+	reward_infra "github.com/Vovarama1992/emelya-go/internal/money/reward/infra"
 	model "github.com/Vovarama1992/emelya-go/internal/money/reward/model"
+	outbox_model "github.com/Vovarama1992/emelya-go/internal/outbox/model"
 	"github.com/Vovarama1992/go-utils/ctxutil"
 )
 
@@ -17,15 +21,18 @@ var (
 type RewardService struct {
 	repo        ports.RewardRepository
 	depositRepo ports.DepositRepository
+	db          *db.DB
 }
 
 func NewRewardService(
 	repo ports.RewardRepository,
 	depositRepo ports.DepositRepository,
+	db *db.DB,
 ) *RewardService {
 	return &RewardService{
 		repo:        repo,
 		depositRepo: depositRepo,
+		db:          db,
 	}
 }
 
@@ -35,6 +42,30 @@ func (s *RewardService) Create(ctx context.Context, reward *model.Reward) error
 	return s.repo.Create(ctx, reward)
 }
 
+// CreateByAdmin — ручное начисление награды администратором с записью в журнал
+func (s *RewardService) CreateByAdmin(ctx context.Context, reward *model.Reward, actorID int64, reason *string) (err error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	if err = reward_infra.NewRewardRepositoryWithTx(tx).Create(ctx, reward); err != nil {
+		return err
+	}
+
+	return writeAudit(ctx, tx, &actorID, audit_model.ActionRewardCreateByAdmin, audit_model.EntityReward, reward.ID, nil, reward, reason)
+}
+
 func (s *RewardService) GetByID(ctx context.Context, id int64) (*model.Reward, error) {
 	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
 	defer cancel()
@@ -53,7 +84,7 @@ func (s *RewardService) FindByUserID(ctx context.Context, userID int64) ([]*mode
 	return s.repo.FindByUserID(ctx, userID)
 }
 
-func (s *RewardService) AccrueDailyRewardForDeposit(ctx context.Context, depositID int64, dailyReward float64) error {
+func (s *RewardService) AccrueDailyRewardForDeposit(ctx context.Context, depositID int64, dailyReward float64) (err error) {
 	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
 	defer cancel()
 
@@ -83,7 +114,32 @@ func (s *RewardService) AccrueDailyRewardForDeposit(ctx context.Context, deposit
 	delta := deposit.Amount * percent * float64(daysMissed)
 	newAccruedAt := last.Add(time.Duration(daysMissed) * 24 * time.Hour)
 
-	return s.repo.UpdateAmountAndLastAccruedAt(ctx, reward.ID, delta, newAccruedAt)
+	// Начисление и событие для уведомления пользователя — в одной транзакции
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	err = reward_infra.NewRewardRepositoryWithTx(tx).UpdateAmountAndLastAccruedAt(ctx, reward.ID, delta, newAccruedAt)
+	if err != nil {
+		return err
+	}
+
+	return writeEvent(ctx, tx, outbox_model.EventRewardAccrued, outbox_model.AggregateReward, reward.ID, outbox_model.RewardAccruedPayload{
+		RewardID:  reward.ID,
+		DepositID: depositID,
+		UserID:    reward.UserID,
+		Amount:    delta,
+		Days:      daysMissed,
+		AccruedAt: newAccruedAt,
+	})
 }
 
 func (s *RewardService) GetTotalAvailableAmount(ctx context.Context) (float64, error) {
@@ -3,48 +3,89 @@ package money_usecase
 import (
 	"context"
 	"errors"
-	"fmt"
+	"os"
 	"time"
 
+	audit_model "github.com/Vovarama1992/emelya-go/internal/audit/model"
 	"github.com/Vovarama1992/emelya-go/internal/db"
 	ports "github.com/Vovarama1992/emelya-go/internal/money/ports"
 	reward_infra "github.com/Vovarama1992/emelya-go/internal/money/reward/infra"
 	withdrawal_infra "github.com/Vovarama1992/emelya-go/internal/money/withdrawal/infra"
 	model "github.com/Vovarama1992/emelya-go/internal/money/withdrawal/model"
-	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	outbox_model "github.com/Vovarama1992/emelya-go/internal/outbox/model"
 	"github.com/Vovarama1992/go-utils/ctxutil"
+	"github.com/jackc/pgx/v5"
 )
 
 var (
 	ErrInsufficientFunds = errors.New("недостаточно средств для вывода")
 	ErrNotFound          = errors.New("заявка не найдена")
 	ErrAlreadyProcessed  = errors.New("заявка уже обработана")
+	ErrInvalidTransition = errors.New("недопустимая смена статуса заявки")
+
+	ErrContactChangeCooldown = errors.New("вывод временно недоступен после смены телефона или email")
 )
 
+const defaultContactChangeCooldown = 48 * time.Hour
+
+// contactChangeCooldown — пауза на вывод после смены контактов (WITHDRAWAL_CONTACT_CHANGE_COOLDOWN)
+func contactChangeCooldown() time.Duration {
+	d, err := time.ParseDuration(os.Getenv("WITHDRAWAL_CONTACT_CHANGE_COOLDOWN"))
+	if err != nil || d < 0 {
+		return defaultContactChangeCooldown
+	}
+	return d
+}
+
 type WithdrawalService struct {
 	repo      ports.WithdrawalRepository
 	rewardSvc ports.RewardService
-	notifier  notifier.NotifierInterface
+	payoutSvc ports.PayoutService
+	kycLevels ports.KYCLevelReader
+	contacts  ports.ContactChangeReader
+	limits    KYCLimits
 	db        *db.DB
 }
 
 func NewWithdrawalService(
 	repo ports.WithdrawalRepository,
 	rewardSvc ports.RewardService,
+	payoutSvc ports.PayoutService,
+	kycLevels ports.KYCLevelReader,
+	contacts ports.ContactChangeReader,
+	limits KYCLimits,
 	db *db.DB,
-	notifier *notifier.Notifier,
 ) *WithdrawalService {
 	return &WithdrawalService{
 		repo:      repo,
 		rewardSvc: rewardSvc,
+		payoutSvc: payoutSvc,
+		kycLevels: kycLevels,
+		contacts:  contacts,
+		limits:    limits,
 		db:        db,
-		notifier:  notifier,
 	}
 }
 
-// Создание заявки на вывод без транзакции
-func (s *WithdrawalService) CreateWithdrawal(ctx context.Context, userID, rewardID int64, amount float64) error {
+// Создание заявки на вывод; событие для операторов пишется в outbox в той же транзакции.
+// Без payoutMethodID вывод идёт на основные реквизиты; реквизиты должны быть проверены оператором.
+// Сумма выводов за 30 дней ограничена лимитом уровня верификации; после смены
+// контактов вывод на время закрыт.
+func (s *WithdrawalService) CreateWithdrawal(ctx context.Context, userID, rewardID int64, payoutMethodID *int64, amount float64) (err error) {
+	if err := s.checkContactChangeCooldown(ctx, userID); err != nil {
+		return err
+	}
+
+	method, err := s.payoutSvc.ResolveForWithdrawal(ctx, userID, payoutMethodID)
+	if err != nil {
+		return err
+	}
+
+	// Награда удалённого депозита не находится, как и чужая
 	reward, err := s.rewardSvc.GetByID(ctx, rewardID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && reward.UserID != userID) {
+		return ErrRewardNotFound
+	}
 	if err != nil {
 		return err
 	}
@@ -54,38 +95,129 @@ func (s *WithdrawalService) CreateWithdrawal(ctx context.Context, userID, reward
 		return ErrInsufficientFunds
 	}
 
+	if err := s.checkKYCLimit(ctx, userID, amount); err != nil {
+		return err
+	}
+
 	withdrawal := &model.Withdrawal{
-		UserID:    userID,
-		RewardID:  rewardID,
-		Amount:    amount,
-		Status:    model.WithdrawalStatusPending,
-		CreatedAt: time.Now(),
+		UserID:         userID,
+		RewardID:       rewardID,
+		PayoutMethodID: &method.ID,
+		Amount:         amount,
+		Status:         model.WithdrawalStatusPending,
+		CreatedAt:      time.Now(),
 	}
 
 	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
 	defer cancel()
 
-	if err := s.repo.Create(ctx, withdrawal); err != nil {
+	tx, err := s.db.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	if err = withdrawal_infra.NewWithdrawalRepositoryWithTx(tx).Create(ctx, withdrawal); err != nil {
 		return err
 	}
 
-	// Отправляем уведомление
-	subject := "Новая заявка на вывод средств"
-	body := fmt.Sprintf(
-		"Пользователь ID: %d подал заявку на вывод %.2f руб. с reward ID: %d",
-		userID, amount, rewardID,
-	)
+	payload := withdrawalPayload(withdrawal, nil, nil)
+	payload.PayoutMethod = describePayoutMethod(method)
+	return writeEvent(ctx, tx, outbox_model.EventWithdrawalRequested, outbox_model.AggregateWithdrawal, withdrawal.ID, payload)
+}
+
+func (s *WithdrawalService) checkKYCLimit(ctx context.Context, userID int64, amount float64) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
 
-	if err := s.notifier.SendEmailToOperator(subject, body); err != nil {
-		// Не падаем, если уведомление не сработало, просто пишем в лог
-		fmt.Printf("[WITHDRAWAL] Не удалось отправить уведомление оператору: %v\n", err)
+	level, err := s.kycLevels.GetLevel(ctx, userID)
+	if err != nil {
+		return err
+	}
+	limit := s.limits.Withdrawal[level]
+	if limit <= 0 {
+		return nil
+	}
+	since := time.Now().AddDate(0, 0, -withdrawalLimitWindowDays)
+	used, err := s.repo.SumSinceByUserID(ctx, userID, since)
+	if err != nil {
+		return err
 	}
+	return checkKYCLimit(limit, used, amount)
+}
 
+// checkContactChangeCooldown не даёт вывести средства сразу после смены телефона или email:
+// при угоне аккаунта это даёт владельцу время заметить уведомление
+func (s *WithdrawalService) checkContactChangeCooldown(ctx context.Context, userID int64) error {
+	changedAt, err := s.contacts.ContactsChangedAt(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if changedAt != nil && time.Since(*changedAt) < contactChangeCooldown() {
+		return ErrContactChangeCooldown
+	}
 	return nil
 }
 
+// Отмена своей заявки пользователем, пока она в статусе pending
+func (s *WithdrawalService) CancelWithdrawal(ctx context.Context, userID, withdrawalID int64) error {
+	return s.changeStatus(ctx, withdrawalID, &userID, model.WithdrawalStatusCancelled, &userID, nil)
+}
+
 // Подтверждение заявки с обновлением награды в транзакции
-func (s *WithdrawalService) ApproveWithdrawal(ctx context.Context, withdrawalID int64) (err error) {
+func (s *WithdrawalService) ApproveWithdrawal(ctx context.Context, withdrawalID, actorID int64) error {
+	return s.changeStatus(ctx, withdrawalID, nil, model.WithdrawalStatusApproved, &actorID, nil)
+}
+
+// Отклонение заявки на вывод
+func (s *WithdrawalService) RejectWithdrawal(ctx context.Context, withdrawalID, actorID int64, reason string) error {
+	return s.changeStatus(ctx, withdrawalID, nil, model.WithdrawalStatusRejected, &actorID, &reason)
+}
+
+// Выплата по одобренной заявке начата
+func (s *WithdrawalService) MarkWithdrawalProcessing(ctx context.Context, withdrawalID, actorID int64) error {
+	return s.changeStatus(ctx, withdrawalID, nil, model.WithdrawalStatusProcessing, &actorID, nil)
+}
+
+// Выплата прошла
+func (s *WithdrawalService) MarkWithdrawalPaid(ctx context.Context, withdrawalID, actorID int64) error {
+	return s.changeStatus(ctx, withdrawalID, nil, model.WithdrawalStatusPaid, &actorID, nil)
+}
+
+// Выплата не прошла — средства остаются списанными до повтора или сторно
+func (s *WithdrawalService) MarkWithdrawalFailed(ctx context.Context, withdrawalID, actorID int64, reason string) error {
+	return s.changeStatus(ctx, withdrawalID, nil, model.WithdrawalStatusFailed, &actorID, &reason)
+}
+
+// Сторно: сумма заявки возвращается на награду
+func (s *WithdrawalService) ReverseWithdrawal(ctx context.Context, withdrawalID, actorID int64, reason string) error {
+	return s.changeStatus(ctx, withdrawalID, nil, model.WithdrawalStatusReversed, &actorID, &reason)
+}
+
+// История смены статусов заявки
+func (s *WithdrawalService) GetWithdrawalHistory(ctx context.Context, withdrawalID int64) ([]*model.WithdrawalTransition, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	return s.repo.FindTransitions(ctx, withdrawalID)
+}
+
+// changeStatus проверяет допустимость перехода, двигает деньги на награде
+// и пишет запись в историю — всё в одной транзакции.
+// ownerID задаётся, когда действие выполняет сам владелец заявки.
+func (s *WithdrawalService) changeStatus(
+	ctx context.Context,
+	withdrawalID int64,
+	ownerID *int64,
+	to model.WithdrawalStatus,
+	actorID *int64,
+	reason *string,
+) (err error) {
 	ctx, cancel := ctxutil.WithTimeout(ctx, 4)
 	defer cancel()
 	tx, err := s.db.Pool.Begin(ctx)
@@ -107,57 +239,106 @@ func (s *WithdrawalService) ApproveWithdrawal(ctx context.Context, withdrawalID
 	if err != nil {
 		return ErrNotFound
 	}
+	if ownerID != nil && withdrawal.UserID != *ownerID {
+		return ErrNotFound
+	}
 
-	if withdrawal.Status != model.WithdrawalStatusPending {
-		return ErrAlreadyProcessed
+	from := withdrawal.Status
+	if !from.CanTransitionTo(to) {
+		if from != model.WithdrawalStatusPending && to == model.WithdrawalStatusApproved {
+			return ErrAlreadyProcessed
+		}
+		return ErrInvalidTransition
 	}
 
-	reward, err := txRewardRepo.GetByID(ctx, withdrawal.RewardID)
+	now := time.Now()
+	var approvedAt, rejectedAt *time.Time
+
+	switch to {
+	case model.WithdrawalStatusApproved:
+		reward, err := txRewardRepo.GetByID(ctx, withdrawal.RewardID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrRewardNotFound
+		}
+		if err != nil {
+			return err
+		}
+		available := reward.Amount - reward.Withdrawn
+		if available < withdrawal.Amount {
+			return ErrInsufficientFunds
+		}
+		if err := txRewardRepo.UpdateWithdrawn(ctx, reward.ID, withdrawal.Amount); err != nil {
+			return err
+		}
+		approvedAt = &now
+	case model.WithdrawalStatusRejected:
+		rejectedAt = &now
+	case model.WithdrawalStatusReversed:
+		if err := txRewardRepo.UpdateWithdrawn(ctx, withdrawal.RewardID, -withdrawal.Amount); err != nil {
+			return err
+		}
+	}
+
+	err = txWithdrawalRepo.UpdateStatus(ctx, withdrawal.ID, from, to, approvedAt, rejectedAt, reason)
+	if errors.Is(err, withdrawal_infra.ErrStatusMismatch) {
+		return ErrAlreadyProcessed
+	}
 	if err != nil {
 		return err
 	}
 
-	available := reward.Amount - reward.Withdrawn
-	if available < withdrawal.Amount {
-		return ErrInsufficientFunds
+	err = txWithdrawalRepo.AddTransition(ctx, &model.WithdrawalTransition{
+		WithdrawalID: withdrawal.ID,
+		FromStatus:   from,
+		ToStatus:     to,
+		ActorID:      actorID,
+		Reason:       reason,
+	})
+	if err != nil {
+		return err
 	}
 
-	now := time.Now()
-	withdrawal.Status = model.WithdrawalStatusApproved
-	withdrawal.ApprovedAt = &now
+	// Отмену своей заявки пользователем в журнал администраторов не пишем
+	if ownerID != nil {
+		return nil
+	}
 
-	err = txWithdrawalRepo.UpdateStatus(ctx, withdrawal.ID, string(withdrawal.Status), withdrawal.ApprovedAt, nil, nil)
+	after, err := txWithdrawalRepo.GetByID(ctx, withdrawal.ID)
 	if err != nil {
 		return err
 	}
-
-	err = txRewardRepo.UpdateWithdrawn(ctx, reward.ID, withdrawal.Amount)
+	err = writeAudit(ctx, tx, actorID, audit_model.ActionWithdrawalStatus, audit_model.EntityWithdrawal, withdrawal.ID, withdrawal, after, reason)
 	if err != nil {
 		return err
 	}
 
+	events := map[model.WithdrawalStatus]outbox_model.EventType{
+		model.WithdrawalStatusApproved: outbox_model.EventWithdrawalApproved,
+		model.WithdrawalStatusRejected: outbox_model.EventWithdrawalRejected,
+	}
+	if eventType, ok := events[to]; ok {
+		return writeEvent(ctx, tx, eventType, outbox_model.AggregateWithdrawal, withdrawal.ID, withdrawalPayload(after, actorID, reason))
+	}
 	return nil
 }
 
-// Отклонение заявки на вывод
-func (s *WithdrawalService) RejectWithdrawal(ctx context.Context, withdrawalID int64, reason string) error {
-	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
-	defer cancel()
-	withdrawal, err := s.repo.GetByID(ctx, withdrawalID)
-	if err != nil {
-		return ErrNotFound
-	}
-
-	if withdrawal.Status != model.WithdrawalStatusPending {
-		return ErrAlreadyProcessed
+func withdrawalPayload(w *model.Withdrawal, actorID *int64, reason *string) outbox_model.WithdrawalPayload {
+	return outbox_model.WithdrawalPayload{
+		WithdrawalID:   w.ID,
+		UserID:         w.UserID,
+		RewardID:       w.RewardID,
+		Amount:         w.Amount,
+		PayoutMethodID: w.PayoutMethodID,
+		ActorID:        actorID,
+		Reason:         reason,
 	}
+}
 
-	withdrawal.Status = model.WithdrawalStatusRejected
-	now := time.Now()
-	withdrawal.RejectedAt = &now
-	withdrawal.Reason = &reason
-
-	return s.repo.UpdateStatus(ctx, withdrawal.ID, string(withdrawal.Status), nil, withdrawal.RejectedAt, &reason)
+// Заявка по ID
+func (s *WithdrawalService) GetWithdrawalByID(ctx context.Context, withdrawalID int64) (*model.Withdrawal, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	return s.repo.GetByID(ctx, withdrawalID)
 }
 
 // Список заявок конкретного пользователя
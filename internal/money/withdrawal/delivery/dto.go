@@ -1,11 +1,22 @@
 package withdrawalhttp
 
 type CreateWithdrawalRequest struct {
-	RewardID int64   `json:"reward_id" validate:"required"`
-	Amount   float64 `json:"amount" validate:"required"`
+	RewardID int64 `json:"reward_id" validate:"required"`
+	// Без payout_method_id используются основные реквизиты
+	PayoutMethodID *int64  `json:"payout_method_id,omitempty"`
+	Amount         float64 `json:"amount" validate:"required"`
 }
 
 type AdminRejectWithdrawalRequest struct {
 	WithdrawalID int64  `json:"withdrawal_id" validate:"required"`
 	Reason       string `json:"reason" validate:"required"`
 }
+
+type CancelWithdrawalRequest struct {
+	WithdrawalID int64 `json:"withdrawal_id" validate:"required"`
+}
+
+type AdminWithdrawalStatusRequest struct {
+	WithdrawalID int64  `json:"withdrawal_id" validate:"required"`
+	Reason       string `json:"reason,omitempty"`
+}
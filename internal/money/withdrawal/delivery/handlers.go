@@ -2,9 +2,12 @@ package withdrawalhttp
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
-	"github.com/Vovarama1992/emelya-go/internal/jwtutil"
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	approval_model "github.com/Vovarama1992/emelya-go/internal/money/approval/model"
 	service "github.com/Vovarama1992/emelya-go/internal/money/usecase"
 	"github.com/go-playground/validator/v10"
 )
@@ -13,11 +16,13 @@ var validate = validator.New()
 
 type Handler struct {
 	withdrawalService *service.WithdrawalService
+	approvalService   *service.ApprovalService
 }
 
-func NewHandler(withdrawalService *service.WithdrawalService) *Handler {
+func NewHandler(withdrawalService *service.WithdrawalService, approvalService *service.ApprovalService) *Handler {
 	return &Handler{
 		withdrawalService: withdrawalService,
+		approvalService:   approvalService,
 	}
 }
 
@@ -28,7 +33,7 @@ func NewHandler(withdrawalService *service.WithdrawalService) *Handler {
 // @Produce json
 // @Param data body CreateWithdrawalRequest true "Данные заявки на вывод"
 // @Success 200 {object} map[string]string
-// @Failure 400,401,500 {object} map[string]string
+// @Failure 400,401,403,404,500 {object} map[string]string
 // @Router /api/withdrawal/request [post]
 func (h *Handler) CreateWithdrawal(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -36,16 +41,7 @@ func (h *Handler) CreateWithdrawal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authHeader := r.Header.Get("Authorization")
-	if authHeader == "" {
-		respondWithError(w, http.StatusUnauthorized, "Токен отсутствует")
-		return
-	}
-	userID, err := jwtutil.ParseToken(authHeader[len("Bearer "):])
-	if err != nil {
-		respondWithError(w, http.StatusUnauthorized, "Неверный токен")
-		return
-	}
+	user := middleware.GetUserFromContext(r.Context())
 
 	var req CreateWithdrawalRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -57,8 +53,8 @@ func (h *Handler) CreateWithdrawal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.withdrawalService.CreateWithdrawal(r.Context(), int64(userID), req.RewardID, req.Amount); err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Не удалось создать заявку")
+	if err := h.withdrawalService.CreateWithdrawal(r.Context(), user.ID, req.RewardID, req.PayoutMethodID, req.Amount); err != nil {
+		respondWithServiceError(w, err, "Не удалось создать заявку")
 		return
 	}
 
@@ -78,18 +74,9 @@ func (h *Handler) GetMyWithdrawals(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authHeader := r.Header.Get("Authorization")
-	if authHeader == "" {
-		respondWithError(w, http.StatusUnauthorized, "Токен отсутствует")
-		return
-	}
-	userID, err := jwtutil.ParseToken(authHeader[len("Bearer "):])
-	if err != nil {
-		respondWithError(w, http.StatusUnauthorized, "Неверный токен")
-		return
-	}
+	userID := middleware.GetUserFromContext(r.Context()).ID
 
-	withdrawals, err := h.withdrawalService.ListWithdrawalsByUser(r.Context(), int64(userID))
+	withdrawals, err := h.withdrawalService.ListWithdrawalsByUser(r.Context(), userID)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Ошибка получения заявок")
 		return
@@ -153,6 +140,7 @@ type AdminApproveWithdrawalRequest struct {
 // @Produce json
 // @Param data body AdminApproveWithdrawalRequest true "ID заявки"
 // @Success 200 {object} map[string]string
+// @Success 202 {object} map[string]interface{} "approval_id"
 // @Failure 400,500 {object} map[string]string
 // @Router /api/admin/withdrawal/approve [post]
 func (h *Handler) AdminApproveWithdrawal(w http.ResponseWriter, r *http.Request) {
@@ -171,8 +159,14 @@ func (h *Handler) AdminApproveWithdrawal(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if err := h.withdrawalService.ApproveWithdrawal(r.Context(), req.WithdrawalID); err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Не удалось подтвердить заявку")
+	admin := middleware.GetUserFromContext(r.Context())
+	approval, err := h.approvalService.SubmitWithdrawalApprove(r.Context(), req.WithdrawalID, admin.ID)
+	if err != nil {
+		respondWithServiceError(w, err, "Не удалось подтвердить заявку")
+		return
+	}
+	if approval != nil {
+		respondApprovalRequired(w, approval)
 		return
 	}
 
@@ -204,14 +198,201 @@ func (h *Handler) AdminRejectWithdrawal(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if err := h.withdrawalService.RejectWithdrawal(r.Context(), req.WithdrawalID, req.Reason); err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Не удалось отклонить заявку")
+	admin := middleware.GetUserFromContext(r.Context())
+	if err := h.withdrawalService.RejectWithdrawal(r.Context(), req.WithdrawalID, admin.ID, req.Reason); err != nil {
+		respondWithServiceError(w, err, "Не удалось отклонить заявку")
 		return
 	}
 
 	json.NewEncoder(w).Encode(map[string]string{"message": "Заявка отклонена"})
 }
 
+// CancelWithdrawal godoc
+// @Summary Юзер: отменить свою заявку на вывод (только pending)
+// @Tags withdrawal
+// @Accept json
+// @Produce json
+// @Param data body CancelWithdrawalRequest true "ID заявки"
+// @Success 200 {object} map[string]string
+// @Failure 400,401,404,409,500 {object} map[string]string
+// @Router /api/withdrawal/cancel [post]
+func (h *Handler) CancelWithdrawal(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req CancelWithdrawalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	if err := h.withdrawalService.CancelWithdrawal(r.Context(), user.ID, req.WithdrawalID); err != nil {
+		respondWithServiceError(w, err, "Не удалось отменить заявку")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Заявка отменена"})
+}
+
+// AdminMarkWithdrawalProcessing godoc
+// @Summary Админ: выплата по заявке начата
+// @Tags admin-withdrawal
+// @Accept json
+// @Produce json
+// @Param data body AdminWithdrawalStatusRequest true "ID заявки"
+// @Success 200 {object} map[string]string
+// @Failure 400,404,409,500 {object} map[string]string
+// @Router /api/admin/withdrawal/processing [post]
+func (h *Handler) AdminMarkWithdrawalProcessing(w http.ResponseWriter, r *http.Request) {
+	h.adminChangeStatus(w, r, "Заявка передана в выплату", func(req AdminWithdrawalStatusRequest, actorID int64) error {
+		return h.withdrawalService.MarkWithdrawalProcessing(r.Context(), req.WithdrawalID, actorID)
+	})
+}
+
+// AdminMarkWithdrawalPaid godoc
+// @Summary Админ: заявка выплачена
+// @Tags admin-withdrawal
+// @Accept json
+// @Produce json
+// @Param data body AdminWithdrawalStatusRequest true "ID заявки"
+// @Success 200 {object} map[string]string
+// @Failure 400,404,409,500 {object} map[string]string
+// @Router /api/admin/withdrawal/paid [post]
+func (h *Handler) AdminMarkWithdrawalPaid(w http.ResponseWriter, r *http.Request) {
+	h.adminChangeStatus(w, r, "Заявка отмечена выплаченной", func(req AdminWithdrawalStatusRequest, actorID int64) error {
+		return h.withdrawalService.MarkWithdrawalPaid(r.Context(), req.WithdrawalID, actorID)
+	})
+}
+
+// AdminMarkWithdrawalFailed godoc
+// @Summary Админ: выплата по заявке не прошла
+// @Tags admin-withdrawal
+// @Accept json
+// @Produce json
+// @Param data body AdminWithdrawalStatusRequest true "ID заявки и причина"
+// @Success 200 {object} map[string]string
+// @Failure 400,404,409,500 {object} map[string]string
+// @Router /api/admin/withdrawal/failed [post]
+func (h *Handler) AdminMarkWithdrawalFailed(w http.ResponseWriter, r *http.Request) {
+	h.adminChangeStatus(w, r, "Заявка отмечена неуспешной", func(req AdminWithdrawalStatusRequest, actorID int64) error {
+		return h.withdrawalService.MarkWithdrawalFailed(r.Context(), req.WithdrawalID, actorID, req.Reason)
+	})
+}
+
+// AdminReverseWithdrawal godoc
+// @Summary Админ: сторнировать заявку (средства возвращаются на награду)
+// @Tags admin-withdrawal
+// @Accept json
+// @Produce json
+// @Param data body AdminWithdrawalStatusRequest true "ID заявки и причина"
+// @Success 200 {object} map[string]string
+// @Failure 400,404,409,500 {object} map[string]string
+// @Router /api/admin/withdrawal/reverse [post]
+func (h *Handler) AdminReverseWithdrawal(w http.ResponseWriter, r *http.Request) {
+	h.adminChangeStatus(w, r, "Заявка сторнирована", func(req AdminWithdrawalStatusRequest, actorID int64) error {
+		return h.withdrawalService.ReverseWithdrawal(r.Context(), req.WithdrawalID, actorID, req.Reason)
+	})
+}
+
+// AdminGetWithdrawalHistory godoc
+// @Summary Админ: история смены статусов заявки
+// @Tags admin-withdrawal
+// @Produce json
+// @Param withdrawal_id query int true "ID заявки"
+// @Success 200 {array} interface{}
+// @Failure 400,500 {object} map[string]string
+// @Router /api/admin/withdrawal/history [get]
+func (h *Handler) AdminGetWithdrawalHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	withdrawalID, err := strconv.ParseInt(r.URL.Query().Get("withdrawal_id"), 10, 64)
+	if err != nil || withdrawalID <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Некорректный withdrawal_id")
+		return
+	}
+
+	history, err := h.withdrawalService.GetWithdrawalHistory(r.Context(), withdrawalID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения истории")
+		return
+	}
+
+	json.NewEncoder(w).Encode(history)
+}
+
+// adminChangeStatus — общий разбор запроса для админских переходов статуса
+func (h *Handler) adminChangeStatus(
+	w http.ResponseWriter,
+	r *http.Request,
+	successMessage string,
+	change func(req AdminWithdrawalStatusRequest, actorID int64) error,
+) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req AdminWithdrawalStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	if err := change(req, admin.ID); err != nil {
+		respondWithServiceError(w, err, "Не удалось сменить статус заявки")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": successMessage})
+}
+
+// respondWithServiceError переводит ошибки сервиса в HTTP-коды
+func respondWithServiceError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrNotFound),
+		errors.Is(err, service.ErrPayoutMethodNotFound),
+		errors.Is(err, service.ErrRewardNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrAlreadyProcessed),
+		errors.Is(err, service.ErrInvalidTransition),
+		errors.Is(err, service.ErrApprovalDuplicate):
+		respondWithError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrInsufficientFunds),
+		errors.Is(err, service.ErrNoPayoutMethod),
+		errors.Is(err, service.ErrPayoutMethodNotVerified):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrKYCLimitExceeded),
+		errors.Is(err, service.ErrContactChangeCooldown):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// respondApprovalRequired — операция выше порога ждёт второго администратора
+func respondApprovalRequired(w http.ResponseWriter, approval *approval_model.Approval) {
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]any{
+		"message":     "Операция ждёт подтверждения второго администратора",
+		"approval_id": approval.ID,
+	})
+}
+
 func respondWithError(w http.ResponseWriter, code int, message string) {
 	w.WriteHeader(code)
 	json.NewEncoder(w).Encode(map[string]string{"error": message})
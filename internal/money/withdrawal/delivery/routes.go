@@ -2,14 +2,16 @@ package withdrawalhttp
 
 import (
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
 	ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
 	"github.com/Vovarama1992/go-utils/httputil"
 )
 
-func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService ports.UserServiceInterface) {
+func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService ports.UserServiceInterface, sessions middleware.SessionChecker, access middleware.PermissionChecker) {
 	withRecover := func(h http.Handler) http.Handler {
 		return httputil.RecoverMiddleware(h)
 	}
@@ -18,33 +20,69 @@ func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService ports.User
 		return httputil.RecoverMiddleware(httputil.NewRateLimiter(3, time.Minute)(h))
 	}
 
-	withAdminAuth := func(h http.Handler) http.Handler {
-		return middleware.AuthMiddleware(userService, true)(h)
+	withUserAuth := func(h http.Handler) http.Handler {
+		return middleware.AuthMiddleware(userService, sessions)(h)
+	}
+
+	withPermission := func(perm rbac.Permission, h http.Handler) http.Handler {
+		return middleware.PermissionMiddleware(userService, sessions, access, perm)(h)
+	}
+
+	// Вывод только с подтверждённым email, если включено WITHDRAWAL_REQUIRE_VERIFIED_EMAIL=true
+	withWithdrawalAuth := withUserAuth
+	if os.Getenv("WITHDRAWAL_REQUIRE_VERIFIED_EMAIL") == "true" {
+		withWithdrawalAuth = func(h http.Handler) http.Handler {
+			return middleware.AuthMiddleware(userService, sessions, middleware.RequireVerifiedEmail())(h)
+		}
 	}
 
 	// === USER ===
 	mux.Handle("/api/withdrawal/request",
-		withRecoverAndRateLimit(http.HandlerFunc(handler.CreateWithdrawal)),
+		withRecoverAndRateLimit(withWithdrawalAuth(http.HandlerFunc(handler.CreateWithdrawal))),
 	)
 
 	mux.Handle("/api/withdrawal/my",
-		withRecoverAndRateLimit(http.HandlerFunc(handler.GetMyWithdrawals)),
+		withRecoverAndRateLimit(withUserAuth(http.HandlerFunc(handler.GetMyWithdrawals))),
+	)
+
+	mux.Handle("/api/withdrawal/cancel",
+		withRecoverAndRateLimit(withUserAuth(http.HandlerFunc(handler.CancelWithdrawal))),
 	)
 
 	// === ADMIN ===
 	mux.Handle("/api/admin/withdrawal/all",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.AdminGetAllWithdrawals))),
+		withRecover(withPermission(rbac.PermWithdrawalsRead, http.HandlerFunc(handler.AdminGetAllWithdrawals))),
 	)
 
 	mux.Handle("/api/admin/withdrawal/pending",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.AdminGetPendingWithdrawals))),
+		withRecover(withPermission(rbac.PermWithdrawalsRead, http.HandlerFunc(handler.AdminGetPendingWithdrawals))),
 	)
 
 	mux.Handle("/api/admin/withdrawal/approve",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.AdminApproveWithdrawal))),
+		withRecover(withPermission(rbac.PermWithdrawalsManage, http.HandlerFunc(handler.AdminApproveWithdrawal))),
 	)
 
 	mux.Handle("/api/admin/withdrawal/reject",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.AdminRejectWithdrawal))),
+		withRecover(withPermission(rbac.PermWithdrawalsManage, http.HandlerFunc(handler.AdminRejectWithdrawal))),
+	)
+
+	mux.Handle("/api/admin/withdrawal/processing",
+		withRecover(withPermission(rbac.PermWithdrawalsManage, http.HandlerFunc(handler.AdminMarkWithdrawalProcessing))),
+	)
+
+	mux.Handle("/api/admin/withdrawal/paid",
+		withRecover(withPermission(rbac.PermWithdrawalsManage, http.HandlerFunc(handler.AdminMarkWithdrawalPaid))),
+	)
+
+	mux.Handle("/api/admin/withdrawal/failed",
+		withRecover(withPermission(rbac.PermWithdrawalsManage, http.HandlerFunc(handler.AdminMarkWithdrawalFailed))),
+	)
+
+	mux.Handle("/api/admin/withdrawal/reverse",
+		withRecover(withPermission(rbac.PermWithdrawalsManage, http.HandlerFunc(handler.AdminReverseWithdrawal))),
+	)
+
+	mux.Handle("/api/admin/withdrawal/history",
+		withRecover(withPermission(rbac.PermWithdrawalsRead, http.HandlerFunc(handler.AdminGetWithdrawalHistory))),
 	)
 }
@@ -2,6 +2,7 @@ package withdrawal_infra
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/Vovarama1992/emelya-go/internal/db"
@@ -17,6 +18,9 @@ type PgxQuerier interface {
 	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
 }
 
+// Статус заявки уже изменился с момента чтения
+var ErrStatusMismatch = errors.New("статус заявки изменился")
+
 type WithdrawalRepository struct {
 	querier PgxQuerier
 }
@@ -31,13 +35,14 @@ func NewWithdrawalRepositoryWithTx(tx pgx.Tx) *WithdrawalRepository {
 
 func (r *WithdrawalRepository) Create(ctx context.Context, w *model.Withdrawal) error {
 	query := `
-		INSERT INTO withdrawals (user_id, reward_id, amount, status, created_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO withdrawals (user_id, reward_id, payout_method_id, amount, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id
 	`
 	err := r.querier.QueryRow(ctx, query,
 		w.UserID,
 		w.RewardID,
+		w.PayoutMethodID,
 		w.Amount,
 		w.Status,
 		time.Now(),
@@ -45,19 +50,89 @@ func (r *WithdrawalRepository) Create(ctx context.Context, w *model.Withdrawal)
 	return err
 }
 
-func (r *WithdrawalRepository) UpdateStatus(ctx context.Context, id int64, status string, approvedAt, rejectedAt *time.Time, reason *string) error {
+// UpdateStatus меняет статус только если заявка всё ещё в статусе from
+func (r *WithdrawalRepository) UpdateStatus(ctx context.Context, id int64, from, to model.WithdrawalStatus, approvedAt, rejectedAt *time.Time, reason *string) error {
 	query := `
 		UPDATE withdrawals
-		SET status = $1, approved_at = $2, rejected_at = $3, reason = $4
-		WHERE id = $5
+		SET status = $1,
+		    approved_at = COALESCE($2, approved_at),
+		    rejected_at = COALESCE($3, rejected_at),
+		    reason = COALESCE($4, reason)
+		WHERE id = $5 AND status = $6
 	`
-	_, err := r.querier.Exec(ctx, query, status, approvedAt, rejectedAt, reason, id)
-	return err
+	tag, err := r.querier.Exec(ctx, query, to, approvedAt, rejectedAt, reason, id, from)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrStatusMismatch
+	}
+	return nil
+}
+
+func (r *WithdrawalRepository) AddTransition(ctx context.Context, t *model.WithdrawalTransition) error {
+	query := `
+		INSERT INTO withdrawal_transitions (withdrawal_id, from_status, to_status, actor_id, reason)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	return r.querier.QueryRow(ctx, query,
+		t.WithdrawalID,
+		t.FromStatus,
+		t.ToStatus,
+		t.ActorID,
+		t.Reason,
+	).Scan(&t.ID, &t.CreatedAt)
+}
+
+func (r *WithdrawalRepository) FindTransitions(ctx context.Context, withdrawalID int64) ([]*model.WithdrawalTransition, error) {
+	query := `
+		SELECT id, withdrawal_id, from_status, to_status, actor_id, reason, created_at
+		FROM withdrawal_transitions
+		WHERE withdrawal_id = $1
+		ORDER BY created_at ASC, id ASC
+	`
+	rows, err := r.querier.Query(ctx, query, withdrawalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transitions []*model.WithdrawalTransition
+	for rows.Next() {
+		var t model.WithdrawalTransition
+		if err := rows.Scan(
+			&t.ID,
+			&t.WithdrawalID,
+			&t.FromStatus,
+			&t.ToStatus,
+			&t.ActorID,
+			&t.Reason,
+			&t.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		transitions = append(transitions, &t)
+	}
+	return transitions, nil
+}
+
+// SumSinceByUserID — сумма заявок пользователя с момента since, кроме отменённых,
+// отклонённых и сторнированных
+func (r *WithdrawalRepository) SumSinceByUserID(ctx context.Context, userID int64, since time.Time) (float64, error) {
+	var total float64
+	query := `
+		SELECT COALESCE(SUM(amount), 0) FROM withdrawals
+		WHERE user_id = $1 AND created_at >= $2
+		  AND status NOT IN ('cancelled', 'rejected', 'reversed')
+	`
+	err := r.querier.QueryRow(ctx, query, userID, since).Scan(&total)
+	return total, err
 }
 
 func (r *WithdrawalRepository) FindByUserID(ctx context.Context, userID int64) ([]*model.Withdrawal, error) {
 	query := `
-		SELECT id, user_id, reward_id, amount, status, created_at, approved_at, rejected_at, reason
+		SELECT id, user_id, reward_id, payout_method_id, amount, status, created_at, approved_at, rejected_at, reason
 		FROM withdrawals
 		WHERE user_id = $1
 	`
@@ -74,6 +149,7 @@ func (r *WithdrawalRepository) FindByUserID(ctx context.Context, userID int64) (
 			&w.ID,
 			&w.UserID,
 			&w.RewardID,
+			&w.PayoutMethodID,
 			&w.Amount,
 			&w.Status,
 			&w.CreatedAt,
@@ -90,7 +166,7 @@ func (r *WithdrawalRepository) FindByUserID(ctx context.Context, userID int64) (
 
 func (r *WithdrawalRepository) GetByID(ctx context.Context, id int64) (*model.Withdrawal, error) {
 	query := `
-		SELECT id, user_id, reward_id, amount, status, created_at, approved_at, rejected_at, reason
+		SELECT id, user_id, reward_id, payout_method_id, amount, status, created_at, approved_at, rejected_at, reason
 		FROM withdrawals
 		WHERE id = $1
 	`
@@ -99,6 +175,7 @@ func (r *WithdrawalRepository) GetByID(ctx context.Context, id int64) (*model.Wi
 		&w.ID,
 		&w.UserID,
 		&w.RewardID,
+		&w.PayoutMethodID,
 		&w.Amount,
 		&w.Status,
 		&w.CreatedAt,
@@ -114,7 +191,7 @@ func (r *WithdrawalRepository) GetByID(ctx context.Context, id int64) (*model.Wi
 
 func (r *WithdrawalRepository) FindAll(ctx context.Context) ([]*model.Withdrawal, error) {
 	query := `
-		SELECT id, user_id, reward_id, amount, status, created_at, approved_at, rejected_at, reason
+		SELECT id, user_id, reward_id, payout_method_id, amount, status, created_at, approved_at, rejected_at, reason
 		FROM withdrawals
 		ORDER BY created_at DESC
 	`
@@ -131,6 +208,7 @@ func (r *WithdrawalRepository) FindAll(ctx context.Context) ([]*model.Withdrawal
 			&w.ID,
 			&w.UserID,
 			&w.RewardID,
+			&w.PayoutMethodID,
 			&w.Amount,
 			&w.Status,
 			&w.CreatedAt,
@@ -145,9 +223,45 @@ func (r *WithdrawalRepository) FindAll(ctx context.Context) ([]*model.Withdrawal
 	return withdrawals, nil
 }
 
+// FindCreatedBetween — заявки, созданные за [from, to), для выгрузки бухгалтерии
+func (r *WithdrawalRepository) FindCreatedBetween(ctx context.Context, from, to time.Time) ([]*model.Withdrawal, error) {
+	query := `
+		SELECT id, user_id, reward_id, payout_method_id, amount, status, created_at, approved_at, rejected_at, reason
+		FROM withdrawals
+		WHERE created_at >= $1 AND created_at < $2
+		ORDER BY created_at
+	`
+	rows, err := r.querier.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var withdrawals []*model.Withdrawal
+	for rows.Next() {
+		var w model.Withdrawal
+		if err := rows.Scan(
+			&w.ID,
+			&w.UserID,
+			&w.RewardID,
+			&w.PayoutMethodID,
+			&w.Amount,
+			&w.Status,
+			&w.CreatedAt,
+			&w.ApprovedAt,
+			&w.RejectedAt,
+			&w.Reason,
+		); err != nil {
+			return nil, err
+		}
+		withdrawals = append(withdrawals, &w)
+	}
+	return withdrawals, rows.Err()
+}
+
 func (r *WithdrawalRepository) FindAllPendings(ctx context.Context) ([]*model.Withdrawal, error) {
 	query := `
-		SELECT id, user_id, reward_id, amount, status, created_at, approved_at, rejected_at, reason
+		SELECT id, user_id, reward_id, payout_method_id, amount, status, created_at, approved_at, rejected_at, reason
 		FROM withdrawals
 		WHERE status = 'pending'
 		ORDER BY created_at ASC
@@ -165,6 +279,7 @@ func (r *WithdrawalRepository) FindAllPendings(ctx context.Context) ([]*model.Wi
 			&w.ID,
 			&w.UserID,
 			&w.RewardID,
+			&w.PayoutMethodID,
 			&w.Amount,
 			&w.Status,
 			&w.CreatedAt,
@@ -5,19 +5,55 @@ import "time"
 type WithdrawalStatus string
 
 const (
-	WithdrawalStatusPending  WithdrawalStatus = "pending"
-	WithdrawalStatusApproved WithdrawalStatus = "approved"
-	WithdrawalStatusRejected WithdrawalStatus = "rejected"
+	WithdrawalStatusPending    WithdrawalStatus = "pending"    // ожидает решения оператора
+	WithdrawalStatusCancelled  WithdrawalStatus = "cancelled"  // отменена пользователем
+	WithdrawalStatusApproved   WithdrawalStatus = "approved"   // одобрена, средства списаны с награды
+	WithdrawalStatusRejected   WithdrawalStatus = "rejected"   // отклонена оператором
+	WithdrawalStatusProcessing WithdrawalStatus = "processing" // выплата в процессе
+	WithdrawalStatusPaid       WithdrawalStatus = "paid"       // выплачена
+	WithdrawalStatusFailed     WithdrawalStatus = "failed"     // выплата не прошла
+	WithdrawalStatusReversed   WithdrawalStatus = "reversed"   // сторнирована, средства возвращены на награду
 )
 
+// Разрешённые переходы между статусами заявки
+var withdrawalTransitions = map[WithdrawalStatus][]WithdrawalStatus{
+	WithdrawalStatusPending:    {WithdrawalStatusCancelled, WithdrawalStatusApproved, WithdrawalStatusRejected},
+	WithdrawalStatusApproved:   {WithdrawalStatusProcessing, WithdrawalStatusReversed},
+	WithdrawalStatusProcessing: {WithdrawalStatusPaid, WithdrawalStatusFailed},
+	WithdrawalStatusFailed:     {WithdrawalStatusProcessing, WithdrawalStatusReversed},
+	WithdrawalStatusPaid:       {WithdrawalStatusReversed},
+}
+
+func (s WithdrawalStatus) CanTransitionTo(next WithdrawalStatus) bool {
+	for _, allowed := range withdrawalTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
 type Withdrawal struct {
-	ID         int64            `json:"id"`
-	UserID     int64            `json:"user_id"`
-	RewardID   int64            `json:"reward_id"`
-	Amount     float64          `json:"amount"`
-	Status     WithdrawalStatus `json:"status"`
-	CreatedAt  time.Time        `json:"created_at"`
-	ApprovedAt *time.Time       `json:"approved_at,omitempty"`
-	RejectedAt *time.Time       `json:"rejected_at,omitempty"`
-	Reason     *string          `json:"reason,omitempty"`
+	ID       int64 `json:"id"`
+	UserID   int64 `json:"user_id"`
+	RewardID int64 `json:"reward_id"`
+	// Реквизиты, на которые выводятся средства; у старых заявок не заданы
+	PayoutMethodID *int64           `json:"payout_method_id,omitempty"`
+	Amount         float64          `json:"amount"`
+	Status         WithdrawalStatus `json:"status"`
+	CreatedAt      time.Time        `json:"created_at"`
+	ApprovedAt     *time.Time       `json:"approved_at,omitempty"`
+	RejectedAt     *time.Time       `json:"rejected_at,omitempty"`
+	Reason         *string          `json:"reason,omitempty"`
+}
+
+// WithdrawalTransition — запись в истории смены статусов заявки
+type WithdrawalTransition struct {
+	ID           int64            `json:"id"`
+	WithdrawalID int64            `json:"withdrawal_id"`
+	FromStatus   WithdrawalStatus `json:"from_status"`
+	ToStatus     WithdrawalStatus `json:"to_status"`
+	ActorID      *int64           `json:"actor_id,omitempty"`
+	Reason       *string          `json:"reason,omitempty"`
+	CreatedAt    time.Time        `json:"created_at"`
 }
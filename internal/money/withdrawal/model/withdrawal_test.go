@@ -0,0 +1,35 @@
+package withdrawal_model
+
+import "testing"
+
+func TestWithdrawalStatusCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to WithdrawalStatus
+		want     bool
+	}{
+		{WithdrawalStatusPending, WithdrawalStatusApproved, true},
+		{WithdrawalStatusPending, WithdrawalStatusRejected, true},
+		{WithdrawalStatusPending, WithdrawalStatusCancelled, true},
+		{WithdrawalStatusPending, WithdrawalStatusPaid, false},
+		{WithdrawalStatusApproved, WithdrawalStatusProcessing, true},
+		{WithdrawalStatusApproved, WithdrawalStatusReversed, true},
+		{WithdrawalStatusApproved, WithdrawalStatusPending, false},
+		{WithdrawalStatusProcessing, WithdrawalStatusPaid, true},
+		{WithdrawalStatusProcessing, WithdrawalStatusFailed, true},
+		{WithdrawalStatusProcessing, WithdrawalStatusReversed, false},
+		{WithdrawalStatusFailed, WithdrawalStatusProcessing, true},
+		{WithdrawalStatusFailed, WithdrawalStatusReversed, true},
+		{WithdrawalStatusPaid, WithdrawalStatusReversed, true},
+		{WithdrawalStatusPaid, WithdrawalStatusFailed, false},
+		// Конечные статусы
+		{WithdrawalStatusRejected, WithdrawalStatusApproved, false},
+		{WithdrawalStatusCancelled, WithdrawalStatusPending, false},
+		{WithdrawalStatusReversed, WithdrawalStatusPaid, false},
+		{WithdrawalStatusPending, WithdrawalStatusPending, false},
+	}
+	for _, tt := range tests {
+		if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+			t.Errorf("%s -> %s: got %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package notificationhttp
+
+import (
+	model "github.com/Vovarama1992/emelya-go/internal/notification/model"
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+)
+
+// UpdatePreferencesRequest — меняются только переданные категории и каналы
+type UpdatePreferencesRequest struct {
+	Channels          map[model.Category]map[notifier.ChannelKind]bool `json:"channels" swaggertype:"object"`
+	QuietHours        *model.QuietHours                                `json:"quiet_hours,omitempty"`
+	DisableQuietHours bool                                             `json:"disable_quiet_hours,omitempty"`
+}
+
+// UpdateTemplateRequest — новые тексты шаблона; пустая часть — в этот канал не пишем
+type UpdateTemplateRequest struct {
+	Key     string `json:"key" validate:"required"`
+	Locale  string `json:"locale,omitempty"`
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	HTML    string `json:"html"`
+	SMS     string `json:"sms"`
+}
+
+type ResetTemplateRequest struct {
+	Key    string `json:"key" validate:"required"`
+	Locale string `json:"locale,omitempty"`
+}
+
+// PreviewTemplateRequest — без draft показывается сохранённый шаблон, без data — пример данных
+type PreviewTemplateRequest struct {
+	Key    string             `json:"key" validate:"required"`
+	Locale string             `json:"locale,omitempty"`
+	Draft  *notifier.Template `json:"draft,omitempty"`
+	Data   map[string]any     `json:"data,omitempty" swaggertype:"object"`
+}
+
+// MarkReadRequest — ID уведомлений или all=true, чтобы прочитать всё
+type MarkReadRequest struct {
+	IDs []int64 `json:"ids"`
+	All bool    `json:"all"`
+}
+
+type RetryEmailRequest struct {
+	ID int64 `json:"id" validate:"required"`
+}
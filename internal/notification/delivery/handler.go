@@ -0,0 +1,680 @@
+package notificationhttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	model "github.com/Vovarama1992/emelya-go/internal/notification/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/notification/ports"
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+// Пауза между пингами live-канала: прокси не закрывают молчащее соединение
+const streamHeartbeat = 25 * time.Second
+
+type Handler struct {
+	prefsService    ports.PreferencesService
+	templateService ports.TemplateService
+	inboxService    ports.InboxService
+	emailService    ports.EmailQueueService
+	smsService      ports.SmsService
+	sessions        middleware.SessionChecker
+}
+
+func NewHandler(prefsService ports.PreferencesService, templateService ports.TemplateService, inboxService ports.InboxService, emailService ports.EmailQueueService, smsService ports.SmsService, sessions middleware.SessionChecker) *Handler {
+	return &Handler{prefsService: prefsService, templateService: templateService, inboxService: inboxService, emailService: emailService, smsService: smsService, sessions: sessions}
+}
+
+// GetMyPreferences godoc
+// @Summary Юзер: настройки уведомлений — каналы по категориям и тихие часы
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} model.Preferences
+// @Failure 401,500 {object} map[string]string
+// @Router /api/notification-settings/my [get]
+func (h *Handler) GetMyPreferences(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	prefs, err := h.prefsService.GetPreferences(r.Context(), user.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения настроек")
+		return
+	}
+
+	json.NewEncoder(w).Encode(prefs)
+}
+
+// UpdatePreferences godoc
+// @Summary Юзер: изменить настройки уведомлений
+// @Description Категории: deposits, accruals, withdrawals. Каналы: sms, email, inapp. SMS в тихие часы откладываются до их окончания.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param data body UpdatePreferencesRequest true "Каналы и тихие часы"
+// @Success 200 {object} model.Preferences
+// @Failure 400,401,500 {object} map[string]string
+// @Router /api/notification-settings/update [post]
+func (h *Handler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req UpdatePreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	prefs, err := h.prefsService.UpdatePreferences(r.Context(), user.ID, model.PreferencesUpdate{
+		Channels:          req.Channels,
+		QuietHours:        req.QuietHours,
+		DisableQuietHours: req.DisableQuietHours,
+	})
+	if err != nil {
+		respondWithServiceError(w, err, "Не удалось сохранить настройки")
+		return
+	}
+
+	json.NewEncoder(w).Encode(prefs)
+}
+
+// GetMyNotifications godoc
+// @Summary Юзер: лента уведомлений, новые первыми
+// @Tags notifications
+// @Produce json
+// @Param unread query bool false "Только непрочитанные"
+// @Param before_id query int false "Курсор: next_before_id предыдущей страницы"
+// @Param limit query int false "Размер страницы (по умолчанию 20, не больше 100)"
+// @Success 200 {object} model.InboxPage
+// @Failure 400,401,500 {object} map[string]string
+// @Router /api/notifications/my [get]
+func (h *Handler) GetMyNotifications(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	filter := model.InboxFilter{UserID: user.ID, UnreadOnly: r.URL.Query().Get("unread") == "true"}
+
+	if v := r.URL.Query().Get("before_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Некорректный before_id")
+			return
+		}
+		filter.BeforeID = id
+	}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Некорректный limit")
+			return
+		}
+		filter.Limit = limit
+	}
+
+	page, err := h.inboxService.List(r.Context(), filter)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения уведомлений")
+		return
+	}
+
+	json.NewEncoder(w).Encode(page)
+}
+
+// GetUnreadCount godoc
+// @Summary Юзер: число непрочитанных уведомлений
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} map[string]int
+// @Failure 401,500 {object} map[string]string
+// @Router /api/notifications/unread-count [get]
+func (h *Handler) GetUnreadCount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	unread, err := h.inboxService.CountUnread(r.Context(), user.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения уведомлений")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]int{"unread": unread})
+}
+
+// MarkNotificationsRead godoc
+// @Summary Юзер: отметить уведомления прочитанными
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param data body MarkReadRequest true "ID уведомлений или all"
+// @Success 200 {object} map[string]int
+// @Failure 400,401,500 {object} map[string]string
+// @Router /api/notifications/read [post]
+func (h *Handler) MarkNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req MarkReadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if !req.All && len(req.IDs) == 0 {
+		respondWithError(w, http.StatusBadRequest, "Передайте ids или all")
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	unread, err := h.inboxService.MarkRead(r.Context(), user.ID, req.IDs, req.All)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Не удалось отметить уведомления")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]int{"unread": unread})
+}
+
+// StreamNotifications godoc
+// @Summary Юзер: live-канал ленты (Server-Sent Events)
+// @Description События: unread — число непрочитанных при подключении; notification — новое уведомление; read — изменилось число непрочитанных. Токен передаётся заголовком Authorization, поэтому на фронте нужен EventSource с поддержкой заголовков (fetch-стрим). Поток закрывается, когда истекает access-токен или сессия завершена: переподключайтесь со свежим токеном.
+// @Tags notifications
+// @Produce text/event-stream
+// @Success 200 {object} model.InboxEvent
+// @Failure 401,500 {object} map[string]string
+// @Router /api/notifications/stream [get]
+func (h *Handler) StreamNotifications(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		respondWithError(w, http.StatusInternalServerError, "Стриминг не поддерживается")
+		return
+	}
+
+	ctx := r.Context()
+	user := middleware.GetUserFromContext(ctx)
+	sessionID := middleware.GetSessionIDFromContext(ctx)
+
+	// Поток живёт не дольше токена, которым открыт
+	if exp := middleware.GetTokenExpiryFromContext(ctx); !exp.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, exp)
+		defer cancel()
+	}
+
+	events, err := h.inboxService.Subscribe(ctx, user.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Не удалось подписаться на уведомления")
+		return
+	}
+	// Число непрочитанных — после подписки, чтобы не пропустить уведомление между ними
+	unread, err := h.inboxService.CountUnread(ctx, user.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения уведомлений")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// nginx не должен буферизовать поток
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	writeEvent(w, model.InboxEventUnread, model.InboxEvent{Type: model.InboxEventUnread, Unread: unread})
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			// Logout и «выйти везде» закрывают и открытые потоки
+			if !h.sessions.IsSessionActive(ctx, sessionID) {
+				return
+			}
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			writeEvent(w, ev.Type, ev)
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, name string, data any) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, payload)
+}
+
+// AdminListTemplates godoc
+// @Summary Админ: шаблоны сообщений — действующие тексты, встроенные тексты и примеры данных
+// @Tags admin-notifications
+// @Produce json
+// @Success 200 {array} model.TemplateView
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/notification-templates [get]
+func (h *Handler) AdminListTemplates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	views, err := h.templateService.ListTemplates(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения шаблонов")
+		return
+	}
+
+	json.NewEncoder(w).Encode(views)
+}
+
+// AdminGetTemplate godoc
+// @Summary Админ: шаблон сообщения
+// @Tags admin-notifications
+// @Produce json
+// @Param key query string true "Ключ шаблона"
+// @Param locale query string false "Язык (по умолчанию ru)"
+// @Success 200 {object} model.TemplateView
+// @Failure 400,404,500 {object} map[string]string
+// @Router /api/admin/notification-templates/get [get]
+func (h *Handler) AdminGetTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		respondWithError(w, http.StatusBadRequest, "Не указан key")
+		return
+	}
+
+	view, err := h.templateService.GetTemplate(r.Context(), key, localeOrDefault(r.URL.Query().Get("locale")))
+	if err != nil {
+		respondWithServiceError(w, err, "Ошибка получения шаблона")
+		return
+	}
+
+	json.NewEncoder(w).Encode(view)
+}
+
+// AdminUpdateTemplate godoc
+// @Summary Админ: изменить шаблон сообщения
+// @Description Go-шаблоны: {{.Поле}}, {{money .Amount}}, {{percent .DailyReward}}. HTML оборачивается в общий макет письма. Шаблон проверяется на примере данных.
+// @Tags admin-notifications
+// @Accept json
+// @Produce json
+// @Param data body UpdateTemplateRequest true "Тексты шаблона"
+// @Success 200 {object} model.TemplateView
+// @Failure 400,404,500 {object} map[string]string
+// @Router /api/admin/notification-templates/update [post]
+func (h *Handler) AdminUpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req UpdateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	view, err := h.templateService.UpdateTemplate(r.Context(), admin.ID, notifier.Template{
+		Key:     req.Key,
+		Locale:  localeOrDefault(req.Locale),
+		Subject: req.Subject,
+		Text:    req.Text,
+		HTML:    req.HTML,
+		SMS:     req.SMS,
+	})
+	if err != nil {
+		respondWithServiceError(w, err, "Не удалось сохранить шаблон")
+		return
+	}
+
+	json.NewEncoder(w).Encode(view)
+}
+
+// AdminResetTemplate godoc
+// @Summary Админ: вернуть встроенный текст шаблона
+// @Tags admin-notifications
+// @Accept json
+// @Produce json
+// @Param data body ResetTemplateRequest true "Ключ и язык"
+// @Success 200 {object} model.TemplateView
+// @Failure 400,404,409,500 {object} map[string]string
+// @Router /api/admin/notification-templates/reset [post]
+func (h *Handler) AdminResetTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req ResetTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	view, err := h.templateService.ResetTemplate(r.Context(), admin.ID, req.Key, localeOrDefault(req.Locale))
+	if err != nil {
+		respondWithServiceError(w, err, "Не удалось сбросить шаблон")
+		return
+	}
+
+	json.NewEncoder(w).Encode(view)
+}
+
+// AdminPreviewTemplate godoc
+// @Summary Админ: предпросмотр шаблона (черновика или сохранённого) на данных
+// @Tags admin-notifications
+// @Accept json
+// @Produce json
+// @Param data body PreviewTemplateRequest true "Ключ, черновик и данные"
+// @Success 200 {object} notifier.Rendered
+// @Failure 400,404,500 {object} map[string]string
+// @Router /api/admin/notification-templates/preview [post]
+func (h *Handler) AdminPreviewTemplate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req PreviewTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+
+	rendered, err := h.templateService.Preview(r.Context(), model.TemplatePreview{
+		Key:    req.Key,
+		Locale: localeOrDefault(req.Locale),
+		Draft:  req.Draft,
+		Data:   req.Data,
+	})
+	if err != nil {
+		respondWithServiceError(w, err, "Не удалось собрать предпросмотр")
+		return
+	}
+
+	json.NewEncoder(w).Encode(rendered)
+}
+
+// AdminListEmails godoc
+// @Summary Админ: очередь писем и статус доставки по адресатам
+// @Tags admin-notifications
+// @Produce json
+// @Param status query string false "pending, sent или failed"
+// @Param to query string false "Адрес получателя"
+// @Param before_id query int false "Письма старше этого (для следующей страницы)"
+// @Param limit query int false "Количество (по умолчанию 50, не больше 200)"
+// @Success 200 {array} model.QueuedEmail
+// @Failure 400,500 {object} map[string]string
+// @Router /api/admin/emails [get]
+func (h *Handler) AdminListEmails(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	q := r.URL.Query()
+	var f model.EmailFilter
+
+	if v := q.Get("status"); v != "" {
+		status := model.EmailStatus(v)
+		if !status.IsValid() {
+			respondWithError(w, http.StatusBadRequest, "Некорректный status")
+			return
+		}
+		f.Status = &status
+	}
+	if v := q.Get("to"); v != "" {
+		f.To = &v
+	}
+	if v := q.Get("before_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Некорректный before_id")
+			return
+		}
+		f.BeforeID = id
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Некорректный limit")
+			return
+		}
+		f.Limit = n
+	}
+
+	emails, err := h.emailService.ListEmails(r.Context(), f)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения писем")
+		return
+	}
+
+	json.NewEncoder(w).Encode(emails)
+}
+
+// AdminRetryEmail godoc
+// @Summary Админ: повторить отправку письма
+// @Description Письмо в статусе failed снова ставится в очередь с полным запасом попыток.
+// @Tags admin-notifications
+// @Accept json
+// @Produce json
+// @Param data body RetryEmailRequest true "ID письма"
+// @Success 202 {object} model.QueuedEmail
+// @Failure 400,404,409,500 {object} map[string]string
+// @Router /api/admin/emails/retry [post]
+func (h *Handler) AdminRetryEmail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req RetryEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	email, err := h.emailService.RetryEmail(r.Context(), admin.ID, req.ID)
+	if err != nil {
+		respondWithServiceError(w, err, "Не удалось повторить отправку")
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(email)
+}
+
+// AdminListSms godoc
+// @Summary Админ: журнал SMS — провайдер, статус доставки и цена
+// @Description Текст сообщений не хранится, вместо него — шаблон или категория.
+// @Tags admin-notifications
+// @Produce json
+// @Param phone query string false "Телефон получателя"
+// @Param status query string false "sent, delivered, undelivered или failed"
+// @Param before_id query int false "Сообщения старше этого (для следующей страницы)"
+// @Param limit query int false "Количество (по умолчанию 50, не больше 200)"
+// @Success 200 {array} model.SmsMessage
+// @Failure 400,500 {object} map[string]string
+// @Router /api/admin/sms [get]
+func (h *Handler) AdminListSms(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	q := r.URL.Query()
+	var f model.SmsFilter
+
+	if v := q.Get("phone"); v != "" {
+		f.Phone = &v
+	}
+	if v := q.Get("status"); v != "" {
+		status := model.SmsStatus(v)
+		if !status.IsValid() {
+			respondWithError(w, http.StatusBadRequest, "Некорректный status")
+			return
+		}
+		f.Status = &status
+	}
+	if v := q.Get("before_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Некорректный before_id")
+			return
+		}
+		f.BeforeID = id
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Некорректный limit")
+			return
+		}
+		f.Limit = n
+	}
+
+	list, err := h.smsService.ListSms(r.Context(), f)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения журнала SMS")
+		return
+	}
+
+	json.NewEncoder(w).Encode(list)
+}
+
+// AdminSmsCosts godoc
+// @Summary Админ: расходы на SMS по провайдерам
+// @Tags admin-notifications
+// @Produce json
+// @Param from query string false "Начало периода (RFC3339), по умолчанию 30 дней назад"
+// @Param to query string false "Конец периода (RFC3339), по умолчанию сейчас"
+// @Success 200 {array} model.SmsCostSummary
+// @Failure 400,500 {object} map[string]string
+// @Router /api/admin/sms/costs [get]
+func (h *Handler) AdminSmsCosts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	q := r.URL.Query()
+	var from, to time.Time
+
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Некорректный from")
+			return
+		}
+		from = t
+	}
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Некорректный to")
+			return
+		}
+		to = t
+	}
+
+	summary, err := h.smsService.CostSummary(r.Context(), from, to)
+	if err != nil {
+		respondWithServiceError(w, err, "Ошибка подсчёта расходов на SMS")
+		return
+	}
+
+	json.NewEncoder(w).Encode(summary)
+}
+
+func localeOrDefault(locale string) string {
+	if locale == "" {
+		return notifier.DefaultLocale
+	}
+	return locale
+}
+
+// respondWithServiceError переводит ошибки сервиса в HTTP-коды
+func respondWithServiceError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, model.ErrInvalidCategory),
+		errors.Is(err, model.ErrInvalidChannel),
+		errors.Is(err, model.ErrInvalidQuietHours),
+		errors.Is(err, model.ErrInvalidTimezone),
+		errors.Is(err, model.ErrUnsupportedLocale),
+		errors.Is(err, model.ErrInvalidTemplate),
+		errors.Is(err, model.ErrInvalidPeriod):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, model.ErrTemplateNotFound),
+		errors.Is(err, model.ErrEmailNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, model.ErrTemplateNotChanged),
+		errors.Is(err, model.ErrEmailNotRetryable):
+		respondWithError(w, http.StatusConflict, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+func respondWithError(w http.ResponseWriter, code int, message string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
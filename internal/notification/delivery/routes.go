@@ -0,0 +1,91 @@
+package notificationhttp
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
+	"github.com/Vovarama1992/go-utils/httputil"
+)
+
+func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService ports.UserServiceInterface, sessions middleware.SessionChecker, access middleware.PermissionChecker) {
+	withRecover := func(h http.Handler) http.Handler {
+		return httputil.RecoverMiddleware(h)
+	}
+
+	withRecoverAndRateLimit := func(h http.Handler) http.Handler {
+		return httputil.RecoverMiddleware(httputil.NewRateLimiter(10, time.Minute)(h))
+	}
+
+	withUserAuth := func(h http.Handler) http.Handler {
+		return middleware.AuthMiddleware(userService, sessions)(h)
+	}
+
+	withPermission := func(perm rbac.Permission, h http.Handler) http.Handler {
+		return middleware.PermissionMiddleware(userService, sessions, access, perm)(h)
+	}
+
+	// === USER ===
+	mux.Handle("/api/notification-settings/my",
+		withRecover(withUserAuth(http.HandlerFunc(handler.GetMyPreferences))),
+	)
+
+	mux.Handle("/api/notification-settings/update",
+		withRecoverAndRateLimit(withUserAuth(http.HandlerFunc(handler.UpdatePreferences))),
+	)
+
+	mux.Handle("/api/notifications/my",
+		withRecover(withUserAuth(http.HandlerFunc(handler.GetMyNotifications))),
+	)
+
+	mux.Handle("/api/notifications/unread-count",
+		withRecover(withUserAuth(http.HandlerFunc(handler.GetUnreadCount))),
+	)
+
+	mux.Handle("/api/notifications/read",
+		withRecover(withUserAuth(http.HandlerFunc(handler.MarkNotificationsRead))),
+	)
+
+	mux.Handle("/api/notifications/stream",
+		withRecover(withUserAuth(http.HandlerFunc(handler.StreamNotifications))),
+	)
+
+	// === ADMIN ===
+	mux.Handle("/api/admin/notification-templates",
+		withRecover(withPermission(rbac.PermNotificationsManage, http.HandlerFunc(handler.AdminListTemplates))),
+	)
+
+	mux.Handle("/api/admin/notification-templates/get",
+		withRecover(withPermission(rbac.PermNotificationsManage, http.HandlerFunc(handler.AdminGetTemplate))),
+	)
+
+	mux.Handle("/api/admin/notification-templates/update",
+		withRecover(withPermission(rbac.PermNotificationsManage, http.HandlerFunc(handler.AdminUpdateTemplate))),
+	)
+
+	mux.Handle("/api/admin/notification-templates/reset",
+		withRecover(withPermission(rbac.PermNotificationsManage, http.HandlerFunc(handler.AdminResetTemplate))),
+	)
+
+	mux.Handle("/api/admin/notification-templates/preview",
+		withRecover(withPermission(rbac.PermNotificationsManage, http.HandlerFunc(handler.AdminPreviewTemplate))),
+	)
+
+	mux.Handle("/api/admin/emails",
+		withRecover(withPermission(rbac.PermNotificationsManage, http.HandlerFunc(handler.AdminListEmails))),
+	)
+
+	mux.Handle("/api/admin/emails/retry",
+		withRecover(withPermission(rbac.PermNotificationsManage, http.HandlerFunc(handler.AdminRetryEmail))),
+	)
+
+	mux.Handle("/api/admin/sms",
+		withRecover(withPermission(rbac.PermNotificationsManage, http.HandlerFunc(handler.AdminListSms))),
+	)
+
+	mux.Handle("/api/admin/sms/costs",
+		withRecover(withPermission(rbac.PermNotificationsManage, http.HandlerFunc(handler.AdminSmsCosts))),
+	)
+}
@@ -0,0 +1,168 @@
+package notification_infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	model "github.com/Vovarama1992/emelya-go/internal/notification/model"
+	"github.com/jackc/pgx/v5"
+)
+
+type EmailQueueRepository struct {
+	DB *db.DB
+}
+
+func NewEmailQueueRepository(db *db.DB) *EmailQueueRepository {
+	return &EmailQueueRepository{DB: db}
+}
+
+const emailColumns = `id, recipient, subject, body, html, status, attempts, next_attempt_at, last_error, created_at, sent_at`
+
+func scanEmail(row pgx.Row) (*model.QueuedEmail, error) {
+	var e model.QueuedEmail
+	err := row.Scan(&e.ID, &e.To, &e.Subject, &e.Body, &e.HTML, &e.Status, &e.Attempts, &e.NextAttemptAt, &e.LastError, &e.CreatedAt, &e.SentAt)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *EmailQueueRepository) Enqueue(ctx context.Context, e *model.QueuedEmail) error {
+	return r.DB.Pool.QueryRow(ctx, `
+		INSERT INTO email_queue (recipient, subject, body, html)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, status, next_attempt_at, created_at
+	`, e.To, e.Subject, e.Body, e.HTML).Scan(&e.ID, &e.Status, &e.NextAttemptAt, &e.CreatedAt)
+}
+
+// Claim забирает пачку созревших писем и сдвигает их следующую попытку на lease
+// вперёд — как в outbox: упавший отправитель вернёт письма в работу после lease,
+// а Mark* с номером попытки из Claim не перезапишут результат нового захвата.
+func (r *EmailQueueRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*model.QueuedEmail, error) {
+	rows, err := r.DB.Pool.Query(ctx, `
+		UPDATE email_queue
+		SET attempts = attempts + 1,
+		    next_attempt_at = now() + $2 * interval '1 second'
+		WHERE id IN (
+			SELECT id FROM email_queue
+			WHERE status = 'pending' AND next_attempt_at <= now()
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+emailColumns, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*model.QueuedEmail
+	for rows.Next() {
+		e, err := scanEmail(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+	}
+	return list, rows.Err()
+}
+
+// MarkSent отмечает отправку и стирает текст письма
+func (r *EmailQueueRepository) MarkSent(ctx context.Context, id int64, attempt int) error {
+	_, err := r.DB.Pool.Exec(ctx, `
+		UPDATE email_queue
+		SET status = 'sent', sent_at = now(), last_error = NULL, body = '', html = ''
+		WHERE id = $1 AND attempts = $2 AND status = 'pending'
+	`, id, attempt)
+	return err
+}
+
+// MarkRetry откладывает следующую попытку
+func (r *EmailQueueRepository) MarkRetry(ctx context.Context, id int64, attempt int, nextAttemptAt time.Time, lastError string) error {
+	_, err := r.DB.Pool.Exec(ctx, `
+		UPDATE email_queue
+		SET next_attempt_at = $3, last_error = $4
+		WHERE id = $1 AND attempts = $2 AND status = 'pending'
+	`, id, attempt, nextAttemptAt, lastError)
+	return err
+}
+
+// MarkFailed снимает письмо с очереди
+func (r *EmailQueueRepository) MarkFailed(ctx context.Context, id int64, attempt int, lastError string) error {
+	_, err := r.DB.Pool.Exec(ctx, `
+		UPDATE email_queue
+		SET status = 'failed', last_error = $3
+		WHERE id = $1 AND attempts = $2 AND status = 'pending'
+	`, id, attempt, lastError)
+	return err
+}
+
+// Requeue — ручной повтор неотправленного письма с полным запасом попыток.
+// Отправленное письмо повторить нельзя: его текст уже стёрт.
+func (r *EmailQueueRepository) Requeue(ctx context.Context, id int64) (*model.QueuedEmail, error) {
+	e, err := scanEmail(r.DB.Pool.QueryRow(ctx, `
+		UPDATE email_queue
+		SET status = 'pending', attempts = 0, next_attempt_at = now()
+		WHERE id = $1 AND status = 'failed'
+		RETURNING `+emailColumns, id))
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return e, err
+	}
+
+	var exists bool
+	if err := r.DB.Pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM email_queue WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, model.ErrEmailNotRetryable
+	}
+	return nil, model.ErrEmailNotFound
+}
+
+func (r *EmailQueueRepository) List(ctx context.Context, f model.EmailFilter) ([]*model.QueuedEmail, error) {
+	var (
+		conds []string
+		args  []interface{}
+	)
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.Status != nil {
+		add("status = $%d", *f.Status)
+	}
+	if f.To != nil {
+		add("lower(recipient) = lower($%d)", *f.To)
+	}
+	if f.BeforeID > 0 {
+		add("id < $%d", f.BeforeID)
+	}
+
+	query := `SELECT ` + emailColumns + ` FROM email_queue`
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, f.Limit)
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args))
+
+	rows, err := r.DB.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*model.QueuedEmail
+	for rows.Next() {
+		e, err := scanEmail(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+	}
+	return list, rows.Err()
+}
@@ -0,0 +1,86 @@
+package notification_infra
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	model "github.com/Vovarama1992/emelya-go/internal/notification/model"
+	"github.com/jackc/pgx/v5"
+)
+
+type InboxRepository struct {
+	DB *db.DB
+}
+
+func NewInboxRepository(db *db.DB) *InboxRepository {
+	return &InboxRepository{DB: db}
+}
+
+// Create добавляет уведомление; false — такое уведомление из этого события уже есть
+func (r *InboxRepository) Create(ctx context.Context, n *model.Notification) (bool, error) {
+	err := r.DB.Pool.QueryRow(ctx, `
+		INSERT INTO notifications (user_id, category, subject, body, source_event_id)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, source_event_id) DO NOTHING
+		RETURNING id, created_at
+	`, n.UserID, n.Category, n.Subject, n.Body, n.SourceEventID).Scan(&n.ID, &n.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *InboxRepository) List(ctx context.Context, f model.InboxFilter) ([]*model.Notification, error) {
+	rows, err := r.DB.Pool.Query(ctx, `
+		SELECT id, user_id, category, subject, body, source_event_id, read_at, created_at
+		FROM notifications
+		WHERE user_id = $1
+		  AND ($2 = false OR read_at IS NULL)
+		  AND ($3 = 0 OR id < $3)
+		ORDER BY id DESC
+		LIMIT $4
+	`, f.UserID, f.UnreadOnly, f.BeforeID, f.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*model.Notification
+	for rows.Next() {
+		var n model.Notification
+		if err := rows.Scan(&n.ID, &n.UserID, &n.Category, &n.Subject, &n.Body, &n.SourceEventID, &n.ReadAt, &n.CreatedAt); err != nil {
+			return nil, err
+		}
+		list = append(list, &n)
+	}
+	return list, rows.Err()
+}
+
+func (r *InboxRepository) CountUnread(ctx context.Context, userID int64) (int, error) {
+	var n int
+	err := r.DB.Pool.QueryRow(ctx, `
+		SELECT count(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL
+	`, userID).Scan(&n)
+	return n, err
+}
+
+// MarkRead отмечает прочитанными уведомления пользователя; чужие ID игнорируются
+func (r *InboxRepository) MarkRead(ctx context.Context, userID int64, ids []int64) error {
+	_, err := r.DB.Pool.Exec(ctx, `
+		UPDATE notifications SET read_at = now()
+		WHERE user_id = $1 AND id = ANY($2) AND read_at IS NULL
+	`, userID, ids)
+	return err
+}
+
+func (r *InboxRepository) MarkAllRead(ctx context.Context, userID int64) error {
+	_, err := r.DB.Pool.Exec(ctx, `
+		UPDATE notifications SET read_at = now()
+		WHERE user_id = $1 AND read_at IS NULL
+	`, userID)
+	return err
+}
@@ -0,0 +1,104 @@
+package notification_infra
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	model "github.com/Vovarama1992/emelya-go/internal/notification/model"
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	"github.com/jackc/pgx/v5"
+)
+
+type PreferencesRepository struct {
+	DB *db.DB
+}
+
+func NewPreferencesRepository(db *db.DB) *PreferencesRepository {
+	return &PreferencesRepository{DB: db}
+}
+
+// Get — настройки пользователя поверх умолчаний
+func (r *PreferencesRepository) Get(ctx context.Context, userID int64) (*model.Preferences, error) {
+	prefs := model.DefaultPreferences(userID)
+
+	rows, err := r.DB.Pool.Query(ctx, `
+		SELECT category, channel, enabled
+		FROM notification_preferences
+		WHERE user_id = $1
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cat model.Category
+		var ch notifier.ChannelKind
+		var enabled bool
+		if err := rows.Scan(&cat, &ch, &enabled); err != nil {
+			return nil, err
+		}
+		if channels, ok := prefs.Channels[cat]; ok {
+			channels[ch] = enabled
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var q model.QuietHours
+	err = r.DB.Pool.QueryRow(ctx, `
+		SELECT to_char(start_time, 'HH24:MI'), to_char(end_time, 'HH24:MI'), timezone
+		FROM notification_quiet_hours
+		WHERE user_id = $1
+	`, userID).Scan(&q.Start, &q.End, &q.Timezone)
+	if err == nil {
+		prefs.QuietHours = &q
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// Save сохраняет каналы и тихие часы целиком; QuietHours == nil отключает тихие часы
+func (r *PreferencesRepository) Save(ctx context.Context, prefs *model.Preferences) (err error) {
+	tx, err := r.DB.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	for cat, channels := range prefs.Channels {
+		for ch, enabled := range channels {
+			_, err = tx.Exec(ctx, `
+				INSERT INTO notification_preferences (user_id, category, channel, enabled)
+				VALUES ($1, $2, $3, $4)
+				ON CONFLICT (user_id, category, channel)
+				DO UPDATE SET enabled = EXCLUDED.enabled, updated_at = now()
+			`, prefs.UserID, cat, ch, enabled)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if prefs.QuietHours == nil {
+		_, err = tx.Exec(ctx, `DELETE FROM notification_quiet_hours WHERE user_id = $1`, prefs.UserID)
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO notification_quiet_hours (user_id, start_time, end_time, timezone)
+		VALUES ($1, $2::time, $3::time, $4)
+		ON CONFLICT (user_id)
+		DO UPDATE SET start_time = EXCLUDED.start_time, end_time = EXCLUDED.end_time,
+		              timezone = EXCLUDED.timezone, updated_at = now()
+	`, prefs.UserID, prefs.QuietHours.Start, prefs.QuietHours.End, prefs.QuietHours.Timezone)
+	return err
+}
@@ -0,0 +1,162 @@
+package notification_infra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	model "github.com/Vovarama1992/emelya-go/internal/notification/model"
+	"github.com/jackc/pgx/v5"
+)
+
+type SmsRepository struct {
+	DB *db.DB
+}
+
+func NewSmsRepository(db *db.DB) *SmsRepository {
+	return &SmsRepository{DB: db}
+}
+
+const smsColumns = `id, phone, template, provider, provider_message_id, status, provider_status, cost, last_error, checks, next_check_at, created_at, status_at`
+
+func scanSms(row pgx.Row) (*model.SmsMessage, error) {
+	var m model.SmsMessage
+	err := row.Scan(&m.ID, &m.Phone, &m.Template, &m.Provider, &m.ProviderMessageID, &m.Status, &m.ProviderStatus,
+		&m.Cost, &m.LastError, &m.Checks, &m.NextCheckAt, &m.CreatedAt, &m.StatusAt)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *SmsRepository) Create(ctx context.Context, m *model.SmsMessage) error {
+	return r.DB.Pool.QueryRow(ctx, `
+		INSERT INTO sms_messages (phone, template, provider, provider_message_id, status, cost, last_error, next_check_at, status_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at
+	`, m.Phone, m.Template, m.Provider, m.ProviderMessageID, m.Status, m.Cost, m.LastError, m.NextCheckAt, m.StatusAt,
+	).Scan(&m.ID, &m.CreatedAt)
+}
+
+// ClaimForCheck забирает сообщения, чей статус пора уточнить у провайдера, и
+// сдвигает следующую проверку на lease вперёд, чтобы их не взял параллельный опрос.
+// UpdateStatus принимает номер проверки из захвата: ответ устаревшей проверки
+// не перезапишет результат следующей.
+func (r *SmsRepository) ClaimForCheck(ctx context.Context, limit int, lease time.Duration) ([]*model.SmsMessage, error) {
+	rows, err := r.DB.Pool.Query(ctx, `
+		UPDATE sms_messages
+		SET checks = checks + 1,
+		    next_check_at = now() + $2 * interval '1 second'
+		WHERE id IN (
+			SELECT id FROM sms_messages
+			WHERE status = 'sent' AND next_check_at <= now()
+			ORDER BY next_check_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+smsColumns, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*model.SmsMessage
+	for rows.Next() {
+		m, err := scanSms(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, m)
+	}
+	return list, rows.Err()
+}
+
+// UpdateStatus сохраняет ответ провайдера. nextCheckAt = nil — больше не опрашивать.
+// Цену, которую провайдер не сообщил, не затираем.
+func (r *SmsRepository) UpdateStatus(ctx context.Context, id int64, check int, status model.SmsStatus, providerStatus string, cost *float64, nextCheckAt *time.Time) error {
+	_, err := r.DB.Pool.Exec(ctx, `
+		UPDATE sms_messages
+		SET status = $2,
+		    provider_status = COALESCE(NULLIF($3, ''), provider_status),
+		    cost = COALESCE($4, cost),
+		    next_check_at = $5,
+		    status_at = CASE WHEN $2 <> 'sent' THEN now() ELSE status_at END
+		WHERE id = $1 AND checks = $6 AND status = 'sent'
+	`, id, status, providerStatus, cost, nextCheckAt, check)
+	return err
+}
+
+func (r *SmsRepository) List(ctx context.Context, f model.SmsFilter) ([]*model.SmsMessage, error) {
+	var (
+		conds []string
+		args  []interface{}
+	)
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.Phone != nil {
+		add("phone = $%d", *f.Phone)
+	}
+	if f.Status != nil {
+		add("status = $%d", *f.Status)
+	}
+	if f.BeforeID > 0 {
+		add("id < $%d", f.BeforeID)
+	}
+
+	query := `SELECT ` + smsColumns + ` FROM sms_messages`
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, f.Limit)
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args))
+
+	rows, err := r.DB.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*model.SmsMessage
+	for rows.Next() {
+		m, err := scanSms(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, m)
+	}
+	return list, rows.Err()
+}
+
+// CostSummary — отправки и расходы по провайдерам за [from, to)
+func (r *SmsRepository) CostSummary(ctx context.Context, from, to time.Time) ([]*model.SmsCostSummary, error) {
+	rows, err := r.DB.Pool.Query(ctx, `
+		SELECT provider,
+		       count(*),
+		       count(*) FILTER (WHERE status = 'delivered'),
+		       count(*) FILTER (WHERE status = 'undelivered'),
+		       COALESCE(sum(cost), 0)::float8
+		FROM sms_messages
+		WHERE provider IS NOT NULL AND created_at >= $1 AND created_at < $2
+		GROUP BY provider
+		ORDER BY provider
+	`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*model.SmsCostSummary
+	for rows.Next() {
+		var s model.SmsCostSummary
+		if err := rows.Scan(&s.Provider, &s.Sent, &s.Delivered, &s.Undelivered, &s.Cost); err != nil {
+			return nil, err
+		}
+		list = append(list, &s)
+	}
+	return list, rows.Err()
+}
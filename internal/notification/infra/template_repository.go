@@ -0,0 +1,99 @@
+package notification_infra
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	model "github.com/Vovarama1992/emelya-go/internal/notification/model"
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	"github.com/jackc/pgx/v5"
+)
+
+// TemplateRepository хранит правки шаблонов и отдаёт их реестру notifier
+type TemplateRepository struct {
+	DB *db.DB
+}
+
+func NewTemplateRepository(db *db.DB) *TemplateRepository {
+	return &TemplateRepository{DB: db}
+}
+
+var _ notifier.TemplateStore = (*TemplateRepository)(nil)
+
+const templateColumns = `key, locale, subject, text_body, html_body, sms_body, updated_by, updated_at`
+
+func scanTemplate(row pgx.Row) (*model.TemplateOverride, error) {
+	var t model.TemplateOverride
+	err := row.Scan(&t.Key, &t.Locale, &t.Subject, &t.Text, &t.HTML, &t.SMS, &t.UpdatedBy, &t.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// GetTemplate — правка шаблона; нет правки — (nil, nil)
+func (r *TemplateRepository) GetTemplate(ctx context.Context, key, locale string) (*notifier.Template, error) {
+	t, err := r.Get(ctx, key, locale)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t.Template, nil
+}
+
+func (r *TemplateRepository) Get(ctx context.Context, key, locale string) (*model.TemplateOverride, error) {
+	return scanTemplate(r.DB.Pool.QueryRow(ctx, `
+		SELECT `+templateColumns+`
+		FROM notification_templates
+		WHERE key = $1 AND locale = $2
+	`, key, locale))
+}
+
+func (r *TemplateRepository) List(ctx context.Context) ([]*model.TemplateOverride, error) {
+	rows, err := r.DB.Pool.Query(ctx, `
+		SELECT `+templateColumns+`
+		FROM notification_templates
+		ORDER BY key, locale
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*model.TemplateOverride
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, t)
+	}
+	return list, rows.Err()
+}
+
+func (r *TemplateRepository) Upsert(ctx context.Context, t notifier.Template, updatedBy int64) (*model.TemplateOverride, error) {
+	return scanTemplate(r.DB.Pool.QueryRow(ctx, `
+		INSERT INTO notification_templates (key, locale, subject, text_body, html_body, sms_body, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, now())
+		ON CONFLICT (key, locale) DO UPDATE SET
+			subject = EXCLUDED.subject,
+			text_body = EXCLUDED.text_body,
+			html_body = EXCLUDED.html_body,
+			sms_body = EXCLUDED.sms_body,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = now()
+		RETURNING `+templateColumns+`
+	`, t.Key, t.Locale, t.Subject, t.Text, t.HTML, t.SMS, updatedBy))
+}
+
+// Delete удаляет правку и возвращает её; нет правки — pgx.ErrNoRows
+func (r *TemplateRepository) Delete(ctx context.Context, key, locale string) (*model.TemplateOverride, error) {
+	return scanTemplate(r.DB.Pool.QueryRow(ctx, `
+		DELETE FROM notification_templates
+		WHERE key = $1 AND locale = $2
+		RETURNING `+templateColumns+`
+	`, key, locale))
+}
@@ -0,0 +1,53 @@
+package notification_model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrEmailNotFound     = errors.New("письмо не найдено")
+	ErrEmailNotRetryable = errors.New("повторить можно только неотправленное письмо")
+)
+
+type EmailStatus string
+
+const (
+	EmailPending EmailStatus = "pending"
+	EmailSent    EmailStatus = "sent"
+	// Сервер отверг адресата или попытки исчерпаны; повторить можно вручную
+	EmailFailed EmailStatus = "failed"
+)
+
+func (s EmailStatus) IsValid() bool {
+	return s == EmailPending || s == EmailSent || s == EmailFailed
+}
+
+// QueuedEmail — письмо одному адресату в очереди отправки. Текст письма
+// стирается после отправки: в нём бывают коды и ссылки подтверждения.
+type QueuedEmail struct {
+	ID            int64       `json:"id"`
+	To            string      `json:"to"`
+	Subject       string      `json:"subject"`
+	Body          string      `json:"-"`
+	HTML          string      `json:"-"`
+	Status        EmailStatus `json:"status"`
+	Attempts      int         `json:"attempts"`
+	NextAttemptAt time.Time   `json:"next_attempt_at"`
+	LastError     *string     `json:"last_error,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+	SentAt        *time.Time  `json:"sent_at,omitempty"`
+}
+
+type EmailFilter struct {
+	Status *EmailStatus
+	To     *string
+	// Письма с ID меньше этого — следующая страница
+	BeforeID int64
+	Limit    int
+}
+
+const (
+	DefaultEmailLimit = 50
+	MaxEmailLimit     = 200
+)
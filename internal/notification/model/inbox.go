@@ -0,0 +1,50 @@
+package notification_model
+
+import "time"
+
+// Notification — запись в ленте уведомлений личного кабинета
+type Notification struct {
+	ID            int64      `json:"id"`
+	UserID        int64      `json:"-"`
+	Category      string     `json:"category,omitempty"`
+	Subject       string     `json:"subject,omitempty"`
+	Body          string     `json:"body"`
+	SourceEventID *int64     `json:"-"`
+	ReadAt        *time.Time `json:"read_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+const (
+	DefaultInboxLimit = 20
+	MaxInboxLimit     = 100
+)
+
+// InboxFilter — страница ленты: новые первыми, BeforeID — курсор следующей страницы
+type InboxFilter struct {
+	UserID     int64
+	UnreadOnly bool
+	BeforeID   int64
+	Limit      int
+}
+
+// InboxPage — страница ленты и число непрочитанных
+type InboxPage struct {
+	Items        []*Notification `json:"items"`
+	Unread       int             `json:"unread"`
+	NextBeforeID *int64          `json:"next_before_id,omitempty"`
+}
+
+// Типы событий live-канала ленты
+const (
+	InboxEventUnread       = "unread"
+	InboxEventNotification = "notification"
+	InboxEventRead         = "read"
+)
+
+// InboxEvent — сообщение в live-канал: новое уведомление или изменение
+// числа непрочитанных (прочитано в другой вкладке)
+type InboxEvent struct {
+	Type         string        `json:"type"`
+	Notification *Notification `json:"notification,omitempty"`
+	Unread       int           `json:"unread"`
+}
@@ -0,0 +1,140 @@
+package notification_model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+)
+
+// Category — группа событий, для которой пользователь выбирает каналы
+type Category string
+
+const (
+	CategoryDeposits    Category = "deposits"
+	CategoryAccruals    Category = "accruals"
+	CategoryWithdrawals Category = "withdrawals"
+)
+
+var AllCategories = []Category{CategoryDeposits, CategoryAccruals, CategoryWithdrawals}
+
+// UserChannels — каналы, которые пользователь может включать и выключать
+var UserChannels = []notifier.ChannelKind{notifier.ChannelSMS, notifier.ChannelEmail, notifier.ChannelInApp}
+
+// Умолчания: о деньгах сообщаем везде, о ежедневных начислениях — только в кабинете
+var defaultChannels = map[Category][]notifier.ChannelKind{
+	CategoryDeposits:    UserChannels,
+	CategoryAccruals:    {notifier.ChannelInApp},
+	CategoryWithdrawals: UserChannels,
+}
+
+var (
+	ErrInvalidCategory   = errors.New("неизвестная категория уведомлений")
+	ErrInvalidChannel    = errors.New("канал недоступен для настройки")
+	ErrInvalidQuietHours = errors.New("тихие часы задаются как ЧЧ:ММ")
+	ErrInvalidTimezone   = errors.New("неизвестный часовой пояс")
+)
+
+const DefaultTimezone = "Europe/Moscow"
+
+// Preferences — каналы по категориям и тихие часы пользователя
+type Preferences struct {
+	UserID     int64                                      `json:"-"`
+	Channels   map[Category]map[notifier.ChannelKind]bool `json:"channels"`
+	QuietHours *QuietHours                                `json:"quiet_hours,omitempty"`
+}
+
+func DefaultPreferences(userID int64) *Preferences {
+	p := &Preferences{UserID: userID, Channels: map[Category]map[notifier.ChannelKind]bool{}}
+	for _, cat := range AllCategories {
+		p.Channels[cat] = map[notifier.ChannelKind]bool{}
+		for _, ch := range UserChannels {
+			p.Channels[cat][ch] = false
+		}
+		for _, ch := range defaultChannels[cat] {
+			p.Channels[cat][ch] = true
+		}
+	}
+	return p
+}
+
+func (p *Preferences) Enabled(cat Category, ch notifier.ChannelKind) bool {
+	return p.Channels[cat][ch]
+}
+
+func ValidCategory(cat Category) bool {
+	for _, c := range AllCategories {
+		if c == cat {
+			return true
+		}
+	}
+	return false
+}
+
+func ValidUserChannel(ch notifier.ChannelKind) bool {
+	for _, c := range UserChannels {
+		if c == ch {
+			return true
+		}
+	}
+	return false
+}
+
+// QuietHours — окно, в которое SMS не отправляются. Окно может переходить через полночь.
+type QuietHours struct {
+	Start    string `json:"start" example:"22:00"`
+	End      string `json:"end" example:"08:00"`
+	Timezone string `json:"timezone" example:"Europe/Moscow"`
+}
+
+func (q QuietHours) Validate() error {
+	if _, err := time.Parse("15:04", q.Start); err != nil {
+		return ErrInvalidQuietHours
+	}
+	if _, err := time.Parse("15:04", q.End); err != nil {
+		return ErrInvalidQuietHours
+	}
+	if _, err := time.LoadLocation(q.Timezone); err != nil {
+		return ErrInvalidTimezone
+	}
+	return nil
+}
+
+// DeferUntil возвращает конец тихих часов, если now попадает в окно
+func (q QuietHours) DeferUntil(now time.Time) (time.Time, bool) {
+	loc, err := time.LoadLocation(q.Timezone)
+	if err != nil {
+		return time.Time{}, false
+	}
+	start, err1 := time.Parse("15:04", q.Start)
+	end, err2 := time.Parse("15:04", q.End)
+	if err1 != nil || err2 != nil || q.Start == q.End {
+		return time.Time{}, false
+	}
+
+	local := now.In(loc)
+	minutes := local.Hour()*60 + local.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+
+	inside := minutes >= from && minutes < to
+	if from > to {
+		inside = minutes >= from || minutes < to
+	}
+	if !inside {
+		return time.Time{}, false
+	}
+
+	until := time.Date(local.Year(), local.Month(), local.Day(), end.Hour(), end.Minute(), 0, 0, loc)
+	if !until.After(local) {
+		until = until.AddDate(0, 0, 1)
+	}
+	return until, true
+}
+
+// PreferencesUpdate — изменения настроек: переданные каналы меняются, остальные остаются
+type PreferencesUpdate struct {
+	Channels          map[Category]map[notifier.ChannelKind]bool
+	QuietHours        *QuietHours
+	DisableQuietHours bool
+}
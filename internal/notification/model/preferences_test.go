@@ -0,0 +1,47 @@
+package notification_model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuietHoursDeferUntil(t *testing.T) {
+	msk, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skip("нет базы часовых поясов:", err)
+	}
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, msk)
+	}
+	night := QuietHours{Start: "22:00", End: "08:00", Timezone: "Europe/Moscow"}
+	day := QuietHours{Start: "13:00", End: "15:30", Timezone: "Europe/Moscow"}
+
+	tests := []struct {
+		name   string
+		q      QuietHours
+		now    time.Time
+		until  time.Time
+		defers bool
+	}{
+		{"через полночь: вечер", night, at(19, 23, 15), at(20, 8, 0), true},
+		{"через полночь: начало окна", night, at(19, 22, 0), at(20, 8, 0), true},
+		{"через полночь: утро", night, at(20, 7, 59), at(20, 8, 0), true},
+		{"через полночь: конец окна", night, at(20, 8, 0), time.Time{}, false},
+		{"через полночь: день", night, at(19, 12, 0), time.Time{}, false},
+		{"днём: внутри", day, at(19, 14, 0), at(19, 15, 30), true},
+		{"днём: до окна", day, at(19, 12, 59), time.Time{}, false},
+		{"днём: после окна", day, at(19, 15, 30), time.Time{}, false},
+		{"время в другом поясе", night, time.Date(2026, 10, 19, 20, 30, 0, 0, time.UTC), at(20, 8, 0), true},
+		{"пустое окно", QuietHours{Start: "22:00", End: "22:00", Timezone: "Europe/Moscow"}, at(19, 22, 0), time.Time{}, false},
+		{"неизвестный пояс", QuietHours{Start: "22:00", End: "08:00", Timezone: "Mars/Olympus"}, at(19, 23, 0), time.Time{}, false},
+		{"битое время", QuietHours{Start: "25:00", End: "08:00", Timezone: "Europe/Moscow"}, at(19, 23, 0), time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			until, defers := tt.q.DeferUntil(tt.now)
+			if defers != tt.defers || !until.Equal(tt.until) {
+				t.Errorf("got (%v, %v), want (%v, %v)", until, defers, tt.until, tt.defers)
+			}
+		})
+	}
+}
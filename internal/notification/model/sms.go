@@ -0,0 +1,86 @@
+package notification_model
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrInvalidPeriod = errors.New("некорректный период")
+
+type SmsStatus string
+
+const (
+	// Провайдер принял сообщение, статус доставки ещё не известен
+	SmsSent        SmsStatus = "sent"
+	SmsDelivered   SmsStatus = "delivered"
+	SmsUndelivered SmsStatus = "undelivered"
+	// Ни один провайдер не принял сообщение
+	SmsFailed SmsStatus = "failed"
+)
+
+func (s SmsStatus) IsValid() bool {
+	switch s {
+	case SmsSent, SmsDelivered, SmsUndelivered, SmsFailed:
+		return true
+	}
+	return false
+}
+
+// SmsMessage — запись журнала SMS. Текста нет: в SMS бывают коды и пароли.
+type SmsMessage struct {
+	ID                int64     `json:"id"`
+	Phone             string    `json:"phone"`
+	Template          string    `json:"template,omitempty"`
+	Provider          *string   `json:"provider,omitempty"`
+	ProviderMessageID *string   `json:"provider_message_id,omitempty"`
+	Status            SmsStatus `json:"status"`
+	ProviderStatus    *string   `json:"provider_status,omitempty"`
+	Cost              *float64  `json:"cost,omitempty"`
+	// Ошибки провайдеров, которые не приняли сообщение раньше
+	LastError   *string    `json:"last_error,omitempty"`
+	Checks      int        `json:"-"`
+	NextCheckAt *time.Time `json:"-"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StatusAt    *time.Time `json:"status_at,omitempty"`
+}
+
+type SmsFilter struct {
+	Phone  *string
+	Status *SmsStatus
+	// Сообщения с ID меньше этого — следующая страница
+	BeforeID int64
+	Limit    int
+}
+
+const (
+	DefaultSmsLimit = 50
+	MaxSmsLimit     = 200
+)
+
+// SmsCostSummary — отправки и расходы одного провайдера за период
+type SmsCostSummary struct {
+	Provider    string  `json:"provider"`
+	Sent        int     `json:"sent"`
+	Delivered   int     `json:"delivered"`
+	Undelivered int     `json:"undelivered"`
+	Cost        float64 `json:"cost"`
+}
+
+// NormalizeSmsPhone — номер в журнале: только цифры, российский номер с 7
+func NormalizeSmsPhone(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	digits := b.String()
+	switch {
+	case len(digits) == 11 && digits[0] == '8':
+		return "7" + digits[1:]
+	case len(digits) == 10 && digits[0] == '9':
+		return "7" + digits
+	}
+	return digits
+}
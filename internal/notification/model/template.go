@@ -0,0 +1,53 @@
+package notification_model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+)
+
+var (
+	ErrTemplateNotFound   = errors.New("шаблон не найден")
+	ErrUnsupportedLocale  = errors.New("язык шаблона не поддерживается")
+	ErrInvalidTemplate    = errors.New("ошибка в шаблоне")
+	ErrTemplateNotChanged = errors.New("для шаблона нет правок")
+)
+
+// TemplateOverride — правка шаблона из админки
+type TemplateOverride struct {
+	notifier.Template
+	UpdatedBy *int64    `json:"updated_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TemplateView — шаблон для админки: действующий текст, встроенный текст и пример данных
+type TemplateView struct {
+	Key         string            `json:"key"`
+	Locale      string            `json:"locale"`
+	Description string            `json:"description"`
+	Sample      map[string]any    `json:"sample"`
+	Current     notifier.Template `json:"current"`
+	Default     notifier.Template `json:"default"`
+	Overridden  bool              `json:"overridden"`
+	UpdatedBy   *int64            `json:"updated_by,omitempty"`
+	UpdatedAt   *time.Time        `json:"updated_at,omitempty"`
+}
+
+// TemplatePreview — черновик (или сохранённый шаблон, если черновика нет) на данных.
+// Без данных используется пример из описания шаблона.
+type TemplatePreview struct {
+	Key    string
+	Locale string
+	Draft  *notifier.Template
+	Data   map[string]any
+}
+
+func ValidLocale(locale string) bool {
+	for _, l := range notifier.SupportedLocales {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
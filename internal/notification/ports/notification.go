@@ -0,0 +1,83 @@
+package notification_ports
+
+import (
+	"context"
+	"time"
+
+	model "github.com/Vovarama1992/emelya-go/internal/notification/model"
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+)
+
+type PreferencesRepository interface {
+	Get(ctx context.Context, userID int64) (*model.Preferences, error)
+	Save(ctx context.Context, prefs *model.Preferences) error
+}
+
+type PreferencesService interface {
+	GetPreferences(ctx context.Context, userID int64) (*model.Preferences, error)
+	UpdatePreferences(ctx context.Context, userID int64, update model.PreferencesUpdate) (*model.Preferences, error)
+}
+
+type TemplateRepository interface {
+	Get(ctx context.Context, key, locale string) (*model.TemplateOverride, error)
+	List(ctx context.Context) ([]*model.TemplateOverride, error)
+	Upsert(ctx context.Context, t notifier.Template, updatedBy int64) (*model.TemplateOverride, error)
+	Delete(ctx context.Context, key, locale string) (*model.TemplateOverride, error)
+}
+
+type TemplateService interface {
+	ListTemplates(ctx context.Context) ([]*model.TemplateView, error)
+	GetTemplate(ctx context.Context, key, locale string) (*model.TemplateView, error)
+	UpdateTemplate(ctx context.Context, actorID int64, t notifier.Template) (*model.TemplateView, error)
+	ResetTemplate(ctx context.Context, actorID int64, key, locale string) (*model.TemplateView, error)
+	Preview(ctx context.Context, p model.TemplatePreview) (*notifier.Rendered, error)
+}
+
+type InboxRepository interface {
+	Create(ctx context.Context, n *model.Notification) (bool, error)
+	List(ctx context.Context, f model.InboxFilter) ([]*model.Notification, error)
+	CountUnread(ctx context.Context, userID int64) (int, error)
+	MarkRead(ctx context.Context, userID int64, ids []int64) error
+	MarkAllRead(ctx context.Context, userID int64) error
+}
+
+// InboxHub — доставка событий ленты в открытые live-подключения
+type InboxHub interface {
+	Publish(ctx context.Context, userID int64, ev model.InboxEvent) error
+	Subscribe(ctx context.Context, userID int64) (<-chan model.InboxEvent, error)
+}
+
+type InboxService interface {
+	List(ctx context.Context, f model.InboxFilter) (*model.InboxPage, error)
+	CountUnread(ctx context.Context, userID int64) (int, error)
+	MarkRead(ctx context.Context, userID int64, ids []int64, all bool) (int, error)
+	Subscribe(ctx context.Context, userID int64) (<-chan model.InboxEvent, error)
+}
+
+type EmailQueueRepository interface {
+	Enqueue(ctx context.Context, e *model.QueuedEmail) error
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*model.QueuedEmail, error)
+	MarkSent(ctx context.Context, id int64, attempt int) error
+	MarkRetry(ctx context.Context, id int64, attempt int, nextAttemptAt time.Time, lastError string) error
+	MarkFailed(ctx context.Context, id int64, attempt int, lastError string) error
+	Requeue(ctx context.Context, id int64) (*model.QueuedEmail, error)
+	List(ctx context.Context, f model.EmailFilter) ([]*model.QueuedEmail, error)
+}
+
+type EmailQueueService interface {
+	ListEmails(ctx context.Context, f model.EmailFilter) ([]*model.QueuedEmail, error)
+	RetryEmail(ctx context.Context, actorID, id int64) (*model.QueuedEmail, error)
+}
+
+type SmsRepository interface {
+	Create(ctx context.Context, m *model.SmsMessage) error
+	ClaimForCheck(ctx context.Context, limit int, lease time.Duration) ([]*model.SmsMessage, error)
+	UpdateStatus(ctx context.Context, id int64, check int, status model.SmsStatus, providerStatus string, cost *float64, nextCheckAt *time.Time) error
+	List(ctx context.Context, f model.SmsFilter) ([]*model.SmsMessage, error)
+	CostSummary(ctx context.Context, from, to time.Time) ([]*model.SmsCostSummary, error)
+}
+
+type SmsService interface {
+	ListSms(ctx context.Context, f model.SmsFilter) ([]*model.SmsMessage, error)
+	CostSummary(ctx context.Context, from, to time.Time) ([]*model.SmsCostSummary, error)
+}
@@ -0,0 +1,198 @@
+package notification_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	audit "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	audit_ports "github.com/Vovarama1992/emelya-go/internal/audit/ports"
+	model "github.com/Vovarama1992/emelya-go/internal/notification/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/notification/ports"
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	"github.com/Vovarama1992/emelya-go/internal/pollutil"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+)
+
+// EmailQueueChannel — канал email для notifier, который только ставит письмо
+// в очередь: HTTP-запрос не ждёт SMTP, а сбой сервера не теряет письмо
+type EmailQueueChannel struct {
+	repo ports.EmailQueueRepository
+}
+
+// EmailQueueDriver — драйвер notifier.DriverEmailQueue поверх очереди в БД
+func EmailQueueDriver(repo ports.EmailQueueRepository) notifier.DriverFactory {
+	return func(kind notifier.ChannelKind, _ notifier.Settings) (notifier.Channel, error) {
+		if kind != notifier.ChannelEmail {
+			return nil, fmt.Errorf("драйвер %s обслуживает только канал email", notifier.DriverEmailQueue)
+		}
+		return &EmailQueueChannel{repo: repo}, nil
+	}
+}
+
+func (c *EmailQueueChannel) Kind() notifier.ChannelKind { return notifier.ChannelEmail }
+
+func (c *EmailQueueChannel) Send(ctx context.Context, msg notifier.Message) error {
+	to := strings.TrimSpace(msg.To)
+	if to == "" {
+		return fmt.Errorf("не указан адресат")
+	}
+	return c.repo.Enqueue(ctx, &model.QueuedEmail{To: to, Subject: msg.Subject, Body: msg.Body, HTML: msg.HTML})
+}
+
+// EmailSenderConfig — параметры опроса очереди писем и повторов
+type EmailSenderConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	MaxAttempts  int
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	SendTimeout  time.Duration
+}
+
+func DefaultEmailSenderConfig() EmailSenderConfig {
+	return EmailSenderConfig{
+		PollInterval: 2 * time.Second,
+		BatchSize:    20,
+		MaxAttempts:  6,
+		BaseBackoff:  30 * time.Second,
+		MaxBackoff:   30 * time.Minute,
+		SendTimeout:  30 * time.Second,
+	}
+}
+
+// LoadEmailSenderConfigFromEnv — EMAIL_QUEUE_POLL_INTERVAL, EMAIL_QUEUE_MAX_ATTEMPTS
+// и NOTIFY_EMAIL_TIMEOUT, остальное по умолчанию
+func LoadEmailSenderConfigFromEnv() EmailSenderConfig {
+	cfg := DefaultEmailSenderConfig()
+	if d, err := time.ParseDuration(os.Getenv("EMAIL_QUEUE_POLL_INTERVAL")); err == nil && d > 0 {
+		cfg.PollInterval = d
+	}
+	if n, err := strconv.Atoi(os.Getenv("EMAIL_QUEUE_MAX_ATTEMPTS")); err == nil && n > 0 {
+		cfg.MaxAttempts = n
+	}
+	if d, err := time.ParseDuration(os.Getenv("NOTIFY_EMAIL_TIMEOUT")); err == nil && d > 0 {
+		cfg.SendTimeout = d
+	}
+	return cfg
+}
+
+// EmailSender отправляет письма из очереди через транспорт (обычно SMTP с пулом
+// сессий). Отказ сервера принять адресата (5xx) — сразу failed, остальные сбои
+// повторяются с экспоненциальной задержкой до MaxAttempts.
+type EmailSender struct {
+	repo      ports.EmailQueueRepository
+	transport notifier.Channel
+	cfg       EmailSenderConfig
+}
+
+func NewEmailSender(repo ports.EmailQueueRepository, transport notifier.Channel, cfg EmailSenderConfig) *EmailSender {
+	return &EmailSender{repo: repo, transport: transport, cfg: cfg}
+}
+
+// Start опрашивает очередь в фоне до отмены ctx
+func (s *EmailSender) Start(ctx context.Context) {
+	go pollutil.Run(ctx, s.cfg.PollInterval, s.cfg.BatchSize, s.SendPending, func(err error) {
+		log.Printf("[EMAIL] Ошибка выборки писем: %v", err)
+	})
+}
+
+// SendPending отправляет одну пачку созревших писем и возвращает её размер
+func (s *EmailSender) SendPending(ctx context.Context) (int, error) {
+	// Письма пачки отправляются по очереди: аренда рассчитана на всю пачку
+	lease := s.cfg.SendTimeout*time.Duration(s.cfg.BatchSize) + s.cfg.BaseBackoff
+	emails, err := s.repo.Claim(ctx, s.cfg.BatchSize, lease)
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range emails {
+		s.send(ctx, e)
+	}
+	return len(emails), nil
+}
+
+func (s *EmailSender) send(ctx context.Context, e *model.QueuedEmail) {
+	sendCtx, cancel := context.WithTimeout(ctx, s.cfg.SendTimeout)
+	err := s.transport.Send(sendCtx, notifier.Message{
+		Channel: notifier.ChannelEmail,
+		To:      e.To,
+		Subject: e.Subject,
+		Body:    e.Body,
+		HTML:    e.HTML,
+	})
+	cancel()
+
+	if err == nil {
+		if err := s.repo.MarkSent(ctx, e.ID, e.Attempts); err != nil {
+			log.Printf("[EMAIL] Не удалось отметить письмо %d: %v", e.ID, err)
+		}
+		return
+	}
+
+	if errors.Is(err, notifier.ErrRecipientRejected) || e.Attempts >= s.cfg.MaxAttempts {
+		log.Printf("[EMAIL] Письмо %d на %s не отправлено за %d попыток: %v", e.ID, e.To, e.Attempts, err)
+		if err := s.repo.MarkFailed(ctx, e.ID, e.Attempts, err.Error()); err != nil {
+			log.Printf("[EMAIL] Не удалось отметить письмо %d как failed: %v", e.ID, err)
+		}
+		return
+	}
+
+	next := time.Now().Add(pollutil.Backoff(s.cfg.BaseBackoff, s.cfg.MaxBackoff, e.Attempts))
+	if err := s.repo.MarkRetry(ctx, e.ID, e.Attempts, next, err.Error()); err != nil {
+		log.Printf("[EMAIL] Не удалось отложить письмо %d: %v", e.ID, err)
+	}
+}
+
+// EmailQueueService — статусы доставки писем для админки
+type EmailQueueService struct {
+	repo         ports.EmailQueueRepository
+	auditService audit_ports.AuditService
+}
+
+func NewEmailQueueService(repo ports.EmailQueueRepository, auditService audit_ports.AuditService) *EmailQueueService {
+	return &EmailQueueService{repo: repo, auditService: auditService}
+}
+
+func (s *EmailQueueService) ListEmails(ctx context.Context, f model.EmailFilter) ([]*model.QueuedEmail, error) {
+	if f.Limit <= 0 {
+		f.Limit = model.DefaultEmailLimit
+	}
+	f.Limit = min(f.Limit, model.MaxEmailLimit)
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+
+	list, err := s.repo.List(ctx, f)
+	if list == nil && err == nil {
+		list = []*model.QueuedEmail{}
+	}
+	return list, err
+}
+
+// RetryEmail возвращает неотправленное письмо в очередь
+func (s *EmailQueueService) RetryEmail(ctx context.Context, actorID, id int64) (*model.QueuedEmail, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	e, err := s.repo.Requeue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &audit.Entry{
+		ActorID:    &actorID,
+		Action:     audit.ActionEmailRetry,
+		EntityType: audit.EntityEmail,
+		EntityID:   &e.ID,
+		After:      audit.Snapshot(e),
+	}
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		log.Printf("[EMAIL] Не удалось записать журнал %s: %v", entry.Action, err)
+	}
+	return e, nil
+}
@@ -0,0 +1,73 @@
+package notification_usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	model "github.com/Vovarama1992/emelya-go/internal/notification/model"
+	"github.com/redis/go-redis/v9"
+)
+
+// InboxHub разносит события ленты по открытым live-подключениям через Redis pub/sub:
+// уведомление, записанное на одном инстансе, доходит до вкладки, подключённой к другому
+type InboxHub struct {
+	redis *redis.Client
+}
+
+func NewInboxHub(redisClient *redis.Client) *InboxHub {
+	return &InboxHub{redis: redisClient}
+}
+
+func inboxChannel(userID int64) string {
+	return fmt.Sprintf("notifications:inbox:%d", userID)
+}
+
+func (h *InboxHub) Publish(ctx context.Context, userID int64, ev model.InboxEvent) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	return h.redis.Publish(ctx, inboxChannel(userID), data).Err()
+}
+
+// Subscribe — события ленты пользователя до отмены ctx. Канал закрывается,
+// когда подписка завершена.
+func (h *InboxHub) Subscribe(ctx context.Context, userID int64) (<-chan model.InboxEvent, error) {
+	sub := h.redis.Subscribe(ctx, inboxChannel(userID))
+	// Дожидаемся подтверждения подписки, чтобы не потерять события сразу после подключения
+	if _, err := sub.Receive(ctx); err != nil {
+		_ = sub.Close()
+		return nil, err
+	}
+
+	out := make(chan model.InboxEvent, 16)
+	go func() {
+		defer close(out)
+		defer sub.Close()
+
+		msgs := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-msgs:
+				if !ok {
+					return
+				}
+				var ev model.InboxEvent
+				if err := json.Unmarshal([]byte(msg.Payload), &ev); err != nil {
+					log.Printf("[INBOX] Некорректное событие ленты пользователя %d: %v", userID, err)
+					continue
+				}
+				select {
+				case out <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
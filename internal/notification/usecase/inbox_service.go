@@ -0,0 +1,146 @@
+package notification_usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	model "github.com/Vovarama1992/emelya-go/internal/notification/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/notification/ports"
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+)
+
+// InboxService — лента уведомлений личного кабинета
+type InboxService struct {
+	repo ports.InboxRepository
+	hub  ports.InboxHub
+}
+
+func NewInboxService(repo ports.InboxRepository, hub ports.InboxHub) *InboxService {
+	return &InboxService{repo: repo, hub: hub}
+}
+
+// Deliver записывает уведомление в ленту и сообщает о нём открытым вкладкам.
+// Повтор того же события ленту не меняет.
+func (s *InboxService) Deliver(ctx context.Context, n *model.Notification) error {
+	created, err := s.repo.Create(ctx, n)
+	if err != nil || !created {
+		return err
+	}
+	s.publish(ctx, n)
+	return nil
+}
+
+func (s *InboxService) List(ctx context.Context, f model.InboxFilter) (*model.InboxPage, error) {
+	if f.Limit <= 0 {
+		f.Limit = model.DefaultInboxLimit
+	}
+	f.Limit = min(f.Limit, model.MaxInboxLimit)
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+
+	items, err := s.repo.List(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := s.repo.CountUnread(ctx, f.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.InboxPage{Items: items, Unread: unread}
+	if page.Items == nil {
+		page.Items = []*model.Notification{}
+	}
+	if len(items) == f.Limit {
+		next := items[len(items)-1].ID
+		page.NextBeforeID = &next
+	}
+	return page, nil
+}
+
+func (s *InboxService) CountUnread(ctx context.Context, userID int64) (int, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	return s.repo.CountUnread(ctx, userID)
+}
+
+// MarkRead отмечает прочитанными переданные уведомления (или все) и возвращает
+// оставшееся число непрочитанных
+func (s *InboxService) MarkRead(ctx context.Context, userID int64, ids []int64, all bool) (int, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+
+	var err error
+	if all {
+		err = s.repo.MarkAllRead(ctx, userID)
+	} else if len(ids) > 0 {
+		err = s.repo.MarkRead(ctx, userID, ids)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	unread, err := s.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.hub.Publish(ctx, userID, model.InboxEvent{Type: model.InboxEventRead, Unread: unread}); err != nil {
+		log.Printf("[INBOX] Не удалось опубликовать прочтение пользователя %d: %v", userID, err)
+	}
+	return unread, nil
+}
+
+func (s *InboxService) Subscribe(ctx context.Context, userID int64) (<-chan model.InboxEvent, error) {
+	return s.hub.Subscribe(ctx, userID)
+}
+
+// publish — сбой live-канала не мешает: уведомление уже в ленте
+func (s *InboxService) publish(ctx context.Context, n *model.Notification) {
+	unread, err := s.repo.CountUnread(ctx, n.UserID)
+	if err == nil {
+		err = s.hub.Publish(ctx, n.UserID, model.InboxEvent{Type: model.InboxEventNotification, Notification: n, Unread: unread})
+	}
+	if err != nil {
+		log.Printf("[INBOX] Не удалось опубликовать уведомление пользователю %d: %v", n.UserID, err)
+	}
+}
+
+// InboxChannel — канал inapp для notifier: сообщение попадает в ленту пользователя.
+// To — ID пользователя, Meta: category и source_event_id.
+type InboxChannel struct {
+	inbox *InboxService
+}
+
+// InboxDriver — драйвер notifier.DriverInbox поверх ленты
+func InboxDriver(inbox *InboxService) notifier.DriverFactory {
+	return func(kind notifier.ChannelKind, _ notifier.Settings) (notifier.Channel, error) {
+		if kind != notifier.ChannelInApp {
+			return nil, fmt.Errorf("драйвер %s обслуживает только канал inapp", notifier.DriverInbox)
+		}
+		return &InboxChannel{inbox: inbox}, nil
+	}
+}
+
+func (c *InboxChannel) Kind() notifier.ChannelKind { return notifier.ChannelInApp }
+
+func (c *InboxChannel) Send(ctx context.Context, msg notifier.Message) error {
+	userID, err := strconv.ParseInt(msg.To, 10, 64)
+	if err != nil || userID <= 0 {
+		return fmt.Errorf("некорректный ID пользователя %q", msg.To)
+	}
+
+	n := &model.Notification{
+		UserID:   userID,
+		Category: msg.Meta["category"],
+		Subject:  msg.Subject,
+		Body:     msg.Body,
+	}
+	if id, err := strconv.ParseInt(msg.Meta["source_event_id"], 10, 64); err == nil && id > 0 {
+		n.SourceEventID = &id
+	}
+	return c.inbox.Deliver(ctx, n)
+}
@@ -0,0 +1,69 @@
+package notification_usecase
+
+import (
+	"context"
+
+	model "github.com/Vovarama1992/emelya-go/internal/notification/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/notification/ports"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+)
+
+type PreferencesService struct {
+	repo ports.PreferencesRepository
+}
+
+func NewPreferencesService(repo ports.PreferencesRepository) *PreferencesService {
+	return &PreferencesService{repo: repo}
+}
+
+func (s *PreferencesService) GetPreferences(ctx context.Context, userID int64) (*model.Preferences, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	return s.repo.Get(ctx, userID)
+}
+
+// UpdatePreferences меняет переданные каналы и тихие часы, остальное оставляет как было
+func (s *PreferencesService) UpdatePreferences(ctx context.Context, userID int64, update model.PreferencesUpdate) (*model.Preferences, error) {
+	for cat, channels := range update.Channels {
+		if !model.ValidCategory(cat) {
+			return nil, model.ErrInvalidCategory
+		}
+		for ch := range channels {
+			if !model.ValidUserChannel(ch) {
+				return nil, model.ErrInvalidChannel
+			}
+		}
+	}
+	if update.QuietHours != nil {
+		if update.QuietHours.Timezone == "" {
+			update.QuietHours.Timezone = model.DefaultTimezone
+		}
+		if err := update.QuietHours.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+
+	prefs, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for cat, channels := range update.Channels {
+		for ch, enabled := range channels {
+			prefs.Channels[cat][ch] = enabled
+		}
+	}
+	switch {
+	case update.DisableQuietHours:
+		prefs.QuietHours = nil
+	case update.QuietHours != nil:
+		prefs.QuietHours = update.QuietHours
+	}
+
+	if err := s.repo.Save(ctx, prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
@@ -0,0 +1,273 @@
+package notification_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	model "github.com/Vovarama1992/emelya-go/internal/notification/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/notification/ports"
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	"github.com/Vovarama1992/emelya-go/internal/pollutil"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+)
+
+// SmsFailoverChannel — канал SMS, который перебирает провайдеров по порядку:
+// следующий пробуется, если предыдущий не принял сообщение. Каждая отправка
+// пишется в журнал с ID сообщения у провайдера — по нему опрашивается статус.
+type SmsFailoverChannel struct {
+	providers  []notifier.SmsProvider
+	repo       ports.SmsRepository
+	firstCheck time.Duration
+}
+
+// SmsFailoverDriver — драйвер notifier.DriverSmsFailover; провайдеры — из SMS_PROVIDERS
+func SmsFailoverDriver(repo ports.SmsRepository, cfg SmsStatusConfig) notifier.DriverFactory {
+	return func(kind notifier.ChannelKind, settings notifier.Settings) (notifier.Channel, error) {
+		if kind != notifier.ChannelSMS {
+			return nil, fmt.Errorf("драйвер %s обслуживает только канал sms", notifier.DriverSmsFailover)
+		}
+		providers, err := notifier.LoadSmsProviders(settings)
+		if err != nil {
+			return nil, err
+		}
+		return &SmsFailoverChannel{providers: providers, repo: repo, firstCheck: cfg.FirstCheck}, nil
+	}
+}
+
+func (c *SmsFailoverChannel) Kind() notifier.ChannelKind { return notifier.ChannelSMS }
+
+func (c *SmsFailoverChannel) Send(ctx context.Context, msg notifier.Message) error {
+	phone := strings.TrimSpace(msg.To)
+	if phone == "" {
+		return fmt.Errorf("не указан номер")
+	}
+
+	rec := &model.SmsMessage{Phone: model.NormalizeSmsPhone(phone), Template: msg.Meta["template"]}
+	// Сообщения по событиям идут без шаблона, но с категорией
+	if rec.Template == "" {
+		rec.Template = msg.Meta["category"]
+	}
+
+	var errs []error
+	for _, p := range c.providers {
+		receipt, err := p.Send(ctx, phone, msg.Body)
+		if err == nil {
+			next := time.Now().Add(c.firstCheck)
+			provider := p.Name()
+			rec.Status = model.SmsSent
+			rec.Provider = &provider
+			rec.ProviderMessageID = &receipt.MessageID
+			rec.Cost = receipt.Cost
+			rec.NextCheckAt = &next
+			if len(errs) > 0 {
+				lastError := errors.Join(errs...).Error()
+				rec.LastError = &lastError
+			}
+			c.record(ctx, rec)
+			log.Printf("[SMS] Отправлено через %s: %s", provider, phone)
+			return nil
+		}
+
+		log.Printf("[SMS] %s не принял SMS на %s: %v", p.Name(), phone, err)
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+		if errors.Is(err, notifier.ErrSmsRejected) || ctx.Err() != nil {
+			break
+		}
+	}
+
+	err := errors.Join(errs...)
+	if err == nil {
+		err = fmt.Errorf("не настроен ни один SMS-провайдер")
+	}
+	now := time.Now()
+	lastError := err.Error()
+	rec.Status = model.SmsFailed
+	rec.LastError = &lastError
+	rec.StatusAt = &now
+	c.record(ctx, rec)
+	return err
+}
+
+// record пишет журнал и после таймаута отправки: сообщение уже ушло, запись о нём
+// нужна для опроса статуса
+func (c *SmsFailoverChannel) record(ctx context.Context, rec *model.SmsMessage) {
+	ctx, cancel := ctxutil.WithTimeout(context.WithoutCancel(ctx), 2)
+	defer cancel()
+
+	if err := c.repo.Create(ctx, rec); err != nil {
+		log.Printf("[SMS] Не удалось записать SMS на %s в журнал: %v", rec.Phone, err)
+	}
+}
+
+// SmsStatusConfig — опрос статусов доставки: первая проверка через FirstCheck,
+// дальше интервал удваивается до MaxDelay; после MaxChecks проверок опрос прекращается
+type SmsStatusConfig struct {
+	PollInterval   time.Duration
+	BatchSize      int
+	FirstCheck     time.Duration
+	MaxDelay       time.Duration
+	MaxChecks      int
+	RequestTimeout time.Duration
+}
+
+func DefaultSmsStatusConfig() SmsStatusConfig {
+	return SmsStatusConfig{
+		PollInterval:   30 * time.Second,
+		BatchSize:      50,
+		FirstCheck:     time.Minute,
+		MaxDelay:       time.Hour,
+		MaxChecks:      15,
+		RequestTimeout: 10 * time.Second,
+	}
+}
+
+// LoadSmsStatusConfigFromEnv — SMS_STATUS_POLL_INTERVAL и SMS_STATUS_MAX_CHECKS,
+// остальное по умолчанию
+func LoadSmsStatusConfigFromEnv() SmsStatusConfig {
+	cfg := DefaultSmsStatusConfig()
+	if d, err := time.ParseDuration(os.Getenv("SMS_STATUS_POLL_INTERVAL")); err == nil && d > 0 {
+		cfg.PollInterval = d
+	}
+	if n, err := strconv.Atoi(os.Getenv("SMS_STATUS_MAX_CHECKS")); err == nil && n > 0 {
+		cfg.MaxChecks = n
+	}
+	return cfg
+}
+
+// SmsStatusPoller уточняет у провайдеров статус доставки и цену отправленных SMS
+type SmsStatusPoller struct {
+	repo      ports.SmsRepository
+	providers map[string]notifier.SmsProvider
+	cfg       SmsStatusConfig
+}
+
+func NewSmsStatusPoller(repo ports.SmsRepository, providers []notifier.SmsProvider, cfg SmsStatusConfig) *SmsStatusPoller {
+	byName := make(map[string]notifier.SmsProvider, len(providers))
+	for _, p := range providers {
+		byName[p.Name()] = p
+	}
+	return &SmsStatusPoller{repo: repo, providers: byName, cfg: cfg}
+}
+
+// Start опрашивает статусы в фоне до отмены ctx
+func (s *SmsStatusPoller) Start(ctx context.Context) {
+	go pollutil.Run(ctx, s.cfg.PollInterval, s.cfg.BatchSize, s.CheckPending, func(err error) {
+		log.Printf("[SMS] Ошибка выборки SMS для проверки статуса: %v", err)
+	})
+}
+
+// CheckPending проверяет одну пачку сообщений и возвращает её размер
+func (s *SmsStatusPoller) CheckPending(ctx context.Context) (int, error) {
+	// Сообщения пачки проверяются по очереди: аренда рассчитана на всю пачку
+	lease := s.cfg.RequestTimeout*time.Duration(s.cfg.BatchSize) + s.cfg.FirstCheck
+	list, err := s.repo.ClaimForCheck(ctx, s.cfg.BatchSize, lease)
+	if err != nil {
+		return 0, err
+	}
+	for _, m := range list {
+		s.check(ctx, m)
+	}
+	return len(list), nil
+}
+
+func (s *SmsStatusPoller) check(ctx context.Context, m *model.SmsMessage) {
+	var p notifier.SmsProvider
+	if m.Provider != nil {
+		p = s.providers[*m.Provider]
+	}
+	if p == nil || m.ProviderMessageID == nil {
+		// Провайдер убрали из SMS_PROVIDERS — спросить статус не у кого
+		s.update(ctx, m, model.SmsSent, "", nil, nil)
+		return
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, s.cfg.RequestTimeout)
+	report, err := p.Status(reqCtx, *m.ProviderMessageID)
+	cancel()
+	if err != nil {
+		log.Printf("[SMS] Не удалось узнать статус SMS %d у %s: %v", m.ID, p.Name(), err)
+		s.update(ctx, m, model.SmsSent, "", nil, s.nextCheck(m.Checks))
+		return
+	}
+
+	switch report.Delivery {
+	case notifier.SmsDelivered:
+		s.update(ctx, m, model.SmsDelivered, report.Detail, report.Cost, nil)
+	case notifier.SmsUndelivered:
+		s.update(ctx, m, model.SmsUndelivered, report.Detail, report.Cost, nil)
+	default:
+		s.update(ctx, m, model.SmsSent, report.Detail, report.Cost, s.nextCheck(m.Checks))
+	}
+}
+
+func (s *SmsStatusPoller) update(ctx context.Context, m *model.SmsMessage, status model.SmsStatus, detail string, cost *float64, next *time.Time) {
+	if err := s.repo.UpdateStatus(ctx, m.ID, m.Checks, status, detail, cost, next); err != nil {
+		log.Printf("[SMS] Не удалось обновить статус SMS %d: %v", m.ID, err)
+	}
+}
+
+// nextCheck — FirstCheck * 2^checks, не больше MaxDelay; nil — проверки исчерпаны
+func (s *SmsStatusPoller) nextCheck(checks int) *time.Time {
+	if checks >= s.cfg.MaxChecks {
+		return nil
+	}
+	next := time.Now().Add(pollutil.Backoff(s.cfg.FirstCheck, s.cfg.MaxDelay, checks+1))
+	return &next
+}
+
+// SmsService — журнал SMS и расходы для админки
+type SmsService struct {
+	repo ports.SmsRepository
+}
+
+func NewSmsService(repo ports.SmsRepository) *SmsService {
+	return &SmsService{repo: repo}
+}
+
+func (s *SmsService) ListSms(ctx context.Context, f model.SmsFilter) ([]*model.SmsMessage, error) {
+	if f.Limit <= 0 {
+		f.Limit = model.DefaultSmsLimit
+	}
+	f.Limit = min(f.Limit, model.MaxSmsLimit)
+	if f.Phone != nil {
+		phone := model.NormalizeSmsPhone(*f.Phone)
+		f.Phone = &phone
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+
+	list, err := s.repo.List(ctx, f)
+	if list == nil && err == nil {
+		list = []*model.SmsMessage{}
+	}
+	return list, err
+}
+
+// CostSummary — расходы по провайдерам за период; по умолчанию последние 30 дней
+func (s *SmsService) CostSummary(ctx context.Context, from, to time.Time) ([]*model.SmsCostSummary, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -30)
+	}
+	if !from.Before(to) {
+		return nil, model.ErrInvalidPeriod
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 5)
+	defer cancel()
+
+	list, err := s.repo.CostSummary(ctx, from, to)
+	if list == nil && err == nil {
+		list = []*model.SmsCostSummary{}
+	}
+	return list, err
+}
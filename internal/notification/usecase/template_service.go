@@ -0,0 +1,202 @@
+package notification_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	audit "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	audit_ports "github.com/Vovarama1992/emelya-go/internal/audit/ports"
+	model "github.com/Vovarama1992/emelya-go/internal/notification/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/notification/ports"
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+	"github.com/jackc/pgx/v5"
+)
+
+// TemplateService — редактирование шаблонов сообщений в админке. Встроенные тексты
+// живут в notifier, здесь — только правки поверх них.
+type TemplateService struct {
+	repo         ports.TemplateRepository
+	templates    *notifier.Templates
+	auditService audit_ports.AuditService
+}
+
+func NewTemplateService(repo ports.TemplateRepository, templates *notifier.Templates, auditService audit_ports.AuditService) *TemplateService {
+	return &TemplateService{repo: repo, templates: templates, auditService: auditService}
+}
+
+// ListTemplates — все шаблоны на языке по умолчанию и переводы, которые уже есть
+func (s *TemplateService) ListTemplates(ctx context.Context) ([]*model.TemplateView, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+
+	overrides, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byKey := map[string]*model.TemplateOverride{}
+	for _, o := range overrides {
+		byKey[o.Key+"/"+o.Locale] = o
+	}
+
+	var views []*model.TemplateView
+	for _, def := range s.templates.Defs() {
+		for _, locale := range notifier.SupportedLocales {
+			o := byKey[def.Key+"/"+locale]
+			if _, translated := def.Locales[locale]; o == nil && !translated {
+				continue
+			}
+			views = append(views, s.view(def, locale, o))
+		}
+	}
+	return views, nil
+}
+
+func (s *TemplateService) GetTemplate(ctx context.Context, key, locale string) (*model.TemplateView, error) {
+	def, err := s.lookup(key, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	o, err := s.repo.Get(ctx, key, locale)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s.view(def, locale, nil), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.view(def, locale, o), nil
+}
+
+// UpdateTemplate сохраняет правку. Шаблон должен разбираться и исполняться на примере
+// данных — иначе сломанное письмо обнаружится только при отправке.
+func (s *TemplateService) UpdateTemplate(ctx context.Context, actorID int64, t notifier.Template) (*model.TemplateView, error) {
+	def, err := s.lookup(t.Key, t.Locale)
+	if err != nil {
+		return nil, err
+	}
+	if err := notifier.ValidateTemplate(t); err != nil {
+		return nil, fmt.Errorf("%w: %v", model.ErrInvalidTemplate, err)
+	}
+	if _, err := notifier.RenderTemplate(t, def.Sample); err != nil {
+		return nil, fmt.Errorf("%w: %v", model.ErrInvalidTemplate, err)
+	}
+
+	before, err := s.GetTemplate(ctx, t.Key, t.Locale)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	o, err := s.repo.Upsert(ctx, t, actorID)
+	if err != nil {
+		return nil, err
+	}
+	after := s.view(def, t.Locale, o)
+	s.record(ctx, actorID, audit.ActionTemplateUpdate, before.Current, after.Current)
+	return after, nil
+}
+
+// ResetTemplate удаляет правку — снова действует встроенный текст
+func (s *TemplateService) ResetTemplate(ctx context.Context, actorID int64, key, locale string) (*model.TemplateView, error) {
+	def, err := s.lookup(key, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	o, err := s.repo.Delete(ctx, key, locale)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, model.ErrTemplateNotChanged
+	}
+	if err != nil {
+		return nil, err
+	}
+	after := s.view(def, locale, nil)
+	s.record(ctx, actorID, audit.ActionTemplateReset, o.Template, after.Current)
+	return after, nil
+}
+
+// Preview заполняет черновик или действующий шаблон данными
+func (s *TemplateService) Preview(ctx context.Context, p model.TemplatePreview) (*notifier.Rendered, error) {
+	def, err := s.lookup(p.Key, p.Locale)
+	if err != nil {
+		return nil, err
+	}
+
+	var tpl notifier.Template
+	if p.Draft != nil {
+		tpl = *p.Draft
+		if err := notifier.ValidateTemplate(tpl); err != nil {
+			return nil, fmt.Errorf("%w: %v", model.ErrInvalidTemplate, err)
+		}
+	} else {
+		view, err := s.GetTemplate(ctx, p.Key, p.Locale)
+		if err != nil {
+			return nil, err
+		}
+		tpl = view.Current
+	}
+
+	data := p.Data
+	if data == nil {
+		data = def.Sample
+	}
+	r, err := notifier.RenderTemplate(tpl, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", model.ErrInvalidTemplate, err)
+	}
+	return r, nil
+}
+
+func (s *TemplateService) lookup(key, locale string) (*notifier.TemplateDef, error) {
+	def, ok := s.templates.Def(key)
+	if !ok {
+		return nil, model.ErrTemplateNotFound
+	}
+	if !model.ValidLocale(locale) {
+		return nil, model.ErrUnsupportedLocale
+	}
+	return def, nil
+}
+
+func (s *TemplateService) view(def *notifier.TemplateDef, locale string, o *model.TemplateOverride) *model.TemplateView {
+	builtin, _ := s.templates.Builtin(def.Key, locale)
+	v := &model.TemplateView{
+		Key:         def.Key,
+		Locale:      locale,
+		Description: def.Description,
+		Sample:      def.Sample,
+		Current:     builtin,
+		Default:     builtin,
+	}
+	if o != nil {
+		v.Current = o.Template
+		v.Overridden = true
+		v.UpdatedBy = o.UpdatedBy
+		v.UpdatedAt = &o.UpdatedAt
+	}
+	return v
+}
+
+func (s *TemplateService) record(ctx context.Context, actorID int64, action audit.Action, before, after notifier.Template) {
+	err := s.auditService.Record(ctx, &audit.Entry{
+		ActorID:    &actorID,
+		Action:     action,
+		EntityType: audit.EntityTemplate,
+		Before:     audit.Snapshot(before),
+		After:      audit.Snapshot(after),
+	})
+	if err != nil {
+		log.Printf("[NOTIFY] Не удалось записать журнал по шаблону %s/%s: %v", after.Key, after.Locale, err)
+	}
+}
@@ -0,0 +1,203 @@
+package notification_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	model "github.com/Vovarama1992/emelya-go/internal/notification/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/notification/ports"
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	outbox_infra "github.com/Vovarama1992/emelya-go/internal/outbox/infra"
+	outbox_model "github.com/Vovarama1992/emelya-go/internal/outbox/model"
+	user_model "github.com/Vovarama1992/emelya-go/internal/user/model"
+	user_ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
+)
+
+// Категория настроек для каждого события, о котором пишем пользователю
+var eventCategories = map[outbox_model.EventType]model.Category{
+	outbox_model.EventDepositApproved:    model.CategoryDeposits,
+	outbox_model.EventRewardAccrued:      model.CategoryAccruals,
+	outbox_model.EventWithdrawalApproved: model.CategoryWithdrawals,
+	outbox_model.EventWithdrawalRejected: model.CategoryWithdrawals,
+}
+
+// Шаблон сообщения для каждого события
+var eventTemplates = map[outbox_model.EventType]string{
+	outbox_model.EventDepositApproved:    notifier.TplUserDepositApproved,
+	outbox_model.EventRewardAccrued:      notifier.TplUserRewardAccrued,
+	outbox_model.EventWithdrawalApproved: notifier.TplUserWithdrawalApproved,
+	outbox_model.EventWithdrawalRejected: notifier.TplUserWithdrawalRejected,
+}
+
+// UserNotifications превращает денежные события в сообщения пользователю по каналам
+// из его настроек. Каждое сообщение ставится в outbox отдельным событием, чтобы
+// сбой одного канала не вызывал повторов в других. SMS в тихие часы откладываются.
+type UserNotifications struct {
+	db          *db.DB
+	prefs       ports.PreferencesRepository
+	userService user_ports.UserServiceInterface
+	notifier    notifier.NotifierInterface
+	now         func() time.Time
+}
+
+func NewUserNotifications(db *db.DB, prefs ports.PreferencesRepository, userService user_ports.UserServiceInterface, notifier notifier.NotifierInterface) *UserNotifications {
+	return &UserNotifications{db: db, prefs: prefs, userService: userService, notifier: notifier, now: time.Now}
+}
+
+// Events — события, о которых пишем пользователям
+func (h *UserNotifications) Events() []outbox_model.EventType {
+	events := make([]outbox_model.EventType, 0, len(eventCategories))
+	for t := range eventCategories {
+		events = append(events, t)
+	}
+	return events
+}
+
+func (h *UserNotifications) Handle(ctx context.Context, e *outbox_model.Event) (err error) {
+	category, ok := eventCategories[e.Type]
+	if !ok {
+		return fmt.Errorf("неизвестный тип события %s", e.Type)
+	}
+
+	userID, data, err := decodeUserEvent(e)
+	if err != nil {
+		return err
+	}
+
+	user, err := h.userService.FindUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	prefs, err := h.prefs.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	msg, err := h.notifier.Render(ctx, eventTemplates[e.Type], data)
+	if err != nil {
+		return err
+	}
+
+	var messages []*outbox_model.Event
+	for _, ch := range model.UserChannels {
+		if !prefs.Enabled(category, ch) || !h.notifier.HasChannel(ch) {
+			continue
+		}
+		payload, ok := userMessage(user, ch, msg)
+		if !ok {
+			continue
+		}
+		payload.SourceEventID = e.ID
+		payload.Category = string(category)
+
+		event, err := outbox_model.NewEvent(outbox_model.EventUserMessage, outbox_model.AggregateUser, userID, payload)
+		if err != nil {
+			return err
+		}
+		if ch == notifier.ChannelSMS && prefs.QuietHours != nil {
+			if until, quiet := prefs.QuietHours.DeferUntil(h.now()); quiet {
+				event.NextAttemptAt = until
+			}
+		}
+		messages = append(messages, event)
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+
+	tx, err := h.db.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	// Ключ (исходное событие, канал): повтор события не размножает сообщения
+	outbox := outbox_infra.NewOutboxRepositoryWithTx(tx)
+	for _, m := range messages {
+		if _, err = outbox.CreateUnique(ctx, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// userMessage подбирает адрес для канала; false — канал пользователю недоступен
+func userMessage(user *user_model.User, ch notifier.ChannelKind, msg *notifier.Rendered) (outbox_model.UserMessagePayload, bool) {
+	p := outbox_model.UserMessagePayload{UserID: user.ID, Channel: string(ch), Subject: msg.Subject, Body: msg.Text}
+	switch ch {
+	case notifier.ChannelSMS:
+		p.To, p.Subject, p.Body = user.Phone, "", msg.SMS
+		return p, user.Phone != ""
+	case notifier.ChannelEmail:
+		// На неподтверждённый адрес ничего, кроме кода подтверждения, не шлём
+		p.To, p.Body, p.HTML = user.Email, msg.Text+"\n\nEmelia Invest", msg.HTML
+		return p, user.Email != "" && user.IsEmailVerified
+	case notifier.ChannelInApp:
+		p.To = strconv.FormatInt(user.ID, 10)
+		return p, true
+	}
+	return p, false
+}
+
+func decodeUserEvent(e *outbox_model.Event) (int64, any, error) {
+	switch e.Type {
+	case outbox_model.EventDepositApproved:
+		var p outbox_model.DepositPayload
+		err := e.DecodePayload(&p)
+		return p.UserID, p, err
+	case outbox_model.EventRewardAccrued:
+		var p outbox_model.RewardAccruedPayload
+		err := e.DecodePayload(&p)
+		return p.UserID, p, err
+	case outbox_model.EventWithdrawalApproved, outbox_model.EventWithdrawalRejected:
+		var p outbox_model.WithdrawalPayload
+		err := e.DecodePayload(&p)
+		return p.UserID, p, err
+	}
+	return 0, nil, fmt.Errorf("неизвестный тип события %s", e.Type)
+}
+
+// UserMessageDelivery отправляет подготовленное сообщение в его канал
+type UserMessageDelivery struct {
+	notifier notifier.NotifierInterface
+}
+
+func NewUserMessageDelivery(notifier notifier.NotifierInterface) *UserMessageDelivery {
+	return &UserMessageDelivery{notifier: notifier}
+}
+
+func (h *UserMessageDelivery) Handle(ctx context.Context, e *outbox_model.Event) error {
+	var p outbox_model.UserMessagePayload
+	if err := e.DecodePayload(&p); err != nil {
+		return err
+	}
+
+	err := h.notifier.Send(ctx, notifier.Message{
+		Channel: notifier.ChannelKind(p.Channel),
+		To:      p.To,
+		Subject: p.Subject,
+		Body:    p.Body,
+		HTML:    p.HTML,
+		Meta: map[string]string{
+			"user_id":         strconv.FormatInt(p.UserID, 10),
+			"category":        p.Category,
+			"source_event_id": strconv.FormatInt(p.SourceEventID, 10),
+		},
+	})
+	// Выключенный канал повтором не починить
+	if errors.Is(err, notifier.ErrChannelDisabled) {
+		log.Printf("[NOTIFY] Канал %s выключен, сообщение пользователю %d пропущено", p.Channel, p.UserID)
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,358 @@
+package notifier
+
+// Ключи встроенных шаблонов. Тексты можно переопределить в админке,
+// данные, которые подставляются в шаблон, — в Sample каждого шаблона.
+const (
+	TplAuthCode              = "auth.code"
+	TplAuthCredentials       = "auth.credentials"
+	TplAuthEmailVerification = "auth.email_verification"
+	TplAuthPasswordChanged   = "auth.password_changed"
+	TplAuthPhoneChanged      = "auth.phone_changed"
+	TplAuthEmailChanged      = "auth.email_changed"
+
+	TplUserDepositApproved       = "user.deposit_approved"
+	TplUserRewardAccrued         = "user.reward_accrued"
+	TplUserWithdrawalApproved    = "user.withdrawal_approved"
+	TplUserWithdrawalRejected    = "user.withdrawal_rejected"
+	TplUserProfileChangeApproved = "user.profile_change_approved"
+	TplUserProfileChangeRejected = "user.profile_change_rejected"
+	TplUserKYCApproved           = "user.kyc_approved"
+	TplUserKYCRejected           = "user.kyc_rejected"
+
+	TplOperatorUserRegistered         = "operator.user_registered"
+	TplOperatorLoginCode              = "operator.login_code"
+	TplOperatorLoginPassword          = "operator.login_password"
+	TplOperatorContactChanged         = "operator.contact_changed"
+	TplOperatorProfileChangeRequested = "operator.profile_change_requested"
+	TplOperatorKYCSubmitted           = "operator.kyc_submitted"
+	TplOperatorPayoutMethodAdded      = "operator.payout_method_added"
+	TplOperatorDepositRequested       = "operator.deposit_requested"
+	TplOperatorDepositApproved        = "operator.deposit_approved"
+	TplOperatorDepositClosed          = "operator.deposit_closed"
+	TplOperatorWithdrawalRequested    = "operator.withdrawal_requested"
+	TplOperatorWithdrawalApproved     = "operator.withdrawal_approved"
+	TplOperatorWithdrawalRejected     = "operator.withdrawal_rejected"
+	TplOperatorLeadCreated            = "operator.lead_created"
+)
+
+const securityWarning = "Если это были не вы, срочно свяжитесь с поддержкой."
+
+func ru(tpl Template) map[string]Template {
+	return map[string]Template{DefaultLocale: tpl}
+}
+
+var builtinTemplates = []*TemplateDef{
+	// Пользователю: вход и безопасность
+	{
+		Key:         TplAuthCode,
+		Description: "Код подтверждения (вход, смена контактов, сброс пароля)",
+		Sample:      map[string]any{"Code": "123456"},
+		Locales: ru(Template{
+			Subject: "Код подтверждения",
+			Text:    "Код подтверждения: {{.Code}}\n\nЕсли вы не запрашивали код, просто проигнорируйте это письмо.\n\nEmelia Invest",
+			HTML: `<p>Код подтверждения:</p>
+<p style="font-size:28px;font-weight:bold;letter-spacing:4px;">{{.Code}}</p>
+<p style="color:#666;">Если вы не запрашивали код, просто проигнорируйте это письмо.</p>`,
+			SMS: "Код подтверждения: {{.Code}}. Emelia Invest",
+		}),
+	},
+	{
+		Key:         TplAuthCredentials,
+		Description: "Логин и пароль после подтверждения регистрации",
+		Sample:      map[string]any{"Login": "user123", "Password": "Qwerty12"},
+		Locales: ru(Template{
+			SMS: "Логин: {{.Login}}\nПароль: {{.Password}}\nEmelia Invest",
+		}),
+	},
+	{
+		Key:         TplAuthEmailVerification,
+		Description: "Подтверждение email: ссылка и код",
+		Sample:      map[string]any{"Code": "123456", "Link": "https://emelia-invest.com/verify-email?code=123456"},
+		Locales: ru(Template{
+			Subject: "Подтверждение email",
+			Text:    "Подтвердите email, перейдя по ссылке:\n{{.Link}}\n\nИли введите код в личном кабинете: {{.Code}}\n\nЕсли вы не регистрировались, просто проигнорируйте это письмо.\n\nEmelia Invest",
+			HTML: `<p>Подтвердите email, нажав на кнопку:</p>
+<p><a href="{{.Link}}" style="display:inline-block;padding:12px 24px;background:#1a73e8;color:#fff;border-radius:6px;text-decoration:none;">Подтвердить email</a></p>
+<p>Или введите код в личном кабинете: <b>{{.Code}}</b></p>
+<p style="color:#666;">Если вы не регистрировались, просто проигнорируйте это письмо.</p>`,
+		}),
+	},
+	{
+		Key:         TplAuthPasswordChanged,
+		Description: "Пароль изменён",
+		Sample:      map[string]any{},
+		Locales: ru(Template{
+			Subject: "Пароль изменён",
+			Text:    "Пароль от вашего аккаунта изменён. " + securityWarning + "\n\nEmelia Invest",
+			HTML:    `<p>Пароль от вашего аккаунта изменён.</p><p><b>` + securityWarning + `</b></p>`,
+			SMS:     "Пароль от вашего аккаунта изменён. " + securityWarning + " Emelia Invest",
+		}),
+	},
+	{
+		Key:         TplAuthPhoneChanged,
+		Description: "Предупреждение на старый номер о смене телефона",
+		Sample:      map[string]any{},
+		Locales: ru(Template{
+			SMS: "Номер телефона вашего аккаунта изменён. " + securityWarning + " Emelia Invest",
+		}),
+	},
+	{
+		Key:         TplAuthEmailChanged,
+		Description: "Предупреждение на старый адрес о смене email",
+		Sample:      map[string]any{"NewEmail": "new@example.com"},
+		Locales: ru(Template{
+			Subject: "Email изменён",
+			Text:    "Email вашего аккаунта изменён на {{.NewEmail}}. " + securityWarning + "\n\nEmelia Invest",
+			HTML:    `<p>Email вашего аккаунта изменён на <b>{{.NewEmail}}</b>.</p><p><b>` + securityWarning + `</b></p>`,
+		}),
+	},
+
+	// Пользователю: деньги, профиль, верификация
+	{
+		Key:         TplUserDepositApproved,
+		Description: "Депозит одобрен",
+		Sample:      map[string]any{"DepositID": 42, "UserID": 1, "Amount": 150000.0, "DailyReward": 0.015, "BlockDays": 90},
+		Locales: ru(Template{
+			Subject: "Депозит одобрен",
+			Text: "Ваш депозит на {{money .Amount}} ₽ одобрен." +
+				"{{if .DailyReward}} Доходность — {{percent .DailyReward}}% в день" +
+				"{{if .BlockDays}}, срок блокировки — {{.BlockDays}} дн.{{else}}.{{end}}{{end}}",
+			HTML: `<p>Ваш депозит на <b>{{money .Amount}} ₽</b> одобрен.</p>` +
+				`{{if .DailyReward}}<p>Доходность — {{percent .DailyReward}}% в день` +
+				`{{if .BlockDays}}, срок блокировки — {{.BlockDays}} дн.{{else}}.{{end}}</p>{{end}}`,
+			SMS: "Депозит на {{money .Amount}} руб. одобрен. Emelia Invest",
+		}),
+	},
+	{
+		Key:         TplUserRewardAccrued,
+		Description: "Начислен доход по депозиту",
+		Sample:      map[string]any{"RewardID": 7, "DepositID": 42, "UserID": 1, "Amount": 2250.0, "Days": 1},
+		Locales: ru(Template{
+			Subject: "Начислен доход",
+			Text:    "По депозиту №{{.DepositID}} начислено {{money .Amount}} ₽{{if gt .Days 1}} за {{.Days}} дн.{{end}}.",
+			HTML:    `<p>По депозиту №{{.DepositID}} начислено <b>{{money .Amount}} ₽</b>{{if gt .Days 1}} за {{.Days}} дн.{{end}}.</p>`,
+			SMS:     "Начислено {{money .Amount}} руб. по депозиту. Emelia Invest",
+		}),
+	},
+	{
+		Key:         TplUserWithdrawalApproved,
+		Description: "Вывод средств одобрен",
+		Sample:      map[string]any{"WithdrawalID": 5, "UserID": 1, "Amount": 10000.0},
+		Locales: ru(Template{
+			Subject: "Вывод средств одобрен",
+			Text:    "Заявка на вывод {{money .Amount}} ₽ одобрена. Средства поступят на ваши реквизиты.",
+			HTML:    `<p>Заявка на вывод <b>{{money .Amount}} ₽</b> одобрена.</p><p>Средства поступят на ваши реквизиты.</p>`,
+			SMS:     "Вывод {{money .Amount}} руб. одобрен. Emelia Invest",
+		}),
+	},
+	{
+		Key:         TplUserWithdrawalRejected,
+		Description: "Вывод средств отклонён",
+		Sample:      map[string]any{"WithdrawalID": 5, "UserID": 1, "Amount": 10000.0, "Reason": "реквизиты не прошли проверку"},
+		Locales: ru(Template{
+			Subject: "Вывод средств отклонён",
+			Text:    "Заявка на вывод {{money .Amount}} ₽ отклонена.{{with .Reason}} Причина: {{.}}{{end}}",
+			HTML:    `<p>Заявка на вывод <b>{{money .Amount}} ₽</b> отклонена.</p>{{with .Reason}}<p>Причина: {{.}}</p>{{end}}`,
+			SMS:     "Вывод {{money .Amount}} руб. отклонён, подробности в личном кабинете. Emelia Invest",
+		}),
+	},
+	{
+		Key:         TplUserProfileChangeApproved,
+		Description: "Изменения профиля одобрены",
+		Sample:      map[string]any{},
+		Locales: ru(Template{
+			Subject: "Изменение профиля",
+			Text:    "Изменения профиля одобрены и применены.\n\nEmelia Invest",
+			HTML:    `<p>Изменения профиля одобрены и применены.</p>`,
+		}),
+	},
+	{
+		Key:         TplUserProfileChangeRejected,
+		Description: "Изменения профиля отклонены",
+		Sample:      map[string]any{"Reason": "фамилия не совпадает с документами"},
+		Locales: ru(Template{
+			Subject: "Изменение профиля",
+			Text:    "Изменения профиля отклонены. Причина: {{.Reason}}\n\nEmelia Invest",
+			HTML:    `<p>Изменения профиля отклонены.</p><p>Причина: {{.Reason}}</p>`,
+		}),
+	},
+	{
+		Key:         TplUserKYCApproved,
+		Description: "Верификация пройдена",
+		Sample:      map[string]any{"Level": 2},
+		Locales: ru(Template{
+			Subject: "Верификация пройдена",
+			Text:    "Ваши документы проверены, лимиты на депозиты и вывод увеличены.\n\nEmelia Invest",
+			HTML:    `<p>Ваши документы проверены, лимиты на депозиты и вывод увеличены.</p>`,
+		}),
+	},
+	{
+		Key:         TplUserKYCRejected,
+		Description: "Верификация не пройдена",
+		Sample:      map[string]any{"Level": 2, "Reason": "фото паспорта нечитаемо"},
+		Locales: ru(Template{
+			Subject: "Верификация не пройдена",
+			Text:    "Документы не прошли проверку{{with .Reason}}: {{.}}{{end}}. Вы можете загрузить документы заново.\n\nEmelia Invest",
+			HTML:    `<p>Документы не прошли проверку{{with .Reason}}: {{.}}{{end}}.</p><p>Вы можете загрузить документы заново.</p>`,
+		}),
+	},
+
+	// Операторам
+	{
+		Key:         TplOperatorUserRegistered,
+		Description: "Новый пользователь подтвердил регистрацию",
+		Sample: map[string]any{
+			"UserID": 101, "FirstName": "Иван", "LastName": "Иванов", "Patronymic": "Иванович",
+			"Phone": "79991234567", "Email": "ivan@example.com", "Login": "ivan101", "ReferrerID": 7,
+			"Referrer": map[string]any{"FirstName": "Пётр", "LastName": "Петров", "Email": "petr@example.com"},
+		},
+		Locales: ru(Template{
+			Subject: "Подтверждение регистрации",
+			Text: "Письмо о подтверждении регистрации:\n" +
+				"{{if not .ReferrerID}}Зарегистрирован новый пользователь без реферальной ссылки." +
+				"{{else if not .Referrer}}Зарегистрирован новый пользователь (реферер указан, но не найден)." +
+				"{{else}}Зарегистрирован новый пользователь по реферальной ссылке от {{.Referrer.FirstName}} {{.Referrer.LastName}} ({{.Referrer.Email}}).{{end}}\n" +
+				"Ссылка на профиль: https://emelia-invest.com/{{.UserID}}\n" +
+				"Имя: {{.FirstName}} {{.LastName}} {{.Patronymic}}\n" +
+				"Телефон: {{.Phone}}\nEmail: {{.Email}}\nЛогин: {{.Login}}",
+		}),
+	},
+	{
+		Key:         TplOperatorLoginCode,
+		Description: "Пользователь вошёл по коду",
+		Sample:      map[string]any{"Phone": "79991234567"},
+		Locales: ru(Template{
+			Subject: "Вход по коду",
+			Text:    "Пользователь вошёл по коду:\nТелефон: {{.Phone}}",
+		}),
+	},
+	{
+		Key:         TplOperatorLoginPassword,
+		Description: "Пользователь вошёл по логину и паролю",
+		Sample:      map[string]any{"Login": "ivan101", "Phone": "79991234567"},
+		Locales: ru(Template{
+			Subject: "Вход по логину",
+			Text:    "Пользователь вошёл по логину и паролю:\nЛогин: {{.Login}}\nТелефон: {{.Phone}}",
+		}),
+	},
+	{
+		Key:         TplOperatorContactChanged,
+		Description: "Пользователь сменил телефон или email",
+		Sample: map[string]any{
+			"Kind": "email", "UserID": 101, "FirstName": "Иван", "LastName": "Иванов", "Patronymic": "Иванович",
+			"OldValue": "old@example.com", "NewValue": "new@example.com",
+		},
+		Locales: ru(Template{
+			Subject: "Смена контактных данных",
+			Text:    "Пользователь сменил {{.Kind}}:\nID: {{.UserID}}\nИмя: {{.FirstName}} {{.LastName}} {{.Patronymic}}\nБыло: {{.OldValue}}\nСтало: {{.NewValue}}",
+		}),
+	},
+	{
+		Key:         TplOperatorProfileChangeRequested,
+		Description: "Заявка на изменение профиля",
+		Sample: map[string]any{
+			"UserID": 101, "FirstName": "Иван", "LastName": "Иванов", "ChangeID": 12,
+			"Before": "фамилия: Иванов", "After": "фамилия: Сидоров",
+		},
+		Locales: ru(Template{
+			Subject: "Заявка на изменение профиля",
+			Text:    "Пользователь {{.FirstName}} {{.LastName}} (ID: {{.UserID}}) запросил изменение профиля, заявка #{{.ChangeID}}.\nБыло: {{.Before}}\nСтало: {{.After}}",
+		}),
+	},
+	{
+		Key:         TplOperatorKYCSubmitted,
+		Description: "Заявка на верификацию личности",
+		Sample: map[string]any{
+			"UserID": 101, "FirstName": "Иван", "LastName": "Иванов", "Patronymic": "Иванович",
+			"ApplicationID": 9, "Level": 2,
+		},
+		Locales: ru(Template{
+			Subject: "Заявка на верификацию личности",
+			Text:    "Пользователь отправил документы на верификацию:\nID: {{.UserID}}\nИмя: {{.FirstName}} {{.LastName}} {{.Patronymic}}\nЗаявка: {{.ApplicationID}}\nУровень: {{.Level}}",
+		}),
+	},
+	{
+		Key:         TplOperatorPayoutMethodAdded,
+		Description: "Пользователь добавил реквизиты для вывода",
+		Sample:      map[string]any{"UserID": 101, "MethodID": 3, "Method": "**** 1234 (visa)"},
+		Locales: ru(Template{
+			Subject: "Новые реквизиты для вывода",
+			Text:    "Пользователь ID: {{.UserID}} добавил реквизиты для вывода: {{.Method}} (ID {{.MethodID}}). Требуется проверка.",
+		}),
+	},
+	{
+		Key:         TplOperatorDepositRequested,
+		Description: "Новая заявка на депозит",
+		Sample:      map[string]any{"DepositID": 42, "UserID": 101, "Amount": 150000.0},
+		Locales: ru(Template{
+			Subject: "Новая заявка на депозит",
+			Text:    "Пользователь ID: {{.UserID}} подал заявку на депозит на сумму {{money .Amount}} руб. (депозит ID {{.DepositID}})",
+		}),
+	},
+	{
+		Key:         TplOperatorDepositApproved,
+		Description: "Депозит одобрен",
+		Sample:      map[string]any{"DepositID": 42, "UserID": 101, "Amount": 150000.0, "DailyReward": 0.015, "BlockDays": 90},
+		Locales: ru(Template{
+			Subject: "Депозит одобрен",
+			Text: "Депозит ID {{.DepositID}} пользователя ID: {{.UserID}} на сумму {{money .Amount}} руб. одобрен" +
+				"{{if and .DailyReward .BlockDays}}: {{percent .DailyReward}}% в день, блокировка {{.BlockDays}} дн.{{end}}",
+		}),
+	},
+	{
+		Key:         TplOperatorDepositClosed,
+		Description: "Депозит закрыт",
+		Sample:      map[string]any{"DepositID": 42, "UserID": 101, "Amount": 150000.0},
+		Locales: ru(Template{
+			Subject: "Депозит закрыт",
+			Text:    "Депозит ID {{.DepositID}} пользователя ID: {{.UserID}} на сумму {{money .Amount}} руб. закрыт",
+		}),
+	},
+	{
+		Key:         TplOperatorWithdrawalRequested,
+		Description: "Новая заявка на вывод средств",
+		Sample: map[string]any{
+			"WithdrawalID": 5, "UserID": 101, "RewardID": 7, "Amount": 10000.0,
+			"PayoutMethodID": 3, "PayoutMethod": "**** 1234",
+		},
+		Locales: ru(Template{
+			Subject: "Новая заявка на вывод средств",
+			Text:    "Пользователь ID: {{.UserID}} подал заявку на вывод {{money .Amount}} руб. с reward ID: {{.RewardID}} на реквизиты {{.PayoutMethod}} (ID {{with .PayoutMethodID}}{{.}}{{else}}—{{end}})",
+		}),
+	},
+	{
+		Key:         TplOperatorWithdrawalApproved,
+		Description: "Вывод средств одобрен",
+		Sample:      map[string]any{"WithdrawalID": 5, "UserID": 101, "Amount": 10000.0, "PayoutMethodID": 3},
+		Locales: ru(Template{
+			Subject: "Вывод средств одобрен",
+			Text:    "Заявка на вывод ID {{.WithdrawalID}} пользователя ID: {{.UserID}} на {{money .Amount}} руб. одобрена{{with .PayoutMethodID}}, реквизиты ID {{.}}{{end}}",
+		}),
+	},
+	{
+		Key:         TplOperatorWithdrawalRejected,
+		Description: "Вывод средств отклонён",
+		Sample:      map[string]any{"WithdrawalID": 5, "UserID": 101, "Amount": 10000.0, "Reason": "реквизиты не прошли проверку"},
+		Locales: ru(Template{
+			Subject: "Вывод средств отклонён",
+			Text:    "Заявка на вывод ID {{.WithdrawalID}} пользователя ID: {{.UserID}} на {{money .Amount}} руб. отклонена{{with .Reason}}. Причина: {{.}}{{end}}",
+		}),
+	},
+	{
+		Key:         TplOperatorLeadCreated,
+		Description: "Новая заявка с сайта",
+		Sample: map[string]any{
+			"LeadID": 12, "Name": "Иван", "Phone": "+79991234567", "Email": "ivan@example.com",
+			"TariffName": "Премиум", "Comment": "Перезвоните после 18:00", "Source": "landing",
+		},
+		Locales: ru(Template{
+			Subject: "Новая заявка с сайта",
+			Text: "Заявка ID {{.LeadID}}\n" +
+				"Имя: {{.Name}}\nТелефон: {{.Phone}}" +
+				"{{with .Email}}\nEmail: {{.}}{{end}}" +
+				"{{with .TariffName}}\nТариф: {{.}}{{end}}" +
+				"{{with .Comment}}\nКомментарий: {{.}}{{end}}" +
+				"{{with .Source}}\nИсточник: {{.}}{{end}}",
+		}),
+	},
+}
@@ -0,0 +1,105 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ChannelKind — вид канала доставки
+type ChannelKind string
+
+const (
+	ChannelSMS      ChannelKind = "sms"
+	ChannelEmail    ChannelKind = "email"
+	ChannelTelegram ChannelKind = "telegram"
+	ChannelWebhook  ChannelKind = "webhook"
+	ChannelInApp    ChannelKind = "inapp"
+)
+
+// AllChannels — все виды каналов в порядке настройки
+var AllChannels = []ChannelKind{ChannelSMS, ChannelEmail, ChannelTelegram, ChannelWebhook, ChannelInApp}
+
+var ErrChannelDisabled = errors.New("канал уведомлений не настроен")
+
+// Message — одно сообщение в один канал. To зависит от канала:
+// телефон, email, chat_id Telegram, URL вебхука или ID пользователя для in-app.
+// HTML — необязательная HTML-версия Body для почты.
+type Message struct {
+	Channel ChannelKind       `json:"channel"`
+	To      string            `json:"to"`
+	Subject string            `json:"subject,omitempty"`
+	Body    string            `json:"body"`
+	HTML    string            `json:"html,omitempty"`
+	Meta    map[string]string `json:"meta,omitempty"`
+}
+
+// Channel — драйвер доставки сообщений одного вида
+type Channel interface {
+	Kind() ChannelKind
+	Send(ctx context.Context, msg Message) error
+}
+
+// Settings — источник настроек драйверов (в проде os.Getenv)
+type Settings func(key string) string
+
+// DriverFactory создаёт драйвер канала по настройкам
+type DriverFactory func(kind ChannelKind, settings Settings) (Channel, error)
+
+var (
+	driversMu sync.RWMutex
+	drivers   = map[string]DriverFactory{}
+)
+
+// RegisterDriver регистрирует драйвер под именем, которое указывается в конфиге
+// (NOTIFY_<КАНАЛ>_DRIVER). Повторная регистрация имени — ошибка программиста.
+func RegisterDriver(name string, factory DriverFactory) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	if _, ok := drivers[name]; ok {
+		panic("notifier: драйвер " + name + " уже зарегистрирован")
+	}
+	drivers[name] = factory
+}
+
+func newChannel(kind ChannelKind, driver string, settings Settings) (Channel, error) {
+	driversMu.RLock()
+	factory, ok := drivers[driver]
+	driversMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("неизвестный драйвер %q для канала %s (доступны: %v)", driver, kind, registeredDrivers())
+	}
+	return factory(kind, settings)
+}
+
+// OpenChannel создаёт драйвер в обход Notifier: так фоновый отправитель очереди
+// получает транспорт, которым доставляет письма
+func OpenChannel(kind ChannelKind, driver string, settings Settings) (Channel, error) {
+	return newChannel(kind, driver, settings)
+}
+
+func registeredDrivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	RegisterDriver(DriverLog, newLogChannel)
+	RegisterDriver(DriverSandbox, newLogChannel)
+	RegisterDriver(DriverRecording, func(kind ChannelKind, _ Settings) (Channel, error) {
+		return NewRecordingChannel(kind), nil
+	})
+	RegisterDriver(DriverRedSms, smsProviderDriver(SmsProviderRedSms))
+	RegisterDriver(DriverSmsRu, smsProviderDriver(SmsProviderSmsRu))
+	RegisterDriver(DriverSMTP, newSMTPChannel)
+	RegisterDriver(DriverTelegram, newTelegramChannel)
+	RegisterDriver(DriverWebhook, newWebhookChannel)
+}
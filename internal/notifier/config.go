@@ -0,0 +1,96 @@
+package notifier
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// Явно выключенный канал
+	DriverOff = "off"
+
+	DriverLog       = "log"
+	DriverSandbox   = "sandbox"
+	DriverRecording = "recording"
+	DriverRedSms    = "redsms"
+	DriverSmsRu     = "smsru"
+	DriverSMTP      = "smtp"
+	DriverTelegram  = "telegram"
+	DriverWebhook   = "http"
+	// Лента в личном кабинете; драйвер регистрирует приложение, у него есть БД
+	DriverInbox = "inbox"
+	// Очередь писем в БД: Send только ставит письмо в очередь, отправляет его
+	// фоновый отправитель через EmailQueueDriver. Драйвер регистрирует приложение.
+	DriverEmailQueue = "queue"
+	// SMS через провайдеров SMS_PROVIDERS по очереди (следующий — при отказе
+	// предыдущего) с журналом отправок в БД. Драйвер регистрирует приложение.
+	DriverSmsFailover = "failover"
+)
+
+// Config — какие драйверы обслуживают каналы и сколько ждать каждый из них.
+// Канал без драйвера выключен: отправка в него возвращает ErrChannelDisabled.
+type Config struct {
+	Drivers        map[ChannelKind]string
+	Timeouts       map[ChannelKind]time.Duration
+	OperatorEmails []string
+	// EmailQueueDriver — чем фоновый отправитель доставляет письма из очереди
+	// (NOTIFY_EMAIL_QUEUE_DRIVER, по умолчанию smtp)
+	EmailQueueDriver string
+	// Locale — язык шаблонов сообщений (NOTIFY_LOCALE)
+	Locale   string
+	Settings Settings
+}
+
+var defaultTimeouts = map[ChannelKind]time.Duration{
+	ChannelSMS:      10 * time.Second,
+	ChannelEmail:    30 * time.Second,
+	ChannelTelegram: 10 * time.Second,
+	ChannelWebhook:  10 * time.Second,
+	ChannelInApp:    5 * time.Second,
+}
+
+// LoadConfigFromEnv читает NOTIFY_<КАНАЛ>_DRIVER и NOTIFY_<КАНАЛ>_TIMEOUT.
+// По умолчанию SMS идут через провайдеров с перебором (или драйвер из старой SMS_DRIVER),
+// почта — через очередь и SMTP, in-app — в ленту личного кабинета, остальные каналы выключены.
+func LoadConfigFromEnv() Config {
+	cfg := Config{
+		Drivers: map[ChannelKind]string{
+			ChannelSMS:   DriverSmsFailover,
+			ChannelEmail: DriverEmailQueue,
+			ChannelInApp: DriverInbox,
+		},
+		Timeouts:         map[ChannelKind]time.Duration{},
+		OperatorEmails:   splitList(os.Getenv("EMAIL_OPERATORS")),
+		EmailQueueDriver: DriverSMTP,
+		Locale:           os.Getenv("NOTIFY_LOCALE"),
+		Settings:         os.Getenv,
+	}
+	if v := os.Getenv("NOTIFY_EMAIL_QUEUE_DRIVER"); v != "" {
+		cfg.EmailQueueDriver = v
+	}
+	if v := os.Getenv("SMS_DRIVER"); v != "" {
+		cfg.Drivers[ChannelSMS] = v
+	}
+
+	for _, kind := range AllChannels {
+		prefix := "NOTIFY_" + strings.ToUpper(string(kind))
+		if v := os.Getenv(prefix + "_DRIVER"); v != "" {
+			cfg.Drivers[kind] = v
+		}
+		if d, err := time.ParseDuration(os.Getenv(prefix + "_TIMEOUT")); err == nil && d > 0 {
+			cfg.Timeouts[kind] = d
+		}
+	}
+	return cfg
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,96 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"time"
+)
+
+// SMTPChannel — письма через SMTP с STARTTLS. Сессии с сервером переиспользуются
+// между письмами (SMTPPool).
+type SMTPChannel struct {
+	cfg  SMTPConfig
+	pool *SMTPPool
+}
+
+func newSMTPChannel(kind ChannelKind, settings Settings) (Channel, error) {
+	if kind != ChannelEmail {
+		return nil, fmt.Errorf("драйвер %s обслуживает только канал email", DriverSMTP)
+	}
+
+	cfg, err := LoadSMTPConfig(settings)
+	if err != nil {
+		return nil, err
+	}
+	return &SMTPChannel{cfg: cfg, pool: NewSMTPPool(cfg)}, nil
+}
+
+func (c *SMTPChannel) Kind() ChannelKind { return ChannelEmail }
+
+func (c *SMTPChannel) Send(ctx context.Context, msg Message) error {
+	if c.cfg.Host == "" || c.cfg.User == "" || c.cfg.Pass == "" || c.cfg.Port == 0 {
+		return fmt.Errorf("SMTP env не заданы (SMTP_HOST/PORT/USER/PASS)")
+	}
+	return c.pool.Send(ctx, msg)
+}
+
+// buildMIME собирает письмо: только текст — text/plain, с HTML — multipart/alternative
+// (текст первым, чтобы клиенты без HTML показывали его). Тема кодируется по RFC 2047.
+func buildMIME(from, to string, msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+	header := func(k, v string) { fmt.Fprintf(&buf, "%s: %s\r\n", k, v) }
+
+	header("From", from)
+	header("To", to)
+	header("Subject", mime.QEncoding.Encode("UTF-8", msg.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+
+	if msg.HTML == "" {
+		header("Content-Type", "text/plain; charset=UTF-8")
+		header("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQuotedPrintable(&buf, msg.Body); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	mw := multipart.NewWriter(&buf)
+	header("Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": mw.Boundary()}))
+	buf.WriteString("\r\n")
+
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=UTF-8", msg.Body},
+		{"text/html; charset=UTF-8", msg.HTML},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
@@ -0,0 +1,93 @@
+package notifier
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"testing"
+)
+
+func TestBuildMIME(t *testing.T) {
+	tests := []struct {
+		name  string
+		msg   Message
+		parts []string // ожидаемые Content-Type частей; nil — письмо без частей
+	}{
+		{
+			name: "только текст",
+			msg:  Message{Subject: "Код подтверждения", Body: "Ваш код: 123456"},
+		},
+		{
+			name:  "текст и html",
+			msg:   Message{Subject: "Депозит одобрен", Body: "Депозит одобрен", HTML: "<p>Депозит одобрен</p>"},
+			parts: []string{"text/plain; charset=UTF-8", "text/html; charset=UTF-8"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := buildMIME("noreply@example.com", "user@example.com", tt.msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, err := mail.ReadMessage(bytes.NewReader(raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := m.Header.Get("From"); got != "noreply@example.com" {
+				t.Errorf("From: %q", got)
+			}
+			if got := m.Header.Get("To"); got != "user@example.com" {
+				t.Errorf("To: %q", got)
+			}
+			subject, err := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+			if err != nil || subject != tt.msg.Subject {
+				t.Errorf("Subject: got %q (%v), want %q", subject, err, tt.msg.Subject)
+			}
+			if _, err := mail.ParseDate(m.Header.Get("Date")); err != nil {
+				t.Errorf("Date: %v", err)
+			}
+
+			mediaType, params, err := mime.ParseMediaType(m.Header.Get("Content-Type"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.parts == nil {
+				if mediaType != "text/plain" {
+					t.Fatalf("Content-Type: %s", mediaType)
+				}
+				body, _ := io.ReadAll(quotedprintable.NewReader(m.Body))
+				if string(body) != tt.msg.Body {
+					t.Errorf("тело: got %q, want %q", body, tt.msg.Body)
+				}
+				return
+			}
+
+			if mediaType != "multipart/alternative" {
+				t.Fatalf("Content-Type: %s", mediaType)
+			}
+			mr := multipart.NewReader(m.Body, params["boundary"])
+			bodies := []string{tt.msg.Body, tt.msg.HTML}
+			for i, want := range tt.parts {
+				p, err := mr.NextPart()
+				if err != nil {
+					t.Fatalf("часть %d: %v", i, err)
+				}
+				if got := p.Header.Get("Content-Type"); got != want {
+					t.Errorf("часть %d: Content-Type %q, want %q", i, got, want)
+				}
+				// multipart.Reader сам раскодирует quoted-printable
+				body, _ := io.ReadAll(p)
+				if string(body) != bodies[i] {
+					t.Errorf("часть %d: got %q, want %q", i, body, bodies[i])
+				}
+			}
+			if _, err := mr.NextPart(); err != io.EOF {
+				t.Errorf("лишние части: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package notifier
+
+import (
+	"context"
+	"log"
+)
+
+// LogChannel ничего не отправляет, а пишет сообщения в лог приложения.
+// Для стендов QA: текст сообщений (включая коды) виден в логе.
+type LogChannel struct {
+	kind ChannelKind
+}
+
+func newLogChannel(kind ChannelKind, _ Settings) (Channel, error) {
+	log.Printf("[NOTIFIER] Канал %s в режиме sandbox: сообщения не отправляются, а пишутся в лог", kind)
+	return &LogChannel{kind: kind}, nil
+}
+
+func (c *LogChannel) Kind() ChannelKind { return c.kind }
+
+func (c *LogChannel) Send(_ context.Context, msg Message) error {
+	if msg.Subject != "" {
+		log.Printf("[NOTIFIER:SANDBOX] %s на %s: %s\n%s", c.kind, msg.To, msg.Subject, msg.Body)
+		return nil
+	}
+	log.Printf("[NOTIFIER:SANDBOX] %s на %s: %s", c.kind, msg.To, msg.Body)
+	return nil
+}
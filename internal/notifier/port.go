@@ -1,7 +1,21 @@
 package notifier
 
+import "context"
+
 type NotifierInterface interface {
+	Send(ctx context.Context, msg Message) error
+	HasChannel(kind ChannelKind) bool
+	Render(ctx context.Context, key string, data any) (*Rendered, error)
+	SendTemplate(ctx context.Context, channel ChannelKind, to, key string, data any) error
+	SendTemplateToOperators(ctx context.Context, key string, data any) error
+
 	SendCodeBySms(phone string, code string) error
 	SendLoginAndPasswordBySms(phone string, login string, password string) error
-	SendEmailToOperator(subject, body string) error
+	SendEmailToUser(to, subject, body string) error
+	SendCodeByEmail(to string, code string) error
+	SendPasswordChangedBySms(phone string) error
+	SendContactChangedBySms(phone string) error
+	SendEmailVerification(to, code, link string) error
 }
+
+var _ NotifierInterface = (*Notifier)(nil)
@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"context"
+	"sync"
+)
+
+// RecordingChannel запоминает отправленные сообщения в памяти.
+// Нужен тестам: можно проверить, что и кому ушло, и сымитировать сбой канала.
+type RecordingChannel struct {
+	kind ChannelKind
+
+	mu       sync.Mutex
+	messages []Message
+	err      error
+}
+
+func NewRecordingChannel(kind ChannelKind) *RecordingChannel {
+	return &RecordingChannel{kind: kind}
+}
+
+func (c *RecordingChannel) Kind() ChannelKind { return c.kind }
+
+func (c *RecordingChannel) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	c.messages = append(c.messages, msg)
+	return nil
+}
+
+// FailWith заставляет канал возвращать ошибку; nil возвращает его в строй
+func (c *RecordingChannel) FailWith(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+// Messages — копия отправленных сообщений в порядке отправки
+func (c *RecordingChannel) Messages() []Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Message(nil), c.messages...)
+}
+
+// Last — последнее сообщение или false, если ничего не отправлялось
+func (c *RecordingChannel) Last() (Message, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.messages) == 0 {
+		return Message{}, false
+	}
+	return c.messages[len(c.messages)-1], true
+}
+
+func (c *RecordingChannel) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.messages = nil
+	c.err = nil
+}
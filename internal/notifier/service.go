@@ -1,210 +1,179 @@
 package notifier
 
 import (
-	"bytes"
-	"crypto/md5"
-	"crypto/tls"
-	"encoding/hex"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"log"
-	"net"
-	"net/http"
-	"net/smtp"
-	"os"
-	"strings"
 	"time"
 )
 
+// Notifier раскладывает сообщения по каналам. Сами каналы — драйверы,
+// выбранные конфигом, поэтому в тестах их можно подменить на RecordingChannel.
+// Тексты сообщений берутся из реестра шаблонов.
 type Notifier struct {
-	smsLogin     string
-	smsApiKey    string
-	smsSender    string
-	smsApiURL    string
-	smtpHost     string
-	smtpPort     int
-	smtpUser     string
-	smtpPass     string
-	smtpFrom     string
+	channels     map[ChannelKind]Channel
+	timeouts     map[ChannelKind]time.Duration
 	emailTargets []string
+	templates    *Templates
+	locale       string
 }
 
-func NewNotifier() *Notifier {
-	// По умолчанию используем 587 (STARTTLS)
-	smtpPort := 587
-	if val := os.Getenv("SMTP_PORT"); val != "" {
-		fmt.Sscanf(val, "%d", &smtpPort)
+func NewNotifier(cfg Config) (*Notifier, error) {
+	locale := cfg.Locale
+	if locale == "" {
+		locale = DefaultLocale
 	}
-
-	emailTargets := strings.Split(os.Getenv("EMAIL_OPERATORS"), ",")
-	for i, v := range emailTargets {
-		emailTargets[i] = strings.TrimSpace(v)
+	n := &Notifier{
+		channels:     map[ChannelKind]Channel{},
+		timeouts:     map[ChannelKind]time.Duration{},
+		emailTargets: cfg.OperatorEmails,
+		templates:    NewTemplates(locale),
+		locale:       locale,
+	}
+	for kind, d := range defaultTimeouts {
+		n.timeouts[kind] = d
+	}
+	for kind, d := range cfg.Timeouts {
+		n.timeouts[kind] = d
 	}
 
-	return &Notifier{
-		smsLogin:     os.Getenv("SMS_LOGIN"),
-		smsApiKey:    os.Getenv("SMS_API_KEY"),
-		smsSender:    os.Getenv("SMS_SENDER_NAME"),
-		smsApiURL:    os.Getenv("REDSMS_API_URL"),
-		smtpHost:     os.Getenv("SMTP_HOST"),
-		smtpPort:     smtpPort,
-		smtpUser:     os.Getenv("SMTP_USER"),
-		smtpPass:     os.Getenv("SMTP_PASS"),
-		smtpFrom:     os.Getenv("SMTP_FROM"),
-		emailTargets: emailTargets,
+	for kind, driver := range cfg.Drivers {
+		if driver == "" || driver == DriverOff {
+			continue
+		}
+		ch, err := newChannel(kind, driver, cfg.Settings)
+		if err != nil {
+			return nil, err
+		}
+		n.channels[kind] = ch
 	}
+	return n, nil
 }
 
-func (n *Notifier) SendCodeBySms(phone string, code string) error {
-	text := fmt.Sprintf("Код подтверждения: %s. Emelia Invest", code)
-	return n.sendSms(phone, text)
+// NewNotifierWithChannels — сборка из готовых каналов, без конфига (для тестов)
+func NewNotifierWithChannels(operatorEmails []string, channels ...Channel) *Notifier {
+	n := &Notifier{
+		channels:     map[ChannelKind]Channel{},
+		timeouts:     map[ChannelKind]time.Duration{},
+		emailTargets: operatorEmails,
+		templates:    NewTemplates(DefaultLocale),
+		locale:       DefaultLocale,
+	}
+	for kind, d := range defaultTimeouts {
+		n.timeouts[kind] = d
+	}
+	for _, ch := range channels {
+		n.channels[ch.Kind()] = ch
+	}
+	return n
 }
 
-func (n *Notifier) SendLoginAndPasswordBySms(phone string, login string, password string) error {
-	text := fmt.Sprintf("Логин: %s\nПароль: %s\nEmelia Invest", login, password)
-	return n.sendSms(phone, text)
+// Channel — драйвер канала; тесты достают так RecordingChannel, собранный по конфигу
+func (n *Notifier) Channel(kind ChannelKind) (Channel, bool) {
+	ch, ok := n.channels[kind]
+	return ch, ok
 }
 
-func (n *Notifier) sendSms(phone string, text string) error {
-	ts := fmt.Sprintf("%d", time.Now().Unix())
-	hash := md5.Sum([]byte(ts + n.smsApiKey))
-	secret := hex.EncodeToString(hash[:])
-
-	payload := map[string]interface{}{
-		"route": "sms",
-		"from":  n.smsSender,
-		"to":    phone,
-		"text":  text,
-	}
-	body, _ := json.Marshal(payload)
+// HasChannel — настроен ли канал
+func (n *Notifier) HasChannel(kind ChannelKind) bool {
+	_, ok := n.channels[kind]
+	return ok
+}
 
-	req, err := http.NewRequest("POST", n.smsApiURL, bytes.NewBuffer(body))
-	if err != nil {
-		return fmt.Errorf("ошибка создания запроса: %w", err)
+// Send отправляет сообщение в его канал с таймаутом этого канала
+func (n *Notifier) Send(ctx context.Context, msg Message) error {
+	ch, ok := n.channels[msg.Channel]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrChannelDisabled, msg.Channel)
 	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("login", n.smsLogin)
-	req.Header.Set("ts", ts)
-	req.Header.Set("secret", secret)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("ошибка отправки запроса: %w", err)
+	if timeout := n.timeouts[msg.Channel]; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("ошибка ответа RedSMS: %s", resp.Status)
+	if err := ch.Send(ctx, msg); err != nil {
+		return fmt.Errorf("[NOTIFIER] %s: %w", msg.Channel, err)
 	}
-
-	log.Println("[NOTIFIER] SMS отправлено:", phone)
 	return nil
 }
 
-func (n *Notifier) SendEmailToOperator(subject, body string) error {
-	if n.smtpHost == "" || n.smtpUser == "" || n.smtpPass == "" || n.smtpPort == 0 {
-		return fmt.Errorf("[NOTIFIER] SMTP env не заданы (SMTP_HOST/PORT/USER/PASS)")
-	}
+// Templates — реестр шаблонов; сюда подключаются правки из админки
+func (n *Notifier) Templates() *Templates {
+	return n.templates
+}
 
-	log.Println("[NOTIFIER] Отправка email через SMTP (STARTTLS)")
+// Render заполняет шаблон на языке уведомлений
+func (n *Notifier) Render(ctx context.Context, key string, data any) (*Rendered, error) {
+	return n.templates.Render(ctx, key, n.locale, data)
+}
 
-	tryFrom := "no-reply@emelia-invest.com"
-	if err := n.sendEmailsWithFrom(tryFrom, subject, body); err != nil {
-		log.Printf("[NOTIFIER] Не удалось отправить от имени %s: %v. Пробуем из ENV...", tryFrom, err)
-		if n.smtpFrom == "" {
-			return fmt.Errorf("[NOTIFIER] SMTP_FROM не задан для fallback")
-		}
-		return n.sendEmailsWithFrom(n.smtpFrom, subject, body)
+// SendTemplate отправляет сообщение по шаблону в один канал
+func (n *Notifier) SendTemplate(ctx context.Context, channel ChannelKind, to, key string, data any) error {
+	r, err := n.Render(ctx, key, data)
+	if err != nil {
+		return fmt.Errorf("[NOTIFIER] шаблон %s: %w", key, err)
 	}
-	return nil
+	msg := r.Message(channel, to)
+	msg.Meta = map[string]string{"template": key}
+	return n.Send(ctx, msg)
 }
 
-// sendEmailsWithFrom — выделенная функция, чтобы не дублировать код отправки
-func (n *Notifier) sendEmailsWithFrom(from string, subject, body string) error {
-	baseHeaders := map[string]string{
-		"From":         from,
-		"Subject":      subject,
-		"MIME-Version": "1.0",
-		"Content-Type": "text/plain; charset=UTF-8",
+// SendTemplateToOperators отправляет письмо по шаблону всем операторам
+func (n *Notifier) SendTemplateToOperators(ctx context.Context, key string, data any) error {
+	if len(n.emailTargets) == 0 {
+		return fmt.Errorf("[NOTIFIER] EMAIL_OPERATORS не задан")
 	}
-
-	addr := fmt.Sprintf("%s:%d", n.smtpHost, n.smtpPort)
-	tlsCfg := &tls.Config{ServerName: n.smtpHost}
-
+	r, err := n.Render(ctx, key, data)
+	if err != nil {
+		return fmt.Errorf("[NOTIFIER] шаблон %s: %w", key, err)
+	}
+	var errs []error
 	for _, to := range n.emailTargets {
-		to = strings.TrimSpace(to)
-		if to == "" {
-			continue
+		if err := n.Send(ctx, r.Message(ChannelEmail, to)); err != nil {
+			errs = append(errs, err)
 		}
+	}
+	return errors.Join(errs...)
+}
 
-		var sb strings.Builder
-		for k, v := range baseHeaders {
-			sb.WriteString(fmt.Sprintf("%s: %s\r\n", k, v))
-		}
-		sb.WriteString(fmt.Sprintf("To: %s\r\n", to))
-		sb.WriteString("\r\n")
-		sb.WriteString(body)
-		msg := []byte(sb.String())
+func (n *Notifier) SendCodeBySms(phone string, code string) error {
+	return n.SendTemplate(context.Background(), ChannelSMS, phone, TplAuthCode, map[string]any{"Code": code})
+}
 
-		conn, err := net.Dial("tcp", addr)
-		if err != nil {
-			return fmt.Errorf("[NOTIFIER] SMTP dial: %w", err)
-		}
+func (n *Notifier) SendLoginAndPasswordBySms(phone string, login string, password string) error {
+	return n.SendTemplate(context.Background(), ChannelSMS, phone, TplAuthCredentials, map[string]any{"Login": login, "Password": password})
+}
 
-		c, err := smtp.NewClient(conn, n.smtpHost)
-		if err != nil {
-			_ = conn.Close()
-			return fmt.Errorf("[NOTIFIER] SMTP new client: %w", err)
-		}
+// SendEmailToUser — письмо пользователю произвольным текстом, без шаблона
+func (n *Notifier) SendEmailToUser(to, subject, body string) error {
+	return n.sendEmail([]string{to}, subject, body)
+}
 
-		if ok, _ := c.Extension("STARTTLS"); ok {
-			if err := c.StartTLS(tlsCfg); err != nil {
-				_ = c.Close()
-				return fmt.Errorf("[NOTIFIER] STARTTLS: %w", err)
-			}
-		}
+func (n *Notifier) SendCodeByEmail(to string, code string) error {
+	return n.SendTemplate(context.Background(), ChannelEmail, to, TplAuthCode, map[string]any{"Code": code})
+}
 
-		auth := smtp.PlainAuth("", n.smtpUser, n.smtpPass, n.smtpHost)
-		if ok, _ := c.Extension("AUTH"); ok {
-			if err := c.Auth(auth); err != nil {
-				_ = c.Close()
-				return fmt.Errorf("[NOTIFIER] SMTP auth: %w", err)
-			}
-		}
+func (n *Notifier) SendEmailVerification(to, code, link string) error {
+	return n.SendTemplate(context.Background(), ChannelEmail, to, TplAuthEmailVerification, map[string]any{"Code": code, "Link": link})
+}
 
-		// envelope-from можно оставить как smtpUser — так безопаснее для SPF
-		if err := c.Mail(n.smtpUser); err != nil {
-			_ = c.Close()
-			return fmt.Errorf("[NOTIFIER] MAIL FROM: %w", err)
-		}
-		if err := c.Rcpt(to); err != nil {
-			_ = c.Close()
-			return fmt.Errorf("[NOTIFIER] RCPT TO (%s): %w", to, err)
-		}
+func (n *Notifier) SendPasswordChangedBySms(phone string) error {
+	return n.SendTemplate(context.Background(), ChannelSMS, phone, TplAuthPasswordChanged, nil)
+}
 
-		w, err := c.Data()
-		if err != nil {
-			_ = c.Close()
-			return fmt.Errorf("[NOTIFIER] DATA open: %w", err)
-		}
-		if _, err := w.Write(msg); err != nil {
-			_ = w.Close()
-			_ = c.Close()
-			return fmt.Errorf("[NOTIFIER] DATA write: %w", err)
-		}
-		if err := w.Close(); err != nil {
-			_ = c.Close()
-			return fmt.Errorf("[NOTIFIER] DATA close: %w", err)
-		}
+func (n *Notifier) SendContactChangedBySms(phone string) error {
+	return n.SendTemplate(context.Background(), ChannelSMS, phone, TplAuthPhoneChanged, nil)
+}
 
-		if err := c.Quit(); err != nil {
-			_ = c.Close()
-			return fmt.Errorf("[NOTIFIER] SMTP quit: %w", err)
+// sendEmail отправляет письмо каждому адресату отдельно; ошибка по одному адресу
+// не мешает остальным
+func (n *Notifier) sendEmail(targets []string, subject, body string) error {
+	var errs []error
+	for _, to := range targets {
+		err := n.Send(context.Background(), Message{Channel: ChannelEmail, To: to, Subject: subject, Body: body})
+		if err != nil {
+			errs = append(errs, err)
 		}
-
-		log.Printf("[NOTIFIER] Email отправлен от %s: %s\n", from, to)
 	}
-	return nil
+	return errors.Join(errs...)
 }
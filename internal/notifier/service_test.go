@@ -0,0 +1,117 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func noSettings(string) string { return "" }
+
+// recordingNotifier собирает Notifier по конфигу с драйвером recording
+// на SMS и почте и возвращает записывающие каналы
+func recordingNotifier(t *testing.T, operatorEmails ...string) (*Notifier, *RecordingChannel, *RecordingChannel) {
+	t.Helper()
+	n, err := NewNotifier(Config{
+		Drivers: map[ChannelKind]string{
+			ChannelSMS:      DriverRecording,
+			ChannelEmail:    DriverRecording,
+			ChannelTelegram: DriverOff,
+		},
+		OperatorEmails: operatorEmails,
+		Settings:       noSettings,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n, recordingChannel(t, n, ChannelSMS), recordingChannel(t, n, ChannelEmail)
+}
+
+func recordingChannel(t *testing.T, n *Notifier, kind ChannelKind) *RecordingChannel {
+	t.Helper()
+	ch, ok := n.Channel(kind)
+	if !ok {
+		t.Fatalf("канал %s не настроен", kind)
+	}
+	rec, ok := ch.(*RecordingChannel)
+	if !ok {
+		t.Fatalf("канал %s: драйвер %T, ожидался RecordingChannel", kind, ch)
+	}
+	return rec
+}
+
+func TestNotifierSendsThroughRecordingDriver(t *testing.T) {
+	n, sms, email := recordingNotifier(t, "ops1@example.com", "ops2@example.com")
+
+	if err := n.SendCodeBySms("79991234567", "4321"); err != nil {
+		t.Fatal(err)
+	}
+	msg, ok := sms.Last()
+	if !ok {
+		t.Fatal("SMS не отправлено")
+	}
+	if msg.Channel != ChannelSMS || msg.To != "79991234567" || !strings.Contains(msg.Body, "4321") {
+		t.Errorf("SMS = %+v", msg)
+	}
+
+	lead := map[string]any{"LeadID": 12, "Name": "Иван", "Phone": "+79991234567"}
+	if err := n.SendTemplateToOperators(context.Background(), TplOperatorLeadCreated, lead); err != nil {
+		t.Fatal(err)
+	}
+	msgs := email.Messages()
+	if len(msgs) != 2 {
+		t.Fatalf("писем: %d, ожидалось 2", len(msgs))
+	}
+	for i, to := range []string{"ops1@example.com", "ops2@example.com"} {
+		if msgs[i].To != to || msgs[i].Subject != "Новая заявка с сайта" || !strings.Contains(msgs[i].Body, "Заявка ID 12") {
+			t.Errorf("письмо %d = %+v", i, msgs[i])
+		}
+	}
+	if len(sms.Messages()) != 1 {
+		t.Errorf("письма попали в SMS-канал: %+v", sms.Messages())
+	}
+}
+
+func TestNotifierChannelErrors(t *testing.T) {
+	n, sms, _ := recordingNotifier(t)
+
+	t.Run("выключенный канал", func(t *testing.T) {
+		if n.HasChannel(ChannelTelegram) {
+			t.Fatal("telegram с драйвером off не должен настраиваться")
+		}
+		err := n.Send(context.Background(), Message{Channel: ChannelTelegram, To: "1", Body: "x"})
+		if !errors.Is(err, ErrChannelDisabled) {
+			t.Errorf("err = %v, ожидалась ErrChannelDisabled", err)
+		}
+	})
+
+	t.Run("сбой драйвера", func(t *testing.T) {
+		cause := errors.New("шлюз недоступен")
+		sms.FailWith(cause)
+		defer sms.Reset()
+
+		if err := n.SendCodeBySms("79991234567", "1111"); !errors.Is(err, cause) {
+			t.Errorf("err = %v, ожидалась %v", err, cause)
+		}
+		if len(sms.Messages()) != 0 {
+			t.Errorf("при сбое сообщение записано: %+v", sms.Messages())
+		}
+	})
+
+	t.Run("нет адресов операторов", func(t *testing.T) {
+		if err := n.SendTemplateToOperators(context.Background(), TplOperatorLeadCreated, map[string]any{}); err == nil {
+			t.Error("ожидалась ошибка без EMAIL_OPERATORS")
+		}
+	})
+}
+
+func TestNewNotifierUnknownDriver(t *testing.T) {
+	_, err := NewNotifier(Config{
+		Drivers:  map[ChannelKind]string{ChannelSMS: "pigeon"},
+		Settings: noSettings,
+	})
+	if err == nil || !strings.Contains(err.Error(), "pigeon") {
+		t.Errorf("err = %v, ожидалась ошибка о неизвестном драйвере", err)
+	}
+}
@@ -0,0 +1,131 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+)
+
+const (
+	SmsProviderRedSms = "redsms"
+	SmsProviderSmsRu  = "smsru"
+)
+
+// ErrSmsRejected — провайдер отверг сам номер: другой провайдер его тоже не примет
+var ErrSmsRejected = errors.New("провайдер отклонил номер получателя")
+
+// SmsProvider — SMS-шлюз. Send возвращает ID сообщения у провайдера, по которому
+// Status потом узнаёт, доставлено ли оно и сколько стоило.
+type SmsProvider interface {
+	Name() string
+	Send(ctx context.Context, to, text string) (*SmsReceipt, error)
+	Status(ctx context.Context, messageID string) (*SmsReport, error)
+}
+
+// SmsReceipt — ответ провайдера на отправку. Cost — если провайдер сообщает
+// цену сразу, иначе она придёт со статусом.
+type SmsReceipt struct {
+	MessageID string
+	Cost      *float64
+}
+
+type SmsDelivery string
+
+const (
+	SmsInProgress  SmsDelivery = "in_progress"
+	SmsDelivered   SmsDelivery = "delivered"
+	SmsUndelivered SmsDelivery = "undelivered"
+)
+
+// SmsReport — статус доставки у провайдера
+type SmsReport struct {
+	Delivery SmsDelivery
+	Cost     *float64
+	// Статус провайдера как есть — для разбора недоставленных
+	Detail string
+}
+
+type SmsProviderFactory func(settings Settings) (SmsProvider, error)
+
+var smsProviders = map[string]SmsProviderFactory{
+	SmsProviderRedSms: newRedSmsProvider,
+	SmsProviderSmsRu:  newSmsRuProvider,
+}
+
+// LoadSmsProviders — провайдеры из SMS_PROVIDERS (через запятую, по умолчанию redsms)
+// в порядке перебора при отказе
+func LoadSmsProviders(settings Settings) ([]SmsProvider, error) {
+	names := splitList(settings("SMS_PROVIDERS"))
+	if len(names) == 0 {
+		names = []string{SmsProviderRedSms}
+	}
+
+	providers := make([]SmsProvider, 0, len(names))
+	for _, name := range names {
+		factory, ok := smsProviders[name]
+		if !ok {
+			return nil, fmt.Errorf("неизвестный SMS-провайдер %q", name)
+		}
+		p, err := factory(settings)
+		if err != nil {
+			return nil, fmt.Errorf("SMS-провайдер %s: %w", name, err)
+		}
+		providers = append(providers, p)
+	}
+	return providers, nil
+}
+
+// SmsProviderChannel — канал SMS через одного провайдера, без журнала и перебора
+type SmsProviderChannel struct {
+	provider SmsProvider
+}
+
+func smsProviderDriver(name string) DriverFactory {
+	return func(kind ChannelKind, settings Settings) (Channel, error) {
+		if kind != ChannelSMS {
+			return nil, fmt.Errorf("драйвер %s обслуживает только канал sms", name)
+		}
+		p, err := smsProviders[name](settings)
+		if err != nil {
+			return nil, err
+		}
+		return &SmsProviderChannel{provider: p}, nil
+	}
+}
+
+func (c *SmsProviderChannel) Kind() ChannelKind { return ChannelSMS }
+
+func (c *SmsProviderChannel) Send(ctx context.Context, msg Message) error {
+	if _, err := c.provider.Send(ctx, msg.To, msg.Body); err != nil {
+		return err
+	}
+	log.Println("[NOTIFIER] SMS отправлено:", msg.To)
+	return nil
+}
+
+// phoneDigits — номер без плюса и разделителей, как его ждут шлюзы
+func phoneDigits(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// parseCost — цена приходит и числом, и строкой
+func parseCost(raw []byte) *float64 {
+	s := strings.Trim(strings.TrimSpace(string(raw)), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
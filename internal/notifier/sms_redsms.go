@@ -0,0 +1,135 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const defaultRedSmsURL = "https://cp.redsms.ru/api/message"
+
+// redSmsProvider — RedSMS: SMS_LOGIN, SMS_API_KEY, SMS_SENDER_NAME, REDSMS_API_URL
+type redSmsProvider struct {
+	login  string
+	apiKey string
+	sender string
+	apiURL string
+	client *http.Client
+}
+
+func newRedSmsProvider(settings Settings) (SmsProvider, error) {
+	apiURL := strings.TrimRight(settings("REDSMS_API_URL"), "/")
+	if apiURL == "" {
+		apiURL = defaultRedSmsURL
+	}
+	return &redSmsProvider{
+		login:  settings("SMS_LOGIN"),
+		apiKey: settings("SMS_API_KEY"),
+		sender: settings("SMS_SENDER_NAME"),
+		apiURL: apiURL,
+		client: &http.Client{},
+	}, nil
+}
+
+func (p *redSmsProvider) Name() string { return SmsProviderRedSms }
+
+type redSmsItem struct {
+	UUID   string          `json:"uuid"`
+	Status string          `json:"status"`
+	Price  json.RawMessage `json:"price"`
+}
+
+type redSmsResponse struct {
+	Success      bool         `json:"success"`
+	ErrorMessage string       `json:"error_message"`
+	Items        []redSmsItem `json:"items"`
+	Item         *redSmsItem  `json:"item"`
+}
+
+func (p *redSmsProvider) Send(ctx context.Context, to, text string) (*SmsReceipt, error) {
+	body, _ := json.Marshal(map[string]interface{}{
+		"route": "sms",
+		"from":  p.sender,
+		"to":    phoneDigits(to),
+		"text":  text,
+	})
+
+	resp, err := p.do(ctx, http.MethodPost, p.apiURL, body)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Items) == 0 || resp.Items[0].UUID == "" {
+		return nil, fmt.Errorf("RedSMS не вернул ID сообщения")
+	}
+	item := resp.Items[0]
+	if item.Status == "reject" {
+		return nil, fmt.Errorf("RedSMS: %w", ErrSmsRejected)
+	}
+	return &SmsReceipt{MessageID: item.UUID, Cost: parseCost(item.Price)}, nil
+}
+
+func (p *redSmsProvider) Status(ctx context.Context, messageID string) (*SmsReport, error) {
+	resp, err := p.do(ctx, http.MethodGet, p.apiURL+"/"+url.PathEscape(messageID), nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Item == nil {
+		return nil, fmt.Errorf("RedSMS не вернул сообщение %s", messageID)
+	}
+
+	report := &SmsReport{Delivery: SmsInProgress, Cost: parseCost(resp.Item.Price), Detail: resp.Item.Status}
+	switch resp.Item.Status {
+	case "delivered", "read", "reply":
+		report.Delivery = SmsDelivered
+	case "undelivered", "reject", "error", "expired":
+		report.Delivery = SmsUndelivered
+	}
+	return report, nil
+}
+
+// do — запрос к API с подписью md5(ts + api_key). HTTP 200 ещё не успех:
+// RedSMS сообщает об ошибке полем success.
+func (p *redSmsProvider) do(ctx context.Context, method, endpoint string, body []byte) (*redSmsResponse, error) {
+	ts := fmt.Sprintf("%d", time.Now().Unix())
+	hash := md5.Sum([]byte(ts + p.apiKey))
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания запроса: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("login", p.login)
+	req.Header.Set("ts", ts)
+	req.Header.Set("secret", hex.EncodeToString(hash[:]))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка отправки запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var out redSmsResponse
+	decodeErr := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&out)
+	if resp.StatusCode != http.StatusOK || !out.Success {
+		if out.ErrorMessage != "" {
+			return nil, fmt.Errorf("ошибка ответа RedSMS: %s: %s", resp.Status, out.ErrorMessage)
+		}
+		return nil, fmt.Errorf("ошибка ответа RedSMS: %s", resp.Status)
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("ответ RedSMS: %w", decodeErr)
+	}
+	return &out, nil
+}
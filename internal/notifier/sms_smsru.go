@@ -0,0 +1,134 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const defaultSmsRuURL = "https://sms.ru"
+
+// smsRuProvider — SMS.ru: SMSRU_API_ID, SMSRU_SENDER (необязательно), SMSRU_API_URL
+type smsRuProvider struct {
+	apiID  string
+	sender string
+	apiURL string
+	client *http.Client
+}
+
+func newSmsRuProvider(settings Settings) (SmsProvider, error) {
+	apiID := settings("SMSRU_API_ID")
+	if apiID == "" {
+		return nil, fmt.Errorf("не задан SMSRU_API_ID")
+	}
+	apiURL := strings.TrimRight(settings("SMSRU_API_URL"), "/")
+	if apiURL == "" {
+		apiURL = defaultSmsRuURL
+	}
+	return &smsRuProvider{
+		apiID:  apiID,
+		sender: settings("SMSRU_SENDER"),
+		apiURL: apiURL,
+		client: &http.Client{},
+	}, nil
+}
+
+func (p *smsRuProvider) Name() string { return SmsProviderSmsRu }
+
+// smsRuResult — ответ SMS.ru по одному сообщению; status_code 100 — принято
+type smsRuResult struct {
+	Status     string          `json:"status"`
+	StatusCode int             `json:"status_code"`
+	StatusText string          `json:"status_text"`
+	SmsID      string          `json:"sms_id"`
+	Cost       json.RawMessage `json:"cost"`
+}
+
+type smsRuResponse struct {
+	smsRuResult
+	Sms map[string]smsRuResult `json:"sms"`
+}
+
+// Коды SMS.ru, при которых другой провайдер номер тоже не примет
+var smsRuRejectedCodes = map[int]bool{
+	202: true, // неправильно указан получатель
+	207: true, // на этот номер нельзя отправлять
+}
+
+func (p *smsRuProvider) Send(ctx context.Context, to, text string) (*SmsReceipt, error) {
+	phone := phoneDigits(to)
+	form := url.Values{"to": {phone}, "msg": {text}}
+	if p.sender != "" {
+		form.Set("from", p.sender)
+	}
+
+	resp, err := p.do(ctx, "/sms/send", form)
+	if err != nil {
+		return nil, err
+	}
+	res, ok := resp.Sms[phone]
+	if !ok {
+		return nil, fmt.Errorf("SMS.ru не вернул результат по номеру")
+	}
+	if res.StatusCode != 100 {
+		if smsRuRejectedCodes[res.StatusCode] {
+			return nil, fmt.Errorf("SMS.ru %d %s: %w", res.StatusCode, res.StatusText, ErrSmsRejected)
+		}
+		return nil, fmt.Errorf("ошибка ответа SMS.ru: %d %s", res.StatusCode, res.StatusText)
+	}
+	return &SmsReceipt{MessageID: res.SmsID, Cost: parseCost(res.Cost)}, nil
+}
+
+func (p *smsRuProvider) Status(ctx context.Context, messageID string) (*SmsReport, error) {
+	resp, err := p.do(ctx, "/sms/status", url.Values{"sms_id": {messageID}})
+	if err != nil {
+		return nil, err
+	}
+	res, ok := resp.Sms[messageID]
+	if !ok {
+		return nil, fmt.Errorf("SMS.ru не вернул сообщение %s", messageID)
+	}
+
+	report := &SmsReport{Delivery: SmsInProgress, Cost: parseCost(res.Cost), Detail: fmt.Sprintf("%d %s", res.StatusCode, res.StatusText)}
+	switch {
+	case res.StatusCode == 103 || res.StatusCode == 110:
+		report.Delivery = SmsDelivered
+	case res.StatusCode >= 104 && res.StatusCode <= 108, res.StatusCode == 150, res.StatusCode == -1:
+		report.Delivery = SmsUndelivered
+	}
+	return report, nil
+}
+
+// do — запрос к API. Ошибка запроса целиком приходит с HTTP 200 и status = ERROR.
+func (p *smsRuProvider) do(ctx context.Context, path string, form url.Values) (*smsRuResponse, error) {
+	form.Set("api_id", p.apiID)
+	form.Set("json", "1")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.apiURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка создания запроса: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка отправки запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ошибка ответа SMS.ru: %s", resp.Status)
+	}
+	var out smsRuResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&out); err != nil {
+		return nil, fmt.Errorf("ответ SMS.ru: %w", err)
+	}
+	if out.Status != "OK" {
+		return nil, fmt.Errorf("ошибка ответа SMS.ru: %d %s", out.StatusCode, out.StatusText)
+	}
+	return &out, nil
+}
@@ -0,0 +1,289 @@
+package notifier
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SMTPConfig — подключение к SMTP и политика отправителя
+type SMTPConfig struct {
+	Host string
+	Port int
+	User string
+	Pass string
+	// From — адреса для заголовка From по порядку: следующий пробуется, только если
+	// сервер отклонил письмо от предыдущего
+	From []string
+	// Сколько открытых сессий держать между письмами
+	MaxIdle int
+	// Сессия, простоявшая дольше, закрывается: серверы рвут неактивные подключения
+	IdleTimeout time.Duration
+}
+
+// LoadSMTPConfig — SMTP_HOST, SMTP_PORT (587), SMTP_USER, SMTP_PASS,
+// SMTP_FROM (по умолчанию SMTP_USER), SMTP_FROM_FALLBACK (через запятую),
+// SMTP_POOL_SIZE (2) и SMTP_IDLE_TIMEOUT (1m)
+func LoadSMTPConfig(settings Settings) (SMTPConfig, error) {
+	cfg := SMTPConfig{
+		Host:        settings("SMTP_HOST"),
+		Port:        587,
+		User:        settings("SMTP_USER"),
+		Pass:        settings("SMTP_PASS"),
+		MaxIdle:     2,
+		IdleTimeout: time.Minute,
+	}
+	if val := settings("SMTP_PORT"); val != "" {
+		p, err := strconv.Atoi(val)
+		if err != nil {
+			return cfg, fmt.Errorf("некорректный SMTP_PORT: %w", err)
+		}
+		cfg.Port = p
+	}
+	if val := settings("SMTP_POOL_SIZE"); val != "" {
+		n, err := strconv.Atoi(val)
+		if err != nil || n < 0 {
+			return cfg, fmt.Errorf("некорректный SMTP_POOL_SIZE: %q", val)
+		}
+		cfg.MaxIdle = n
+	}
+	if val := settings("SMTP_IDLE_TIMEOUT"); val != "" {
+		d, err := time.ParseDuration(val)
+		if err != nil || d <= 0 {
+			return cfg, fmt.Errorf("некорректный SMTP_IDLE_TIMEOUT: %q", val)
+		}
+		cfg.IdleTimeout = d
+	}
+
+	from := strings.TrimSpace(settings("SMTP_FROM"))
+	if from == "" {
+		from = cfg.User
+	}
+	if from != "" {
+		cfg.From = append(cfg.From, from)
+	}
+	cfg.From = append(cfg.From, splitList(settings("SMTP_FROM_FALLBACK"))...)
+	return cfg, nil
+}
+
+// ErrRecipientRejected — сервер окончательно (5xx) отказался принять адресата:
+// повтор того же письма не поможет
+var ErrRecipientRejected = errors.New("адресат отклонён сервером")
+
+// errSenderRejected — сервер не принял письмо от этого отправителя
+var errSenderRejected = errors.New("отправитель отклонён")
+
+func isPermanentReply(err error) bool {
+	var tpErr *textproto.Error
+	return errors.As(err, &tpErr) && tpErr.Code >= 500
+}
+
+// SMTPPool держит открытые SMTP-сессии (уже после STARTTLS и AUTH) и отдаёт
+// их следующим письмам, а не подключается заново на каждое письмо
+type SMTPPool struct {
+	cfg SMTPConfig
+
+	mu   sync.Mutex
+	idle []*smtpConn
+}
+
+type smtpConn struct {
+	conn     net.Conn
+	client   *smtp.Client
+	lastUsed time.Time
+}
+
+func NewSMTPPool(cfg SMTPConfig) *SMTPPool {
+	return &SMTPPool{cfg: cfg}
+}
+
+// Send отправляет письмо одному адресату, перебирая отправителей по политике
+func (p *SMTPPool) Send(ctx context.Context, msg Message) error {
+	to := strings.TrimSpace(msg.To)
+	if to == "" {
+		return fmt.Errorf("не указан адресат")
+	}
+	if len(p.cfg.From) == 0 {
+		return fmt.Errorf("не задан отправитель (SMTP_FROM)")
+	}
+
+	var err error
+	for i, from := range p.cfg.From {
+		if err = p.sendAs(ctx, from, to, msg); err == nil {
+			log.Printf("[NOTIFIER] Email отправлен от %s: %s", from, to)
+			return nil
+		}
+		if !errors.Is(err, errSenderRejected) || i == len(p.cfg.From)-1 {
+			break
+		}
+		log.Printf("[NOTIFIER] Не удалось отправить от имени %s: %v. Пробуем %s", from, err, p.cfg.From[i+1])
+	}
+	return err
+}
+
+func (p *SMTPPool) sendAs(ctx context.Context, from, to string, msg Message) error {
+	data, err := buildMIME(from, to, msg)
+	if err != nil {
+		return fmt.Errorf("сборка письма: %w", err)
+	}
+
+	c, err := p.get(ctx)
+	if err != nil {
+		return err
+	}
+	if err := p.deliver(c, to, data); err != nil {
+		// Состояние сессии после сбоя неизвестно — в пул её не возвращаем
+		c.close()
+		return err
+	}
+	p.put(c)
+	return nil
+}
+
+// deliver — одна транзакция MAIL/RCPT/DATA в открытой сессии
+func (p *SMTPPool) deliver(c *smtpConn, to string, data []byte) error {
+	// envelope-from — сам SMTP_USER: так безопаснее для SPF
+	if err := c.client.Mail(p.cfg.User); err != nil {
+		return fmt.Errorf("MAIL FROM: %w", err)
+	}
+	if err := c.client.Rcpt(to); err != nil {
+		if isPermanentReply(err) {
+			return fmt.Errorf("RCPT TO (%s): %w: %w", to, ErrRecipientRejected, err)
+		}
+		return fmt.Errorf("RCPT TO (%s): %w", to, err)
+	}
+
+	w, err := c.client.Data()
+	if err != nil {
+		return fmt.Errorf("DATA open: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("DATA write: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		// Письмо целиком отклонено после заголовков — чаще всего из-за From
+		if isPermanentReply(err) {
+			return fmt.Errorf("DATA close: %w: %w", errSenderRejected, err)
+		}
+		return fmt.Errorf("DATA close: %w", err)
+	}
+	return nil
+}
+
+// get — живая сессия из пула или новое подключение. Сессию из пула проверяем
+// RSET: сервер мог закрыть её, пока она простаивала.
+func (p *SMTPPool) get(ctx context.Context) (*smtpConn, error) {
+	for {
+		p.mu.Lock()
+		if len(p.idle) == 0 {
+			p.mu.Unlock()
+			return p.dial(ctx)
+		}
+		c := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+		p.mu.Unlock()
+
+		if time.Since(c.lastUsed) > p.cfg.IdleTimeout {
+			c.close()
+			continue
+		}
+		c.setDeadline(ctx)
+		if err := c.client.Reset(); err != nil {
+			c.close()
+			continue
+		}
+		return c, nil
+	}
+}
+
+func (p *SMTPPool) put(c *smtpConn) {
+	_ = c.conn.SetDeadline(time.Time{})
+	c.lastUsed = time.Now()
+
+	p.mu.Lock()
+	if len(p.idle) < p.cfg.MaxIdle {
+		p.idle = append(p.idle, c)
+		c = nil
+	}
+	p.mu.Unlock()
+
+	if c != nil {
+		c.quit()
+	}
+}
+
+func (p *SMTPPool) dial(ctx context.Context) (*smtpConn, error) {
+	addr := net.JoinHostPort(p.cfg.Host, strconv.Itoa(p.cfg.Port))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("SMTP dial: %w", err)
+	}
+	c := &smtpConn{conn: conn}
+	// Таймаут отправки распространяется на весь SMTP-диалог
+	c.setDeadline(ctx)
+
+	c.client, err = smtp.NewClient(conn, p.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("SMTP new client: %w", err)
+	}
+
+	if ok, _ := c.client.Extension("STARTTLS"); ok {
+		if err := c.client.StartTLS(&tls.Config{ServerName: p.cfg.Host}); err != nil {
+			c.close()
+			return nil, fmt.Errorf("STARTTLS: %w", err)
+		}
+	}
+	if ok, _ := c.client.Extension("AUTH"); ok {
+		if err := c.client.Auth(smtp.PlainAuth("", p.cfg.User, p.cfg.Pass, p.cfg.Host)); err != nil {
+			c.close()
+			return nil, fmt.Errorf("SMTP auth: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// Close закрывает простаивающие сессии
+func (p *SMTPPool) Close() {
+	p.mu.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.mu.Unlock()
+
+	for _, c := range idle {
+		c.quit()
+	}
+}
+
+func (c *smtpConn) setDeadline(ctx context.Context) {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = c.conn.SetDeadline(deadline)
+	}
+}
+
+// quit — вежливое завершение сессии с коротким таймаутом
+func (c *smtpConn) quit() {
+	_ = c.conn.SetDeadline(time.Now().Add(5 * time.Second))
+	if err := c.client.Quit(); err != nil {
+		c.close()
+	}
+}
+
+func (c *smtpConn) close() {
+	if c.client != nil {
+		_ = c.client.Close()
+		return
+	}
+	_ = c.conn.Close()
+}
@@ -0,0 +1,85 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const defaultTelegramAPIURL = "https://api.telegram.org"
+
+// TelegramChannel — сообщения через Telegram Bot API (sendMessage).
+// Без To сообщение уходит в чат по умолчанию (TELEGRAM_CHAT_ID).
+type TelegramChannel struct {
+	apiURL        string
+	token         string
+	defaultChatID string
+	client        *http.Client
+}
+
+func newTelegramChannel(kind ChannelKind, settings Settings) (Channel, error) {
+	if kind != ChannelTelegram {
+		return nil, fmt.Errorf("драйвер %s обслуживает только канал telegram", DriverTelegram)
+	}
+	token := settings("TELEGRAM_BOT_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("TELEGRAM_BOT_TOKEN не задан")
+	}
+	apiURL := settings("TELEGRAM_API_URL")
+	if apiURL == "" {
+		apiURL = defaultTelegramAPIURL
+	}
+	return &TelegramChannel{
+		apiURL:        strings.TrimRight(apiURL, "/"),
+		token:         token,
+		defaultChatID: settings("TELEGRAM_CHAT_ID"),
+		client:        &http.Client{},
+	}, nil
+}
+
+func (c *TelegramChannel) Kind() ChannelKind { return ChannelTelegram }
+
+func (c *TelegramChannel) Send(ctx context.Context, msg Message) error {
+	chatID := msg.To
+	if chatID == "" {
+		chatID = c.defaultChatID
+	}
+	if chatID == "" {
+		return fmt.Errorf("не указан chat_id")
+	}
+
+	text := msg.Body
+	if msg.Subject != "" {
+		text = msg.Subject + "\n\n" + msg.Body
+	}
+	body, _ := json.Marshal(map[string]string{"chat_id": chatID, "text": text})
+
+	url := fmt.Sprintf("%s/bot%s/sendMessage", c.apiURL, c.token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("ошибка создания запроса: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		// Ошибка содержит URL с токеном — в лог её не пропускаем
+		return fmt.Errorf("ошибка отправки запроса в Telegram")
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("некорректный ответ Telegram: %s", resp.Status)
+	}
+	if !result.OK {
+		return fmt.Errorf("ошибка ответа Telegram: %s", result.Description)
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"log"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const DefaultLocale = "ru"
+
+// SupportedLocales — языки, для которых можно завести шаблон
+var SupportedLocales = []string{"ru", "en"}
+
+var ErrTemplateNotFound = errors.New("шаблон не найден")
+
+// Template — тексты одного сообщения на одном языке. Пустая часть означает,
+// что сообщение в этот канал не предназначено (или для почты — только текст).
+type Template struct {
+	Key     string `json:"key"`
+	Locale  string `json:"locale"`
+	Subject string `json:"subject,omitempty"`
+	Text    string `json:"text,omitempty"`
+	HTML    string `json:"html,omitempty"`
+	SMS     string `json:"sms,omitempty"`
+}
+
+// TemplateDef — встроенный шаблон: описание для админки, пример данных для
+// предпросмотра и тексты по умолчанию
+type TemplateDef struct {
+	Key         string              `json:"key"`
+	Description string              `json:"description"`
+	Sample      map[string]any      `json:"sample"`
+	Locales     map[string]Template `json:"-"`
+}
+
+// TemplateStore — правки шаблонов, сделанные в админке. Нет правки — (nil, nil).
+type TemplateStore interface {
+	GetTemplate(ctx context.Context, key, locale string) (*Template, error)
+}
+
+// Rendered — готовые тексты для всех каналов
+type Rendered struct {
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	HTML    string `json:"html,omitempty"`
+	SMS     string `json:"sms,omitempty"`
+}
+
+// Message раскладывает тексты под канал: в SMS и Telegram уходит короткий текст,
+// в почту — тема, текст и HTML
+func (r *Rendered) Message(channel ChannelKind, to string) Message {
+	msg := Message{Channel: channel, To: to, Subject: r.Subject, Body: r.Text}
+	switch channel {
+	case ChannelSMS, ChannelTelegram:
+		msg.Subject = ""
+		if r.SMS != "" {
+			msg.Body = r.SMS
+		}
+	case ChannelEmail:
+		msg.HTML = r.HTML
+	}
+	return msg
+}
+
+// Templates — реестр шаблонов: встроенные тексты плюс правки из TemplateStore
+type Templates struct {
+	defs   map[string]*TemplateDef
+	store  TemplateStore
+	locale string
+}
+
+func NewTemplates(locale string) *Templates {
+	t := &Templates{defs: map[string]*TemplateDef{}, locale: locale}
+	for _, def := range builtinTemplates {
+		t.defs[def.Key] = def
+	}
+	return t
+}
+
+// SetStore подключает правки из админки; вызывается при старте
+func (t *Templates) SetStore(store TemplateStore) {
+	t.store = store
+}
+
+// Defs — встроенные шаблоны по ключу
+func (t *Templates) Defs() []*TemplateDef {
+	defs := make([]*TemplateDef, 0, len(t.defs))
+	for _, def := range t.defs {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Key < defs[j].Key })
+	return defs
+}
+
+func (t *Templates) Def(key string) (*TemplateDef, bool) {
+	def, ok := t.defs[key]
+	return def, ok
+}
+
+// Builtin — текст по умолчанию; для языка без перевода — на языке по умолчанию
+func (t *Templates) Builtin(key, locale string) (Template, bool) {
+	def, ok := t.defs[key]
+	if !ok {
+		return Template{}, false
+	}
+	if tpl, ok := def.Locales[locale]; ok {
+		return withKey(tpl, key, locale), true
+	}
+	tpl, ok := def.Locales[DefaultLocale]
+	return withKey(tpl, key, DefaultLocale), ok
+}
+
+// Resolve — действующий шаблон: правка из админки или встроенный текст,
+// сначала на запрошенном языке, затем на языке по умолчанию
+func (t *Templates) Resolve(ctx context.Context, key, locale string) (Template, error) {
+	if _, ok := t.defs[key]; !ok {
+		return Template{}, fmt.Errorf("%w: %s", ErrTemplateNotFound, key)
+	}
+	if locale == "" {
+		locale = t.locale
+	}
+
+	for _, loc := range []string{locale, DefaultLocale} {
+		if t.store != nil {
+			tpl, err := t.store.GetTemplate(ctx, key, loc)
+			if err != nil {
+				// Без правки из БД письмо всё равно лучше отправить
+				log.Printf("[NOTIFIER] Не удалось прочитать шаблон %s/%s: %v", key, loc, err)
+			} else if tpl != nil {
+				return *tpl, nil
+			}
+		}
+		if tpl, ok := t.defs[key].Locales[loc]; ok {
+			return withKey(tpl, key, loc), nil
+		}
+	}
+	return Template{}, fmt.Errorf("%w: %s/%s", ErrTemplateNotFound, key, locale)
+}
+
+func (t *Templates) Render(ctx context.Context, key, locale string, data any) (*Rendered, error) {
+	tpl, err := t.Resolve(ctx, key, locale)
+	if err != nil {
+		return nil, err
+	}
+	return RenderTemplate(tpl, data)
+}
+
+// ValidateTemplate проверяет, что все части шаблона разбираются
+func ValidateTemplate(tpl Template) error {
+	if !hasParts(tpl) {
+		return errors.New("шаблон пуст")
+	}
+	for part, text := range map[string]string{"subject": tpl.Subject, "text": tpl.Text, "sms": tpl.SMS} {
+		if _, err := parseText(part, text); err != nil {
+			return fmt.Errorf("%s: %w", part, err)
+		}
+	}
+	if _, err := parseHTML(tpl.HTML); err != nil {
+		return fmt.Errorf("html: %w", err)
+	}
+	return nil
+}
+
+// RenderTemplate исполняет шаблон на данных. HTML экранирует подставляемые значения
+// и оборачивается в общий макет письма.
+func RenderTemplate(tpl Template, data any) (*Rendered, error) {
+	var r Rendered
+	var err error
+	if r.Subject, err = execText("subject", tpl.Subject, data); err != nil {
+		return nil, err
+	}
+	if r.Text, err = execText("text", tpl.Text, data); err != nil {
+		return nil, err
+	}
+	if r.SMS, err = execText("sms", tpl.SMS, data); err != nil {
+		return nil, err
+	}
+	if tpl.HTML != "" {
+		t, err := parseHTML(tpl.HTML)
+		if err != nil {
+			return nil, fmt.Errorf("html: %w", err)
+		}
+		var sb strings.Builder
+		if err := t.Execute(&sb, data); err != nil {
+			return nil, fmt.Errorf("html: %w", err)
+		}
+		r.HTML = fmt.Sprintf(emailLayout, htmltemplate.HTMLEscapeString(r.Subject), sb.String())
+	}
+	// Тема письма — одна строка
+	r.Subject = strings.Join(strings.Fields(r.Subject), " ")
+	return &r, nil
+}
+
+const emailLayout = `<!DOCTYPE html>
+<html lang="ru">
+<head><meta charset="UTF-8"><title>%s</title></head>
+<body style="margin:0;padding:24px;background:#f5f6f8;font-family:Arial,Helvetica,sans-serif;color:#222;">
+<div style="max-width:560px;margin:0 auto;background:#fff;border-radius:8px;padding:24px;line-height:1.5;">
+%s
+<p style="margin-top:32px;color:#888;font-size:12px;">Emelia Invest</p>
+</div>
+</body>
+</html>`
+
+func execText(part, text string, data any) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	t, err := parseText(part, text)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", part, err)
+	}
+	var sb strings.Builder
+	if err := t.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("%s: %w", part, err)
+	}
+	return sb.String(), nil
+}
+
+func parseText(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(templateFuncs).Parse(text)
+}
+
+func parseHTML(text string) (*htmltemplate.Template, error) {
+	return htmltemplate.New("html").Funcs(htmltemplate.FuncMap(templateFuncs)).Parse(text)
+}
+
+func hasParts(tpl Template) bool {
+	return tpl.Subject != "" || tpl.Text != "" || tpl.HTML != "" || tpl.SMS != ""
+}
+
+func withKey(tpl Template, key, locale string) Template {
+	tpl.Key, tpl.Locale = key, locale
+	return tpl
+}
+
+var templateFuncs = template.FuncMap{
+	// money — сумма в рублях: 1 234,50
+	"money": func(v float64) string {
+		s := fmt.Sprintf("%.2f", v)
+		whole, frac, _ := strings.Cut(s, ".")
+		var sb strings.Builder
+		for i, r := range whole {
+			if i > 0 && (len(whole)-i)%3 == 0 && whole[i-1] != '-' {
+				sb.WriteRune(' ')
+			}
+			sb.WriteRune(r)
+		}
+		return sb.String() + "," + frac
+	},
+	// percent — доля в процентах: 0.015 → 1,5
+	"percent": func(v float64) string {
+		s := strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.4f", v*100), "0"), ".")
+		return strings.ReplaceAll(s, ".", ",")
+	},
+}
@@ -0,0 +1,98 @@
+package notifier
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	data := map[string]any{"Name": "Иван <script>", "Amount": 1234567.5, "Rate": 0.015}
+
+	tests := []struct {
+		name    string
+		tpl     Template
+		want    Rendered
+		html    []string // фрагменты, которые должны быть в HTML
+		wantErr bool
+	}{
+		{
+			name: "текст и sms",
+			tpl:  Template{Subject: "Здравствуйте, {{.Name}}", Text: "Сумма {{money .Amount}} ₽", SMS: "Ставка {{percent .Rate}}%"},
+			want: Rendered{Subject: "Здравствуйте, Иван <script>", Text: "Сумма 1 234 567,50 ₽", SMS: "Ставка 1,5%"},
+		},
+		{
+			name: "тема в одну строку",
+			tpl:  Template{Subject: "  Вывод\n  одобрен  "},
+			want: Rendered{Subject: "Вывод одобрен"},
+		},
+		{
+			name: "html экранируется и оборачивается в макет",
+			tpl:  Template{Subject: "Привет", HTML: "<p>{{.Name}}</p>"},
+			want: Rendered{Subject: "Привет"},
+			html: []string{"<!DOCTYPE html>", "<title>Привет</title>", "<p>Иван &lt;script&gt;</p>"},
+		},
+		{
+			name:    "ошибка разбора",
+			tpl:     Template{Text: "{{.Name"},
+			wantErr: true,
+		},
+		{
+			name:    "неизвестная функция",
+			tpl:     Template{SMS: "{{rubles .Amount}}"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderTemplate(tt.tpl, data)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("ожидалась ошибка")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Subject != tt.want.Subject || got.Text != tt.want.Text || got.SMS != tt.want.SMS {
+				t.Errorf("got %+v, want %+v", *got, tt.want)
+			}
+			if len(tt.html) == 0 && got.HTML != "" {
+				t.Errorf("HTML должен быть пустым, got %q", got.HTML)
+			}
+			for _, frag := range tt.html {
+				if !strings.Contains(got.HTML, frag) {
+					t.Errorf("в HTML нет %q:\n%s", frag, got.HTML)
+				}
+			}
+		})
+	}
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	money := templateFuncs["money"].(func(float64) string)
+	percent := templateFuncs["percent"].(func(float64) string)
+
+	for v, want := range map[float64]string{
+		0:        "0,00",
+		999.999:  "1 000,00",
+		1234.5:   "1 234,50",
+		-1234.5:  "-1 234,50",
+		-123:     "-123,00",
+		100000.1: "100 000,10",
+	} {
+		if got := money(v); got != want {
+			t.Errorf("money(%v): got %q, want %q", v, got, want)
+		}
+	}
+	for v, want := range map[float64]string{
+		0.015: "1,5",
+		0.1:   "10",
+		0:     "0",
+		0.001: "0,1",
+	} {
+		if got := percent(v); got != want {
+			t.Errorf("percent(%v): got %q, want %q", v, got, want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// WebhookChannel отправляет сообщение JSON-ом на URL (To или NOTIFY_WEBHOOK_URL).
+// При заданном NOTIFY_WEBHOOK_SECRET тело подписывается HMAC-SHA256 в X-Signature.
+type WebhookChannel struct {
+	defaultURL string
+	secret     string
+	client     *http.Client
+}
+
+func newWebhookChannel(kind ChannelKind, settings Settings) (Channel, error) {
+	if kind != ChannelWebhook {
+		return nil, fmt.Errorf("драйвер %s обслуживает только канал webhook", DriverWebhook)
+	}
+	return &WebhookChannel{
+		defaultURL: settings("NOTIFY_WEBHOOK_URL"),
+		secret:     settings("NOTIFY_WEBHOOK_SECRET"),
+		client:     &http.Client{},
+	}, nil
+}
+
+func (c *WebhookChannel) Kind() ChannelKind { return ChannelWebhook }
+
+func (c *WebhookChannel) Send(ctx context.Context, msg Message) error {
+	url := msg.To
+	if url == "" {
+		url = c.defaultURL
+	}
+	if url == "" {
+		return fmt.Errorf("не указан URL вебхука")
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("ошибка создания запроса: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.secret != "" {
+		mac := hmac.New(sha256.New, []byte(c.secret))
+		mac.Write(body)
+		req.Header.Set("X-Signature", hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("ошибка отправки запроса: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("вебхук ответил %s", resp.Status)
+	}
+	return nil
+}
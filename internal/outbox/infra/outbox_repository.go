@@ -0,0 +1,197 @@
+package outbox_infra
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	model "github.com/Vovarama1992/emelya-go/internal/outbox/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Интерфейс для пула и транзакции
+type PgxQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+type OutboxRepository struct {
+	querier PgxQuerier
+}
+
+func NewOutboxRepository(db *db.DB) *OutboxRepository {
+	return &OutboxRepository{querier: db.Pool}
+}
+
+// Событие пишется в той же транзакции, что и изменение, которое оно описывает
+func NewOutboxRepositoryWithTx(tx pgx.Tx) *OutboxRepository {
+	return &OutboxRepository{querier: tx}
+}
+
+const eventColumns = `id, event_type, aggregate_type, aggregate_id, payload, status, attempts, next_attempt_at, last_error, created_at, delivered_at`
+
+// Create ставит событие в очередь; заданный NextAttemptAt откладывает первую доставку
+func (r *OutboxRepository) Create(ctx context.Context, e *model.Event) error {
+	var notBefore *time.Time
+	if !e.NextAttemptAt.IsZero() {
+		notBefore = &e.NextAttemptAt
+	}
+
+	query := `
+		INSERT INTO outbox_events (event_type, aggregate_type, aggregate_id, payload, next_attempt_at)
+		VALUES ($1, $2, $3, $4, COALESCE($5, now()))
+		RETURNING id, status, next_attempt_at, created_at
+	`
+	return r.querier.QueryRow(ctx, query, e.Type, e.AggregateType, e.AggregateID, string(e.Payload), notBefore).
+		Scan(&e.ID, &e.Status, &e.NextAttemptAt, &e.CreatedAt)
+}
+
+// CreateUnique — Create для событий с уникальным ключом: повторная запись того же
+// события пропускается, false — событие уже было
+func (r *OutboxRepository) CreateUnique(ctx context.Context, e *model.Event) (bool, error) {
+	var notBefore *time.Time
+	if !e.NextAttemptAt.IsZero() {
+		notBefore = &e.NextAttemptAt
+	}
+
+	query := `
+		INSERT INTO outbox_events (event_type, aggregate_type, aggregate_id, payload, next_attempt_at)
+		VALUES ($1, $2, $3, $4, COALESCE($5, now()))
+		ON CONFLICT DO NOTHING
+		RETURNING id, status, next_attempt_at, created_at
+	`
+	err := r.querier.QueryRow(ctx, query, e.Type, e.AggregateType, e.AggregateID, string(e.Payload), notBefore).
+		Scan(&e.ID, &e.Status, &e.NextAttemptAt, &e.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Claim забирает пачку созревших событий и сдвигает их следующую попытку на lease вперёд.
+// Если диспетчер упадёт посреди доставки, события вернутся в работу после lease;
+// SKIP LOCKED не даёт двум экземплярам взять одно событие. Отметки Mark* принимают
+// номер попытки из Claim: если lease всё же истёк и событие забрал другой экземпляр,
+// устаревшая отметка не перезапишет его результат.
+func (r *OutboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*model.Event, error) {
+	query := `
+		UPDATE outbox_events
+		SET attempts = attempts + 1,
+		    next_attempt_at = now() + $2 * interval '1 second'
+		WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE status = 'pending' AND next_attempt_at <= now()
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + eventColumns
+	rows, err := r.querier.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*model.Event
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// MarkDelivered завершает событие; отметки отдельных обработчиков больше не нужны
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id int64, attempt int) error {
+	query := `
+		WITH done AS (
+			UPDATE outbox_events
+			SET status = 'delivered', delivered_at = now(), last_error = NULL
+			WHERE id = $1 AND attempts = $2 AND status = 'pending'
+			RETURNING id
+		)
+		DELETE FROM outbox_handler_deliveries WHERE event_id IN (SELECT id FROM done)
+	`
+	_, err := r.querier.Exec(ctx, query, id, attempt)
+	return err
+}
+
+// DeliveredHandlers — обработчики, которые уже приняли событие на прошлых попытках
+func (r *OutboxRepository) DeliveredHandlers(ctx context.Context, id int64) (map[string]bool, error) {
+	rows, err := r.querier.Query(ctx, `SELECT handler FROM outbox_handler_deliveries WHERE event_id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	done := map[string]bool{}
+	for rows.Next() {
+		var handler string
+		if err := rows.Scan(&handler); err != nil {
+			return nil, err
+		}
+		done[handler] = true
+	}
+	return done, rows.Err()
+}
+
+// MarkHandlerDelivered запоминает, что обработчик принял событие
+func (r *OutboxRepository) MarkHandlerDelivered(ctx context.Context, id int64, handler string) error {
+	query := `
+		INSERT INTO outbox_handler_deliveries (event_id, handler)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	_, err := r.querier.Exec(ctx, query, id, handler)
+	return err
+}
+
+// MarkRetry откладывает следующую попытку доставки
+func (r *OutboxRepository) MarkRetry(ctx context.Context, id int64, attempt int, nextAttemptAt time.Time, lastError string) error {
+	query := `
+		UPDATE outbox_events
+		SET next_attempt_at = $1, last_error = $2
+		WHERE id = $3 AND attempts = $4 AND status = 'pending'
+	`
+	_, err := r.querier.Exec(ctx, query, nextAttemptAt, lastError, id, attempt)
+	return err
+}
+
+// MarkFailed снимает событие с доставки после исчерпания попыток
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int64, attempt int, lastError string) error {
+	query := `
+		UPDATE outbox_events
+		SET status = 'failed', last_error = $1
+		WHERE id = $2 AND attempts = $3 AND status = 'pending'
+	`
+	_, err := r.querier.Exec(ctx, query, lastError, id, attempt)
+	return err
+}
+
+func scanEvent(row pgx.Row) (*model.Event, error) {
+	var e model.Event
+	var payload []byte
+	err := row.Scan(
+		&e.ID,
+		&e.Type,
+		&e.AggregateType,
+		&e.AggregateID,
+		&payload,
+		&e.Status,
+		&e.Attempts,
+		&e.NextAttemptAt,
+		&e.LastError,
+		&e.CreatedAt,
+		&e.DeliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	e.Payload = payload
+	return &e, nil
+}
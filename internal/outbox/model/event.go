@@ -0,0 +1,149 @@
+package outbox_model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventDepositRequested EventType = "deposit.requested"
+	EventDepositApproved  EventType = "deposit.approved"
+	EventDepositClosed    EventType = "deposit.closed"
+
+	EventWithdrawalRequested EventType = "withdrawal.requested"
+	EventWithdrawalApproved  EventType = "withdrawal.approved"
+	EventWithdrawalRejected  EventType = "withdrawal.rejected"
+
+	EventRewardAccrued EventType = "reward.accrued"
+
+	EventUserRegistered EventType = "user.registered"
+
+	EventLeadCreated EventType = "lead.created"
+
+	// Готовое сообщение пользователю в один канал; доставляется отдельно от
+	// породившего его события, чтобы повтор по одному каналу не дублировал другие
+	EventUserMessage EventType = "notification.user_message"
+)
+
+const (
+	AggregateDeposit    = "deposit"
+	AggregateWithdrawal = "withdrawal"
+	AggregateReward     = "reward"
+	AggregateUser       = "user"
+	AggregateLead       = "lead"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusDelivered Status = "delivered"
+	// Попытки исчерпаны, событие больше не доставляется
+	StatusFailed Status = "failed"
+)
+
+// Event — доменное событие в outbox
+type Event struct {
+	ID            int64           `json:"id"`
+	Type          EventType       `json:"event_type"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateID   int64           `json:"aggregate_id"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        Status          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	LastError     *string         `json:"last_error,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+}
+
+// NewEvent сериализует payload; ID и служебные поля заполняет БД
+func NewEvent(eventType EventType, aggregateType string, aggregateID int64, payload any) (*Event, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &Event{
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       b,
+		Status:        StatusPending,
+	}, nil
+}
+
+// DecodePayload разбирает payload в структуру события
+func (e *Event) DecodePayload(v any) error {
+	return json.Unmarshal(e.Payload, v)
+}
+
+// DepositPayload — события deposit.*
+type DepositPayload struct {
+	DepositID   int64    `json:"deposit_id"`
+	UserID      int64    `json:"user_id"`
+	Amount      float64  `json:"amount"`
+	DailyReward *float64 `json:"daily_reward,omitempty"`
+	BlockDays   *int     `json:"block_days,omitempty"`
+	ActorID     *int64   `json:"actor_id,omitempty"`
+}
+
+// WithdrawalPayload — события withdrawal.*
+type WithdrawalPayload struct {
+	WithdrawalID   int64   `json:"withdrawal_id"`
+	UserID         int64   `json:"user_id"`
+	RewardID       int64   `json:"reward_id"`
+	Amount         float64 `json:"amount"`
+	PayoutMethodID *int64  `json:"payout_method_id,omitempty"`
+	// Маска реквизитов на момент заявки, без полного номера
+	PayoutMethod string  `json:"payout_method,omitempty"`
+	ActorID      *int64  `json:"actor_id,omitempty"`
+	Reason       *string `json:"reason,omitempty"`
+}
+
+// UserRegisteredPayload — user.registered, пишется при подтверждении телефона
+type UserRegisteredPayload struct {
+	UserID     int64  `json:"user_id"`
+	FirstName  string `json:"first_name"`
+	LastName   string `json:"last_name"`
+	Patronymic string `json:"patronymic"`
+	Phone      string `json:"phone"`
+	Email      string `json:"email"`
+	Login      string `json:"login"`
+	ReferrerID *int64 `json:"referrer_id,omitempty"`
+}
+
+// RewardAccruedPayload — reward.accrued, начисление дохода по депозиту
+type RewardAccruedPayload struct {
+	RewardID  int64     `json:"reward_id"`
+	DepositID int64     `json:"deposit_id"`
+	UserID    int64     `json:"user_id"`
+	Amount    float64   `json:"amount"`
+	Days      int       `json:"days"`
+	AccruedAt time.Time `json:"accrued_at"`
+}
+
+// LeadPayload — lead.created, заявка с сайта (спам не публикуется)
+type LeadPayload struct {
+	LeadID     int64  `json:"lead_id"`
+	Name       string `json:"name"`
+	Phone      string `json:"phone"`
+	Email      string `json:"email,omitempty"`
+	TariffID   *int64 `json:"tariff_id,omitempty"`
+	TariffName string `json:"tariff_name,omitempty"`
+	Comment    string `json:"comment,omitempty"`
+	Source     string `json:"source,omitempty"`
+}
+
+// UserMessagePayload — notification.user_message
+type UserMessagePayload struct {
+	UserID        int64  `json:"user_id"`
+	Channel       string `json:"channel"`
+	Category      string `json:"category,omitempty"`
+	To            string `json:"to"`
+	Subject       string `json:"subject,omitempty"`
+	Body          string `json:"body"`
+	HTML          string `json:"html,omitempty"`
+	SourceEventID int64  `json:"source_event_id"`
+}
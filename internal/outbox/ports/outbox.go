@@ -0,0 +1,38 @@
+package outbox_ports
+
+import (
+	"context"
+	"time"
+
+	model "github.com/Vovarama1992/emelya-go/internal/outbox/model"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+)
+
+type OutboxRepository interface {
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*model.Event, error)
+	MarkDelivered(ctx context.Context, id int64, attempt int) error
+	DeliveredHandlers(ctx context.Context, id int64) (map[string]bool, error)
+	MarkHandlerDelivered(ctx context.Context, id int64, handler string) error
+	MarkRetry(ctx context.Context, id int64, attempt int, nextAttemptAt time.Time, lastError string) error
+	MarkFailed(ctx context.Context, id int64, attempt int, lastError string) error
+}
+
+// Handler доставляет событие в один канал уведомлений.
+// Доставка «хотя бы один раз»: обработчик, который уже принял событие, при повторе
+// из-за сбоя другого обработчика его не получает, но может получить снова, если
+// диспетчер упал до отметки о приёме.
+type Handler interface {
+	Handle(ctx context.Context, e *model.Event) error
+}
+
+// HandlerFunc позволяет подписать на события обычную функцию
+type HandlerFunc func(ctx context.Context, e *model.Event) error
+
+func (f HandlerFunc) Handle(ctx context.Context, e *model.Event) error {
+	return f(ctx, e)
+}
+
+// StaffDirectory — сотрудники, которым положено видеть событие
+type StaffDirectory interface {
+	UsersWithPermission(ctx context.Context, perm rbac.Permission) ([]int64, error)
+}
@@ -0,0 +1,182 @@
+package outbox_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	model "github.com/Vovarama1992/emelya-go/internal/outbox/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/outbox/ports"
+	"github.com/Vovarama1992/emelya-go/internal/pollutil"
+)
+
+// DispatcherConfig — параметры опроса outbox и повторов доставки
+type DispatcherConfig struct {
+	PollInterval   time.Duration
+	BatchSize      int
+	MaxAttempts    int
+	BaseBackoff    time.Duration
+	MaxBackoff     time.Duration
+	HandlerTimeout time.Duration
+}
+
+func DefaultDispatcherConfig() DispatcherConfig {
+	return DispatcherConfig{
+		PollInterval:   5 * time.Second,
+		BatchSize:      50,
+		MaxAttempts:    10,
+		BaseBackoff:    30 * time.Second,
+		MaxBackoff:     time.Hour,
+		HandlerTimeout: 15 * time.Second,
+	}
+}
+
+// LoadDispatcherConfigFromEnv — OUTBOX_POLL_INTERVAL и OUTBOX_MAX_ATTEMPTS, остальное по умолчанию
+func LoadDispatcherConfigFromEnv() DispatcherConfig {
+	cfg := DefaultDispatcherConfig()
+	if d, err := time.ParseDuration(os.Getenv("OUTBOX_POLL_INTERVAL")); err == nil && d > 0 {
+		cfg.PollInterval = d
+	}
+	if n, err := strconv.Atoi(os.Getenv("OUTBOX_MAX_ATTEMPTS")); err == nil && n > 0 {
+		cfg.MaxAttempts = n
+	}
+	return cfg
+}
+
+// Dispatcher разбирает outbox и передаёт события подписанным обработчикам.
+// Упавшая доставка повторяется с экспоненциальной задержкой только для тех
+// обработчиков, которые событие ещё не приняли; после MaxAttempts событие
+// помечается failed и остаётся в таблице для разбора.
+type Dispatcher struct {
+	repo     ports.OutboxRepository
+	cfg      DispatcherConfig
+	handlers map[model.EventType][]subscription
+	names    map[string]bool
+}
+
+type subscription struct {
+	name    string
+	handler ports.Handler
+}
+
+func NewDispatcher(repo ports.OutboxRepository, cfg DispatcherConfig) *Dispatcher {
+	return &Dispatcher{
+		repo:     repo,
+		cfg:      cfg,
+		handlers: make(map[model.EventType][]subscription),
+		names:    make(map[string]bool),
+	}
+}
+
+// Subscribe подписывает обработчик на типы событий. Вызывается до Start.
+// По name запоминается, что обработчик уже принял событие, поэтому имя
+// должно быть уникальным и не меняться между релизами.
+func (d *Dispatcher) Subscribe(name string, h ports.Handler, types ...model.EventType) {
+	if d.names[name] {
+		panic("outbox: обработчик " + name + " уже подписан")
+	}
+	d.names[name] = true
+	for _, t := range types {
+		d.handlers[t] = append(d.handlers[t], subscription{name: name, handler: h})
+	}
+}
+
+// Start опрашивает outbox в фоне до отмены ctx
+func (d *Dispatcher) Start(ctx context.Context) {
+	go pollutil.Run(ctx, d.cfg.PollInterval, d.cfg.BatchSize, d.DispatchPending, func(err error) {
+		log.Printf("[OUTBOX] Ошибка выборки событий: %v", err)
+	})
+}
+
+// DispatchPending доставляет одну пачку созревших событий и возвращает её размер
+func (d *Dispatcher) DispatchPending(ctx context.Context) (int, error) {
+	// События пачки доставляются по очереди, поэтому аренда рассчитана на всю
+	// пачку: последнее событие не должно вернуться в очередь, пока ждёт своей очереди
+	lease := d.cfg.HandlerTimeout*time.Duration(d.maxHandlers()*d.cfg.BatchSize) + d.cfg.BaseBackoff
+
+	events, err := d.repo.Claim(ctx, d.cfg.BatchSize, lease)
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range events {
+		d.deliver(ctx, e)
+	}
+	return len(events), nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, e *model.Event) {
+	subs := d.handlers[e.Type]
+
+	// На повторе пропускаем обработчики, принявшие событие раньше
+	done := map[string]bool{}
+	if e.Attempts > 1 && len(subs) > 1 {
+		var err error
+		if done, err = d.repo.DeliveredHandlers(ctx, e.ID); err != nil {
+			d.fail(ctx, e, fmt.Errorf("отметки обработчиков: %w", err))
+			return
+		}
+	}
+
+	var errs []error
+	for _, sub := range subs {
+		if done[sub.name] {
+			continue
+		}
+		if err := d.handle(ctx, sub.handler, e); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sub.name, err))
+			continue
+		}
+		if len(subs) > 1 {
+			if err := d.repo.MarkHandlerDelivered(ctx, e.ID, sub.name); err != nil {
+				log.Printf("[OUTBOX] Не удалось отметить приём события %d обработчиком %s: %v", e.ID, sub.name, err)
+			}
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		d.fail(ctx, e, err)
+		return
+	}
+	if err := d.repo.MarkDelivered(ctx, e.ID, e.Attempts); err != nil {
+		log.Printf("[OUTBOX] Не удалось отметить событие %d доставленным: %v", e.ID, err)
+	}
+}
+
+func (d *Dispatcher) handle(ctx context.Context, h ports.Handler, e *model.Event) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, d.cfg.HandlerTimeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return h.Handle(ctx, e)
+}
+
+func (d *Dispatcher) fail(ctx context.Context, e *model.Event, cause error) {
+	if e.Attempts >= d.cfg.MaxAttempts {
+		log.Printf("[OUTBOX] Событие %d (%s) не доставлено за %d попыток: %v", e.ID, e.Type, e.Attempts, cause)
+		if err := d.repo.MarkFailed(ctx, e.ID, e.Attempts, cause.Error()); err != nil {
+			log.Printf("[OUTBOX] Не удалось отметить событие %d как failed: %v", e.ID, err)
+		}
+		return
+	}
+
+	next := time.Now().Add(pollutil.Backoff(d.cfg.BaseBackoff, d.cfg.MaxBackoff, e.Attempts))
+	if err := d.repo.MarkRetry(ctx, e.ID, e.Attempts, next, cause.Error()); err != nil {
+		log.Printf("[OUTBOX] Не удалось отложить событие %d: %v", e.ID, err)
+	}
+}
+
+func (d *Dispatcher) maxHandlers() int {
+	n := 1
+	for _, hs := range d.handlers {
+		n = max(n, len(hs))
+	}
+	return n
+}
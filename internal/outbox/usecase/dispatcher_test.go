@@ -0,0 +1,212 @@
+package outbox_usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	model "github.com/Vovarama1992/emelya-go/internal/outbox/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/outbox/ports"
+)
+
+// memoryOutbox — outbox в памяти. Claim, как и настоящий, увеличивает Attempts,
+// а Mark* пропускают отметки устаревшей попытки, но NextAttemptAt не ждётся:
+// повтор можно проверить без ожидания.
+type memoryOutbox struct {
+	events    map[int64]*model.Event
+	delivered map[int64]map[string]bool
+	retries   map[int64]time.Time
+}
+
+func newMemoryOutbox(events ...*model.Event) *memoryOutbox {
+	o := &memoryOutbox{
+		events:    map[int64]*model.Event{},
+		delivered: map[int64]map[string]bool{},
+		retries:   map[int64]time.Time{},
+	}
+	for _, e := range events {
+		e.Status = model.StatusPending
+		o.events[e.ID] = e
+	}
+	return o
+}
+
+func (o *memoryOutbox) Claim(_ context.Context, limit int, _ time.Duration) ([]*model.Event, error) {
+	var list []*model.Event
+	for _, e := range o.events {
+		if e.Status == model.StatusPending && len(list) < limit {
+			e.Attempts++
+			copied := *e
+			list = append(list, &copied)
+		}
+	}
+	return list, nil
+}
+
+func (o *memoryOutbox) MarkDelivered(_ context.Context, id int64, attempt int) error {
+	if o.events[id].Attempts != attempt {
+		return nil
+	}
+	o.events[id].Status = model.StatusDelivered
+	delete(o.delivered, id)
+	return nil
+}
+
+func (o *memoryOutbox) DeliveredHandlers(_ context.Context, id int64) (map[string]bool, error) {
+	done := map[string]bool{}
+	for name := range o.delivered[id] {
+		done[name] = true
+	}
+	return done, nil
+}
+
+func (o *memoryOutbox) MarkHandlerDelivered(_ context.Context, id int64, handler string) error {
+	if o.delivered[id] == nil {
+		o.delivered[id] = map[string]bool{}
+	}
+	o.delivered[id][handler] = true
+	return nil
+}
+
+func (o *memoryOutbox) MarkRetry(_ context.Context, id int64, attempt int, next time.Time, lastError string) error {
+	if o.events[id].Attempts != attempt {
+		return nil
+	}
+	o.retries[id] = next
+	o.events[id].LastError = &lastError
+	return nil
+}
+
+func (o *memoryOutbox) MarkFailed(_ context.Context, id int64, attempt int, lastError string) error {
+	if o.events[id].Attempts != attempt {
+		return nil
+	}
+	o.events[id].Status = model.StatusFailed
+	o.events[id].LastError = &lastError
+	return nil
+}
+
+// sendTo — обработчик, который отправляет событие в канал
+func sendTo(ch notifier.Channel) ports.Handler {
+	return ports.HandlerFunc(func(ctx context.Context, e *model.Event) error {
+		return ch.Send(ctx, notifier.Message{Channel: ch.Kind(), To: "user", Body: string(e.Type)})
+	})
+}
+
+func testConfig() DispatcherConfig {
+	cfg := DefaultDispatcherConfig()
+	cfg.HandlerTimeout = time.Second
+	cfg.MaxAttempts = 3
+	return cfg
+}
+
+func TestDispatcherRetriesOnlyFailedHandlers(t *testing.T) {
+	ctx := context.Background()
+	outbox := newMemoryOutbox(&model.Event{ID: 1, Type: model.EventDepositApproved})
+	sms := notifier.NewRecordingChannel(notifier.ChannelSMS)
+	email := notifier.NewRecordingChannel(notifier.ChannelEmail)
+	email.FailWith(errors.New("smtp недоступен"))
+
+	d := NewDispatcher(outbox, testConfig())
+	d.Subscribe("sms", sendTo(sms), model.EventDepositApproved)
+	d.Subscribe("email", sendTo(email), model.EventDepositApproved)
+
+	// Первая попытка: SMS ушло, письмо нет — событие откладывается
+	if _, err := d.DispatchPending(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := outbox.events[1].Status; got != model.StatusPending {
+		t.Fatalf("статус после сбоя: %s", got)
+	}
+	if _, ok := outbox.retries[1]; !ok {
+		t.Fatal("повтор не запланирован")
+	}
+	if n := len(sms.Messages()); n != 1 {
+		t.Fatalf("SMS после первой попытки: %d", n)
+	}
+
+	// Повтор: почта ожила, SMS второй раз не отправляется
+	email.FailWith(nil)
+	if _, err := d.DispatchPending(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := outbox.events[1].Status; got != model.StatusDelivered {
+		t.Fatalf("статус после повтора: %s", got)
+	}
+	if n := len(sms.Messages()); n != 1 {
+		t.Errorf("SMS отправлено %d раз, want 1", n)
+	}
+	if n := len(email.Messages()); n != 1 {
+		t.Errorf("писем отправлено %d, want 1", n)
+	}
+}
+
+func TestDispatcherFailsAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	outbox := newMemoryOutbox(&model.Event{ID: 7, Type: model.EventWithdrawalApproved})
+	email := notifier.NewRecordingChannel(notifier.ChannelEmail)
+	email.FailWith(errors.New("smtp недоступен"))
+
+	cfg := testConfig()
+	d := NewDispatcher(outbox, cfg)
+	d.Subscribe("email", sendTo(email), model.EventWithdrawalApproved)
+
+	for i := 0; i < cfg.MaxAttempts; i++ {
+		if _, err := d.DispatchPending(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	e := outbox.events[7]
+	if e.Status != model.StatusFailed {
+		t.Fatalf("статус после %d попыток: %s", cfg.MaxAttempts, e.Status)
+	}
+	if e.LastError == nil || *e.LastError != "email: smtp недоступен" {
+		t.Errorf("last_error: %v", e.LastError)
+	}
+	if n, _ := d.DispatchPending(ctx); n != 0 {
+		t.Errorf("failed-событие снова взято в работу")
+	}
+}
+
+func TestDispatcherRecoversHandlerPanic(t *testing.T) {
+	ctx := context.Background()
+	outbox := newMemoryOutbox(&model.Event{ID: 3, Type: model.EventLeadCreated})
+	inbox := notifier.NewRecordingChannel(notifier.ChannelInApp)
+
+	d := NewDispatcher(outbox, testConfig())
+	d.Subscribe("broken", ports.HandlerFunc(func(context.Context, *model.Event) error {
+		panic("nil map")
+	}), model.EventLeadCreated)
+	d.Subscribe("inbox", sendTo(inbox), model.EventLeadCreated)
+
+	if _, err := d.DispatchPending(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(inbox.Messages()); n != 1 {
+		t.Errorf("паника одного обработчика помешала другому: %d сообщений", n)
+	}
+	if e := outbox.events[3]; e.Status != model.StatusPending || e.LastError == nil {
+		t.Errorf("событие после паники: %s, %v", e.Status, e.LastError)
+	}
+}
+
+func TestDispatcherIgnoresStaleClaim(t *testing.T) {
+	ctx := context.Background()
+	outbox := newMemoryOutbox(&model.Event{ID: 5, Type: model.EventDepositApproved})
+
+	d := NewDispatcher(outbox, testConfig())
+	d.Subscribe("slow", ports.HandlerFunc(func(context.Context, *model.Event) error {
+		// Пока обработчик работал, аренда истекла и событие забрал другой экземпляр
+		outbox.events[5].Attempts++
+		return nil
+	}), model.EventDepositApproved)
+
+	if _, err := d.DispatchPending(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := outbox.events[5].Status; got != model.StatusPending {
+		t.Errorf("устаревшая попытка перезаписала статус: %s", got)
+	}
+}
@@ -0,0 +1,78 @@
+package outbox_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	model "github.com/Vovarama1992/emelya-go/internal/outbox/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/outbox/ports"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	user_ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
+)
+
+// Кто из сотрудников видит событие в ленте: те, кто может по нему что-то сделать
+var operatorInboxPermissions = map[model.EventType]rbac.Permission{
+	model.EventDepositRequested:    rbac.PermDepositsManage,
+	model.EventDepositApproved:     rbac.PermDepositsManage,
+	model.EventDepositClosed:       rbac.PermDepositsManage,
+	model.EventWithdrawalRequested: rbac.PermWithdrawalsManage,
+	model.EventWithdrawalApproved:  rbac.PermWithdrawalsManage,
+	model.EventWithdrawalRejected:  rbac.PermWithdrawalsManage,
+	model.EventUserRegistered:      rbac.PermUsersRead,
+	model.EventLeadCreated:         rbac.PermLeadsManage,
+}
+
+// OperatorInbox кладёт операторские уведомления в ленты сотрудников — тем же
+// текстом, что уходит операторам на почту
+type OperatorInbox struct {
+	OperatorNotifications
+	staff ports.StaffDirectory
+}
+
+func NewOperatorInbox(notifier notifier.NotifierInterface, userService user_ports.UserServiceInterface, staff ports.StaffDirectory) *OperatorInbox {
+	return &OperatorInbox{
+		OperatorNotifications: OperatorNotifications{notifier: notifier, userService: userService},
+		staff:                 staff,
+	}
+}
+
+func (h *OperatorInbox) Handle(ctx context.Context, e *model.Event) error {
+	if !h.notifier.HasChannel(notifier.ChannelInApp) {
+		return nil
+	}
+	perm, ok := operatorInboxPermissions[e.Type]
+	if !ok {
+		return fmt.Errorf("неизвестный тип события %s", e.Type)
+	}
+
+	data, err := h.templateData(ctx, e)
+	if err != nil {
+		return err
+	}
+	r, err := h.notifier.Render(ctx, operatorTemplates[e.Type], data)
+	if err != nil {
+		return err
+	}
+	staffIDs, err := h.staff.UsersWithPermission(ctx, perm)
+	if err != nil {
+		return err
+	}
+
+	// Лента не дублирует уведомление из того же события, поэтому при повторе
+	// безопасно пройти по всем сотрудникам заново
+	var errs []error
+	for _, id := range staffIDs {
+		msg := r.Message(notifier.ChannelInApp, strconv.FormatInt(id, 10))
+		msg.Meta = map[string]string{
+			"category":        "operator",
+			"source_event_id": strconv.FormatInt(e.ID, 10),
+		}
+		if err := h.notifier.Send(ctx, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
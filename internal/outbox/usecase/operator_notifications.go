@@ -0,0 +1,99 @@
+package outbox_usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	model "github.com/Vovarama1992/emelya-go/internal/outbox/model"
+	user_model "github.com/Vovarama1992/emelya-go/internal/user/model"
+	user_ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
+)
+
+// OperatorNotifications — письма операторам о доменных событиях
+type OperatorNotifications struct {
+	notifier    notifier.NotifierInterface
+	userService user_ports.UserServiceInterface
+}
+
+func NewOperatorNotifications(notifier notifier.NotifierInterface, userService user_ports.UserServiceInterface) *OperatorNotifications {
+	return &OperatorNotifications{notifier: notifier, userService: userService}
+}
+
+// Events — события, о которых пишем операторам
+func (h *OperatorNotifications) Events() []model.EventType {
+	return []model.EventType{
+		model.EventDepositRequested,
+		model.EventDepositApproved,
+		model.EventDepositClosed,
+		model.EventWithdrawalRequested,
+		model.EventWithdrawalApproved,
+		model.EventWithdrawalRejected,
+		model.EventUserRegistered,
+		model.EventLeadCreated,
+	}
+}
+
+// Шаблон письма для каждого события
+var operatorTemplates = map[model.EventType]string{
+	model.EventDepositRequested:    notifier.TplOperatorDepositRequested,
+	model.EventDepositApproved:     notifier.TplOperatorDepositApproved,
+	model.EventDepositClosed:       notifier.TplOperatorDepositClosed,
+	model.EventWithdrawalRequested: notifier.TplOperatorWithdrawalRequested,
+	model.EventWithdrawalApproved:  notifier.TplOperatorWithdrawalApproved,
+	model.EventWithdrawalRejected:  notifier.TplOperatorWithdrawalRejected,
+	model.EventUserRegistered:      notifier.TplOperatorUserRegistered,
+	model.EventLeadCreated:         notifier.TplOperatorLeadCreated,
+}
+
+func (h *OperatorNotifications) Handle(ctx context.Context, e *model.Event) error {
+	key, ok := operatorTemplates[e.Type]
+	if !ok {
+		return fmt.Errorf("неизвестный тип события %s", e.Type)
+	}
+	data, err := h.templateData(ctx, e)
+	if err != nil {
+		return err
+	}
+	return h.notifier.SendTemplateToOperators(ctx, key, data)
+}
+
+// registrationData — данные регистрации и реферер, если он найден
+type registrationData struct {
+	model.UserRegisteredPayload
+	Referrer *user_model.User
+}
+
+func (h *OperatorNotifications) templateData(ctx context.Context, e *model.Event) (any, error) {
+	switch e.Type {
+	case model.EventDepositRequested, model.EventDepositApproved, model.EventDepositClosed:
+		var p model.DepositPayload
+		err := e.DecodePayload(&p)
+		return p, err
+
+	case model.EventWithdrawalRequested, model.EventWithdrawalApproved, model.EventWithdrawalRejected:
+		var p model.WithdrawalPayload
+		err := e.DecodePayload(&p)
+		return p, err
+
+	case model.EventUserRegistered:
+		var p model.UserRegisteredPayload
+		if err := e.DecodePayload(&p); err != nil {
+			return nil, err
+		}
+		data := registrationData{UserRegisteredPayload: p}
+		if p.ReferrerID != nil {
+			// Реферер не найден — письмо всё равно уходит, с пометкой
+			if refUser, err := h.userService.FindUserByID(ctx, *p.ReferrerID); err == nil {
+				data.Referrer = refUser
+			}
+		}
+		return data, nil
+
+	case model.EventLeadCreated:
+		var p model.LeadPayload
+		err := e.DecodePayload(&p)
+		return p, err
+	}
+	return nil, fmt.Errorf("неизвестный тип события %s", e.Type)
+}
@@ -0,0 +1,44 @@
+// Package pollutil — общий цикл фоновых обработчиков очередей в БД (outbox,
+// вебхуки, письма, статусы SMS) и экспоненциальная задержка повторов
+package pollutil
+
+import (
+	"context"
+	"time"
+)
+
+// Run разбирает очередь пачками до отмены ctx. Пока пачки приходят полными,
+// следующая берётся без паузы, иначе — ждём interval. Ошибка выборки передаётся
+// в onErr и прерывает разбор до следующего тика.
+func Run(ctx context.Context, interval time.Duration, batchSize int, batch func(context.Context) (int, error), onErr func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := batch(ctx)
+			if err != nil {
+				onErr(err)
+				break
+			}
+			if n < batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Backoff — base * 2^(attempt-1), не больше maxDelay
+func Backoff(base, maxDelay time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
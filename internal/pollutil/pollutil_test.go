@@ -0,0 +1,64 @@
+package pollutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		base, max time.Duration
+		attempt   int
+		want      time.Duration
+	}{
+		{30 * time.Second, time.Hour, 1, 30 * time.Second},
+		{30 * time.Second, time.Hour, 2, time.Minute},
+		{30 * time.Second, time.Hour, 4, 4 * time.Minute},
+		{30 * time.Second, time.Hour, 8, time.Hour},
+		{30 * time.Second, time.Hour, 1000, time.Hour},
+		{time.Minute, 6 * time.Hour, 0, time.Minute},
+		{2 * time.Hour, time.Hour, 1, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := Backoff(tt.base, tt.max, tt.attempt); got != tt.want {
+			t.Errorf("Backoff(%v, %v, %d): got %v, want %v", tt.base, tt.max, tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestRunDrainsFullBatches(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	// Две полные пачки, неполная и ошибка; после неё — ждём тика, а тест
+	// останавливает цикл
+	results := []int{10, 10, 3}
+	var calls int
+	var errs []error
+	batch := func(context.Context) (int, error) {
+		calls++
+		if calls > len(results) {
+			cancel()
+			return 0, errors.New("stop")
+		}
+		return results[calls-1], nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		Run(ctx, time.Millisecond, 10, batch, func(err error) { errs = append(errs, err) })
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run не завершился после отмены ctx")
+	}
+
+	if calls != 4 {
+		t.Errorf("вызовов batch: %d, want 4", calls)
+	}
+	if len(errs) != 1 {
+		t.Errorf("ошибок передано: %d, want 1", len(errs))
+	}
+}
@@ -0,0 +1,11 @@
+package rbachttp
+
+type PermissionRequest struct {
+	Role       string `json:"role" validate:"required"`
+	Permission string `json:"permission" validate:"required"`
+}
+
+type SetUserRoleRequest struct {
+	UserID int64  `json:"user_id" validate:"required"`
+	Role   string `json:"role" validate:"required"`
+}
@@ -0,0 +1,156 @@
+package rbachttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	model "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/rbac/ports"
+	service "github.com/Vovarama1992/emelya-go/internal/rbac/usecase"
+	user "github.com/Vovarama1992/emelya-go/internal/user/model"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type Handler struct {
+	rbacService ports.RBACService
+}
+
+func NewHandler(rbacService ports.RBACService) *Handler {
+	return &Handler{rbacService: rbacService}
+}
+
+// ListRoles godoc
+// @Summary Админ: роли и их права
+// @Tags admin-rbac
+// @Produce json
+// @Success 200 {array} model.RolePermissions
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/rbac/roles [get]
+func (h *Handler) ListRoles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	roles, err := h.rbacService.ListRolePermissions(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения прав")
+		return
+	}
+
+	json.NewEncoder(w).Encode(roles)
+}
+
+// Grant godoc
+// @Summary Админ: выдать право роли
+// @Tags admin-rbac
+// @Accept json
+// @Produce json
+// @Param data body PermissionRequest true "Роль и право"
+// @Success 200 {object} map[string]string
+// @Failure 400,500 {object} map[string]string
+// @Router /api/admin/rbac/grant [post]
+func (h *Handler) Grant(w http.ResponseWriter, r *http.Request) {
+	h.changePermission(w, r, h.rbacService.Grant, "Право выдано")
+}
+
+// Revoke godoc
+// @Summary Админ: отозвать право у роли
+// @Tags admin-rbac
+// @Accept json
+// @Produce json
+// @Param data body PermissionRequest true "Роль и право"
+// @Success 200 {object} map[string]string
+// @Failure 400,500 {object} map[string]string
+// @Router /api/admin/rbac/revoke [post]
+func (h *Handler) Revoke(w http.ResponseWriter, r *http.Request) {
+	h.changePermission(w, r, h.rbacService.Revoke, "Право отозвано")
+}
+
+// SetUserRole godoc
+// @Summary Админ: назначить роль пользователю
+// @Tags admin-rbac
+// @Accept json
+// @Produce json
+// @Param data body SetUserRoleRequest true "ID пользователя и роль"
+// @Success 200 {object} map[string]string
+// @Failure 400,403,404,500 {object} map[string]string
+// @Router /api/admin/rbac/user-role [post]
+func (h *Handler) SetUserRole(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req SetUserRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	if err := h.rbacService.SetUserRole(r.Context(), admin.ID, req.UserID, user.UserRole(req.Role)); err != nil {
+		respondWithServiceError(w, err, "Не удалось назначить роль")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Роль назначена"})
+}
+
+type permissionChange func(ctx context.Context, actorID int64, role user.UserRole, perm model.Permission) error
+
+func (h *Handler) changePermission(w http.ResponseWriter, r *http.Request, change permissionChange, message string) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req PermissionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные запроса")
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	if err := change(r.Context(), admin.ID, user.UserRole(req.Role), model.Permission(req.Permission)); err != nil {
+		respondWithServiceError(w, err, "Не удалось изменить права")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
+
+// respondWithServiceError переводит ошибки сервиса в HTTP-коды
+func respondWithServiceError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrUnknownRole),
+		errors.Is(err, service.ErrUnknownPermission),
+		errors.Is(err, service.ErrSuperadminFixed):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrOwnRole),
+		errors.Is(err, service.ErrSuperadminOnly):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrUserNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, fallback+": "+err.Error())
+	}
+}
+
+func respondWithError(w http.ResponseWriter, code int, message string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
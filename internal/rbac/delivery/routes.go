@@ -0,0 +1,36 @@
+package rbachttp
+
+import (
+	"net/http"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	user_ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
+	"github.com/Vovarama1992/go-utils/httputil"
+)
+
+func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService user_ports.UserServiceInterface, sessions middleware.SessionChecker, access middleware.PermissionChecker) {
+	withRecover := func(h http.Handler) http.Handler {
+		return httputil.RecoverMiddleware(h)
+	}
+
+	withPermission := func(perm rbac.Permission, h http.Handler) http.Handler {
+		return middleware.PermissionMiddleware(userService, sessions, access, perm)(h)
+	}
+
+	mux.Handle("/api/admin/rbac/roles",
+		withRecover(withPermission(rbac.PermRBACManage, http.HandlerFunc(handler.ListRoles))),
+	)
+
+	mux.Handle("/api/admin/rbac/grant",
+		withRecover(withPermission(rbac.PermRBACManage, http.HandlerFunc(handler.Grant))),
+	)
+
+	mux.Handle("/api/admin/rbac/revoke",
+		withRecover(withPermission(rbac.PermRBACManage, http.HandlerFunc(handler.Revoke))),
+	)
+
+	mux.Handle("/api/admin/rbac/user-role",
+		withRecover(withPermission(rbac.PermRBACManage, http.HandlerFunc(handler.SetUserRole))),
+	)
+}
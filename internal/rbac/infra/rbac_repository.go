@@ -0,0 +1,53 @@
+package rbac_infra
+
+import (
+	"context"
+
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	model "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	user "github.com/Vovarama1992/emelya-go/internal/user/model"
+)
+
+type RBACRepository struct {
+	DB *db.DB
+}
+
+func NewRBACRepository(db *db.DB) *RBACRepository {
+	return &RBACRepository{DB: db}
+}
+
+func (r *RBACRepository) FindAll(ctx context.Context) (map[user.UserRole][]model.Permission, error) {
+	query := `SELECT role, permission FROM role_permissions ORDER BY role, permission`
+	rows, err := r.DB.Pool.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[user.UserRole][]model.Permission)
+	for rows.Next() {
+		var role user.UserRole
+		var perm model.Permission
+		if err := rows.Scan(&role, &perm); err != nil {
+			return nil, err
+		}
+		result[role] = append(result[role], perm)
+	}
+	return result, nil
+}
+
+func (r *RBACRepository) Grant(ctx context.Context, role user.UserRole, perm model.Permission) error {
+	query := `
+		INSERT INTO role_permissions (role, permission)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	_, err := r.DB.Pool.Exec(ctx, query, role, perm)
+	return err
+}
+
+func (r *RBACRepository) Revoke(ctx context.Context, role user.UserRole, perm model.Permission) error {
+	query := `DELETE FROM role_permissions WHERE role = $1 AND permission = $2`
+	_, err := r.DB.Pool.Exec(ctx, query, role, perm)
+	return err
+}
@@ -0,0 +1,73 @@
+package rbac_model
+
+import user "github.com/Vovarama1992/emelya-go/internal/user/model"
+
+type Permission string
+
+const (
+	PermUsersRead  Permission = "users.read"
+	PermUsersWrite Permission = "users.write"
+	PermKYCReview  Permission = "kyc.review"
+
+	PermDepositsRead   Permission = "deposits.read"
+	PermDepositsManage Permission = "deposits.manage"
+	PermDepositsDelete Permission = "deposits.delete"
+
+	PermWithdrawalsRead   Permission = "withdrawals.read"
+	PermWithdrawalsManage Permission = "withdrawals.manage"
+	// Просмотр полного номера карты или счёта; по умолчанию только у superadmin
+	PermPayoutsReveal Permission = "payouts.reveal"
+
+	PermRewardsRead   Permission = "rewards.read"
+	PermRewardsManage Permission = "rewards.manage"
+
+	PermTariffsManage Permission = "tariffs.manage"
+	PermFinanceRead   Permission = "finance.read"
+	// Выгрузка депозитов, выводов и наград в CSV
+	PermFinanceExport Permission = "finance.export"
+	PermAuditRead     Permission = "audit.read"
+
+	PermApprovalsManage     Permission = "approvals.manage"
+	PermRBACManage          Permission = "rbac.manage"
+	PermNotificationsManage Permission = "notifications.manage"
+	PermWebhooksManage      Permission = "webhooks.manage"
+	PermLeadsManage         Permission = "leads.manage"
+)
+
+var AllPermissions = []Permission{
+	PermUsersRead,
+	PermUsersWrite,
+	PermKYCReview,
+	PermDepositsRead,
+	PermDepositsManage,
+	PermDepositsDelete,
+	PermWithdrawalsRead,
+	PermWithdrawalsManage,
+	PermPayoutsReveal,
+	PermRewardsRead,
+	PermRewardsManage,
+	PermTariffsManage,
+	PermFinanceRead,
+	PermFinanceExport,
+	PermAuditRead,
+	PermApprovalsManage,
+	PermRBACManage,
+	PermNotificationsManage,
+	PermWebhooksManage,
+	PermLeadsManage,
+}
+
+func (p Permission) IsValid() bool {
+	for _, known := range AllPermissions {
+		if known == p {
+			return true
+		}
+	}
+	return false
+}
+
+// RolePermissions — набор прав роли
+type RolePermissions struct {
+	Role        user.UserRole `json:"role"`
+	Permissions []Permission  `json:"permissions"`
+}
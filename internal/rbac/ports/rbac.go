@@ -0,0 +1,23 @@
+package rbac_ports
+
+import (
+	"context"
+
+	model "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	user "github.com/Vovarama1992/emelya-go/internal/user/model"
+)
+
+type RBACRepository interface {
+	FindAll(ctx context.Context) (map[user.UserRole][]model.Permission, error)
+	Grant(ctx context.Context, role user.UserRole, perm model.Permission) error
+	Revoke(ctx context.Context, role user.UserRole, perm model.Permission) error
+}
+
+type RBACService interface {
+	HasPermission(ctx context.Context, role user.UserRole, perm model.Permission) bool
+	UsersWithPermission(ctx context.Context, perm model.Permission) ([]int64, error)
+	ListRolePermissions(ctx context.Context) ([]model.RolePermissions, error)
+	Grant(ctx context.Context, actorID int64, role user.UserRole, perm model.Permission) error
+	Revoke(ctx context.Context, actorID int64, role user.UserRole, perm model.Permission) error
+	SetUserRole(ctx context.Context, actorID, userID int64, role user.UserRole) error
+}
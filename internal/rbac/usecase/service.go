@@ -0,0 +1,233 @@
+package rbac_usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	audit "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	audit_ports "github.com/Vovarama1992/emelya-go/internal/audit/ports"
+	model "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/rbac/ports"
+	user "github.com/Vovarama1992/emelya-go/internal/user/model"
+	user_ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+)
+
+var (
+	ErrUnknownRole       = errors.New("неизвестная роль")
+	ErrUnknownPermission = errors.New("неизвестное право")
+	ErrSuperadminFixed   = errors.New("права superadmin не настраиваются")
+	ErrOwnRole           = errors.New("нельзя менять собственную роль")
+	ErrSuperadminOnly    = errors.New("назначать и снимать superadmin может только superadmin")
+	ErrUserNotFound      = errors.New("пользователь не найден")
+)
+
+// Права перечитываются из базы не чаще этого интервала,
+// чтобы изменения с другого инстанса доезжали без рестарта
+const cacheTTL = 30 * time.Second
+
+type RBACService struct {
+	repo         ports.RBACRepository
+	userService  user_ports.UserServiceInterface
+	auditService audit_ports.AuditService
+
+	mu       sync.RWMutex
+	cache    map[user.UserRole]map[model.Permission]bool
+	loadedAt time.Time
+}
+
+func NewRBACService(repo ports.RBACRepository, userService user_ports.UserServiceInterface, auditService audit_ports.AuditService) *RBACService {
+	return &RBACService{
+		repo:         repo,
+		userService:  userService,
+		auditService: auditService,
+	}
+}
+
+// HasPermission — при ошибке чтения прав доступ запрещается
+func (s *RBACService) HasPermission(ctx context.Context, role user.UserRole, perm model.Permission) bool {
+	if role == user.RoleSuperadmin {
+		return true
+	}
+
+	perms, err := s.permissions(ctx)
+	if err != nil {
+		log.Printf("[RBAC] Не удалось загрузить права: %v", err)
+		return false
+	}
+	return perms[role][perm]
+}
+
+// UsersWithPermission — сотрудники, чьей роли выдано право (superadmin — всегда)
+func (s *RBACService) UsersWithPermission(ctx context.Context, perm model.Permission) ([]int64, error) {
+	perms, err := s.permissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := []user.UserRole{user.RoleSuperadmin}
+	for role, granted := range perms {
+		if role != user.RoleSuperadmin && granted[perm] {
+			roles = append(roles, role)
+		}
+	}
+	return s.userService.FindUserIDsByRoles(ctx, roles)
+}
+
+func (s *RBACService) ListRolePermissions(ctx context.Context) ([]model.RolePermissions, error) {
+	perms, err := s.permissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.RolePermissions, 0, len(user.AllRoles))
+	for _, role := range user.AllRoles {
+		rp := model.RolePermissions{Role: role, Permissions: []model.Permission{}}
+		for _, perm := range model.AllPermissions {
+			if role == user.RoleSuperadmin || perms[role][perm] {
+				rp.Permissions = append(rp.Permissions, perm)
+			}
+		}
+		result = append(result, rp)
+	}
+	return result, nil
+}
+
+func (s *RBACService) Grant(ctx context.Context, actorID int64, role user.UserRole, perm model.Permission) error {
+	if err := validate(role, perm); err != nil {
+		return err
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	if err := s.repo.Grant(ctx, role, perm); err != nil {
+		return err
+	}
+	s.invalidate()
+
+	s.record(ctx, &audit.Entry{
+		ActorID:    &actorID,
+		Action:     audit.ActionRBACGrant,
+		EntityType: audit.EntityRole,
+		After:      audit.Snapshot(map[string]any{"role": role, "permission": perm}),
+	})
+	return nil
+}
+
+func (s *RBACService) Revoke(ctx context.Context, actorID int64, role user.UserRole, perm model.Permission) error {
+	if err := validate(role, perm); err != nil {
+		return err
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	if err := s.repo.Revoke(ctx, role, perm); err != nil {
+		return err
+	}
+	s.invalidate()
+
+	s.record(ctx, &audit.Entry{
+		ActorID:    &actorID,
+		Action:     audit.ActionRBACRevoke,
+		EntityType: audit.EntityRole,
+		After:      audit.Snapshot(map[string]any{"role": role, "permission": perm}),
+	})
+	return nil
+}
+
+func (s *RBACService) SetUserRole(ctx context.Context, actorID, userID int64, role user.UserRole) error {
+	if !role.IsValid() {
+		return ErrUnknownRole
+	}
+	if actorID == userID {
+		return ErrOwnRole
+	}
+
+	target, err := s.userService.FindUserByID(ctx, userID)
+	if err != nil || target == nil {
+		return ErrUserNotFound
+	}
+
+	// rbac.manage есть и у admin, но superadmin имеет все права без записей
+	// в таблице — выдать или отнять его может только другой superadmin
+	if role == user.RoleSuperadmin || target.Role == user.RoleSuperadmin {
+		actor, err := s.userService.FindUserByID(ctx, actorID)
+		if err != nil || actor == nil || actor.Role != user.RoleSuperadmin {
+			return ErrSuperadminOnly
+		}
+	}
+
+	if err := s.userService.SetRole(ctx, userID, role); err != nil {
+		return err
+	}
+
+	s.record(ctx, &audit.Entry{
+		ActorID:    &actorID,
+		Action:     audit.ActionUserRoleChange,
+		EntityType: audit.EntityUser,
+		EntityID:   &userID,
+		Before:     audit.Snapshot(map[string]any{"role": target.Role}),
+		After:      audit.Snapshot(map[string]any{"role": role}),
+	})
+	return nil
+}
+
+// record — сбой записи в журнал не отменяет уже применённое изменение прав
+func (s *RBACService) record(ctx context.Context, e *audit.Entry) {
+	if err := s.auditService.Record(ctx, e); err != nil {
+		log.Printf("[RBAC] Не удалось записать журнал %s: %v", e.Action, err)
+	}
+}
+
+func (s *RBACService) permissions(ctx context.Context) (map[user.UserRole]map[model.Permission]bool, error) {
+	s.mu.RLock()
+	if s.cache != nil && time.Since(s.loadedAt) < cacheTTL {
+		cache := s.cache
+		s.mu.RUnlock()
+		return cache, nil
+	}
+	s.mu.RUnlock()
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	rows, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := make(map[user.UserRole]map[model.Permission]bool, len(rows))
+	for role, perms := range rows {
+		cache[role] = make(map[model.Permission]bool, len(perms))
+		for _, perm := range perms {
+			cache[role][perm] = true
+		}
+	}
+
+	s.mu.Lock()
+	s.cache = cache
+	s.loadedAt = time.Now()
+	s.mu.Unlock()
+	return cache, nil
+}
+
+func (s *RBACService) invalidate() {
+	s.mu.Lock()
+	s.cache = nil
+	s.mu.Unlock()
+}
+
+func validate(role user.UserRole, perm model.Permission) error {
+	if !role.IsValid() {
+		return ErrUnknownRole
+	}
+	if role == user.RoleSuperadmin {
+		return ErrSuperadminFixed
+	}
+	if !perm.IsValid() {
+		return ErrUnknownPermission
+	}
+	return nil
+}
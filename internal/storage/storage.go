@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+var ErrNotFound = errors.New("файл не найден")
+
+// Storage — хранилище загруженных файлов. Ключ — относительный путь вида
+// "kyc/42/<случайное имя>", его формирует вызывающий код.
+type Storage interface {
+	Save(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+const defaultLocalDir = "./uploads"
+
+// NewFromEnv выбирает драйвер по STORAGE_DRIVER; пока поддерживается только local
+// (каталог STORAGE_LOCAL_DIR, по умолчанию ./uploads)
+func NewFromEnv() (Storage, error) {
+	switch driver := os.Getenv("STORAGE_DRIVER"); driver {
+	case "", "local":
+		dir := os.Getenv("STORAGE_LOCAL_DIR")
+		if dir == "" {
+			dir = defaultLocalDir
+		}
+		return NewLocal(dir)
+	default:
+		return nil, fmt.Errorf("неизвестный STORAGE_DRIVER: %s", driver)
+	}
+}
@@ -0,0 +1,110 @@
+package telegramhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	model "github.com/Vovarama1992/emelya-go/internal/telegram/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/telegram/ports"
+)
+
+type Handler struct {
+	linkService ports.LinkService
+}
+
+func NewHandler(linkService ports.LinkService) *Handler {
+	return &Handler{linkService: linkService}
+}
+
+// CreateLinkCode godoc
+// @Summary Админ: код привязки Telegram-бота
+// @Description Код одноразовый и живёт 10 минут: его нужно отправить боту командой /start <код> (или открыть ссылку url). Доступно сотрудникам с правом на депозиты или выводы.
+// @Tags telegram
+// @Produce json
+// @Success 200 {object} model.LinkCode
+// @Failure 401,403,500,503 {object} map[string]string
+// @Router /api/admin/telegram/link-code [post]
+func (h *Handler) CreateLinkCode(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	code, err := h.linkService.CreateLinkCode(r.Context(), user.ID)
+	if err != nil {
+		respondWithServiceError(w, err, "Ошибка создания кода привязки")
+		return
+	}
+
+	json.NewEncoder(w).Encode(code)
+}
+
+// GetLink godoc
+// @Summary Админ: привязанный аккаунт Telegram
+// @Tags telegram
+// @Produce json
+// @Success 200 {object} model.Link
+// @Failure 401,404,500 {object} map[string]string
+// @Router /api/admin/telegram/link [get]
+func (h *Handler) GetLink(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	link, err := h.linkService.GetLink(r.Context(), user.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения привязки")
+		return
+	}
+	if link == nil {
+		respondWithError(w, http.StatusNotFound, model.ErrNotLinked.Error())
+		return
+	}
+
+	json.NewEncoder(w).Encode(link)
+}
+
+// Unlink godoc
+// @Summary Админ: отвязать аккаунт Telegram
+// @Tags telegram
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Failure 401,404,500 {object} map[string]string
+// @Router /api/admin/telegram/unlink [post]
+func (h *Handler) Unlink(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	user := middleware.GetUserFromContext(r.Context())
+	if err := h.linkService.Unlink(r.Context(), user.ID); err != nil {
+		respondWithServiceError(w, err, "Ошибка отвязки аккаунта")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Аккаунт Telegram отвязан"})
+}
+
+func respondWithServiceError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, model.ErrNotStaff):
+		respondWithError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, model.ErrNotLinked):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, model.ErrBotDisabled):
+		respondWithError(w, http.StatusServiceUnavailable, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+func respondWithError(w http.ResponseWriter, code int, message string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,39 @@
+package telegramhttp
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
+	"github.com/Vovarama1992/go-utils/httputil"
+)
+
+// Права на заявки проверяет сервис: привязка нужна сотрудникам и с правом
+// на депозиты, и с правом на выводы
+func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService ports.UserServiceInterface, sessions middleware.SessionChecker) {
+	withRecover := func(h http.Handler) http.Handler {
+		return httputil.RecoverMiddleware(h)
+	}
+
+	withRecoverAndRateLimit := func(h http.Handler) http.Handler {
+		return httputil.RecoverMiddleware(httputil.NewRateLimiter(10, time.Minute)(h))
+	}
+
+	withUserAuth := func(h http.Handler) http.Handler {
+		return middleware.AuthMiddleware(userService, sessions)(h)
+	}
+
+	// === ADMIN ===
+	mux.Handle("/api/admin/telegram/link-code",
+		withRecoverAndRateLimit(withUserAuth(http.HandlerFunc(handler.CreateLinkCode))),
+	)
+
+	mux.Handle("/api/admin/telegram/link",
+		withRecover(withUserAuth(http.HandlerFunc(handler.GetLink))),
+	)
+
+	mux.Handle("/api/admin/telegram/unlink",
+		withRecover(withUserAuth(http.HandlerFunc(handler.Unlink))),
+	)
+}
@@ -0,0 +1,145 @@
+package telegram_infra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	model "github.com/Vovarama1992/emelya-go/internal/telegram/model"
+)
+
+const DefaultAPIURL = "https://api.telegram.org"
+
+// BotClient — клиент Telegram Bot API. Адрес API настраивается, чтобы бота
+// можно было гонять против локальной заглушки.
+type BotClient struct {
+	apiURL string
+	token  string
+	client *http.Client
+}
+
+// NewBotClient — pollTimeout задаёт запас HTTP-таймаута над long polling
+func NewBotClient(apiURL, token string, pollTimeout time.Duration) *BotClient {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	return &BotClient{
+		apiURL: strings.TrimRight(apiURL, "/"),
+		token:  token,
+		client: &http.Client{Timeout: pollTimeout + 10*time.Second},
+	}
+}
+
+// APIError — Bot API ответил ok=false
+type APIError struct {
+	Method      string
+	Code        int
+	Description string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("ошибка Telegram %s (%d): %s", e.Method, e.Code, e.Description)
+}
+
+func (c *BotClient) call(ctx context.Context, method string, params any, result any) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/bot%s/%s", c.apiURL, c.token, method)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("ошибка создания запроса: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// Ошибка содержит URL с токеном — в лог её не пропускаем
+		return fmt.Errorf("ошибка запроса %s в Telegram", method)
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		OK          bool            `json:"ok"`
+		ErrorCode   int             `json:"error_code"`
+		Description string          `json:"description"`
+		Result      json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return fmt.Errorf("некорректный ответ Telegram на %s: %s", method, resp.Status)
+	}
+	if !envelope.OK {
+		return &APIError{Method: method, Code: envelope.ErrorCode, Description: envelope.Description}
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(envelope.Result, result)
+}
+
+func (c *BotClient) GetUpdates(ctx context.Context, offset int64, timeoutSec int) ([]model.Update, error) {
+	var updates []model.Update
+	err := c.call(ctx, "getUpdates", map[string]any{
+		"offset":          offset,
+		"timeout":         timeoutSec,
+		"allowed_updates": []string{"message", "callback_query"},
+	}, &updates)
+	return updates, err
+}
+
+func (c *BotClient) SendMessage(ctx context.Context, msg model.OutgoingMessage) (*model.Message, error) {
+	params := map[string]any{
+		"chat_id": msg.ChatID,
+		"text":    msg.Text,
+	}
+	switch {
+	case len(msg.Keyboard) > 0:
+		params["reply_markup"] = map[string]any{"inline_keyboard": msg.Keyboard}
+	case msg.ForceReply:
+		params["reply_markup"] = map[string]any{"force_reply": true}
+	}
+
+	var sent model.Message
+	if err := c.call(ctx, "sendMessage", params, &sent); err != nil {
+		return nil, err
+	}
+	return &sent, nil
+}
+
+// EditMessage меняет текст; пустая клавиатура снимает кнопки
+func (c *BotClient) EditMessage(ctx context.Context, chatID, messageID int64, text string, keyboard model.InlineKeyboard) error {
+	if keyboard == nil {
+		keyboard = model.InlineKeyboard{}
+	}
+	err := c.call(ctx, "editMessageText", map[string]any{
+		"chat_id":      chatID,
+		"message_id":   messageID,
+		"text":         text,
+		"reply_markup": map[string]any{"inline_keyboard": keyboard},
+	}, nil)
+
+	// Повторная правка тем же текстом — не ошибка
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && strings.Contains(apiErr.Description, "message is not modified") {
+		return nil
+	}
+	return err
+}
+
+func (c *BotClient) AnswerCallback(ctx context.Context, callbackID, text string, alert bool) error {
+	return c.call(ctx, "answerCallbackQuery", map[string]any{
+		"callback_query_id": callbackID,
+		"text":              text,
+		"show_alert":        alert,
+	}, nil)
+}
@@ -0,0 +1,95 @@
+package telegram_infra
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	model "github.com/Vovarama1992/emelya-go/internal/telegram/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type LinkRepository struct {
+	DB *db.DB
+}
+
+func NewLinkRepository(db *db.DB) *LinkRepository {
+	return &LinkRepository{DB: db}
+}
+
+const linkColumns = `user_id, telegram_user_id, chat_id, username, linked_at`
+
+func scanLink(row pgx.Row) (*model.Link, error) {
+	var l model.Link
+	if err := row.Scan(&l.UserID, &l.TelegramUserID, &l.ChatID, &l.Username, &l.LinkedAt); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// GetByUserID — nil, если аккаунт не привязан
+func (r *LinkRepository) GetByUserID(ctx context.Context, userID int64) (*model.Link, error) {
+	l, err := scanLink(r.DB.Pool.QueryRow(ctx, `SELECT `+linkColumns+` FROM telegram_links WHERE user_id = $1`, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return l, err
+}
+
+// GetByTelegramUserID — nil, если аккаунт Telegram ни к кому не привязан
+func (r *LinkRepository) GetByTelegramUserID(ctx context.Context, telegramUserID int64) (*model.Link, error) {
+	l, err := scanLink(r.DB.Pool.QueryRow(ctx, `SELECT `+linkColumns+` FROM telegram_links WHERE telegram_user_id = $1`, telegramUserID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return l, err
+}
+
+func (r *LinkRepository) FindByUserIDs(ctx context.Context, userIDs []int64) ([]*model.Link, error) {
+	rows, err := r.DB.Pool.Query(ctx, `SELECT `+linkColumns+` FROM telegram_links WHERE user_id = ANY($1)`, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*model.Link
+	for rows.Next() {
+		l, err := scanLink(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, l)
+	}
+	return list, rows.Err()
+}
+
+// Save привязывает аккаунт заново, заменяя прежнюю привязку сотрудника.
+// Аккаунт Telegram, привязанный к другому сотруднику, — ErrLinkedElsewhere.
+func (r *LinkRepository) Save(ctx context.Context, l *model.Link) error {
+	err := r.DB.Pool.QueryRow(ctx, `
+		INSERT INTO telegram_links (user_id, telegram_user_id, chat_id, username)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET telegram_user_id = EXCLUDED.telegram_user_id,
+		    chat_id = EXCLUDED.chat_id,
+		    username = EXCLUDED.username,
+		    linked_at = now()
+		RETURNING linked_at
+	`, l.UserID, l.TelegramUserID, l.ChatID, l.Username).Scan(&l.LinkedAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return model.ErrLinkedElsewhere
+	}
+	return err
+}
+
+// Delete — false, если привязки не было
+func (r *LinkRepository) Delete(ctx context.Context, userID int64) (bool, error) {
+	tag, err := r.DB.Pool.Exec(ctx, `DELETE FROM telegram_links WHERE user_id = $1`, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,64 @@
+package telegram_infra
+
+import (
+	"context"
+
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	model "github.com/Vovarama1992/emelya-go/internal/telegram/model"
+)
+
+type PostRepository struct {
+	DB *db.DB
+}
+
+func NewPostRepository(db *db.DB) *PostRepository {
+	return &PostRepository{DB: db}
+}
+
+func (r *PostRepository) Exists(ctx context.Context, eventID, chatID int64) (bool, error) {
+	var exists bool
+	err := r.DB.Pool.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM telegram_posts WHERE event_id = $1 AND chat_id = $2)
+	`, eventID, chatID).Scan(&exists)
+	return exists, err
+}
+
+func (r *PostRepository) Create(ctx context.Context, p *model.Post) error {
+	_, err := r.DB.Pool.Exec(ctx, `
+		INSERT INTO telegram_posts (event_id, chat_id, message_id, entity_type, entity_id, text)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (event_id, chat_id) DO NOTHING
+	`, p.EventID, p.ChatID, p.MessageID, p.EntityType, p.EntityID, p.Text)
+	return err
+}
+
+// FindOpen — сообщения о заявке, на которых ещё висят кнопки
+func (r *PostRepository) FindOpen(ctx context.Context, entityType string, entityID int64) ([]*model.Post, error) {
+	rows, err := r.DB.Pool.Query(ctx, `
+		SELECT event_id, chat_id, message_id, entity_type, entity_id, text, closed_at
+		FROM telegram_posts
+		WHERE entity_type = $1 AND entity_id = $2 AND closed_at IS NULL
+	`, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*model.Post
+	for rows.Next() {
+		var p model.Post
+		if err := rows.Scan(&p.EventID, &p.ChatID, &p.MessageID, &p.EntityType, &p.EntityID, &p.Text, &p.ClosedAt); err != nil {
+			return nil, err
+		}
+		list = append(list, &p)
+	}
+	return list, rows.Err()
+}
+
+func (r *PostRepository) Close(ctx context.Context, p *model.Post) error {
+	_, err := r.DB.Pool.Exec(ctx, `
+		UPDATE telegram_posts SET closed_at = now()
+		WHERE event_id = $1 AND chat_id = $2
+	`, p.EventID, p.ChatID)
+	return err
+}
@@ -0,0 +1,51 @@
+package telegram_model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrBotDisabled     = errors.New("Telegram-бот не подключён")
+	ErrNotStaff        = errors.New("привязка Telegram доступна только сотрудникам, обрабатывающим заявки")
+	ErrLinkCodeInvalid = errors.New("код привязки недействителен или устарел")
+	ErrNotLinked       = errors.New("аккаунт Telegram не привязан")
+	ErrLinkedElsewhere = errors.New("этот аккаунт Telegram уже привязан к другому сотруднику")
+)
+
+// Срок жизни кода привязки из личного кабинета
+const LinkCodeTTL = 10 * time.Minute
+
+// Link — аккаунт сотрудника, привязанный к Telegram
+type Link struct {
+	UserID         int64     `json:"user_id"`
+	TelegramUserID int64     `json:"telegram_user_id"`
+	ChatID         int64     `json:"chat_id"`
+	Username       string    `json:"username"`
+	LinkedAt       time.Time `json:"linked_at"`
+}
+
+// LinkCode — одноразовый код: сотрудник отправляет боту /start <code>
+type LinkCode struct {
+	Code string `json:"code"`
+	// Ссылка на бота с кодом, если задан TELEGRAM_BOT_USERNAME
+	URL       string    `json:"url,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Заявки, о которых бот пишет сотрудникам
+const (
+	EntityDeposit    = "deposit"
+	EntityWithdrawal = "withdrawal"
+)
+
+// Post — сообщение бота о заявке в чате сотрудника
+type Post struct {
+	EventID    int64
+	ChatID     int64
+	MessageID  int64
+	EntityType string
+	EntityID   int64
+	Text       string
+	ClosedAt   *time.Time
+}
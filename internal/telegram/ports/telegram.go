@@ -0,0 +1,70 @@
+package telegram_ports
+
+import (
+	"context"
+
+	approval_model "github.com/Vovarama1992/emelya-go/internal/money/approval/model"
+	deposit_model "github.com/Vovarama1992/emelya-go/internal/money/deposit/model"
+	tariff_model "github.com/Vovarama1992/emelya-go/internal/money/tariff/model"
+	withdrawal_model "github.com/Vovarama1992/emelya-go/internal/money/withdrawal/model"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	model "github.com/Vovarama1992/emelya-go/internal/telegram/model"
+	user "github.com/Vovarama1992/emelya-go/internal/user/model"
+)
+
+type LinkRepository interface {
+	GetByUserID(ctx context.Context, userID int64) (*model.Link, error)
+	GetByTelegramUserID(ctx context.Context, telegramUserID int64) (*model.Link, error)
+	FindByUserIDs(ctx context.Context, userIDs []int64) ([]*model.Link, error)
+	Save(ctx context.Context, link *model.Link) error
+	Delete(ctx context.Context, userID int64) (bool, error)
+}
+
+type PostRepository interface {
+	Exists(ctx context.Context, eventID, chatID int64) (bool, error)
+	Create(ctx context.Context, p *model.Post) error
+	FindOpen(ctx context.Context, entityType string, entityID int64) ([]*model.Post, error)
+	Close(ctx context.Context, p *model.Post) error
+}
+
+// BotAPI — методы Telegram Bot API, которыми пользуется бот
+type BotAPI interface {
+	GetUpdates(ctx context.Context, offset int64, timeoutSec int) ([]model.Update, error)
+	SendMessage(ctx context.Context, msg model.OutgoingMessage) (*model.Message, error)
+	EditMessage(ctx context.Context, chatID, messageID int64, text string, keyboard model.InlineKeyboard) error
+	AnswerCallback(ctx context.Context, callbackID, text string, alert bool) error
+}
+
+// Access — права сотрудников из RBAC
+type Access interface {
+	HasPermission(ctx context.Context, role user.UserRole, perm rbac.Permission) bool
+	UsersWithPermission(ctx context.Context, perm rbac.Permission) ([]int64, error)
+}
+
+// Решения по заявкам идут через те же сервисы, что и из админки,
+// включая подтверждение вторым администратором выше порога
+
+type DepositService interface {
+	GetDepositByID(ctx context.Context, id int64) (*deposit_model.Deposit, error)
+	RejectDeposit(ctx context.Context, depositID, actorID int64, reason string) error
+}
+
+type WithdrawalService interface {
+	GetWithdrawalByID(ctx context.Context, withdrawalID int64) (*withdrawal_model.Withdrawal, error)
+	RejectWithdrawal(ctx context.Context, withdrawalID, actorID int64, reason string) error
+}
+
+type ApprovalService interface {
+	SubmitDepositApprove(ctx context.Context, p approval_model.DepositApproveParams, actorID int64) (*approval_model.Approval, error)
+	SubmitWithdrawalApprove(ctx context.Context, withdrawalID, actorID int64) (*approval_model.Approval, error)
+}
+
+type TariffService interface {
+	GetAll(ctx context.Context) ([]tariff_model.Tariff, error)
+}
+
+type LinkService interface {
+	CreateLinkCode(ctx context.Context, userID int64) (*model.LinkCode, error)
+	GetLink(ctx context.Context, userID int64) (*model.Link, error)
+	Unlink(ctx context.Context, userID int64) error
+}
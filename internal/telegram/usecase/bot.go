@@ -0,0 +1,480 @@
+package telegram_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	approval_model "github.com/Vovarama1992/emelya-go/internal/money/approval/model"
+	deposit_model "github.com/Vovarama1992/emelya-go/internal/money/deposit/model"
+	money "github.com/Vovarama1992/emelya-go/internal/money/usecase"
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	model "github.com/Vovarama1992/emelya-go/internal/telegram/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/telegram/ports"
+	user_model "github.com/Vovarama1992/emelya-go/internal/user/model"
+	user_ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// Пауза после ошибки getUpdates, чтобы не долбить API
+	pollRetryDelay = 5 * time.Second
+	updateTimeout  = 15 * time.Second
+	// Сколько ждём ответа с причиной отказа
+	reasonTTL = time.Hour
+)
+
+var errNoPermission = errors.New("недостаточно прав для этого действия")
+
+// Ошибки сервисов, текст которых можно показать оператору как есть
+var operatorErrors = []error{
+	model.ErrNotLinked,
+	model.ErrNotStaff,
+	model.ErrLinkCodeInvalid,
+	model.ErrLinkedElsewhere,
+	errNoPermission,
+	money.ErrDepositNotFound,
+	money.ErrDepositAlreadyProcessed,
+	money.ErrDepositInvalidTransition,
+	money.ErrKYCLimitExceeded,
+	money.ErrNotFound,
+	money.ErrAlreadyProcessed,
+	money.ErrInvalidTransition,
+	money.ErrInsufficientFunds,
+	money.ErrApprovalDuplicate,
+}
+
+// В ключе — сообщение бота с вопросом о причине, в значении — заявка
+func reasonKey(chatID, messageID int64) string {
+	return fmt.Sprintf("telegram_reject_reason:%d:%d", chatID, messageID)
+}
+
+// Bot — Telegram-бот для операторов: присылает новые заявки на депозит и вывод
+// с кнопками решения. Действия принимаются только от привязанных аккаунтов
+// сотрудников и проверяются по правам RBAC на каждом нажатии.
+type Bot struct {
+	api               ports.BotAPI
+	links             ports.LinkRepository
+	posts             ports.PostRepository
+	linkService       *LinkService
+	userService       user_ports.UserServiceInterface
+	access            ports.Access
+	depositService    ports.DepositService
+	withdrawalService ports.WithdrawalService
+	approvalService   ports.ApprovalService
+	tariffService     ports.TariffService
+	notifier          notifier.NotifierInterface
+	redis             *redis.Client
+	cfg               BotConfig
+}
+
+type BotDeps struct {
+	API               ports.BotAPI
+	Links             ports.LinkRepository
+	Posts             ports.PostRepository
+	LinkService       *LinkService
+	UserService       user_ports.UserServiceInterface
+	Access            ports.Access
+	DepositService    ports.DepositService
+	WithdrawalService ports.WithdrawalService
+	ApprovalService   ports.ApprovalService
+	TariffService     ports.TariffService
+	Notifier          notifier.NotifierInterface
+	Redis             *redis.Client
+}
+
+func NewBot(deps BotDeps, cfg BotConfig) *Bot {
+	return &Bot{
+		api:               deps.API,
+		links:             deps.Links,
+		posts:             deps.Posts,
+		linkService:       deps.LinkService,
+		userService:       deps.UserService,
+		access:            deps.Access,
+		depositService:    deps.DepositService,
+		withdrawalService: deps.WithdrawalService,
+		approvalService:   deps.ApprovalService,
+		tariffService:     deps.TariffService,
+		notifier:          deps.Notifier,
+		redis:             deps.Redis,
+		cfg:               cfg,
+	}
+}
+
+// Start читает обновления long polling в фоне до отмены ctx
+func (b *Bot) Start(ctx context.Context) {
+	go func() {
+		timeoutSec := int(b.cfg.PollTimeout / time.Second)
+		var offset int64
+		for ctx.Err() == nil {
+			updates, err := b.api.GetUpdates(ctx, offset, timeoutSec)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				log.Printf("[TELEGRAM] Ошибка получения обновлений: %v", err)
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(pollRetryDelay):
+				}
+				continue
+			}
+			for _, u := range updates {
+				offset = u.UpdateID + 1
+				b.handleUpdate(ctx, u)
+			}
+		}
+	}()
+}
+
+func (b *Bot) handleUpdate(ctx context.Context, u model.Update) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[TELEGRAM] Паника при обработке обновления %d: %v", u.UpdateID, r)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	switch {
+	case u.CallbackQuery != nil:
+		b.handleCallback(ctx, u.CallbackQuery)
+	case u.Message != nil && u.Message.From != nil && u.Message.Chat.Type == model.ChatPrivate:
+		b.handleMessage(ctx, u.Message)
+	}
+}
+
+func (b *Bot) handleMessage(ctx context.Context, msg *model.Message) {
+	text := strings.TrimSpace(msg.Text)
+	switch {
+	case text == "/start" || strings.HasPrefix(text, "/start "):
+		code := strings.TrimSpace(strings.TrimPrefix(text, "/start"))
+		if code == "" {
+			b.reply(ctx, msg.Chat.ID, "Чтобы получать заявки, получите код привязки в админке и отправьте его командой /start <код>.")
+			return
+		}
+		link, err := b.linkService.Redeem(ctx, code, *msg.From, msg.Chat.ID)
+		if err != nil {
+			b.reply(ctx, msg.Chat.ID, b.errorText(err))
+			return
+		}
+		log.Printf("[TELEGRAM] Сотрудник %d привязал аккаунт Telegram %d", link.UserID, link.TelegramUserID)
+		b.reply(ctx, msg.Chat.ID, "Аккаунт привязан. Новые заявки будут приходить сюда. Отвязать — /stop.")
+
+	case text == "/stop":
+		if err := b.linkService.UnlinkTelegram(ctx, msg.From.ID); err != nil {
+			b.reply(ctx, msg.Chat.ID, b.errorText(err))
+			return
+		}
+		b.reply(ctx, msg.Chat.ID, "Аккаунт отвязан, заявки больше не приходят.")
+
+	case msg.ReplyToMessage != nil:
+		b.handleReason(ctx, msg)
+
+	default:
+		b.reply(ctx, msg.Chat.ID, "Бот присылает заявки на депозит и вывод. Решение — кнопками под заявкой.")
+	}
+}
+
+// Данные кнопок: <заявка>:<действие>:<id>[:<тариф>]
+const (
+	cbDeposit    = "dep"
+	cbWithdrawal = "wd"
+
+	cbApprove = "ok"
+	cbReject  = "no"
+	cbTariff  = "tariff"
+	cbBack    = "back"
+)
+
+func requestKeyboard(kind string, id int64) model.InlineKeyboard {
+	return model.InlineKeyboard{{
+		{Text: "Одобрить", CallbackData: fmt.Sprintf("%s:%s:%d", kind, cbApprove, id)},
+		{Text: "Отклонить", CallbackData: fmt.Sprintf("%s:%s:%d", kind, cbReject, id)},
+	}}
+}
+
+func (b *Bot) handleCallback(ctx context.Context, cq *model.CallbackQuery) {
+	parts := strings.Split(cq.Data, ":")
+	var id int64
+	if len(parts) >= 3 {
+		id, _ = strconv.ParseInt(parts[2], 10, 64)
+	}
+	if id <= 0 || cq.Message == nil {
+		b.answer(ctx, cq, "Неизвестная команда", true)
+		return
+	}
+
+	var err error
+	switch parts[0] + ":" + parts[1] {
+	case cbDeposit + ":" + cbApprove:
+		err = b.chooseTariff(ctx, cq, id)
+	case cbDeposit + ":" + cbTariff:
+		var tariffID int64
+		if len(parts) == 4 {
+			tariffID, _ = strconv.ParseInt(parts[3], 10, 64)
+		}
+		if tariffID <= 0 {
+			b.answer(ctx, cq, "Неизвестный тариф", true)
+			return
+		}
+		err = b.approveDeposit(ctx, cq, id, tariffID)
+	case cbDeposit + ":" + cbBack:
+		if err = b.api.EditMessage(ctx, cq.Message.Chat.ID, cq.Message.MessageID, cq.Message.Text, requestKeyboard(cbDeposit, id)); err == nil {
+			b.answer(ctx, cq, "", false)
+		}
+	case cbDeposit + ":" + cbReject:
+		err = b.askReason(ctx, cq, model.EntityDeposit, id)
+	case cbWithdrawal + ":" + cbApprove:
+		err = b.approveWithdrawal(ctx, cq, id)
+	case cbWithdrawal + ":" + cbReject:
+		err = b.askReason(ctx, cq, model.EntityWithdrawal, id)
+	default:
+		b.answer(ctx, cq, "Неизвестная команда", true)
+		return
+	}
+
+	if err != nil {
+		b.answer(ctx, cq, b.errorText(err), true)
+		b.closeIfProcessed(ctx, err, parts[0], id)
+	}
+}
+
+// chooseTariff — одобрение депозита требует тарифа: меняем кнопки на список тарифов
+func (b *Bot) chooseTariff(ctx context.Context, cq *model.CallbackQuery, depositID int64) error {
+	if _, err := b.actor(ctx, cq.From.ID, rbac.PermDepositsManage); err != nil {
+		return err
+	}
+	if err := b.checkDepositPending(ctx, depositID); err != nil {
+		return err
+	}
+	tariffs, err := b.tariffService.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	if len(tariffs) == 0 {
+		b.answer(ctx, cq, "Нет ни одного тарифа — одобрите депозит в админке", true)
+		return nil
+	}
+
+	keyboard := make(model.InlineKeyboard, 0, len(tariffs)+1)
+	for _, t := range tariffs {
+		keyboard = append(keyboard, []model.InlineKeyboardButton{{
+			Text:         t.Name,
+			CallbackData: fmt.Sprintf("%s:%s:%d:%d", cbDeposit, cbTariff, depositID, t.ID),
+		}})
+	}
+	keyboard = append(keyboard, []model.InlineKeyboardButton{{
+		Text:         "Назад",
+		CallbackData: fmt.Sprintf("%s:%s:%d", cbDeposit, cbBack, depositID),
+	}})
+
+	if err := b.api.EditMessage(ctx, cq.Message.Chat.ID, cq.Message.MessageID, cq.Message.Text, keyboard); err != nil {
+		return err
+	}
+	b.answer(ctx, cq, "Выберите тариф", false)
+	return nil
+}
+
+func (b *Bot) approveDeposit(ctx context.Context, cq *model.CallbackQuery, depositID, tariffID int64) error {
+	actor, err := b.actor(ctx, cq.From.ID, rbac.PermDepositsManage)
+	if err != nil {
+		return err
+	}
+	approval, err := b.approvalService.SubmitDepositApprove(ctx, approval_model.DepositApproveParams{
+		DepositID:  depositID,
+		ApprovedAt: time.Now(),
+		TariffID:   &tariffID,
+	}, actor.ID)
+	if err != nil {
+		return err
+	}
+	b.decided(ctx, cq, model.EntityDeposit, depositID, actor, approval, "Депозит одобрен")
+	return nil
+}
+
+func (b *Bot) approveWithdrawal(ctx context.Context, cq *model.CallbackQuery, withdrawalID int64) error {
+	actor, err := b.actor(ctx, cq.From.ID, rbac.PermWithdrawalsManage)
+	if err != nil {
+		return err
+	}
+	approval, err := b.approvalService.SubmitWithdrawalApprove(ctx, withdrawalID, actor.ID)
+	if err != nil {
+		return err
+	}
+	b.decided(ctx, cq, model.EntityWithdrawal, withdrawalID, actor, approval, "Вывод одобрен")
+	return nil
+}
+
+// decided снимает кнопки у всех сообщений о заявке. Выше порога операция
+// ждёт второго администратора — об этом и пишем.
+func (b *Bot) decided(ctx context.Context, cq *model.CallbackQuery, entityType string, id int64, actor *user_model.User, approval *approval_model.Approval, done string) {
+	status := fmt.Sprintf("%s: %s", done, actorName(actor))
+	if approval != nil {
+		status = fmt.Sprintf("Ждёт подтверждения второго администратора (заявка на подтверждение #%d, отправил %s)", approval.ID, actorName(actor))
+	}
+	b.answer(ctx, cq, status, approval != nil)
+	if err := b.closePosts(ctx, entityType, id, status); err != nil {
+		log.Printf("[TELEGRAM] Не удалось обновить сообщения о %s %d: %v", entityType, id, err)
+	}
+}
+
+// askReason просит причину отказа ответом на сообщение бота
+func (b *Bot) askReason(ctx context.Context, cq *model.CallbackQuery, entityType string, id int64) error {
+	perm := rbac.PermDepositsManage
+	what := "депозиту"
+	if entityType == model.EntityWithdrawal {
+		perm = rbac.PermWithdrawalsManage
+		what = "заявке на вывод"
+	}
+	if _, err := b.actor(ctx, cq.From.ID, perm); err != nil {
+		return err
+	}
+
+	prompt, err := b.api.SendMessage(ctx, model.OutgoingMessage{
+		ChatID:     cq.Message.Chat.ID,
+		Text:       fmt.Sprintf("Причина отказа по %s #%d — ответьте на это сообщение.", what, id),
+		ForceReply: true,
+	})
+	if err != nil {
+		return err
+	}
+	value := fmt.Sprintf("%s:%d", entityType, id)
+	if err := b.redis.Set(ctx, reasonKey(prompt.Chat.ID, prompt.MessageID), value, reasonTTL).Err(); err != nil {
+		return err
+	}
+	b.answer(ctx, cq, "", false)
+	return nil
+}
+
+func (b *Bot) handleReason(ctx context.Context, msg *model.Message) {
+	key := reasonKey(msg.Chat.ID, msg.ReplyToMessage.MessageID)
+	value, err := b.redis.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		b.reply(ctx, msg.Chat.ID, "Этот вопрос устарел — нажмите «Отклонить» под заявкой ещё раз.")
+		return
+	}
+	if err != nil {
+		b.reply(ctx, msg.Chat.ID, b.errorText(err))
+		return
+	}
+
+	reason := strings.TrimSpace(msg.Text)
+	if reason == "" {
+		b.reply(ctx, msg.Chat.ID, "Причина не может быть пустой — ответьте на вопрос текстом.")
+		return
+	}
+	entityType, idStr, _ := strings.Cut(value, ":")
+	id, _ := strconv.ParseInt(idStr, 10, 64)
+
+	perm := rbac.PermDepositsManage
+	if entityType == model.EntityWithdrawal {
+		perm = rbac.PermWithdrawalsManage
+	}
+	actor, err := b.actor(ctx, msg.From.ID, perm)
+	if err == nil {
+		if entityType == model.EntityWithdrawal {
+			err = b.withdrawalService.RejectWithdrawal(ctx, id, actor.ID, reason)
+		} else {
+			err = b.depositService.RejectDeposit(ctx, id, actor.ID, reason)
+		}
+	}
+	if err != nil {
+		b.reply(ctx, msg.Chat.ID, b.errorText(err))
+		kind := cbDeposit
+		if entityType == model.EntityWithdrawal {
+			kind = cbWithdrawal
+		}
+		b.closeIfProcessed(ctx, err, kind, id)
+		return
+	}
+
+	b.redis.Del(ctx, key)
+	status := fmt.Sprintf("Отклонено: %s. Причина: %s", actorName(actor), reason)
+	if err := b.closePosts(ctx, entityType, id, status); err != nil {
+		log.Printf("[TELEGRAM] Не удалось обновить сообщения о %s %d: %v", entityType, id, err)
+	}
+	b.reply(ctx, msg.Chat.ID, "Заявка отклонена.")
+}
+
+// actor — сотрудник, привязанный к аккаунту Telegram, если у него есть право
+func (b *Bot) actor(ctx context.Context, telegramUserID int64, perm rbac.Permission) (*user_model.User, error) {
+	link, err := b.links.GetByTelegramUserID(ctx, telegramUserID)
+	if err != nil {
+		return nil, err
+	}
+	if link == nil {
+		return nil, model.ErrNotLinked
+	}
+	u, err := b.userService.FindUserByID(ctx, link.UserID)
+	if err != nil || u == nil {
+		return nil, model.ErrNotLinked
+	}
+	if !u.Role.IsStaff() || !b.access.HasPermission(ctx, u.Role, perm) {
+		return nil, errNoPermission
+	}
+	return u, nil
+}
+
+func (b *Bot) checkDepositPending(ctx context.Context, depositID int64) error {
+	d, err := b.depositService.GetDepositByID(ctx, depositID)
+	if err != nil || d == nil {
+		return money.ErrDepositNotFound
+	}
+	if d.Status != deposit_model.StatusPending {
+		return money.ErrDepositAlreadyProcessed
+	}
+	return nil
+}
+
+// closeIfProcessed — заявку уже обработали в админке или отменил пользователь:
+// кнопки больше не нужны
+func (b *Bot) closeIfProcessed(ctx context.Context, err error, kind string, id int64) {
+	if !errors.Is(err, money.ErrDepositAlreadyProcessed) && !errors.Is(err, money.ErrAlreadyProcessed) {
+		return
+	}
+	entityType := model.EntityDeposit
+	if kind == cbWithdrawal {
+		entityType = model.EntityWithdrawal
+	}
+	if err := b.closePosts(ctx, entityType, id, "Заявка уже обработана"); err != nil {
+		log.Printf("[TELEGRAM] Не удалось обновить сообщения о %s %d: %v", entityType, id, err)
+	}
+}
+
+func (b *Bot) errorText(err error) string {
+	for _, known := range operatorErrors {
+		if errors.Is(err, known) {
+			return known.Error()
+		}
+	}
+	log.Printf("[TELEGRAM] Ошибка обработки действия: %v", err)
+	return "Не удалось выполнить действие, попробуйте в админке"
+}
+
+func (b *Bot) reply(ctx context.Context, chatID int64, text string) {
+	if _, err := b.api.SendMessage(ctx, model.OutgoingMessage{ChatID: chatID, Text: text}); err != nil {
+		log.Printf("[TELEGRAM] Не удалось ответить в чат %d: %v", chatID, err)
+	}
+}
+
+func (b *Bot) answer(ctx context.Context, cq *model.CallbackQuery, text string, alert bool) {
+	if err := b.api.AnswerCallback(ctx, cq.ID, text, alert); err != nil {
+		log.Printf("[TELEGRAM] Не удалось ответить на нажатие: %v", err)
+	}
+}
+
+func actorName(u *user_model.User) string {
+	name := strings.TrimSpace(u.FirstName + " " + u.LastName)
+	if name == "" {
+		return fmt.Sprintf("сотрудник #%d", u.ID)
+	}
+	return name
+}
@@ -0,0 +1,35 @@
+package telegram_usecase
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// BotConfig — бот для операторов. Включается на одном инстансе:
+// Telegram не даёт двум процессам одновременно читать getUpdates.
+type BotConfig struct {
+	Enabled     bool
+	Token       string
+	APIURL      string
+	Username    string
+	PollTimeout time.Duration
+}
+
+const defaultPollTimeout = 25 * time.Second
+
+// LoadBotConfigFromEnv — TELEGRAM_BOT_ENABLED, TELEGRAM_BOT_TOKEN, TELEGRAM_API_URL,
+// TELEGRAM_BOT_USERNAME (для ссылки привязки) и TELEGRAM_POLL_TIMEOUT в секундах
+func LoadBotConfigFromEnv() BotConfig {
+	cfg := BotConfig{
+		Token:       os.Getenv("TELEGRAM_BOT_TOKEN"),
+		APIURL:      os.Getenv("TELEGRAM_API_URL"),
+		Username:    os.Getenv("TELEGRAM_BOT_USERNAME"),
+		PollTimeout: defaultPollTimeout,
+	}
+	cfg.Enabled = os.Getenv("TELEGRAM_BOT_ENABLED") == "true" && cfg.Token != ""
+	if n, err := strconv.Atoi(os.Getenv("TELEGRAM_POLL_TIMEOUT")); err == nil && n > 0 {
+		cfg.PollTimeout = time.Duration(n) * time.Second
+	}
+	return cfg
+}
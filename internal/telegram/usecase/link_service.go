@@ -0,0 +1,179 @@
+package telegram_usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	audit "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	audit_ports "github.com/Vovarama1992/emelya-go/internal/audit/ports"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	model "github.com/Vovarama1992/emelya-go/internal/telegram/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/telegram/ports"
+	user_ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+	"github.com/redis/go-redis/v9"
+)
+
+// Права, ради которых сотруднику нужен бот
+var botPermissions = []rbac.Permission{rbac.PermDepositsManage, rbac.PermWithdrawalsManage}
+
+func linkCodeKey(code string) string { return "telegram_link_code:" + code }
+
+// LinkService привязывает аккаунты сотрудников к Telegram через одноразовый код
+type LinkService struct {
+	repo         ports.LinkRepository
+	redis        *redis.Client
+	userService  user_ports.UserServiceInterface
+	access       ports.Access
+	auditService audit_ports.AuditService
+	cfg          BotConfig
+}
+
+func NewLinkService(
+	repo ports.LinkRepository,
+	redisClient *redis.Client,
+	userService user_ports.UserServiceInterface,
+	access ports.Access,
+	auditService audit_ports.AuditService,
+	cfg BotConfig,
+) *LinkService {
+	return &LinkService{
+		repo:         repo,
+		redis:        redisClient,
+		userService:  userService,
+		access:       access,
+		auditService: auditService,
+		cfg:          cfg,
+	}
+}
+
+// CreateLinkCode выдаёт код, который сотрудник отправляет боту командой /start
+func (s *LinkService) CreateLinkCode(ctx context.Context, userID int64) (*model.LinkCode, error) {
+	if !s.cfg.Enabled {
+		return nil, model.ErrBotDisabled
+	}
+	if !s.canUseBot(ctx, userID) {
+		return nil, model.ErrNotStaff
+	}
+
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	code := hex.EncodeToString(b)
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	if err := s.redis.Set(ctx, linkCodeKey(code), userID, model.LinkCodeTTL).Err(); err != nil {
+		return nil, err
+	}
+
+	lc := &model.LinkCode{Code: code, ExpiresAt: time.Now().Add(model.LinkCodeTTL)}
+	if s.cfg.Username != "" {
+		lc.URL = fmt.Sprintf("https://t.me/%s?start=%s", s.cfg.Username, code)
+	}
+	return lc, nil
+}
+
+// GetLink — nil, если аккаунт не привязан
+func (s *LinkService) GetLink(ctx context.Context, userID int64) (*model.Link, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	return s.repo.GetByUserID(ctx, userID)
+}
+
+func (s *LinkService) Unlink(ctx context.Context, userID int64) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	link, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if link == nil {
+		return model.ErrNotLinked
+	}
+	if _, err := s.repo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	s.record(ctx, &audit.Entry{
+		ActorID:    &userID,
+		Action:     audit.ActionTelegramUnlink,
+		EntityType: audit.EntityUser,
+		EntityID:   &userID,
+		Before:     audit.Snapshot(link),
+	})
+	return nil
+}
+
+// Redeem привязывает аккаунт Telegram по коду из /start. Код одноразовый.
+func (s *LinkService) Redeem(ctx context.Context, code string, from model.User, chatID int64) (*model.Link, error) {
+	userID, err := s.redis.GetDel(ctx, linkCodeKey(code)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return nil, model.ErrLinkCodeInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Права могли забрать, пока код ждал
+	if !s.canUseBot(ctx, userID) {
+		return nil, model.ErrNotStaff
+	}
+
+	link := &model.Link{
+		UserID:         userID,
+		TelegramUserID: from.ID,
+		ChatID:         chatID,
+		Username:       from.Username,
+	}
+	if err := s.repo.Save(ctx, link); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, &audit.Entry{
+		ActorID:    &userID,
+		Action:     audit.ActionTelegramLink,
+		EntityType: audit.EntityUser,
+		EntityID:   &userID,
+		After:      audit.Snapshot(link),
+	})
+	return link, nil
+}
+
+// UnlinkTelegram — сотрудник отвязывает аккаунт из самого бота (/stop)
+func (s *LinkService) UnlinkTelegram(ctx context.Context, telegramUserID int64) error {
+	link, err := s.repo.GetByTelegramUserID(ctx, telegramUserID)
+	if err != nil {
+		return err
+	}
+	if link == nil {
+		return model.ErrNotLinked
+	}
+	return s.Unlink(ctx, link.UserID)
+}
+
+func (s *LinkService) canUseBot(ctx context.Context, userID int64) bool {
+	u, err := s.userService.FindUserByID(ctx, userID)
+	if err != nil || u == nil || !u.Role.IsStaff() {
+		return false
+	}
+	for _, perm := range botPermissions {
+		if s.access.HasPermission(ctx, u.Role, perm) {
+			return true
+		}
+	}
+	return false
+}
+
+// record — сбой записи в журнал не отменяет привязку
+func (s *LinkService) record(ctx context.Context, e *audit.Entry) {
+	if err := s.auditService.Record(ctx, e); err != nil {
+		log.Printf("[TELEGRAM] Не удалось записать журнал %s: %v", e.Action, err)
+	}
+}
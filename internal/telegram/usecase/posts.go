@@ -0,0 +1,155 @@
+package telegram_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	outbox "github.com/Vovarama1992/emelya-go/internal/outbox/model"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	model "github.com/Vovarama1992/emelya-go/internal/telegram/model"
+)
+
+// Events — бот присылает новые заявки и снимает кнопки, когда по заявке
+// приняли решение (в том числе из админки)
+func (b *Bot) Events() []outbox.EventType {
+	return []outbox.EventType{
+		outbox.EventDepositRequested,
+		outbox.EventDepositApproved,
+		outbox.EventWithdrawalRequested,
+		outbox.EventWithdrawalApproved,
+		outbox.EventWithdrawalRejected,
+	}
+}
+
+func (b *Bot) Handle(ctx context.Context, e *outbox.Event) error {
+	switch e.Type {
+	case outbox.EventDepositRequested:
+		var p outbox.DepositPayload
+		if err := e.DecodePayload(&p); err != nil {
+			return err
+		}
+		return b.postRequest(ctx, e.ID, model.EntityDeposit, p.DepositID, rbac.PermDepositsManage, notifier.TplOperatorDepositRequested, p)
+
+	case outbox.EventWithdrawalRequested:
+		var p outbox.WithdrawalPayload
+		if err := e.DecodePayload(&p); err != nil {
+			return err
+		}
+		return b.postRequest(ctx, e.ID, model.EntityWithdrawal, p.WithdrawalID, rbac.PermWithdrawalsManage, notifier.TplOperatorWithdrawalRequested, p)
+
+	case outbox.EventDepositApproved:
+		var p outbox.DepositPayload
+		if err := e.DecodePayload(&p); err != nil {
+			return err
+		}
+		return b.closePosts(ctx, model.EntityDeposit, p.DepositID, b.decisionText(ctx, "Депозит одобрен", p.ActorID, nil))
+
+	case outbox.EventWithdrawalApproved, outbox.EventWithdrawalRejected:
+		var p outbox.WithdrawalPayload
+		if err := e.DecodePayload(&p); err != nil {
+			return err
+		}
+		done := "Вывод одобрен"
+		if e.Type == outbox.EventWithdrawalRejected {
+			done = "Отклонено"
+		}
+		return b.closePosts(ctx, model.EntityWithdrawal, p.WithdrawalID, b.decisionText(ctx, done, p.ActorID, p.Reason))
+	}
+	return fmt.Errorf("неизвестный тип события %s", e.Type)
+}
+
+// postRequest присылает заявку с кнопками всем привязанным сотрудникам с правом
+// на её обработку. При повторе события уже получившие сообщение пропускаются.
+func (b *Bot) postRequest(ctx context.Context, eventID int64, entityType string, id int64, perm rbac.Permission, tpl string, data any) error {
+	r, err := b.notifier.Render(ctx, tpl, data)
+	if err != nil {
+		return err
+	}
+	msg := r.Message(notifier.ChannelTelegram, "")
+	text := r.Subject + "\n\n" + msg.Body
+
+	staffIDs, err := b.access.UsersWithPermission(ctx, perm)
+	if err != nil {
+		return err
+	}
+	if len(staffIDs) == 0 {
+		return nil
+	}
+	links, err := b.links.FindByUserIDs(ctx, staffIDs)
+	if err != nil {
+		return err
+	}
+
+	kind := cbDeposit
+	if entityType == model.EntityWithdrawal {
+		kind = cbWithdrawal
+	}
+
+	var errs []error
+	for _, link := range links {
+		posted, err := b.posts.Exists(ctx, eventID, link.ChatID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if posted {
+			continue
+		}
+
+		sent, err := b.api.SendMessage(ctx, model.OutgoingMessage{
+			ChatID:   link.ChatID,
+			Text:     text,
+			Keyboard: requestKeyboard(kind, id),
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("чат сотрудника %d: %w", link.UserID, err))
+			continue
+		}
+		if err := b.posts.Create(ctx, &model.Post{
+			EventID:    eventID,
+			ChatID:     link.ChatID,
+			MessageID:  sent.MessageID,
+			EntityType: entityType,
+			EntityID:   id,
+			Text:       text,
+		}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// closePosts дописывает решение ко всем сообщениям о заявке и снимает кнопки
+func (b *Bot) closePosts(ctx context.Context, entityType string, id int64, status string) error {
+	posts, err := b.posts.FindOpen(ctx, entityType, id)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, p := range posts {
+		if err := b.api.EditMessage(ctx, p.ChatID, p.MessageID, p.Text+"\n\n"+status, nil); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := b.posts.Close(ctx, p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (b *Bot) decisionText(ctx context.Context, done string, actorID *int64, reason *string) string {
+	text := done
+	if actorID != nil {
+		if u, err := b.userService.FindUserByID(ctx, *actorID); err == nil && u != nil {
+			text += ": " + actorName(u)
+		}
+	}
+	if reason != nil && *reason != "" {
+		text += ". Причина: " + *reason
+	}
+	return text
+}
@@ -1,11 +1,11 @@
 package user
 
+// Телефон и email меняются только через /api/auth/contacts/change с подтверждением кодами,
+// карты и счета для вывода — через /api/payout-methods
 type UpdateProfileRequest struct {
 	FirstName  *string `json:"first_name,omitempty" validate:"omitempty"`
 	LastName   *string `json:"last_name,omitempty" validate:"omitempty"`
 	Patronymic *string `json:"patronymic,omitempty" validate:"omitempty"`
-	Phone      *string `json:"phone,omitempty" validate:"omitempty,e164"`
-	CardNumber *string `json:"card_number,omitempty" validate:"omitempty"`
 }
 
 type AdminUpdateProfileRequest struct {
@@ -14,7 +14,15 @@ type AdminUpdateProfileRequest struct {
 	LastName   *string `json:"last_name,omitempty" validate:"omitempty"`
 	Patronymic *string `json:"patronymic,omitempty" validate:"omitempty"`
 	Phone      *string `json:"phone,omitempty" validate:"omitempty,e164"`
-	CardNumber *string `json:"card_number,omitempty" validate:"omitempty"`
+}
+
+type ProfileChangeDecisionRequest struct {
+	ID int64 `json:"id" validate:"required"`
+}
+
+type ProfileChangeRejectRequest struct {
+	ID     int64  `json:"id" validate:"required"`
+	Reason string `json:"reason" validate:"required,max=500"`
 }
 
 type AddReferralRequest struct {
@@ -2,16 +2,19 @@ package user
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"strconv"
 
-	"github.com/Vovarama1992/emelya-go/internal/jwtutil"
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
 	operation "github.com/Vovarama1992/emelya-go/internal/money/operation_model"
 	ports "github.com/Vovarama1992/emelya-go/internal/money/ports"
 	"github.com/Vovarama1992/emelya-go/internal/notifier"
 	model "github.com/Vovarama1992/emelya-go/internal/user/model"
 	user "github.com/Vovarama1992/emelya-go/internal/user/ports"
+	usecase "github.com/Vovarama1992/emelya-go/internal/user/usecase"
+	"github.com/Vovarama1992/emelya-go/internal/utils"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -36,15 +39,20 @@ func NewHandler(
 	}
 }
 
-// @Summary Обновить профиль (самостоятельно)
+// @Summary Изменить профиль: заявка на изменение ФИО уходит на модерацию
 // @Tags user
 // @Accept json
 // @Produce json
-// @Param data body UpdateProfileRequest true "Обновляемые поля"
-// @Success 200 {object} map[string]string
-// @Failure 400,401,500 {object} map[string]string
+// @Param data body UpdateProfileRequest true "Изменяемые поля (не переданные не меняются)"
+// @Success 202 {object} model.ProfileChange
+// @Failure 400,401,409,500 {object} map[string]string
 // @Router /api/user/update-profile [post]
 func (h *Handler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Метод не разрешён", http.StatusMethodNotAllowed)
+		return
+	}
+
 	var req UpdateProfileRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Некорректный JSON", http.StatusBadRequest)
@@ -56,55 +64,129 @@ func (h *Handler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authHeader := r.Header.Get("Authorization")
-	if authHeader == "" {
-		http.Error(w, "Отсутствует токен", http.StatusUnauthorized)
+	current := middleware.GetUserFromContext(r.Context())
+	change, err := h.userService.RequestProfileChange(r.Context(), current, model.ProfileFields{
+		FirstName:  req.FirstName,
+		LastName:   req.LastName,
+		Patronymic: req.Patronymic,
+	})
+	if err != nil {
+		respondProfileChangeError(w, err)
 		return
 	}
-	tokenStr := authHeader[len("Bearer "):]
-	userID, err := jwtutil.ParseToken(tokenStr)
-	if err != nil {
-		http.Error(w, "Неверный токен", http.StatusUnauthorized)
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(change)
+}
+
+// @Summary Свои заявки на изменение профиля
+// @Tags user
+// @Produce json
+// @Success 200 {array} model.ProfileChange
+// @Failure 401,500 {object} map[string]string
+// @Router /api/user/profile-changes [get]
+func (h *Handler) GetMyProfileChanges(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Метод не разрешён", http.StatusMethodNotAllowed)
 		return
 	}
 
-	userModel := &model.User{
-		ID: userID,
+	current := middleware.GetUserFromContext(r.Context())
+	changes, err := h.userService.GetProfileHistory(r.Context(), current.ID)
+	if err != nil {
+		http.Error(w, "Не удалось получить заявки", http.StatusInternalServerError)
+		return
 	}
-	if req.FirstName != nil {
-		userModel.FirstName = *req.FirstName
+
+	json.NewEncoder(w).Encode(changes)
+}
+
+// @Summary Админ обновляет профиль пользователя (без модерации, с записью в историю)
+// @Tags admin-user
+// @Accept json
+// @Produce json
+// @Param data body AdminUpdateProfileRequest true "Обновляемые поля пользователя"
+// @Success 200 {object} map[string]string
+// @Failure 400,401,409,500 {object} map[string]string
+// @Router /api/admin/user/update-profile [post]
+func (h *Handler) AdminUpdateProfile(w http.ResponseWriter, r *http.Request) {
+	var req AdminUpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Некорректный JSON", http.StatusBadRequest)
+		return
 	}
-	if req.LastName != nil {
-		userModel.LastName = *req.LastName
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "Ошибка валидации", http.StatusBadRequest)
+		return
 	}
-	if req.Patronymic != nil {
-		userModel.Patronymic = *req.Patronymic
+
+	fields := model.ProfileFields{
+		FirstName:  req.FirstName,
+		LastName:   req.LastName,
+		Patronymic: req.Patronymic,
 	}
 	if req.Phone != nil {
-		userModel.Phone = *req.Phone
-	}
-	if req.CardNumber != nil {
-		userModel.CardNumber = req.CardNumber
+		phone := utils.NormalizePhone(*req.Phone)
+		fields.Phone = &phone
 	}
 
-	if err := h.userService.UpdateProfile(r.Context(), userModel); err != nil {
-		http.Error(w, "Не удалось обновить профиль", http.StatusInternalServerError)
+	admin := middleware.GetUserFromContext(r.Context())
+	_, err := h.userService.AdminUpdateProfile(r.Context(), admin.ID, req.UserID, fields)
+	if errors.Is(err, usecase.ErrPhoneTaken) {
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+	if err != nil && !errors.Is(err, usecase.ErrNoProfileChanges) {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{
+			"error": err.Error(),
+		})
 		return
 	}
 
 	json.NewEncoder(w).Encode(map[string]string{"message": "Профиль обновлён"})
 }
 
-// @Summary Админ обновляет профиль пользователя
+// @Summary Админ: заявки на изменение профиля, ожидающие модерации
+// @Tags admin-user
+// @Produce json
+// @Success 200 {array} model.ProfileChange
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/user/profile-changes/pending [get]
+func (h *Handler) AdminListPendingProfileChanges(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Метод не разрешён", http.StatusMethodNotAllowed)
+		return
+	}
+
+	changes, err := h.userService.ListPendingProfileChanges(r.Context())
+	if err != nil {
+		http.Error(w, "Не удалось получить заявки", http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(changes)
+}
+
+// @Summary Админ: одобрить заявку на изменение профиля
 // @Tags admin-user
 // @Accept json
 // @Produce json
-// @Param data body AdminUpdateProfileRequest true "Обновляемые поля пользователя"
+// @Param data body ProfileChangeDecisionRequest true "ID заявки"
 // @Success 200 {object} map[string]string
-// @Failure 400,401,500 {object} map[string]string
-// @Router /api/admin/user/update-profile [post]
-func (h *Handler) AdminUpdateProfile(w http.ResponseWriter, r *http.Request) {
-	var req AdminUpdateProfileRequest
+// @Failure 400,404,409,500 {object} map[string]string
+// @Router /api/admin/user/profile-changes/approve [post]
+func (h *Handler) AdminApproveProfileChange(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Метод не разрешён", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req ProfileChangeDecisionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Некорректный JSON", http.StatusBadRequest)
 		return
@@ -115,34 +197,75 @@ func (h *Handler) AdminUpdateProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userModel := &model.User{
-		ID: req.UserID,
+	admin := middleware.GetUserFromContext(r.Context())
+	if err := h.userService.ApproveProfileChange(r.Context(), req.ID, admin.ID); err != nil {
+		respondProfileChangeError(w, err)
+		return
 	}
-	if req.FirstName != nil {
-		userModel.FirstName = *req.FirstName
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Изменения применены"})
+}
+
+// @Summary Админ: отклонить заявку на изменение профиля
+// @Tags admin-user
+// @Accept json
+// @Produce json
+// @Param data body ProfileChangeRejectRequest true "ID заявки и причина"
+// @Success 200 {object} map[string]string
+// @Failure 400,404,409,500 {object} map[string]string
+// @Router /api/admin/user/profile-changes/reject [post]
+func (h *Handler) AdminRejectProfileChange(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Метод не разрешён", http.StatusMethodNotAllowed)
+		return
 	}
-	if req.LastName != nil {
-		userModel.LastName = *req.LastName
+
+	var req ProfileChangeRejectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Некорректный JSON", http.StatusBadRequest)
+		return
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		http.Error(w, "Ошибка валидации", http.StatusBadRequest)
+		return
 	}
-	if req.Patronymic != nil {
-		userModel.Patronymic = *req.Patronymic
+
+	admin := middleware.GetUserFromContext(r.Context())
+	if err := h.userService.RejectProfileChange(r.Context(), req.ID, admin.ID, req.Reason); err != nil {
+		respondProfileChangeError(w, err)
+		return
 	}
-	if req.Phone != nil {
-		userModel.Phone = *req.Phone
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Заявка отклонена"})
+}
+
+// @Summary Админ: история изменений профиля пользователя с прежними значениями
+// @Tags admin-user
+// @Produce json
+// @Param user_id query int true "ID пользователя"
+// @Success 200 {array} model.ProfileChange
+// @Failure 400,500 {object} map[string]string
+// @Router /api/admin/user/profile-history [get]
+func (h *Handler) AdminGetProfileHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Метод не разрешён", http.StatusMethodNotAllowed)
+		return
 	}
-	if req.CardNumber != nil {
-		userModel.CardNumber = req.CardNumber
+
+	userID, err := strconv.ParseInt(r.URL.Query().Get("user_id"), 10, 64)
+	if err != nil || userID <= 0 {
+		http.Error(w, "Некорректный user_id", http.StatusBadRequest)
+		return
 	}
 
-	if err := h.userService.UpdateProfile(r.Context(), userModel); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{
-			"error": err.Error(),
-		})
+	history, err := h.userService.GetProfileHistory(r.Context(), userID)
+	if err != nil {
+		http.Error(w, "Не удалось получить историю", http.StatusInternalServerError)
 		return
 	}
 
-	json.NewEncoder(w).Encode(map[string]string{"message": "Профиль обновлён"})
+	json.NewEncoder(w).Encode(history)
 }
 
 // @Summary Админ добавляет реферала пользователю
@@ -285,3 +408,17 @@ func (h *Handler) GetUserFullBalance(w http.ResponseWriter, r *http.Request) {
 		"reward_balance": rewardBalance,
 	})
 }
+
+func respondProfileChangeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrNoProfileChanges):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, usecase.ErrProfileChangeNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, usecase.ErrProfileChangeDuplicate),
+		errors.Is(err, usecase.ErrProfileChangeNotPending):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, "Не удалось обработать изменение профиля", http.StatusInternalServerError)
+	}
+}
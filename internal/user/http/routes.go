@@ -5,11 +5,12 @@ import (
 	"time"
 
 	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
 	user_ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
 	"github.com/Vovarama1992/go-utils/httputil"
 )
 
-func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService user_ports.UserServiceInterface) {
+func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService user_ports.UserServiceInterface, sessions middleware.SessionChecker, access middleware.PermissionChecker) {
 	withRecover := func(h http.Handler) http.Handler {
 		return httputil.RecoverMiddleware(h)
 	}
@@ -19,16 +20,20 @@ func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService user_ports
 	}
 
 	withUserAuth := func(h http.Handler) http.Handler {
-		return middleware.AuthMiddleware(userService, false)(h)
+		return middleware.AuthMiddleware(userService, sessions)(h)
 	}
 
-	withAdminAuth := func(h http.Handler) http.Handler {
-		return middleware.AuthMiddleware(userService, true)(h)
+	withPermission := func(perm rbac.Permission, h http.Handler) http.Handler {
+		return middleware.PermissionMiddleware(userService, sessions, access, perm)(h)
 	}
 
 	// === USER ===
 	mux.Handle("/api/user/update-profile",
-		withRecoverAndRateLimit(http.HandlerFunc(handler.UpdateProfile)),
+		withRecoverAndRateLimit(withUserAuth(http.HandlerFunc(handler.UpdateProfile))),
+	)
+
+	mux.Handle("/api/user/profile-changes",
+		withRecover(withUserAuth(http.HandlerFunc(handler.GetMyProfileChanges))),
 	)
 
 	mux.Handle("/api/user/balance",
@@ -37,21 +42,37 @@ func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService user_ports
 
 	// === ADMIN ===
 	mux.Handle("/api/admin/user/search-id",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.AdminSearchByID))),
+		withRecover(withPermission(rbac.PermUsersRead, http.HandlerFunc(handler.AdminSearchByID))),
 	)
 	mux.Handle("/api/admin/user/all",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.GetAllUsers))),
+		withRecover(withPermission(rbac.PermUsersRead, http.HandlerFunc(handler.GetAllUsers))),
 	)
 
 	mux.Handle("/api/admin/user/operations",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.GetUserOperations))),
+		withRecover(withPermission(rbac.PermUsersRead, http.HandlerFunc(handler.GetUserOperations))),
 	)
 
 	mux.Handle("/api/admin/user/update-profile",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.AdminUpdateProfile))),
+		withRecover(withPermission(rbac.PermUsersWrite, http.HandlerFunc(handler.AdminUpdateProfile))),
+	)
+
+	mux.Handle("/api/admin/user/profile-changes/pending",
+		withRecover(withPermission(rbac.PermUsersRead, http.HandlerFunc(handler.AdminListPendingProfileChanges))),
+	)
+
+	mux.Handle("/api/admin/user/profile-changes/approve",
+		withRecover(withPermission(rbac.PermUsersWrite, http.HandlerFunc(handler.AdminApproveProfileChange))),
+	)
+
+	mux.Handle("/api/admin/user/profile-changes/reject",
+		withRecover(withPermission(rbac.PermUsersWrite, http.HandlerFunc(handler.AdminRejectProfileChange))),
+	)
+
+	mux.Handle("/api/admin/user/profile-history",
+		withRecover(withPermission(rbac.PermUsersRead, http.HandlerFunc(handler.AdminGetProfileHistory))),
 	)
 
 	mux.Handle("/api/admin/user/add-referal",
-		withRecover(withAdminAuth(http.HandlerFunc(handler.AdminAddReferal))),
+		withRecover(withPermission(rbac.PermUsersWrite, http.HandlerFunc(handler.AdminAddReferal))),
 	)
 }
@@ -3,11 +3,15 @@ package user
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"log"
 	"regexp"
 
 	"github.com/Vovarama1992/emelya-go/internal/db"
+	outbox_infra "github.com/Vovarama1992/emelya-go/internal/outbox/infra"
+	outbox_model "github.com/Vovarama1992/emelya-go/internal/outbox/model"
 	model "github.com/Vovarama1992/emelya-go/internal/user/model"
+	"github.com/jackc/pgx/v5"
 )
 
 type UserRepository struct {
@@ -50,33 +54,22 @@ func (r *UserRepository) CreateUser(ctx context.Context, user *model.User) error
 	return err
 }
 
-func (r *UserRepository) UpdateProfile(ctx context.Context, user *model.User) error {
-	query := `
-		UPDATE users
-		SET first_name = $1, last_name = $2, patronymic = $3, phone = $4, card_number = $5
-		WHERE id = $6
-	`
-	_, err := r.DB.Pool.Exec(ctx, query,
-		user.FirstName,
-		user.LastName,
-		user.Patronymic,
-		user.Phone,
-		user.CardNumber,
-		user.ID,
-	)
-	return err
-}
-
 func (r *UserRepository) SetReferrer(ctx context.Context, userID int64, referrerID int64) error {
 	query := `UPDATE users SET referrer_id = $1 WHERE id = $2`
 	_, err := r.DB.Pool.Exec(ctx, query, referrerID, userID)
 	return err
 }
 
+func (r *UserRepository) SetRole(ctx context.Context, userID int64, role model.UserRole) error {
+	query := `UPDATE users SET role = $1 WHERE id = $2`
+	_, err := r.DB.Pool.Exec(ctx, query, role, userID)
+	return err
+}
+
 func (r *UserRepository) FindUserByID(ctx context.Context, userID int64) (*model.User, error) {
 	query := `
 		SELECT id, first_name, last_name, patronymic, email, phone, is_email_verified,
-		       is_phone_verified, login, password_hash, referrer_id, card_number, role, balance
+		       is_phone_verified, login, password_hash, referrer_id, card_number, role, balance, totp_enabled, contacts_changed_at, kyc_level
 		FROM users
 		WHERE id = $1
 	`
@@ -100,6 +93,9 @@ func (r *UserRepository) FindUserByID(ctx context.Context, userID int64) (*model
 		&user.CardNumber,
 		&user.Role,
 		&balance,
+		&user.TOTPEnabled,
+		&user.ContactsChangedAt,
+		&user.KYCLevel,
 	)
 	if err != nil {
 		return nil, err
@@ -119,7 +115,7 @@ func (r *UserRepository) FindUserByPhone(ctx context.Context, phone string) (*mo
 
 	query := `
 		SELECT id, first_name, last_name, patronymic, email, phone, is_email_verified, is_phone_verified,
-		       login, password_hash, referrer_id, card_number, role
+		       login, password_hash, referrer_id, card_number, role, totp_enabled, contacts_changed_at, kyc_level
 		FROM users
 		WHERE regexp_replace(phone, '[^0-9]', '', 'g') = $1
 	`
@@ -140,6 +136,9 @@ func (r *UserRepository) FindUserByPhone(ctx context.Context, phone string) (*mo
 		&user.ReferrerID,
 		&user.CardNumber,
 		&user.Role,
+		&user.TOTPEnabled,
+		&user.ContactsChangedAt,
+		&user.KYCLevel,
 	)
 	if err != nil {
 		return nil, err
@@ -151,7 +150,7 @@ func (r *UserRepository) FindUserByPhone(ctx context.Context, phone string) (*mo
 func (r *UserRepository) FindUserByLogin(ctx context.Context, login string) (*model.User, error) {
 	query := `
 		SELECT id, first_name, last_name, patronymic, email, phone, is_email_verified, is_phone_verified,
-		       login, password_hash, card_number, role
+		       login, password_hash, card_number, role, totp_enabled, contacts_changed_at, kyc_level
 		FROM users
 		WHERE login = $1
 	`
@@ -171,6 +170,44 @@ func (r *UserRepository) FindUserByLogin(ctx context.Context, login string) (*mo
 		&user.PasswordHash,
 		&user.CardNumber,
 		&user.Role,
+		&user.TOTPEnabled,
+		&user.ContactsChangedAt,
+		&user.KYCLevel,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *UserRepository) FindUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	query := `
+		SELECT id, first_name, last_name, patronymic, email, phone, is_email_verified, is_phone_verified,
+		       login, password_hash, referrer_id, card_number, role, totp_enabled, contacts_changed_at, kyc_level
+		FROM users
+		WHERE lower(email) = lower($1)
+	`
+	row := r.DB.Pool.QueryRow(ctx, query, email)
+
+	var user model.User
+	err := row.Scan(
+		&user.ID,
+		&user.FirstName,
+		&user.LastName,
+		&user.Patronymic,
+		&user.Email,
+		&user.Phone,
+		&user.IsEmailVerified,
+		&user.IsPhoneVerified,
+		&user.Login,
+		&user.PasswordHash,
+		&user.ReferrerID,
+		&user.CardNumber,
+		&user.Role,
+		&user.TOTPEnabled,
+		&user.ContactsChangedAt,
+		&user.KYCLevel,
 	)
 	if err != nil {
 		return nil, err
@@ -179,6 +216,12 @@ func (r *UserRepository) FindUserByLogin(ctx context.Context, login string) (*mo
 	return &user, nil
 }
 
+func (r *UserRepository) UpdatePassword(ctx context.Context, userID int64, passwordHash string) error {
+	query := `UPDATE users SET password_hash = $1 WHERE id = $2`
+	_, err := r.DB.Pool.Exec(ctx, query, passwordHash, userID)
+	return err
+}
+
 func (r *UserRepository) SetEmailVerified(ctx context.Context, userID int64) error {
 	query := `
 		UPDATE users
@@ -189,20 +232,47 @@ func (r *UserRepository) SetEmailVerified(ctx context.Context, userID int64) err
 	return err
 }
 
-func (r *UserRepository) SetPhoneVerified(ctx context.Context, userID int64) error {
-	query := `
+// ConfirmRegistration подтверждает телефон после регистрации. Событие user.registered
+// пишется в outbox в той же транзакции и только при первом подтверждении.
+func (r *UserRepository) ConfirmRegistration(ctx context.Context, userID int64) (err error) {
+	tx, err := r.DB.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	var p outbox_model.UserRegisteredPayload
+	err = tx.QueryRow(ctx, `
 		UPDATE users
 		SET is_phone_verified = true
-		WHERE id = $1
-	`
-	_, err := r.DB.Pool.Exec(ctx, query, userID)
-	return err
+		WHERE id = $1 AND is_phone_verified = false
+		RETURNING id, first_name, last_name, patronymic, phone, email, login, referrer_id
+	`, userID).Scan(&p.UserID, &p.FirstName, &p.LastName, &p.Patronymic, &p.Phone, &p.Email, &p.Login, &p.ReferrerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Телефон уже подтверждён — повторная регистрация не новость для операторов
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	event, err := outbox_model.NewEvent(outbox_model.EventUserRegistered, outbox_model.AggregateUser, p.UserID, p)
+	if err != nil {
+		return err
+	}
+	return outbox_infra.NewOutboxRepositoryWithTx(tx).Create(ctx, event)
 }
 
 func (r *UserRepository) GetAllUsers(ctx context.Context) ([]model.User, error) {
 	query := `
 		SELECT id, first_name, last_name, patronymic, email, phone, is_email_verified,
-		       is_phone_verified, login, password_hash, referrer_id, card_number, balance, role
+		       is_phone_verified, login, password_hash, referrer_id, card_number, balance, role, totp_enabled, contacts_changed_at, kyc_level
 		FROM users
 	`
 	rows, err := r.DB.Pool.Query(ctx, query)
@@ -230,6 +300,9 @@ func (r *UserRepository) GetAllUsers(ctx context.Context) ([]model.User, error)
 			&user.CardNumber,
 			&user.Balance, // добавлено
 			&user.Role,
+			&user.TOTPEnabled,
+			&user.ContactsChangedAt,
+			&user.KYCLevel,
 		)
 		if err != nil {
 			log.Printf("Ошибка сканирования GetAllUsers: %v", err)
@@ -240,3 +313,26 @@ func (r *UserRepository) GetAllUsers(ctx context.Context) ([]model.User, error)
 
 	return users, nil
 }
+
+func (r *UserRepository) FindUserIDsByRoles(ctx context.Context, roles []model.UserRole) ([]int64, error) {
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = string(role)
+	}
+
+	rows, err := r.DB.Pool.Query(ctx, `SELECT id FROM users WHERE role = ANY($1) ORDER BY id`, names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,227 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	model "github.com/Vovarama1992/emelya-go/internal/user/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Заявка уже не в статусе pending
+var ErrProfileChangeNotPending = errors.New("заявка на изменение профиля уже обработана")
+
+// У пользователя уже есть заявка на модерации
+var ErrProfileChangeDuplicate = errors.New("предыдущая заявка на изменение профиля ещё на модерации")
+
+type ProfileChangeRepository struct {
+	DB *db.DB
+}
+
+func NewProfileChangeRepository(db *db.DB) *ProfileChangeRepository {
+	return &ProfileChangeRepository{DB: db}
+}
+
+const profileChangeColumns = `id, user_id, changes, previous, status, requested_by, decided_by, decided_at, reason, created_at`
+
+func (r *ProfileChangeRepository) Create(ctx context.Context, c *model.ProfileChange) error {
+	changes, previous, err := marshalProfileFields(c)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO profile_change_requests (user_id, changes, previous, status, requested_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	err = r.DB.Pool.QueryRow(ctx, query, c.UserID, changes, previous, c.Status, c.RequestedBy).
+		Scan(&c.ID, &c.CreatedAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrProfileChangeDuplicate
+	}
+	return err
+}
+
+func (r *ProfileChangeRepository) GetByID(ctx context.Context, id int64) (*model.ProfileChange, error) {
+	query := `SELECT ` + profileChangeColumns + ` FROM profile_change_requests WHERE id = $1`
+	return scanProfileChange(r.DB.Pool.QueryRow(ctx, query, id))
+}
+
+func (r *ProfileChangeRepository) FindPending(ctx context.Context) ([]*model.ProfileChange, error) {
+	query := `
+		SELECT ` + profileChangeColumns + `
+		FROM profile_change_requests
+		WHERE status = 'pending'
+		ORDER BY created_at ASC
+	`
+	return r.query(ctx, query)
+}
+
+// FindByUser — все заявки и правки пользователя, новые сверху
+func (r *ProfileChangeRepository) FindByUser(ctx context.Context, userID int64) ([]*model.ProfileChange, error) {
+	query := `
+		SELECT ` + profileChangeColumns + `
+		FROM profile_change_requests
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+	return r.query(ctx, query, userID)
+}
+
+// Approve применяет изменения к профилю и закрывает заявку в одной транзакции
+func (r *ProfileChangeRepository) Approve(ctx context.Context, c *model.ProfileChange, decidedBy int64) (err error) {
+	tx, err := r.DB.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	if err = applyProfileFields(ctx, tx, c.UserID, c.Changes); err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE profile_change_requests
+		SET status = 'approved', decided_by = $1, decided_at = now()
+		WHERE id = $2 AND status = 'pending'
+	`, decidedBy, c.ID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrProfileChangeNotPending
+	}
+	return nil
+}
+
+func (r *ProfileChangeRepository) Reject(ctx context.Context, id, decidedBy int64, reason string) error {
+	query := `
+		UPDATE profile_change_requests
+		SET status = 'rejected', decided_by = $1, decided_at = now(), reason = $2
+		WHERE id = $3 AND status = 'pending'
+	`
+	tag, err := r.DB.Pool.Exec(ctx, query, decidedBy, reason, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrProfileChangeNotPending
+	}
+	return nil
+}
+
+// ApplyDirect — правка администратора: профиль меняется сразу, в истории остаётся запись
+func (r *ProfileChangeRepository) ApplyDirect(ctx context.Context, c *model.ProfileChange) (err error) {
+	changes, previous, err := marshalProfileFields(c)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.DB.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	if err = applyProfileFields(ctx, tx, c.UserID, c.Changes); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO profile_change_requests (user_id, changes, previous, status, requested_by, decided_by, decided_at)
+		VALUES ($1, $2, $3, 'applied', $4, $4, now())
+		RETURNING id, created_at
+	`
+	return tx.QueryRow(ctx, query, c.UserID, changes, previous, c.RequestedBy).Scan(&c.ID, &c.CreatedAt)
+}
+
+// applyProfileFields — частичное обновление: не переданные поля не трогаются.
+// Смена телефона, как и самостоятельная, включает паузу на вывод средств.
+func applyProfileFields(ctx context.Context, tx pgx.Tx, userID int64, f model.ProfileFields) error {
+	query := `
+		UPDATE users
+		SET first_name = COALESCE($1, first_name),
+		    last_name = COALESCE($2, last_name),
+		    patronymic = COALESCE($3, patronymic),
+		    phone = COALESCE($4, phone),
+		    contacts_changed_at = CASE WHEN $4::text IS NULL THEN contacts_changed_at ELSE now() END
+		WHERE id = $5
+	`
+	_, err := tx.Exec(ctx, query, f.FirstName, f.LastName, f.Patronymic, f.Phone, userID)
+	return err
+}
+
+func (r *ProfileChangeRepository) query(ctx context.Context, query string, args ...any) ([]*model.ProfileChange, error) {
+	rows, err := r.DB.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []*model.ProfileChange
+	for rows.Next() {
+		c, err := scanProfileChange(rows)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+	return changes, rows.Err()
+}
+
+func scanProfileChange(row pgx.Row) (*model.ProfileChange, error) {
+	var c model.ProfileChange
+	var changes, previous []byte
+	err := row.Scan(
+		&c.ID,
+		&c.UserID,
+		&changes,
+		&previous,
+		&c.Status,
+		&c.RequestedBy,
+		&c.DecidedBy,
+		&c.DecidedAt,
+		&c.Reason,
+		&c.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(changes, &c.Changes); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(previous, &c.Previous); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func marshalProfileFields(c *model.ProfileChange) (changes, previous string, err error) {
+	b, err := json.Marshal(c.Changes)
+	if err != nil {
+		return "", "", err
+	}
+	p, err := json.Marshal(c.Previous)
+	if err != nil {
+		return "", "", err
+	}
+	return string(b), string(p), nil
+}
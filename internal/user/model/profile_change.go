@@ -0,0 +1,77 @@
+package user
+
+import "time"
+
+// ProfileFields — чувствительные поля профиля; nil — поле не меняется.
+// Телефон правит только администратор: сам пользователь меняет его с подтверждением кодами.
+type ProfileFields struct {
+	FirstName  *string `json:"first_name,omitempty"`
+	LastName   *string `json:"last_name,omitempty"`
+	Patronymic *string `json:"patronymic,omitempty"`
+	Phone      *string `json:"phone,omitempty"`
+}
+
+type ProfileChangeStatus string
+
+const (
+	ProfileChangePending  ProfileChangeStatus = "pending"  // ждёт оператора
+	ProfileChangeApproved ProfileChangeStatus = "approved" // одобрена и применена
+	ProfileChangeRejected ProfileChangeStatus = "rejected" // отклонена
+	ProfileChangeApplied  ProfileChangeStatus = "applied"  // правка администратора без модерации
+)
+
+// ProfileChange — заявка на изменение профиля; одобренные и применённые заявки
+// образуют историю предыдущих значений
+type ProfileChange struct {
+	ID          int64               `json:"id"`
+	UserID      int64               `json:"user_id"`
+	Changes     ProfileFields       `json:"changes"`
+	Previous    ProfileFields       `json:"previous"`
+	Status      ProfileChangeStatus `json:"status"`
+	RequestedBy int64               `json:"requested_by"`
+	DecidedBy   *int64              `json:"decided_by,omitempty"`
+	DecidedAt   *time.Time          `json:"decided_at,omitempty"`
+	Reason      *string             `json:"reason,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+// Diff оставляет только поля, которые действительно отличаются от текущих,
+// и возвращает их прежние значения
+func (f ProfileFields) Diff(u *User) (changes, previous ProfileFields) {
+	if f.FirstName != nil && *f.FirstName != u.FirstName {
+		changes.FirstName, previous.FirstName = f.FirstName, stringPtr(u.FirstName)
+	}
+	if f.LastName != nil && *f.LastName != u.LastName {
+		changes.LastName, previous.LastName = f.LastName, stringPtr(u.LastName)
+	}
+	if f.Patronymic != nil && *f.Patronymic != u.Patronymic {
+		changes.Patronymic, previous.Patronymic = f.Patronymic, stringPtr(u.Patronymic)
+	}
+	if f.Phone != nil && *f.Phone != u.Phone {
+		changes.Phone, previous.Phone = f.Phone, stringPtr(u.Phone)
+	}
+	return changes, previous
+}
+
+// Копия значения: previous не должен меняться вместе с пользователем
+func stringPtr(s string) *string { return &s }
+
+func (f ProfileFields) IsEmpty() bool {
+	return f.FirstName == nil && f.LastName == nil && f.Patronymic == nil && f.Phone == nil
+}
+
+// ApplyTo переносит заданные поля в пользователя
+func (f ProfileFields) ApplyTo(u *User) {
+	if f.FirstName != nil {
+		u.FirstName = *f.FirstName
+	}
+	if f.LastName != nil {
+		u.LastName = *f.LastName
+	}
+	if f.Patronymic != nil {
+		u.Patronymic = *f.Patronymic
+	}
+	if f.Phone != nil {
+		u.Phone = *f.Phone
+	}
+}
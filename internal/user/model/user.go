@@ -1,12 +1,34 @@
 package user
 
+import "time"
+
 type UserRole string
 
 const (
-	RoleUser  UserRole = "user"
-	RoleAdmin UserRole = "admin"
+	RoleUser       UserRole = "user"
+	RoleAdmin      UserRole = "admin"
+	RoleOperator   UserRole = "operator"   // подтверждает депозиты
+	RoleAccountant UserRole = "accountant" // только чтение финансов
+	RoleSupport    UserRole = "support"    // только чтение профилей, без денег
+	RoleSuperadmin UserRole = "superadmin" // все права, включая управление ролями
 )
 
+var AllRoles = []UserRole{RoleUser, RoleAdmin, RoleOperator, RoleAccountant, RoleSupport, RoleSuperadmin}
+
+func (r UserRole) IsValid() bool {
+	for _, role := range AllRoles {
+		if role == r {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStaff — любая роль сотрудника, а не инвестора
+func (r UserRole) IsStaff() bool {
+	return r != RoleUser && r.IsValid()
+}
+
 type User struct {
 	ID              int64
 	FirstName       string
@@ -17,9 +39,13 @@ type User struct {
 	IsEmailVerified bool
 	IsPhoneVerified bool
 	Login           string
-	PasswordHash    string
+	PasswordHash    string `json:"-"`
 	ReferrerID      *int64
-	CardNumber      *string
+	CardNumber      *string  `json:"-"` // устарело: при старте переносится в payout_methods
 	Balance         *float64 `json:"balance"`
 	Role            UserRole `json:"role"`
+	TOTPEnabled     bool     `json:"totp_enabled"`
+	KYCLevel        int      `json:"kyc_level"`
+	// Время последней смены телефона или email; после неё вывод временно закрыт
+	ContactsChangedAt *time.Time `json:"contacts_changed_at,omitempty"`
 }
@@ -0,0 +1,17 @@
+package user
+
+import (
+	"context"
+
+	model "github.com/Vovarama1992/emelya-go/internal/user/model"
+)
+
+type ProfileChangeRepository interface {
+	Create(ctx context.Context, c *model.ProfileChange) error
+	GetByID(ctx context.Context, id int64) (*model.ProfileChange, error)
+	FindPending(ctx context.Context) ([]*model.ProfileChange, error)
+	FindByUser(ctx context.Context, userID int64) ([]*model.ProfileChange, error)
+	Approve(ctx context.Context, c *model.ProfileChange, decidedBy int64) error
+	Reject(ctx context.Context, id, decidedBy int64, reason string) error
+	ApplyDirect(ctx context.Context, c *model.ProfileChange) error
+}
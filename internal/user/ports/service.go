@@ -11,10 +11,20 @@ type UserServiceInterface interface {
 	FindUserByID(ctx context.Context, userID int64) (*user.User, error)
 	FindUserByPhone(ctx context.Context, phone string) (*user.User, error)
 	FindUserByLogin(ctx context.Context, login string) (*user.User, error)
-	VerifyPhone(ctx context.Context, userID int64) error
-	UpdateProfile(ctx context.Context, user *user.User) error
+	FindUserByEmail(ctx context.Context, email string) (*user.User, error)
+	UpdatePassword(ctx context.Context, userID int64, passwordHash string) error
+	ConfirmRegistration(ctx context.Context, userID int64) error
+	VerifyEmail(ctx context.Context, userID int64) error
 	SetReferrer(ctx context.Context, userID int64, referrerID int64) error
+	SetRole(ctx context.Context, userID int64, role user.UserRole) error
 	GetAllUsers(ctx context.Context) ([]user.User, error)
+	FindUserIDsByRoles(ctx context.Context, roles []user.UserRole) ([]int64, error)
+	RequestProfileChange(ctx context.Context, u *user.User, fields user.ProfileFields) (*user.ProfileChange, error)
+	AdminUpdateProfile(ctx context.Context, actorID, userID int64, fields user.ProfileFields) (*user.ProfileChange, error)
+	ListPendingProfileChanges(ctx context.Context) ([]*user.ProfileChange, error)
+	ApproveProfileChange(ctx context.Context, id, actorID int64) error
+	RejectProfileChange(ctx context.Context, id, actorID int64, reason string) error
+	GetProfileHistory(ctx context.Context, userID int64) ([]*user.ProfileChange, error)
 	GetCurrentBalance(ctx context.Context, userID int64) (float64, error)
 	GetTotalRewardBalance(ctx context.Context, userID int64) (float64, error)
 }
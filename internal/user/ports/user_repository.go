@@ -10,10 +10,13 @@ type UserRepository interface {
 	CreateUser(ctx context.Context, user *model.User) error
 	FindUserByPhone(ctx context.Context, phone string) (*model.User, error)
 	FindUserByLogin(ctx context.Context, login string) (*model.User, error)
+	FindUserByEmail(ctx context.Context, email string) (*model.User, error)
+	UpdatePassword(ctx context.Context, userID int64, passwordHash string) error
 	SetEmailVerified(ctx context.Context, userID int64) error
-	SetPhoneVerified(ctx context.Context, userID int64) error
+	ConfirmRegistration(ctx context.Context, userID int64) error
 	FindUserByID(ctx context.Context, userID int64) (*model.User, error)
-	UpdateProfile(ctx context.Context, user *model.User) error
 	SetReferrer(ctx context.Context, userID int64, referrerID int64) error
+	SetRole(ctx context.Context, userID int64, role model.UserRole) error
 	GetAllUsers(ctx context.Context) ([]model.User, error)
+	FindUserIDsByRoles(ctx context.Context, roles []model.UserRole) ([]int64, error)
 }
@@ -0,0 +1,195 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	audit "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	"github.com/Vovarama1992/emelya-go/internal/notifier"
+	infra "github.com/Vovarama1992/emelya-go/internal/user/infra"
+	model "github.com/Vovarama1992/emelya-go/internal/user/model"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrNoProfileChanges        = errors.New("нет изменений профиля")
+	ErrProfileChangeNotFound   = errors.New("заявка на изменение профиля не найдена")
+	ErrProfileChangeNotPending = infra.ErrProfileChangeNotPending
+	ErrProfileChangeDuplicate  = infra.ErrProfileChangeDuplicate
+	ErrPhoneTaken              = errors.New("телефон уже используется другим аккаунтом")
+)
+
+// RequestProfileChange — пользователь меняет ФИО; изменения применятся
+// после одобрения оператором
+func (s *Service) RequestProfileChange(ctx context.Context, u *model.User, fields model.ProfileFields) (*model.ProfileChange, error) {
+	// Телефон меняется только через подтверждение кодами
+	fields.Phone = nil
+	changes, previous := fields.Diff(u)
+	if changes.IsEmpty() {
+		return nil, ErrNoProfileChanges
+	}
+
+	change := &model.ProfileChange{
+		UserID:      u.ID,
+		Changes:     changes,
+		Previous:    previous,
+		Status:      model.ProfileChangePending,
+		RequestedBy: u.ID,
+	}
+
+	repoCtx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	if err := s.profileChanges.Create(repoCtx, change); err != nil {
+		return nil, err
+	}
+
+	err := s.notifier.SendTemplateToOperators(ctx, notifier.TplOperatorProfileChangeRequested, map[string]any{
+		"UserID":    u.ID,
+		"FirstName": u.FirstName,
+		"LastName":  u.LastName,
+		"ChangeID":  change.ID,
+		"Before":    describeProfileFields(previous),
+		"After":     describeProfileFields(changes),
+	})
+	if err != nil {
+		log.Printf("[USER] Не удалось уведомить оператора о заявке #%d: %v", change.ID, err)
+	}
+	return change, nil
+}
+
+// AdminUpdateProfile — правка администратора без модерации, с записью в историю
+// профиля и журнал действий
+func (s *Service) AdminUpdateProfile(ctx context.Context, actorID, userID int64, fields model.ProfileFields) (*model.ProfileChange, error) {
+	u, err := s.FindUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, previous := fields.Diff(u)
+	if changes.IsEmpty() {
+		return nil, ErrNoProfileChanges
+	}
+
+	change := &model.ProfileChange{
+		UserID:      userID,
+		Changes:     changes,
+		Previous:    previous,
+		Status:      model.ProfileChangeApplied,
+		RequestedBy: actorID,
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+	var pgErr *pgconn.PgError
+	if err := s.profileChanges.ApplyDirect(ctx, change); errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return nil, ErrPhoneTaken
+	} else if err != nil {
+		return nil, err
+	}
+
+	entry := &audit.Entry{
+		ActorID:    &actorID,
+		Action:     audit.ActionUserProfileUpdate,
+		EntityType: audit.EntityUser,
+		EntityID:   &userID,
+		Before:     audit.Snapshot(previous),
+		After:      audit.Snapshot(changes),
+	}
+	if err := s.auditService.Record(ctx, entry); err != nil {
+		log.Printf("[USER] Не удалось записать журнал %s: %v", entry.Action, err)
+	}
+	return change, nil
+}
+
+func (s *Service) ListPendingProfileChanges(ctx context.Context) ([]*model.ProfileChange, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	return s.profileChanges.FindPending(ctx)
+}
+
+// ApproveProfileChange применяет заявку. Поля, которые успели измениться иначе,
+// перезаписываются значениями из заявки.
+func (s *Service) ApproveProfileChange(ctx context.Context, id, actorID int64) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+
+	change, err := s.getProfileChange(ctx, id)
+	if err != nil {
+		return err
+	}
+	if change.Status != model.ProfileChangePending {
+		return ErrProfileChangeNotPending
+	}
+
+	if err := s.profileChanges.Approve(ctx, change, actorID); err != nil {
+		return err
+	}
+
+	s.notifyProfileDecision(ctx, change.UserID, notifier.TplUserProfileChangeApproved, nil)
+	return nil
+}
+
+func (s *Service) RejectProfileChange(ctx context.Context, id, actorID int64, reason string) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+
+	change, err := s.getProfileChange(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.profileChanges.Reject(ctx, id, actorID, reason); err != nil {
+		return err
+	}
+
+	s.notifyProfileDecision(ctx, change.UserID, notifier.TplUserProfileChangeRejected, map[string]any{"Reason": reason})
+	return nil
+}
+
+// GetProfileHistory — заявки и правки профиля пользователя с прежними значениями
+func (s *Service) GetProfileHistory(ctx context.Context, userID int64) ([]*model.ProfileChange, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	return s.profileChanges.FindByUser(ctx, userID)
+}
+
+func (s *Service) getProfileChange(ctx context.Context, id int64) (*model.ProfileChange, error) {
+	change, err := s.profileChanges.GetByID(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrProfileChangeNotFound
+	}
+	return change, err
+}
+
+func (s *Service) notifyProfileDecision(ctx context.Context, userID int64, key string, data any) {
+	u, err := s.repo.FindUserByID(ctx, userID)
+	if err != nil || u.Email == "" {
+		return
+	}
+	if err := s.notifier.SendTemplate(ctx, notifier.ChannelEmail, u.Email, key, data); err != nil {
+		log.Printf("[USER] Не удалось уведомить пользователя %d о решении по профилю: %v", userID, err)
+	}
+}
+
+func describeProfileFields(f model.ProfileFields) string {
+	var out string
+	add := func(name string, v *string) {
+		if v == nil {
+			return
+		}
+		if out != "" {
+			out += ", "
+		}
+		out += name + ": " + *v
+	}
+	add("имя", f.FirstName)
+	add("фамилия", f.LastName)
+	add("отчество", f.Patronymic)
+	add("телефон", f.Phone)
+	if out == "" {
+		return "—"
+	}
+	return out
+}
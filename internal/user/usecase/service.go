@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 
+	audit_ports "github.com/Vovarama1992/emelya-go/internal/audit/ports"
 	deposit "github.com/Vovarama1992/emelya-go/internal/money/deposit/model"
 	money_ports "github.com/Vovarama1992/emelya-go/internal/money/ports"
 	"github.com/Vovarama1992/emelya-go/internal/notifier"
@@ -12,23 +13,29 @@ import (
 )
 
 type Service struct {
-	repo       ports.UserRepository
-	notifier   *notifier.Notifier
-	depositSvc money_ports.DepositService
-	rewardSvc  money_ports.RewardService
+	repo           ports.UserRepository
+	profileChanges ports.ProfileChangeRepository
+	notifier       notifier.NotifierInterface
+	depositSvc     money_ports.DepositService
+	rewardSvc      money_ports.RewardService
+	auditService   audit_ports.AuditService
 }
 
 func NewService(
 	repo ports.UserRepository,
-	notifier *notifier.Notifier,
+	profileChanges ports.ProfileChangeRepository,
+	notifier notifier.NotifierInterface,
 	depositSvc money_ports.DepositService,
 	rewardSvc money_ports.RewardService,
+	auditService audit_ports.AuditService,
 ) *Service {
 	return &Service{
-		repo:       repo,
-		notifier:   notifier,
-		depositSvc: depositSvc,
-		rewardSvc:  rewardSvc,
+		repo:           repo,
+		profileChanges: profileChanges,
+		notifier:       notifier,
+		depositSvc:     depositSvc,
+		rewardSvc:      rewardSvc,
+		auditService:   auditService,
 	}
 }
 
@@ -38,6 +45,13 @@ func (s *Service) GetAllUsers(ctx context.Context) ([]model.User, error) {
 	return s.repo.GetAllUsers(ctx)
 }
 
+// FindUserIDsByRoles — ID пользователей с одной из ролей (получатели служебных уведомлений)
+func (s *Service) FindUserIDsByRoles(ctx context.Context, roles []model.UserRole) ([]int64, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	return s.repo.FindUserIDsByRoles(ctx, roles)
+}
+
 func (s *Service) FindUserByID(ctx context.Context, userID int64) (*model.User, error) {
 	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
 	defer cancel()
@@ -56,20 +70,35 @@ func (s *Service) FindUserByLogin(ctx context.Context, login string) (*model.Use
 	return s.repo.FindUserByLogin(ctx, login)
 }
 
-func (s *Service) VerifyPhone(ctx context.Context, userID int64) error {
-	return s.repo.SetPhoneVerified(ctx, userID)
+func (s *Service) FindUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	return s.repo.FindUserByEmail(ctx, email)
 }
 
-func (s *Service) CreateUser(ctx context.Context, newUser *model.User) error {
+func (s *Service) UpdatePassword(ctx context.Context, userID int64, passwordHash string) error {
 	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
 	defer cancel()
-	return s.repo.CreateUser(ctx, newUser)
+	return s.repo.UpdatePassword(ctx, userID, passwordHash)
+}
+
+// ConfirmRegistration — подтверждение телефона при регистрации
+func (s *Service) ConfirmRegistration(ctx context.Context, userID int64) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	return s.repo.ConfirmRegistration(ctx, userID)
 }
 
-func (s *Service) UpdateProfile(ctx context.Context, user *model.User) error {
+func (s *Service) VerifyEmail(ctx context.Context, userID int64) error {
 	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
 	defer cancel()
-	return s.repo.UpdateProfile(ctx, user)
+	return s.repo.SetEmailVerified(ctx, userID)
+}
+
+func (s *Service) CreateUser(ctx context.Context, newUser *model.User) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	return s.repo.CreateUser(ctx, newUser)
 }
 
 func (s *Service) SetReferrer(ctx context.Context, userID int64, referrerID int64) error {
@@ -78,6 +107,12 @@ func (s *Service) SetReferrer(ctx context.Context, userID int64, referrerID int6
 	return s.repo.SetReferrer(ctx, userID, referrerID)
 }
 
+func (s *Service) SetRole(ctx context.Context, userID int64, role model.UserRole) error {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	return s.repo.SetRole(ctx, userID, role)
+}
+
 func (s *Service) GetCurrentBalance(ctx context.Context, userID int64) (float64, error) {
 	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
 	defer cancel()
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+var (
+	trustedOnce    sync.Once
+	trustedProxies []*net.IPNet
+)
+
+// TRUSTED_PROXIES — адреса или подсети прокси перед приложением через запятую,
+// по умолчанию только localhost
+func loadTrustedProxies() {
+	list := os.Getenv("TRUSTED_PROXIES")
+	if strings.TrimSpace(list) == "" {
+		list = "127.0.0.0/8,::1/128"
+	}
+	for _, v := range strings.Split(list, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if !strings.Contains(v, "/") {
+			if ip := net.ParseIP(v); ip != nil && ip.To4() != nil {
+				v += "/32"
+			} else {
+				v += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(v)
+		if err != nil {
+			log.Printf("[HTTP] Некорректный адрес в TRUSTED_PROXIES: %q", v)
+			continue
+		}
+		trustedProxies = append(trustedProxies, network)
+	}
+}
+
+func isTrustedProxy(addr string) bool {
+	trustedOnce.Do(loadTrustedProxies)
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP — адрес клиента. Заголовкам X-Real-IP и X-Forwarded-For верим, только
+// если запрос пришёл от доверенного прокси: иначе клиент подставит любой адрес.
+// В X-Forwarded-For берём самый правый адрес, не принадлежащий нашим прокси, —
+// левые записи присылает сам клиент.
+func ClientIP(r *http.Request) string {
+	remote, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remote = r.RemoteAddr
+	}
+	if !isTrustedProxy(remote) {
+		return remote
+	}
+
+	if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); net.ParseIP(realIP) != nil {
+		return realIP
+	}
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		hops := strings.Split(forwarded, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if net.ParseIP(hop) == nil {
+				break
+			}
+			if !isTrustedProxy(hop) {
+				return hop
+			}
+		}
+	}
+	return remote
+}
@@ -0,0 +1,28 @@
+package webhookhttp
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,url"`
+	EventTypes []string `json:"event_types" validate:"required,min=1"`
+	// Без секрета он генерируется и возвращается в ответе
+	Secret      *string `json:"secret,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Active      *bool   `json:"active,omitempty"`
+}
+
+// UpdateWebhookRequest — меняются только переданные поля
+type UpdateWebhookRequest struct {
+	ID          int64    `json:"id" validate:"required"`
+	URL         *string  `json:"url,omitempty" validate:"omitempty,url"`
+	EventTypes  []string `json:"event_types,omitempty"`
+	Secret      *string  `json:"secret,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Active      *bool    `json:"active,omitempty"`
+}
+
+type DeleteWebhookRequest struct {
+	ID int64 `json:"id" validate:"required"`
+}
+
+type RedeliverRequest struct {
+	DeliveryID int64 `json:"delivery_id" validate:"required"`
+}
@@ -0,0 +1,330 @@
+package webhookhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	model "github.com/Vovarama1992/emelya-go/internal/webhook/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/webhook/ports"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = validator.New()
+
+type Handler struct {
+	webhookService ports.WebhookService
+}
+
+func NewHandler(webhookService ports.WebhookService) *Handler {
+	return &Handler{webhookService: webhookService}
+}
+
+// ListWebhooks godoc
+// @Summary Админ: подписки на вебхуки
+// @Tags admin-webhooks
+// @Produce json
+// @Success 200 {array} model.Subscription
+// @Failure 500 {object} map[string]string
+// @Router /api/admin/webhooks [get]
+func (h *Handler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	subs, err := h.webhookService.ListSubscriptions(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения подписок")
+		return
+	}
+
+	json.NewEncoder(w).Encode(subs)
+}
+
+// ListWebhookEvents godoc
+// @Summary Админ: типы событий, на которые можно подписаться
+// @Tags admin-webhooks
+// @Produce json
+// @Success 200 {array} string
+// @Router /api/admin/webhooks/events [get]
+func (h *Handler) ListWebhookEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	json.NewEncoder(w).Encode(model.SupportedEvents)
+}
+
+// CreateWebhook godoc
+// @Summary Админ: создать подписку на вебхуки
+// @Description Запросы подписываются HMAC-SHA256 секретом подписки от "<X-Emelya-Timestamp>.<тело>", подпись — в X-Emelya-Signature (sha256=<hex>). Секрет возвращается только в этом ответе.
+// @Tags admin-webhooks
+// @Accept json
+// @Produce json
+// @Param data body CreateWebhookRequest true "Адрес, события, секрет"
+// @Success 200 {object} model.CreatedSubscription
+// @Failure 400,500 {object} map[string]string
+// @Router /api/admin/webhooks/create [post]
+func (h *Handler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	sub, err := h.webhookService.CreateSubscription(r.Context(), admin.ID, model.SubscriptionInput{
+		URL:         &req.URL,
+		EventTypes:  req.EventTypes,
+		Secret:      req.Secret,
+		Description: req.Description,
+		Active:      req.Active,
+	})
+	if err != nil {
+		respondWithServiceError(w, err, "Ошибка создания подписки")
+		return
+	}
+
+	json.NewEncoder(w).Encode(sub)
+}
+
+// UpdateWebhook godoc
+// @Summary Админ: изменить подписку на вебхуки
+// @Description Меняются только переданные поля. Новый секрет возвращается в ответе.
+// @Tags admin-webhooks
+// @Accept json
+// @Produce json
+// @Param data body UpdateWebhookRequest true "ID и изменяемые поля"
+// @Success 200 {object} model.CreatedSubscription
+// @Failure 400,404,500 {object} map[string]string
+// @Router /api/admin/webhooks/update [post]
+func (h *Handler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req UpdateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	sub, err := h.webhookService.UpdateSubscription(r.Context(), admin.ID, req.ID, model.SubscriptionInput{
+		URL:         req.URL,
+		EventTypes:  req.EventTypes,
+		Secret:      req.Secret,
+		Description: req.Description,
+		Active:      req.Active,
+	})
+	if err != nil {
+		respondWithServiceError(w, err, "Ошибка изменения подписки")
+		return
+	}
+
+	json.NewEncoder(w).Encode(sub)
+}
+
+// DeleteWebhook godoc
+// @Summary Админ: удалить подписку вместе с журналом доставок
+// @Tags admin-webhooks
+// @Accept json
+// @Produce json
+// @Param data body DeleteWebhookRequest true "ID подписки"
+// @Success 200 {object} map[string]string
+// @Failure 400,404,500 {object} map[string]string
+// @Router /api/admin/webhooks/delete [post]
+func (h *Handler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req DeleteWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	if err := h.webhookService.DeleteSubscription(r.Context(), admin.ID, req.ID); err != nil {
+		respondWithServiceError(w, err, "Ошибка удаления подписки")
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"message": "Подписка удалена"})
+}
+
+// ListDeliveries godoc
+// @Summary Админ: журнал доставок вебхуков
+// @Tags admin-webhooks
+// @Produce json
+// @Param subscription_id query int false "ID подписки"
+// @Param status query string false "pending, delivered или failed"
+// @Param event_type query string false "Тип события"
+// @Param before_id query int false "Доставки старше этой (для следующей страницы)"
+// @Param limit query int false "Количество (по умолчанию 50, не больше 200)"
+// @Success 200 {array} model.Delivery
+// @Failure 400,500 {object} map[string]string
+// @Router /api/admin/webhooks/deliveries [get]
+func (h *Handler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	q := r.URL.Query()
+	var f model.DeliveryFilter
+
+	if v := q.Get("subscription_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Некорректный subscription_id")
+			return
+		}
+		f.SubscriptionID = &id
+	}
+	if v := q.Get("status"); v != "" {
+		status := model.DeliveryStatus(v)
+		if !status.IsValid() {
+			respondWithError(w, http.StatusBadRequest, "Некорректный status")
+			return
+		}
+		f.Status = &status
+	}
+	if v := q.Get("event_type"); v != "" {
+		f.EventType = &v
+	}
+	if v := q.Get("before_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Некорректный before_id")
+			return
+		}
+		f.BeforeID = id
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Некорректный limit")
+			return
+		}
+		f.Limit = n
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(r.Context(), f)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Ошибка получения доставок")
+		return
+	}
+
+	json.NewEncoder(w).Encode(deliveries)
+}
+
+// GetDelivery godoc
+// @Summary Админ: доставка вебхука с журналом попыток и кодами ответов
+// @Tags admin-webhooks
+// @Produce json
+// @Param id query int true "ID доставки"
+// @Success 200 {object} model.DeliveryDetails
+// @Failure 400,404,500 {object} map[string]string
+// @Router /api/admin/webhooks/delivery [get]
+func (h *Handler) GetDelivery(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil || id <= 0 {
+		respondWithError(w, http.StatusBadRequest, "Некорректный id")
+		return
+	}
+
+	details, err := h.webhookService.GetDelivery(r.Context(), id)
+	if err != nil {
+		respondWithServiceError(w, err, "Ошибка получения доставки")
+		return
+	}
+
+	json.NewEncoder(w).Encode(details)
+}
+
+// Redeliver godoc
+// @Summary Админ: отправить доставку повторно
+// @Description Доставка снова ставится в очередь с полным запасом попыток.
+// @Tags admin-webhooks
+// @Accept json
+// @Produce json
+// @Param data body RedeliverRequest true "ID доставки"
+// @Success 202 {object} model.Delivery
+// @Failure 400,404,500 {object} map[string]string
+// @Router /api/admin/webhooks/redeliver [post]
+func (h *Handler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondWithError(w, http.StatusMethodNotAllowed, "Метод не разрешён")
+		return
+	}
+
+	var req RedeliverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректный JSON")
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Некорректные данные: "+err.Error())
+		return
+	}
+
+	admin := middleware.GetUserFromContext(r.Context())
+	d, err := h.webhookService.Redeliver(r.Context(), admin.ID, req.DeliveryID)
+	if err != nil {
+		respondWithServiceError(w, err, "Ошибка повторной отправки")
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(d)
+}
+
+func respondWithServiceError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, model.ErrInvalidURL),
+		errors.Is(err, model.ErrInvalidEventType),
+		errors.Is(err, model.ErrNoEventTypes),
+		errors.Is(err, model.ErrSecretTooShort):
+		respondWithError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, model.ErrSubscriptionNotFound),
+		errors.Is(err, model.ErrDeliveryNotFound):
+		respondWithError(w, http.StatusNotFound, err.Error())
+	default:
+		respondWithError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+func respondWithError(w http.ResponseWriter, code int, message string) {
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
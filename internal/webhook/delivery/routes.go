@@ -0,0 +1,53 @@
+package webhookhttp
+
+import (
+	"net/http"
+
+	"github.com/Vovarama1992/emelya-go/internal/auth/middleware"
+	rbac "github.com/Vovarama1992/emelya-go/internal/rbac/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/user/ports"
+	"github.com/Vovarama1992/go-utils/httputil"
+)
+
+func RegisterRoutes(mux *http.ServeMux, handler *Handler, userService ports.UserServiceInterface, sessions middleware.SessionChecker, access middleware.PermissionChecker) {
+	withRecover := func(h http.Handler) http.Handler {
+		return httputil.RecoverMiddleware(h)
+	}
+
+	withPermission := func(perm rbac.Permission, h http.Handler) http.Handler {
+		return middleware.PermissionMiddleware(userService, sessions, access, perm)(h)
+	}
+
+	// === ADMIN ===
+	mux.Handle("/api/admin/webhooks",
+		withRecover(withPermission(rbac.PermWebhooksManage, http.HandlerFunc(handler.ListWebhooks))),
+	)
+
+	mux.Handle("/api/admin/webhooks/events",
+		withRecover(withPermission(rbac.PermWebhooksManage, http.HandlerFunc(handler.ListWebhookEvents))),
+	)
+
+	mux.Handle("/api/admin/webhooks/create",
+		withRecover(withPermission(rbac.PermWebhooksManage, http.HandlerFunc(handler.CreateWebhook))),
+	)
+
+	mux.Handle("/api/admin/webhooks/update",
+		withRecover(withPermission(rbac.PermWebhooksManage, http.HandlerFunc(handler.UpdateWebhook))),
+	)
+
+	mux.Handle("/api/admin/webhooks/delete",
+		withRecover(withPermission(rbac.PermWebhooksManage, http.HandlerFunc(handler.DeleteWebhook))),
+	)
+
+	mux.Handle("/api/admin/webhooks/deliveries",
+		withRecover(withPermission(rbac.PermWebhooksManage, http.HandlerFunc(handler.ListDeliveries))),
+	)
+
+	mux.Handle("/api/admin/webhooks/delivery",
+		withRecover(withPermission(rbac.PermWebhooksManage, http.HandlerFunc(handler.GetDelivery))),
+	)
+
+	mux.Handle("/api/admin/webhooks/redeliver",
+		withRecover(withPermission(rbac.PermWebhooksManage, http.HandlerFunc(handler.Redeliver))),
+	)
+}
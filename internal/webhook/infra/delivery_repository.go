@@ -0,0 +1,225 @@
+package webhook_infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	model "github.com/Vovarama1992/emelya-go/internal/webhook/model"
+	"github.com/jackc/pgx/v5"
+)
+
+type DeliveryRepository struct {
+	DB *db.DB
+}
+
+func NewDeliveryRepository(db *db.DB) *DeliveryRepository {
+	return &DeliveryRepository{DB: db}
+}
+
+const deliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, last_response_code, last_error, created_at, delivered_at`
+
+func scanDelivery(row pgx.Row) (*model.Delivery, error) {
+	var d model.Delivery
+	var payload []byte
+	err := row.Scan(
+		&d.ID,
+		&d.SubscriptionID,
+		&d.EventID,
+		&d.EventType,
+		&payload,
+		&d.Status,
+		&d.Attempts,
+		&d.NextAttemptAt,
+		&d.LastResponseCode,
+		&d.LastError,
+		&d.CreatedAt,
+		&d.DeliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	d.Payload = payload
+	return &d, nil
+}
+
+// Create ставит доставку в очередь; false — доставка этого события подписке уже есть
+func (r *DeliveryRepository) Create(ctx context.Context, d *model.Delivery) (bool, error) {
+	err := r.DB.Pool.QueryRow(ctx, `
+		INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+		RETURNING id, status, next_attempt_at, created_at
+	`, d.SubscriptionID, d.EventID, d.EventType, string(d.Payload)).Scan(&d.ID, &d.Status, &d.NextAttemptAt, &d.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Claim забирает пачку созревших доставок и сдвигает их следующую попытку на lease
+// вперёд — как в outbox: упавший отправитель вернёт доставки в работу после lease,
+// а Mark* с номером попытки из Claim не перезапишут результат нового захвата.
+func (r *DeliveryRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]*model.Delivery, error) {
+	rows, err := r.DB.Pool.Query(ctx, `
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1,
+		    next_attempt_at = now() + $2 * interval '1 second'
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= now()
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+deliveryColumns, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*model.Delivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, d)
+	}
+	return list, rows.Err()
+}
+
+func (r *DeliveryRepository) MarkDelivered(ctx context.Context, id int64, attempt int, code int) error {
+	_, err := r.DB.Pool.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'delivered', delivered_at = now(), last_response_code = $3, last_error = NULL
+		WHERE id = $1 AND attempts = $2 AND status = 'pending'
+	`, id, attempt, code)
+	return err
+}
+
+// MarkRetry откладывает следующую попытку
+func (r *DeliveryRepository) MarkRetry(ctx context.Context, id int64, attempt int, nextAttemptAt time.Time, code *int, lastError string) error {
+	_, err := r.DB.Pool.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = $3, last_response_code = $4, last_error = $5
+		WHERE id = $1 AND attempts = $2 AND status = 'pending'
+	`, id, attempt, nextAttemptAt, code, lastError)
+	return err
+}
+
+// MarkFailed снимает доставку с очереди после исчерпания попыток
+func (r *DeliveryRepository) MarkFailed(ctx context.Context, id int64, attempt int, code *int, lastError string) error {
+	_, err := r.DB.Pool.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'failed', last_response_code = $3, last_error = $4
+		WHERE id = $1 AND attempts = $2 AND status = 'pending'
+	`, id, attempt, code, lastError)
+	return err
+}
+
+// Requeue — ручная повторная отправка: доставка снова в очереди с полным запасом попыток
+func (r *DeliveryRepository) Requeue(ctx context.Context, id int64) (*model.Delivery, error) {
+	d, err := scanDelivery(r.DB.Pool.QueryRow(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'pending', attempts = 0, next_attempt_at = now(), delivered_at = NULL
+		WHERE id = $1
+		RETURNING `+deliveryColumns, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, model.ErrDeliveryNotFound
+	}
+	return d, err
+}
+
+func (r *DeliveryRepository) AddAttempt(ctx context.Context, a *model.Attempt) error {
+	return r.DB.Pool.QueryRow(ctx, `
+		INSERT INTO webhook_delivery_attempts (delivery_id, attempt, response_code, response_body, error, duration_ms)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`, a.DeliveryID, a.Attempt, a.ResponseCode, a.ResponseBody, a.Error, a.DurationMs).Scan(&a.ID, &a.CreatedAt)
+}
+
+// GetByID — nil, если доставки нет
+func (r *DeliveryRepository) GetByID(ctx context.Context, id int64) (*model.Delivery, error) {
+	d, err := scanDelivery(r.DB.Pool.QueryRow(ctx, `SELECT `+deliveryColumns+` FROM webhook_deliveries WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return d, err
+}
+
+func (r *DeliveryRepository) List(ctx context.Context, f model.DeliveryFilter) ([]*model.Delivery, error) {
+	var (
+		conds []string
+		args  []interface{}
+	)
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.SubscriptionID != nil {
+		add("subscription_id = $%d", *f.SubscriptionID)
+	}
+	if f.Status != nil {
+		add("status = $%d", *f.Status)
+	}
+	if f.EventType != nil {
+		add("event_type = $%d", *f.EventType)
+	}
+	if f.BeforeID > 0 {
+		add("id < $%d", f.BeforeID)
+	}
+
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries`
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, f.Limit)
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args))
+
+	rows, err := r.DB.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*model.Delivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, d)
+	}
+	return list, rows.Err()
+}
+
+func (r *DeliveryRepository) ListAttempts(ctx context.Context, deliveryID int64) ([]*model.Attempt, error) {
+	rows, err := r.DB.Pool.Query(ctx, `
+		SELECT id, delivery_id, attempt, response_code, response_body, error, duration_ms, created_at
+		FROM webhook_delivery_attempts
+		WHERE delivery_id = $1
+		ORDER BY id
+	`, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*model.Attempt
+	for rows.Next() {
+		var a model.Attempt
+		if err := rows.Scan(&a.ID, &a.DeliveryID, &a.Attempt, &a.ResponseCode, &a.ResponseBody, &a.Error, &a.DurationMs, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		list = append(list, &a)
+	}
+	return list, rows.Err()
+}
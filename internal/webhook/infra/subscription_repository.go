@@ -0,0 +1,102 @@
+package webhook_infra
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Vovarama1992/emelya-go/internal/db"
+	model "github.com/Vovarama1992/emelya-go/internal/webhook/model"
+	"github.com/jackc/pgx/v5"
+)
+
+type SubscriptionRepository struct {
+	DB *db.DB
+}
+
+func NewSubscriptionRepository(db *db.DB) *SubscriptionRepository {
+	return &SubscriptionRepository{DB: db}
+}
+
+const subscriptionColumns = `id, url, event_types, secret_encrypted, description, active, created_by, created_at, updated_at`
+
+func scanSubscription(row pgx.Row) (*model.Subscription, error) {
+	var s model.Subscription
+	err := row.Scan(&s.ID, &s.URL, &s.EventTypes, &s.SecretEncrypted, &s.Description, &s.Active, &s.CreatedBy, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *SubscriptionRepository) Create(ctx context.Context, s *model.Subscription) error {
+	return r.DB.Pool.QueryRow(ctx, `
+		INSERT INTO webhook_subscriptions (url, event_types, secret_encrypted, description, active, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`, s.URL, s.EventTypes, s.SecretEncrypted, s.Description, s.Active, s.CreatedBy).Scan(&s.ID, &s.CreatedAt, &s.UpdatedAt)
+}
+
+func (r *SubscriptionRepository) Update(ctx context.Context, s *model.Subscription) error {
+	err := r.DB.Pool.QueryRow(ctx, `
+		UPDATE webhook_subscriptions
+		SET url = $2, event_types = $3, secret_encrypted = $4, description = $5, active = $6, updated_at = now()
+		WHERE id = $1
+		RETURNING updated_at
+	`, s.ID, s.URL, s.EventTypes, s.SecretEncrypted, s.Description, s.Active).Scan(&s.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.ErrSubscriptionNotFound
+	}
+	return err
+}
+
+// Delete удаляет подписку вместе с журналом её доставок
+func (r *SubscriptionRepository) Delete(ctx context.Context, id int64) error {
+	tag, err := r.DB.Pool.Exec(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return model.ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+// GetByID — nil, если подписки нет
+func (r *SubscriptionRepository) GetByID(ctx context.Context, id int64) (*model.Subscription, error) {
+	s, err := scanSubscription(r.DB.Pool.QueryRow(ctx, `SELECT `+subscriptionColumns+` FROM webhook_subscriptions WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return s, err
+}
+
+func (r *SubscriptionRepository) List(ctx context.Context) ([]*model.Subscription, error) {
+	return r.query(ctx, `SELECT `+subscriptionColumns+` FROM webhook_subscriptions ORDER BY id`)
+}
+
+// FindActiveByEvent — включённые подписки на тип события
+func (r *SubscriptionRepository) FindActiveByEvent(ctx context.Context, eventType string) ([]*model.Subscription, error) {
+	return r.query(ctx, `
+		SELECT `+subscriptionColumns+` FROM webhook_subscriptions
+		WHERE active AND $1 = ANY(event_types)
+		ORDER BY id
+	`, eventType)
+}
+
+func (r *SubscriptionRepository) query(ctx context.Context, sql string, args ...any) ([]*model.Subscription, error) {
+	rows, err := r.DB.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*model.Subscription
+	for rows.Next() {
+		s, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, s)
+	}
+	return list, rows.Err()
+}
@@ -0,0 +1,147 @@
+package webhook_model
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	outbox "github.com/Vovarama1992/emelya-go/internal/outbox/model"
+)
+
+var (
+	ErrSubscriptionNotFound = errors.New("подписка не найдена")
+	ErrDeliveryNotFound     = errors.New("доставка не найдена")
+	ErrInvalidURL           = errors.New("некорректный адрес вебхука")
+	ErrInvalidEventType     = errors.New("неизвестный тип события")
+	ErrNoEventTypes         = errors.New("укажите хотя бы один тип события")
+	ErrSecretTooShort       = errors.New("секрет должен быть не короче 16 символов")
+)
+
+// Минимальная длина секрета подписи
+const MinSecretLength = 16
+
+// SupportedEvents — события, на которые можно подписаться
+var SupportedEvents = []outbox.EventType{
+	outbox.EventUserRegistered,
+	outbox.EventDepositRequested,
+	outbox.EventDepositApproved,
+	outbox.EventDepositClosed,
+	outbox.EventWithdrawalRequested,
+	outbox.EventWithdrawalApproved,
+	outbox.EventWithdrawalRejected,
+	outbox.EventLeadCreated,
+}
+
+func IsSupportedEvent(t string) bool {
+	for _, e := range SupportedEvents {
+		if string(e) == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscription — адрес, на который отправляются выбранные события
+type Subscription struct {
+	ID              int64     `json:"id"`
+	URL             string    `json:"url"`
+	EventTypes      []string  `json:"event_types"`
+	SecretEncrypted string    `json:"-"`
+	Description     string    `json:"description"`
+	Active          bool      `json:"active"`
+	CreatedBy       *int64    `json:"created_by,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// CreatedSubscription — секрет показывается один раз: при создании и при смене
+type CreatedSubscription struct {
+	Subscription
+	Secret string `json:"secret,omitempty"`
+}
+
+// SubscriptionInput — поля подписки из админки; nil — не менять
+type SubscriptionInput struct {
+	URL         *string
+	EventTypes  []string
+	Secret      *string
+	Description *string
+	Active      *bool
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// Попытки исчерпаны; можно отправить повторно вручную
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+func (s DeliveryStatus) IsValid() bool {
+	return s == DeliveryPending || s == DeliveryDelivered || s == DeliveryFailed
+}
+
+// Delivery — доставка одного события одной подписке
+type Delivery struct {
+	ID               int64           `json:"id"`
+	SubscriptionID   int64           `json:"subscription_id"`
+	EventID          int64           `json:"event_id"`
+	EventType        string          `json:"event_type"`
+	Payload          json.RawMessage `json:"payload" swaggertype:"object"`
+	Status           DeliveryStatus  `json:"status"`
+	Attempts         int             `json:"attempts"`
+	NextAttemptAt    time.Time       `json:"next_attempt_at"`
+	LastResponseCode *int            `json:"last_response_code,omitempty"`
+	LastError        *string         `json:"last_error,omitempty"`
+	CreatedAt        time.Time       `json:"created_at"`
+	DeliveredAt      *time.Time      `json:"delivered_at,omitempty"`
+}
+
+// Attempt — одна попытка доставки в журнале
+type Attempt struct {
+	ID           int64     `json:"id"`
+	DeliveryID   int64     `json:"delivery_id"`
+	Attempt      int       `json:"attempt"`
+	ResponseCode *int      `json:"response_code,omitempty"`
+	ResponseBody *string   `json:"response_body,omitempty"`
+	Error        *string   `json:"error,omitempty"`
+	DurationMs   int       `json:"duration_ms"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// DeliveryDetails — доставка с журналом попыток
+type DeliveryDetails struct {
+	Delivery
+	AttemptLog []*Attempt `json:"attempt_log"`
+}
+
+const (
+	DefaultDeliveryLimit = 50
+	MaxDeliveryLimit     = 200
+)
+
+// DeliveryFilter — пустые поля не ограничивают выборку; BeforeID — страница старше этой доставки
+type DeliveryFilter struct {
+	SubscriptionID *int64
+	Status         *DeliveryStatus
+	EventType      *string
+	BeforeID       int64
+	Limit          int
+}
+
+// Envelope — тело запроса к получателю
+type Envelope struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Заголовки запроса. Подпись — HMAC-SHA256 от "<timestamp>.<тело>" в hex.
+const (
+	HeaderEvent     = "X-Emelya-Event"
+	HeaderDelivery  = "X-Emelya-Delivery"
+	HeaderTimestamp = "X-Emelya-Timestamp"
+	HeaderSignature = "X-Emelya-Signature"
+)
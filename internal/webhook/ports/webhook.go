@@ -0,0 +1,41 @@
+package webhook_ports
+
+import (
+	"context"
+	"time"
+
+	model "github.com/Vovarama1992/emelya-go/internal/webhook/model"
+)
+
+type SubscriptionRepository interface {
+	Create(ctx context.Context, s *model.Subscription) error
+	Update(ctx context.Context, s *model.Subscription) error
+	Delete(ctx context.Context, id int64) error
+	GetByID(ctx context.Context, id int64) (*model.Subscription, error)
+	List(ctx context.Context) ([]*model.Subscription, error)
+	FindActiveByEvent(ctx context.Context, eventType string) ([]*model.Subscription, error)
+}
+
+type DeliveryRepository interface {
+	Create(ctx context.Context, d *model.Delivery) (bool, error)
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*model.Delivery, error)
+	MarkDelivered(ctx context.Context, id int64, attempt int, code int) error
+	MarkRetry(ctx context.Context, id int64, attempt int, nextAttemptAt time.Time, code *int, lastError string) error
+	MarkFailed(ctx context.Context, id int64, attempt int, code *int, lastError string) error
+	Requeue(ctx context.Context, id int64) (*model.Delivery, error)
+	AddAttempt(ctx context.Context, a *model.Attempt) error
+	GetByID(ctx context.Context, id int64) (*model.Delivery, error)
+	List(ctx context.Context, f model.DeliveryFilter) ([]*model.Delivery, error)
+	ListAttempts(ctx context.Context, deliveryID int64) ([]*model.Attempt, error)
+}
+
+type WebhookService interface {
+	ListSubscriptions(ctx context.Context) ([]*model.Subscription, error)
+	GetSubscription(ctx context.Context, id int64) (*model.Subscription, error)
+	CreateSubscription(ctx context.Context, actorID int64, in model.SubscriptionInput) (*model.CreatedSubscription, error)
+	UpdateSubscription(ctx context.Context, actorID, id int64, in model.SubscriptionInput) (*model.CreatedSubscription, error)
+	DeleteSubscription(ctx context.Context, actorID, id int64) error
+	ListDeliveries(ctx context.Context, f model.DeliveryFilter) ([]*model.Delivery, error)
+	GetDelivery(ctx context.Context, id int64) (*model.DeliveryDetails, error)
+	Redeliver(ctx context.Context, actorID, id int64) (*model.Delivery, error)
+}
@@ -0,0 +1,58 @@
+package webhook_usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	outbox "github.com/Vovarama1992/emelya-go/internal/outbox/model"
+	model "github.com/Vovarama1992/emelya-go/internal/webhook/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/webhook/ports"
+)
+
+// Fanout ставит доставки вебхуков по событиям outbox. Сама отправка — в Sender,
+// чтобы медленный получатель не задерживал остальные каналы уведомлений.
+type Fanout struct {
+	subs       ports.SubscriptionRepository
+	deliveries ports.DeliveryRepository
+}
+
+func NewFanout(subs ports.SubscriptionRepository, deliveries ports.DeliveryRepository) *Fanout {
+	return &Fanout{subs: subs, deliveries: deliveries}
+}
+
+func (f *Fanout) Events() []outbox.EventType {
+	return model.SupportedEvents
+}
+
+// Handle — повтор события не дублирует доставку: она уникальна для пары подписка-событие
+func (f *Fanout) Handle(ctx context.Context, e *outbox.Event) error {
+	subs, err := f.subs.FindActiveByEvent(ctx, string(e.Type))
+	if err != nil || len(subs) == 0 {
+		return err
+	}
+
+	payload, err := json.Marshal(model.Envelope{
+		ID:        e.ID,
+		Type:      string(e.Type),
+		CreatedAt: e.CreatedAt,
+		Data:      e.Payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, sub := range subs {
+		_, err := f.deliveries.Create(ctx, &model.Delivery{
+			SubscriptionID: sub.ID,
+			EventID:        e.ID,
+			EventType:      string(e.Type),
+			Payload:        payload,
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,207 @@
+package webhook_usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Vovarama1992/emelya-go/internal/cryptoutil"
+	"github.com/Vovarama1992/emelya-go/internal/pollutil"
+	model "github.com/Vovarama1992/emelya-go/internal/webhook/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/webhook/ports"
+)
+
+// Сколько ответа получателя сохраняем в журнал попыток
+const maxLoggedResponse = 1024
+
+// SenderConfig — параметры опроса очереди и повторов
+type SenderConfig struct {
+	PollInterval   time.Duration
+	BatchSize      int
+	MaxAttempts    int
+	BaseBackoff    time.Duration
+	MaxBackoff     time.Duration
+	RequestTimeout time.Duration
+}
+
+func DefaultSenderConfig() SenderConfig {
+	return SenderConfig{
+		PollInterval:   5 * time.Second,
+		BatchSize:      20,
+		MaxAttempts:    8,
+		BaseBackoff:    time.Minute,
+		MaxBackoff:     6 * time.Hour,
+		RequestTimeout: 10 * time.Second,
+	}
+}
+
+// LoadSenderConfigFromEnv — WEBHOOK_POLL_INTERVAL, WEBHOOK_MAX_ATTEMPTS и WEBHOOK_TIMEOUT,
+// остальное по умолчанию
+func LoadSenderConfigFromEnv() SenderConfig {
+	cfg := DefaultSenderConfig()
+	if d, err := time.ParseDuration(os.Getenv("WEBHOOK_POLL_INTERVAL")); err == nil && d > 0 {
+		cfg.PollInterval = d
+	}
+	if n, err := strconv.Atoi(os.Getenv("WEBHOOK_MAX_ATTEMPTS")); err == nil && n > 0 {
+		cfg.MaxAttempts = n
+	}
+	if d, err := time.ParseDuration(os.Getenv("WEBHOOK_TIMEOUT")); err == nil && d > 0 {
+		cfg.RequestTimeout = d
+	}
+	return cfg
+}
+
+// Sender отправляет доставки из очереди. Ответ 2xx — доставлено; иначе повтор
+// с экспоненциальной задержкой, после MaxAttempts — failed до ручной повторной отправки.
+type Sender struct {
+	subs       ports.SubscriptionRepository
+	deliveries ports.DeliveryRepository
+	cfg        SenderConfig
+	client     *http.Client
+}
+
+func NewSender(subs ports.SubscriptionRepository, deliveries ports.DeliveryRepository, cfg SenderConfig) *Sender {
+	return &Sender{
+		subs:       subs,
+		deliveries: deliveries,
+		cfg:        cfg,
+		client: &http.Client{
+			Timeout: cfg.RequestTimeout,
+			// Редирект на другой адрес — не то, на что подписывались
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Start опрашивает очередь в фоне до отмены ctx
+func (s *Sender) Start(ctx context.Context) {
+	go pollutil.Run(ctx, s.cfg.PollInterval, s.cfg.BatchSize, s.SendPending, func(err error) {
+		log.Printf("[WEBHOOK] Ошибка выборки доставок: %v", err)
+	})
+}
+
+// SendPending отправляет одну пачку созревших доставок и возвращает её размер
+func (s *Sender) SendPending(ctx context.Context) (int, error) {
+	// Доставки пачки отправляются по очереди: аренда рассчитана на всю пачку
+	lease := s.cfg.RequestTimeout*time.Duration(s.cfg.BatchSize) + s.cfg.BaseBackoff
+	deliveries, err := s.deliveries.Claim(ctx, s.cfg.BatchSize, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	subs := make(map[int64]*model.Subscription)
+	for _, d := range deliveries {
+		sub, ok := subs[d.SubscriptionID]
+		if !ok {
+			if sub, err = s.subs.GetByID(ctx, d.SubscriptionID); err != nil {
+				log.Printf("[WEBHOOK] Не удалось загрузить подписку %d: %v", d.SubscriptionID, err)
+				continue
+			}
+			subs[d.SubscriptionID] = sub
+		}
+		s.send(ctx, sub, d)
+	}
+	return len(deliveries), nil
+}
+
+func (s *Sender) send(ctx context.Context, sub *model.Subscription, d *model.Delivery) {
+	if sub == nil || !sub.Active {
+		// Отключённую подписку не бомбим повторами; после включения доставку можно повторить вручную
+		s.finish(ctx, d, nil, "подписка отключена", true)
+		return
+	}
+
+	started := time.Now()
+	code, body, err := s.post(ctx, sub, d)
+	attempt := &model.Attempt{
+		DeliveryID: d.ID,
+		Attempt:    d.Attempts,
+		DurationMs: int(time.Since(started).Milliseconds()),
+	}
+	if code > 0 {
+		attempt.ResponseCode = &code
+		attempt.ResponseBody = &body
+	}
+	if err != nil {
+		msg := err.Error()
+		attempt.Error = &msg
+	}
+	if err := s.deliveries.AddAttempt(ctx, attempt); err != nil {
+		log.Printf("[WEBHOOK] Не удалось записать попытку доставки %d: %v", d.ID, err)
+	}
+
+	if err == nil {
+		if err := s.deliveries.MarkDelivered(ctx, d.ID, d.Attempts, code); err != nil {
+			log.Printf("[WEBHOOK] Не удалось отметить доставку %d: %v", d.ID, err)
+		}
+		return
+	}
+	s.finish(ctx, d, attempt.ResponseCode, err.Error(), d.Attempts >= s.cfg.MaxAttempts)
+}
+
+// finish откладывает доставку или, если попыток больше не будет, снимает её с очереди
+func (s *Sender) finish(ctx context.Context, d *model.Delivery, code *int, cause string, final bool) {
+	if final {
+		log.Printf("[WEBHOOK] Доставка %d (%s) не удалась за %d попыток: %s", d.ID, d.EventType, d.Attempts, cause)
+		if err := s.deliveries.MarkFailed(ctx, d.ID, d.Attempts, code, cause); err != nil {
+			log.Printf("[WEBHOOK] Не удалось отметить доставку %d как failed: %v", d.ID, err)
+		}
+		return
+	}
+
+	next := time.Now().Add(pollutil.Backoff(s.cfg.BaseBackoff, s.cfg.MaxBackoff, d.Attempts))
+	if err := s.deliveries.MarkRetry(ctx, d.ID, d.Attempts, next, code, cause); err != nil {
+		log.Printf("[WEBHOOK] Не удалось отложить доставку %d: %v", d.ID, err)
+	}
+}
+
+// post отправляет подписанный запрос; ошибка — всё, кроме ответа 2xx
+func (s *Sender) post(ctx context.Context, sub *model.Subscription, d *model.Delivery) (int, string, error) {
+	secret, err := cryptoutil.Decrypt(sub.SecretEncrypted)
+	if err != nil {
+		return 0, "", fmt.Errorf("не удалось расшифровать секрет подписки")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.RequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, "", fmt.Errorf("ошибка создания запроса: %w", err)
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Emelya-Webhooks/1.0")
+	req.Header.Set(model.HeaderEvent, d.EventType)
+	req.Header.Set(model.HeaderDelivery, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(model.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(model.HeaderSignature, Sign(secret, timestamp, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("ошибка отправки: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxLoggedResponse))
+	body := responseText(raw)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, body, fmt.Errorf("получатель ответил %s", resp.Status)
+	}
+	return resp.StatusCode, body, nil
+}
+
+// responseText — ответ для журнала: обрезанный по лимиту ответ может кончаться
+// посреди символа, а Postgres не примет невалидный UTF-8 и нулевые байты
+func responseText(b []byte) string {
+	return strings.ReplaceAll(strings.ToValidUTF8(string(b), ""), "\x00", "")
+}
@@ -0,0 +1,281 @@
+package webhook_usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+
+	audit "github.com/Vovarama1992/emelya-go/internal/audit/model"
+	audit_ports "github.com/Vovarama1992/emelya-go/internal/audit/ports"
+	"github.com/Vovarama1992/emelya-go/internal/cryptoutil"
+	model "github.com/Vovarama1992/emelya-go/internal/webhook/model"
+	ports "github.com/Vovarama1992/emelya-go/internal/webhook/ports"
+	"github.com/Vovarama1992/go-utils/ctxutil"
+)
+
+// WebhookService — подписки на вебхуки и журнал доставок для админки
+type WebhookService struct {
+	subs         ports.SubscriptionRepository
+	deliveries   ports.DeliveryRepository
+	auditService audit_ports.AuditService
+	// http:// разрешён только для локальной отладки (WEBHOOK_ALLOW_HTTP=true)
+	allowHTTP bool
+}
+
+func NewWebhookService(subs ports.SubscriptionRepository, deliveries ports.DeliveryRepository, auditService audit_ports.AuditService) *WebhookService {
+	return &WebhookService{
+		subs:         subs,
+		deliveries:   deliveries,
+		auditService: auditService,
+		allowHTTP:    os.Getenv("WEBHOOK_ALLOW_HTTP") == "true",
+	}
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]*model.Subscription, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	list, err := s.subs.List(ctx)
+	if list == nil && err == nil {
+		list = []*model.Subscription{}
+	}
+	return list, err
+}
+
+func (s *WebhookService) GetSubscription(ctx context.Context, id int64) (*model.Subscription, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	sub, err := s.subs.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sub == nil {
+		return nil, model.ErrSubscriptionNotFound
+	}
+	return sub, nil
+}
+
+// CreateSubscription — без секрета он генерируется; в ответе секрет виден один раз
+func (s *WebhookService) CreateSubscription(ctx context.Context, actorID int64, in model.SubscriptionInput) (*model.CreatedSubscription, error) {
+	if in.URL == nil {
+		return nil, model.ErrInvalidURL
+	}
+	sub := &model.Subscription{Active: true, CreatedBy: &actorID}
+	secret, err := s.apply(sub, in, true)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	if err := s.subs.Create(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, &audit.Entry{
+		ActorID:    &actorID,
+		Action:     audit.ActionWebhookCreate,
+		EntityType: audit.EntityWebhook,
+		EntityID:   &sub.ID,
+		After:      audit.Snapshot(sub),
+	})
+	return &model.CreatedSubscription{Subscription: *sub, Secret: secret}, nil
+}
+
+// UpdateSubscription меняет переданные поля; новый секрет возвращается в ответе
+func (s *WebhookService) UpdateSubscription(ctx context.Context, actorID, id int64, in model.SubscriptionInput) (*model.CreatedSubscription, error) {
+	sub, err := s.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *sub
+
+	secret, err := s.apply(sub, in, false)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	if err := s.subs.Update(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	after := audit.Snapshot(sub)
+	if secret != "" {
+		after = audit.Snapshot(map[string]any{"subscription": sub, "secret_rotated": true})
+	}
+	s.record(ctx, &audit.Entry{
+		ActorID:    &actorID,
+		Action:     audit.ActionWebhookUpdate,
+		EntityType: audit.EntityWebhook,
+		EntityID:   &sub.ID,
+		Before:     audit.Snapshot(before),
+		After:      after,
+	})
+	return &model.CreatedSubscription{Subscription: *sub, Secret: secret}, nil
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, actorID, id int64) error {
+	sub, err := s.GetSubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+	if err := s.subs.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.record(ctx, &audit.Entry{
+		ActorID:    &actorID,
+		Action:     audit.ActionWebhookDelete,
+		EntityType: audit.EntityWebhook,
+		EntityID:   &id,
+		Before:     audit.Snapshot(sub),
+	})
+	return nil
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, f model.DeliveryFilter) ([]*model.Delivery, error) {
+	if f.Limit <= 0 {
+		f.Limit = model.DefaultDeliveryLimit
+	}
+	f.Limit = min(f.Limit, model.MaxDeliveryLimit)
+
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+
+	list, err := s.deliveries.List(ctx, f)
+	if list == nil && err == nil {
+		list = []*model.Delivery{}
+	}
+	return list, err
+}
+
+// GetDelivery — доставка с журналом попыток и кодами ответов
+func (s *WebhookService) GetDelivery(ctx context.Context, id int64) (*model.DeliveryDetails, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 3)
+	defer cancel()
+
+	d, err := s.deliveries.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, model.ErrDeliveryNotFound
+	}
+	attempts, err := s.deliveries.ListAttempts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if attempts == nil {
+		attempts = []*model.Attempt{}
+	}
+	return &model.DeliveryDetails{Delivery: *d, AttemptLog: attempts}, nil
+}
+
+// Redeliver ставит доставку в очередь заново; отправит её фоновый отправитель
+func (s *WebhookService) Redeliver(ctx context.Context, actorID, id int64) (*model.Delivery, error) {
+	ctx, cancel := ctxutil.WithTimeout(ctx, 2)
+	defer cancel()
+
+	d, err := s.deliveries.Requeue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.record(ctx, &audit.Entry{
+		ActorID:    &actorID,
+		Action:     audit.ActionWebhookRedeliver,
+		EntityType: audit.EntityWebhook,
+		EntityID:   &d.SubscriptionID,
+		After:      audit.Snapshot(map[string]any{"delivery_id": d.ID, "event_id": d.EventID}),
+	})
+	return d, nil
+}
+
+// apply проверяет и переносит поля в подписку. Возвращает новый секрет в открытом
+// виде, если он задан или сгенерирован.
+func (s *WebhookService) apply(sub *model.Subscription, in model.SubscriptionInput, create bool) (string, error) {
+	if in.URL != nil {
+		u := strings.TrimSpace(*in.URL)
+		if err := s.validateURL(u); err != nil {
+			return "", err
+		}
+		sub.URL = u
+	}
+
+	if in.EventTypes != nil || create {
+		if len(in.EventTypes) == 0 {
+			return "", model.ErrNoEventTypes
+		}
+		types := make([]string, 0, len(in.EventTypes))
+		seen := make(map[string]bool, len(in.EventTypes))
+		for _, t := range in.EventTypes {
+			if !model.IsSupportedEvent(t) {
+				return "", model.ErrInvalidEventType
+			}
+			if !seen[t] {
+				seen[t] = true
+				types = append(types, t)
+			}
+		}
+		sub.EventTypes = types
+	}
+
+	if in.Description != nil {
+		sub.Description = strings.TrimSpace(*in.Description)
+	}
+	if in.Active != nil {
+		sub.Active = *in.Active
+	}
+
+	var secret string
+	switch {
+	case in.Secret != nil:
+		secret = *in.Secret
+		if len(secret) < model.MinSecretLength {
+			return "", model.ErrSecretTooShort
+		}
+	case create:
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		secret = hex.EncodeToString(b)
+	default:
+		return "", nil
+	}
+
+	encrypted, err := cryptoutil.Encrypt(secret)
+	if err != nil {
+		return "", err
+	}
+	sub.SecretEncrypted = encrypted
+	return secret, nil
+}
+
+func (s *WebhookService) validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || u.User != nil {
+		return model.ErrInvalidURL
+	}
+	if u.Scheme != "https" && !(s.allowHTTP && u.Scheme == "http") {
+		return model.ErrInvalidURL
+	}
+	return nil
+}
+
+// record — сбой записи в журнал не отменяет изменение подписки
+func (s *WebhookService) record(ctx context.Context, e *audit.Entry) {
+	if err := s.auditService.Record(ctx, e); err != nil {
+		log.Printf("[WEBHOOK] Не удалось записать журнал %s: %v", e.Action, err)
+	}
+}
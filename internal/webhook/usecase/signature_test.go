@@ -0,0 +1,43 @@
+package webhook_usecase
+
+import "testing"
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      string
+		want      string
+	}{
+		{
+			"тело события", "whsec_test", 1700000000, `{"event":"deposit.approved"}`,
+			"sha256=25c9b9d2764ac8ce0faa8b48d85b61363afe0c132a0926c769bbcd7cdd0ce18f",
+		},
+		{
+			"пустое тело", "whsec_test", 1700000000, "",
+			"sha256=5967f3c560522fa40cf2876ebc3c3a08551dd6959aaade3b413460591895bdcc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// Подпись должна меняться от любой из трёх составляющих
+func TestSignDependsOnAllInputs(t *testing.T) {
+	base := Sign("secret", 1700000000, []byte("body"))
+	for name, got := range map[string]string{
+		"секрет":        Sign("secret2", 1700000000, []byte("body")),
+		"метка времени": Sign("secret", 1700000001, []byte("body")),
+		"тело":          Sign("secret", 1700000000, []byte("body2")),
+	} {
+		if got == base {
+			t.Errorf("подпись не зависит от параметра %q", name)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+DROP TABLE IF EXISTS withdrawal_transitions;
+
+-- Новые статусы сворачиваем в ближайшие старые
+ALTER TABLE withdrawals ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE withdrawals ALTER COLUMN status TYPE TEXT;
+
+UPDATE withdrawals SET status = 'rejected' WHERE status IN ('cancelled', 'failed', 'reversed');
+UPDATE withdrawals SET status = 'approved' WHERE status IN ('processing', 'paid');
+
+DROP TYPE withdrawal_status;
+CREATE TYPE withdrawal_status AS ENUM ('pending', 'approved', 'rejected');
+
+ALTER TABLE withdrawals ALTER COLUMN status TYPE withdrawal_status USING status::withdrawal_status;
+ALTER TABLE withdrawals ALTER COLUMN status SET DEFAULT 'pending';
@@ -0,0 +1,17 @@
+ALTER TYPE withdrawal_status ADD VALUE IF NOT EXISTS 'cancelled';
+ALTER TYPE withdrawal_status ADD VALUE IF NOT EXISTS 'processing';
+ALTER TYPE withdrawal_status ADD VALUE IF NOT EXISTS 'paid';
+ALTER TYPE withdrawal_status ADD VALUE IF NOT EXISTS 'failed';
+ALTER TYPE withdrawal_status ADD VALUE IF NOT EXISTS 'reversed';
+
+CREATE TABLE withdrawal_transitions (
+    id SERIAL PRIMARY KEY,
+    withdrawal_id INT NOT NULL REFERENCES withdrawals(id) ON DELETE CASCADE,
+    from_status withdrawal_status NOT NULL,
+    to_status withdrawal_status NOT NULL,
+    actor_id INT REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_withdrawal_transitions_withdrawal_id ON withdrawal_transitions(withdrawal_id);
@@ -0,0 +1,21 @@
+-- В старой схеме нет статуса для отклонённых и отменённых заявок, а удалять
+-- их вместе с историей нельзя: откат возможен, только пока таких заявок нет
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM deposits WHERE status IN ('rejected', 'cancelled')) THEN
+        RAISE EXCEPTION 'есть отклонённые или отменённые депозиты, откат статусов невозможен';
+    END IF;
+END $$;
+
+DROP TABLE IF EXISTS deposit_transitions;
+
+ALTER TABLE deposits DROP COLUMN IF EXISTS reason;
+
+ALTER TABLE deposits ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE deposits ALTER COLUMN status TYPE TEXT;
+
+DROP TYPE deposit_status;
+CREATE TYPE deposit_status AS ENUM ('pending', 'approved', 'closed');
+
+ALTER TABLE deposits ALTER COLUMN status TYPE deposit_status USING status::deposit_status;
+ALTER TABLE deposits ALTER COLUMN status SET DEFAULT 'pending';
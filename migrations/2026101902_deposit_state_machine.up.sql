@@ -0,0 +1,16 @@
+ALTER TYPE deposit_status ADD VALUE IF NOT EXISTS 'rejected';
+ALTER TYPE deposit_status ADD VALUE IF NOT EXISTS 'cancelled';
+
+ALTER TABLE deposits ADD COLUMN reason TEXT;
+
+CREATE TABLE deposit_transitions (
+    id SERIAL PRIMARY KEY,
+    deposit_id INT NOT NULL REFERENCES deposits(id) ON DELETE CASCADE,
+    from_status deposit_status NOT NULL,
+    to_status deposit_status NOT NULL,
+    actor_id INT REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_deposit_transitions_deposit_id ON deposit_transitions(deposit_id);
@@ -0,0 +1,4 @@
+ALTER TABLE deposits DROP COLUMN IF EXISTS deleted_by;
+ALTER TABLE deposits DROP COLUMN IF EXISTS deleted_at;
+
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS pending_approvals;
+DROP TYPE IF EXISTS approval_status;
@@ -0,0 +1,20 @@
+CREATE TYPE approval_status AS ENUM ('pending', 'confirmed', 'rejected', 'failed');
+
+CREATE TABLE pending_approvals (
+    id SERIAL PRIMARY KEY,
+    operation TEXT NOT NULL,
+    entity_id BIGINT,
+    amount NUMERIC(12, 2) NOT NULL,
+    payload JSONB NOT NULL,
+    status approval_status NOT NULL DEFAULT 'pending',
+    requested_by INT NOT NULL REFERENCES users(id),
+    decided_by INT REFERENCES users(id),
+    decided_at TIMESTAMPTZ,
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Не больше одной ожидающей заявки на одну и ту же сущность
+CREATE UNIQUE INDEX idx_pending_approvals_entity
+    ON pending_approvals(operation, entity_id)
+    WHERE status = 'pending' AND entity_id IS NOT NULL;
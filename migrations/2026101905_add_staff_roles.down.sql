@@ -0,0 +1,11 @@
+ALTER TABLE users ALTER COLUMN role DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN role TYPE TEXT;
+
+UPDATE users SET role = 'admin' WHERE role = 'superadmin';
+UPDATE users SET role = 'user' WHERE role IN ('operator', 'accountant', 'support');
+
+DROP TYPE user_role;
+CREATE TYPE user_role AS ENUM ('user', 'admin');
+
+ALTER TABLE users ALTER COLUMN role TYPE user_role USING role::user_role;
+ALTER TABLE users ALTER COLUMN role SET DEFAULT 'user';
@@ -0,0 +1,4 @@
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'operator';
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'accountant';
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'support';
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'superadmin';
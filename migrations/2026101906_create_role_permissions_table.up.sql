@@ -0,0 +1,37 @@
+CREATE TABLE role_permissions (
+    role TEXT NOT NULL,
+    permission TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (role, permission)
+);
+
+-- Права по умолчанию; superadmin имеет все права без записей в таблице.
+-- Первого superadmin назначают вручную: UPDATE users SET role = 'superadmin' WHERE id = ...;
+INSERT INTO role_permissions (role, permission) VALUES
+    ('support', 'users.read'),
+
+    ('operator', 'users.read'),
+    ('operator', 'deposits.read'),
+    ('operator', 'deposits.manage'),
+
+    ('accountant', 'users.read'),
+    ('accountant', 'deposits.read'),
+    ('accountant', 'withdrawals.read'),
+    ('accountant', 'rewards.read'),
+    ('accountant', 'finance.read'),
+    ('accountant', 'finance.export'),
+
+    ('admin', 'users.read'),
+    ('admin', 'users.write'),
+    ('admin', 'deposits.read'),
+    ('admin', 'deposits.manage'),
+    ('admin', 'deposits.delete'),
+    ('admin', 'withdrawals.read'),
+    ('admin', 'withdrawals.manage'),
+    ('admin', 'rewards.read'),
+    ('admin', 'rewards.manage'),
+    ('admin', 'tariffs.manage'),
+    ('admin', 'finance.read'),
+    ('admin', 'finance.export'),
+    ('admin', 'audit.read'),
+    ('admin', 'approvals.manage');
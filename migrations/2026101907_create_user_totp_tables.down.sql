@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_recovery_codes;
+DROP TABLE IF EXISTS user_totp;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_enabled;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS user_contact_changes;
+ALTER TABLE users DROP COLUMN IF EXISTS contacts_changed_at;
@@ -0,0 +1,20 @@
+-- После смены телефона или email вывод средств временно недоступен
+ALTER TABLE users ADD COLUMN contacts_changed_at TIMESTAMPTZ;
+
+-- Заявка на смену контакта; применяется после ввода кодов со старого и нового контакта
+CREATE TABLE user_contact_changes (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('phone', 'email')),
+    old_value TEXT NOT NULL,
+    new_value TEXT NOT NULL,
+    old_code_hash TEXT NOT NULL,
+    new_code_hash TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed', 'cancelled', 'expired')),
+    expires_at TIMESTAMPTZ NOT NULL,
+    confirmed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_user_contact_changes_user_id ON user_contact_changes(user_id);
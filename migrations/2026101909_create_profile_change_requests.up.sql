@@ -0,0 +1,21 @@
+-- Изменения ФИО и номера карты: заявки пользователя на модерацию оператором
+-- и прямые правки администратора (status = 'applied'). previous — значения до изменения.
+CREATE TABLE profile_change_requests (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changes JSONB NOT NULL,
+    previous JSONB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected', 'applied')),
+    requested_by INT NOT NULL REFERENCES users(id),
+    decided_by INT REFERENCES users(id),
+    decided_at TIMESTAMPTZ,
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_profile_change_requests_user_id ON profile_change_requests(user_id);
+
+-- Не больше одной ожидающей заявки на пользователя
+CREATE UNIQUE INDEX idx_profile_change_requests_pending
+    ON profile_change_requests(user_id)
+    WHERE status = 'pending';
@@ -0,0 +1,12 @@
+-- После MigrateLegacyCards номера карт есть только здесь, и они зашифрованы:
+-- вернуть их в users.card_number SQL не может, поэтому откат возможен,
+-- только пока реквизитов нет
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM payout_methods) THEN
+        RAISE EXCEPTION 'в payout_methods есть реквизиты, откат удалит номера карт';
+    END IF;
+END $$;
+
+ALTER TABLE withdrawals DROP COLUMN IF EXISTS payout_method_id;
+DROP TABLE IF EXISTS payout_methods;
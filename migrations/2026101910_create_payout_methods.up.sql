@@ -0,0 +1,43 @@
+-- Реквизиты для вывода: карты и банковские счета. Номер хранится зашифрованным
+-- (DATA_ENCRYPTION_KEY), fingerprint — HMAC номера для поиска дублей.
+-- Старые users.card_number переносятся сюда при старте приложения.
+CREATE TABLE payout_methods (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('card', 'bank_account')),
+    number_encrypted TEXT NOT NULL,
+    fingerprint TEXT NOT NULL,
+    last4 TEXT NOT NULL,
+    brand TEXT,
+    bank_bik TEXT,
+    holder_name TEXT,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'verified', 'rejected')),
+    reject_reason TEXT,
+    verified_by INT REFERENCES users(id),
+    verified_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_payout_methods_user_id ON payout_methods(user_id);
+
+CREATE UNIQUE INDEX idx_payout_methods_default
+    ON payout_methods(user_id)
+    WHERE is_default AND deleted_at IS NULL;
+
+CREATE UNIQUE INDEX idx_payout_methods_fingerprint
+    ON payout_methods(user_id, fingerprint)
+    WHERE deleted_at IS NULL;
+
+ALTER TABLE withdrawals ADD COLUMN payout_method_id INT REFERENCES payout_methods(id);
+
+-- Карта больше не меняется через модерацию профиля: убираем номера из истории,
+-- а заявки, в которых оставалась только карта, закрываем
+UPDATE profile_change_requests
+SET changes = changes - 'card_number',
+    previous = previous - 'card_number';
+
+UPDATE profile_change_requests
+SET status = 'rejected', decided_at = now(), reason = 'Карта для вывода теперь добавляется в разделе реквизитов'
+WHERE status = 'pending' AND changes = '{}'::jsonb;
@@ -0,0 +1,4 @@
+DELETE FROM role_permissions WHERE permission = 'kyc.review';
+DROP TABLE IF EXISTS kyc_documents;
+DROP TABLE IF EXISTS kyc_applications;
+ALTER TABLE users DROP COLUMN IF EXISTS kyc_level;
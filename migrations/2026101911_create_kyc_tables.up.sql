@@ -0,0 +1,39 @@
+-- Уровень верификации личности: 0 — нет, 1 — паспорт, 2 — паспорт с пропиской и селфи.
+-- От уровня зависят лимиты на депозиты и вывод.
+ALTER TABLE users ADD COLUMN kyc_level SMALLINT NOT NULL DEFAULT 0;
+
+CREATE TABLE kyc_applications (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    level SMALLINT CHECK (level IN (1, 2)),
+    status TEXT NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'submitted', 'approved', 'rejected')),
+    birth_date DATE,
+    passport_number_encrypted TEXT,
+    reviewed_by INT REFERENCES users(id),
+    reviewed_at TIMESTAMPTZ,
+    reason TEXT,
+    submitted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_kyc_applications_user_id ON kyc_applications(user_id);
+
+-- Одна незакрытая заявка на пользователя
+CREATE UNIQUE INDEX idx_kyc_applications_open
+    ON kyc_applications(user_id)
+    WHERE status IN ('draft', 'submitted');
+
+CREATE TABLE kyc_documents (
+    id SERIAL PRIMARY KEY,
+    application_id INT NOT NULL REFERENCES kyc_applications(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('passport_main', 'passport_registration', 'selfie')),
+    storage_key TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (application_id, kind)
+);
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('operator', 'kyc.review'),
+    ('admin', 'kyc.review');
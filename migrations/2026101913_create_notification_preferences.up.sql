@@ -0,0 +1,19 @@
+-- Настройки уведомлений пользователя. Хранятся только отличия от умолчаний:
+-- нет строки — действует умолчание для категории и канала.
+CREATE TABLE notification_preferences (
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category TEXT NOT NULL CHECK (category IN ('deposits', 'accruals', 'withdrawals')),
+    channel TEXT NOT NULL CHECK (channel IN ('sms', 'email', 'inapp')),
+    enabled BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, category, channel)
+);
+
+-- Тихие часы: SMS в это время откладываются до их окончания
+CREATE TABLE notification_quiet_hours (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    timezone TEXT NOT NULL DEFAULT 'Europe/Moscow',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
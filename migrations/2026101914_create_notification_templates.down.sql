@@ -0,0 +1,2 @@
+DELETE FROM role_permissions WHERE permission = 'notifications.manage';
+DROP TABLE IF EXISTS notification_templates;
@@ -0,0 +1,15 @@
+-- Правки шаблонов сообщений из админки. Нет строки — действует встроенный текст.
+CREATE TABLE notification_templates (
+    key TEXT NOT NULL,
+    locale TEXT NOT NULL,
+    subject TEXT NOT NULL DEFAULT '',
+    text_body TEXT NOT NULL DEFAULT '',
+    html_body TEXT NOT NULL DEFAULT '',
+    sms_body TEXT NOT NULL DEFAULT '',
+    updated_by INT REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (key, locale)
+);
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'notifications.manage');
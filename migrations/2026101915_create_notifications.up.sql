@@ -0,0 +1,17 @@
+-- Лента уведомлений в личном кабинете (пользователи и сотрудники).
+-- source_event_id — событие outbox, из которого уведомление выросло: повторная
+-- обработка того же события не создаёт дубль.
+CREATE TABLE notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    category TEXT NOT NULL DEFAULT '',
+    subject TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    source_event_id BIGINT,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (user_id, source_event_id)
+);
+
+CREATE INDEX idx_notifications_user ON notifications (user_id, id DESC);
+CREATE INDEX idx_notifications_unread ON notifications (user_id) WHERE read_at IS NULL;
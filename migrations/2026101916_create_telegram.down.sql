@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS telegram_posts;
+DROP TABLE IF EXISTS telegram_links;
@@ -0,0 +1,25 @@
+-- Привязка аккаунтов сотрудников к Telegram: бот принимает решения по заявкам
+-- только от привязанных аккаунтов с нужными правами.
+CREATE TABLE telegram_links (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    telegram_user_id BIGINT NOT NULL UNIQUE,
+    chat_id BIGINT NOT NULL,
+    username TEXT NOT NULL DEFAULT '',
+    linked_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Сообщения бота о заявках: по ним снимаются кнопки, когда заявку обработали.
+-- (event_id, chat_id) — повтор события outbox не шлёт сообщение второй раз.
+CREATE TABLE telegram_posts (
+    event_id BIGINT NOT NULL,
+    chat_id BIGINT NOT NULL,
+    message_id BIGINT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id BIGINT NOT NULL,
+    text TEXT NOT NULL,
+    closed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (event_id, chat_id)
+);
+
+CREATE INDEX idx_telegram_posts_open ON telegram_posts (entity_type, entity_id) WHERE closed_at IS NULL;
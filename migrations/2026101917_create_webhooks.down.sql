@@ -0,0 +1,4 @@
+DELETE FROM role_permissions WHERE permission = 'webhooks.manage';
+DROP TABLE IF EXISTS webhook_delivery_attempts;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
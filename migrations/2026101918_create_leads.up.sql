@@ -0,0 +1,30 @@
+-- Заявки с формы на сайте. Спам тоже сохраняется (status = 'spam'), но
+-- операторам не пересылается.
+CREATE TABLE leads (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    phone TEXT NOT NULL,
+    email TEXT NOT NULL DEFAULT '',
+    tariff_id INT REFERENCES tariffs(id) ON DELETE SET NULL,
+    -- Название тарифа на момент заявки: тариф могут переименовать или удалить
+    tariff_name TEXT NOT NULL DEFAULT '',
+    comment TEXT NOT NULL DEFAULT '',
+    source TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'new' CHECK (status IN ('new', 'in_progress', 'converted', 'rejected', 'spam')),
+    spam_reason TEXT,
+    assignee_id INT REFERENCES users(id) ON DELETE SET NULL,
+    user_id INT REFERENCES users(id) ON DELETE SET NULL,
+    ip TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    converted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_leads_status ON leads(status, id);
+CREATE INDEX idx_leads_assignee ON leads(assignee_id, id) WHERE assignee_id IS NOT NULL;
+CREATE INDEX idx_leads_phone ON leads(phone);
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'leads.manage'),
+    ('operator', 'leads.manage');
@@ -0,0 +1,18 @@
+-- Очередь писем: строка на адресата, со статусом доставки и повторами.
+-- Текст письма стирается после отправки.
+CREATE TABLE email_queue (
+    id BIGSERIAL PRIMARY KEY,
+    recipient TEXT NOT NULL,
+    subject TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL DEFAULT '',
+    html TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed')),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    sent_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_email_queue_pending ON email_queue(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_email_queue_recipient ON email_queue(lower(recipient), id);
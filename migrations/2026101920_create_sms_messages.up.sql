@@ -0,0 +1,21 @@
+-- Журнал SMS: кто отправил, ID у провайдера, статус доставки и цена.
+-- Текст не храним: в SMS коды и пароли, вместо него — ключ шаблона.
+CREATE TABLE sms_messages (
+    id BIGSERIAL PRIMARY KEY,
+    phone TEXT NOT NULL,
+    template TEXT NOT NULL DEFAULT '',
+    provider TEXT,
+    provider_message_id TEXT,
+    status TEXT NOT NULL CHECK (status IN ('sent', 'delivered', 'undelivered', 'failed')),
+    provider_status TEXT,
+    cost NUMERIC(12, 4),
+    last_error TEXT,
+    checks INT NOT NULL DEFAULT 0,
+    next_check_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    status_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_sms_messages_phone ON sms_messages(phone, id);
+CREATE INDEX idx_sms_messages_check ON sms_messages(next_check_at) WHERE status = 'sent';
+CREATE INDEX idx_sms_messages_created ON sms_messages(created_at);
@@ -0,0 +1,9 @@
+-- Какие обработчики уже приняли событие: при повторе доставки после сбоя
+-- одного обработчика остальные событие второй раз не получают.
+CREATE TABLE outbox_handler_deliveries (
+    event_id BIGINT NOT NULL REFERENCES outbox_events(id) ON DELETE CASCADE,
+    handler TEXT NOT NULL,
+    delivered_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (event_id, handler)
+);
+
@@ -0,0 +1,4 @@
+-- Одно сообщение пользователю на исходное событие и канал
+CREATE UNIQUE INDEX idx_outbox_events_user_message
+    ON outbox_events((payload->>'source_event_id'), (payload->>'channel'))
+    WHERE event_type = 'notification.user_message';